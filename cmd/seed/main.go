@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
 	"math"
@@ -12,14 +13,66 @@ import (
 	"strings"
 	"time"
 
+	"leaderboard-service/internal/shared/config"
+
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
 
+// extraSeasons are seeded after the default season (LEADERBOARD_DEFAULT_SEASON) when --seasons is not given
+const extraSeasons = "season_1,season_2,season_3"
+
+// scoreDistribution returns a score between min and max; mean is the centre of the bell curve
+type scoreDistribution func(rng *rand.Rand, min, mean, max float64) int64
+
+// distributions are the --distribution choices
+var distributions = map[string]scoreDistribution{
+	"gaussian": gaussianScore,
+	"uniform":  uniformScore,
+	"power":    powerScore,
+}
+
+// seedOptions are the command line settings of a seed run
+type seedOptions struct {
+	rngSeed      int64
+	seasons      []string
+	distribution scoreDistribution
+}
+
 func main() {
+	seed := flag.Int64("seed", 0, "RNG seed for a reproducible data set (default: random)")
+	seasonList := flag.String("seasons", "", "comma-separated seasons to seed (default: LEADERBOARD_DEFAULT_SEASON,"+extraSeasons+")")
+	distributionName := flag.String("distribution", "gaussian", "score distribution: gaussian, uniform or power")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [number of users]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	opts := seedOptions{rngSeed: time.Now().UnixNano()}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			opts.rngSeed = *seed
+		}
+	})
+
 	// Load .env
 	_ = godotenv.Load()
 
+	// Сезон по умолчанию берется из .env, поэтому список собирается после его загрузки
+	if *seasonList == "" {
+		*seasonList = config.DefaultSeasonFromEnv() + "," + extraSeasons
+	}
+	var err error
+	if opts.seasons, err = parseSeasons(*seasonList); err != nil {
+		log.Fatal(err)
+	}
+	distribution, ok := distributions[*distributionName]
+	if !ok {
+		log.Fatalf("unknown distribution %q (use gaussian, uniform or power)", *distributionName)
+	}
+	opts.distribution = distribution
+
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
 		log.Fatal("DATABASE_URL not set")
@@ -35,8 +88,8 @@ func main() {
 	}
 
 	numUsers := 1000000
-	if len(os.Args) > 1 {
-		if n, err := strconv.Atoi(os.Args[1]); err == nil {
+	if flag.NArg() > 0 {
+		if n, err := strconv.Atoi(flag.Arg(0)); err == nil {
 			numUsers = n
 		}
 	}
@@ -55,20 +108,42 @@ func main() {
 		log.Fatal("Failed to connect:", err)
 	}
 
-	fmt.Printf("Starting seed with %d users...\n", numUsers)
+	fmt.Printf("Starting seed with %d users (seed %d, seasons %s, %s scores)...\n",
+		numUsers, opts.rngSeed, strings.Join(opts.seasons, ","), *distributionName)
 
 	// Seed users and scores
-	if err := seedData(db, numUsers); err != nil {
+	if err := seedData(db, numUsers, opts); err != nil {
 		log.Fatal(err)
 	}
 
 	fmt.Println("Seeding complete!")
 }
 
-func seedData(db *sql.DB, numUsers int) error {
+// parseSeasons splits the --seasons value, dropping blanks and duplicates
+func parseSeasons(value string) ([]string, error) {
+	var seasons []string
+	seen := make(map[string]bool)
+	for _, season := range strings.Split(value, ",") {
+		season = strings.TrimSpace(season)
+		if season == "" || seen[season] {
+			continue
+		}
+		if len(season) > 50 {
+			return nil, fmt.Errorf("season %q is longer than 50 characters", season)
+		}
+		seen[season] = true
+		seasons = append(seasons, season)
+	}
+	if len(seasons) == 0 {
+		return nil, fmt.Errorf("--seasons must list at least one season")
+	}
+	return seasons, nil
+}
+
+func seedData(db *sql.DB, numUsers int, opts seedOptions) error {
 	ctx := context.Background()
 	batchSize := 10000
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rng := rand.New(rand.NewSource(opts.rngSeed))
 
 	names := []string{
 		"Alex", "Bailey", "Casey", "Dakota", "Evan", "Finley", "Graham", "Harper",
@@ -87,7 +162,11 @@ func seedData(db *sql.DB, numUsers int) error {
 		"gmail.com", "yahoo.com", "hotmail.com", "outlook.com", "proton.me", "test.local",
 	}
 
-	seasons := []string{"global", "season_1", "season_2", "season_3"}
+	countries := []string{"US", "GB", "DE", "FR", "BR", "JP", "KR", "PL", "UA", "CA"}
+
+	// Weighted so that most players end up in the lower tiers
+	tiers := []string{"bronze", "bronze", "bronze", "silver", "silver", "gold", "platinum"}
+
 	passwordHash := "$2a$10$dummy_hash_for_load_test"
 
 	for batch := 0; batch*batchSize < numUsers; batch++ {
@@ -103,14 +182,17 @@ func seedData(db *sql.DB, numUsers int) error {
 			// Generate realistic user data
 			name := names[rng.Intn(len(names))] + " " + surnames[rng.Intn(len(surnames))]
 			email := fmt.Sprintf("player_%d@%s", i, domains[rng.Intn(len(domains))])
+			avatarURL := fmt.Sprintf("https://avatars.example.com/player_%d.png", i)
+			country := countries[rng.Intn(len(countries))]
+			tier := tiers[rng.Intn(len(tiers))]
 
 			// Insert user
 			var userID string
 			err := tx.QueryRowContext(ctx,
-				`INSERT INTO users (name, email, password_hash) 
-				 VALUES ($1, $2, $3) 
+				`INSERT INTO users (name, email, password_hash, avatar_url, country, tier)
+				 VALUES ($1, $2, $3, $4, $5, $6)
 				 RETURNING id`,
-				name, email, passwordHash,
+				name, email, passwordHash, avatarURL, country, tier,
 			).Scan(&userID)
 			if err != nil {
 				_ = tx.Rollback()
@@ -122,12 +204,8 @@ func seedData(db *sql.DB, numUsers int) error {
 			}
 
 			// Insert scores for multiple seasons (realistic distribution)
-			for _, season := range seasons {
-				// Use normal-like distribution for scores (most players mid-range, few at extremes)
-				score := gaussianScore(rng, 0, 500000, 1000000)
-				if score < 0 {
-					score = 0
-				}
+			for _, season := range opts.seasons {
+				score := opts.distribution(rng, 0, 500000, 1000000)
 
 				_, err := tx.ExecContext(ctx,
 					`INSERT INTO scores (user_id, score, season, timestamp)
@@ -171,3 +249,14 @@ func gaussianScore(rng *rand.Rand, min, mean, max float64) int64 {
 
 	return int64(math.Round(value))
 }
+
+// uniformScore spreads scores evenly between min and max; mean is ignored
+func uniformScore(rng *rand.Rand, min, mean, max float64) int64 {
+	return int64(math.Round(min + rng.Float64()*(max-min)))
+}
+
+// powerScore skews scores towards min like real games: most players score low, few reach the top.
+// mean is ignored; the median lands at about 1/8 of the range.
+func powerScore(rng *rand.Rand, min, mean, max float64) int64 {
+	return int64(math.Round(min + math.Pow(rng.Float64(), 3)*(max-min)))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeasons(t *testing.T) {
+	seasons, err := parseSeasons(" global, season1,,season2,global ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"global", "season1", "season2"}, seasons)
+
+	_, err = parseSeasons(" , ")
+	assert.Error(t, err)
+}
+
+func TestDistributions_StayInRangeAndRepeatForSameSeed(t *testing.T) {
+	for name, distribution := range distributions {
+		t.Run(name, func(t *testing.T) {
+			first, second := rand.New(rand.NewSource(42)), rand.New(rand.NewSource(42))
+			for i := 0; i < 1000; i++ {
+				score := distribution(first, 0, 500, 1000)
+				assert.Equal(t, score, distribution(second, 0, 500, 1000))
+				assert.GreaterOrEqual(t, score, int64(0))
+				assert.LessOrEqual(t, score, int64(1000))
+			}
+		})
+	}
+}
+
+func TestPowerScore_SkewsTowardsLowScores(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	low := 0
+	for i := 0; i < 1000; i++ {
+		if powerScore(rng, 0, 500, 1000) < 500 {
+			low++
+		}
+	}
+	// P(u^3 < 0.5) = 0.5^(1/3) ≈ 0.79
+	assert.Greater(t, low, 700)
+}
@@ -10,23 +10,42 @@ import (
 	"time"
 
 	authhandler "leaderboard-service/internal/auth/handler"
-	authrepo "leaderboard-service/internal/auth/repository"
 	authservice "leaderboard-service/internal/auth/service"
+	challengehandler "leaderboard-service/internal/challenge/handler"
+	challengerepository "leaderboard-service/internal/challenge/repository"
+	challengeservice "leaderboard-service/internal/challenge/service"
+	"leaderboard-service/internal/factory"
 	"leaderboard-service/internal/handlers"
+	"leaderboard-service/internal/jobs"
 	leaderboardhandler "leaderboard-service/internal/leaderboard/handler"
-	leaderboardrepo "leaderboard-service/internal/leaderboard/repository"
+	leaderboardrepository "leaderboard-service/internal/leaderboard/repository"
 	leaderboardservice "leaderboard-service/internal/leaderboard/service"
+	seasonhandler "leaderboard-service/internal/season/handler"
+	seasonrepository "leaderboard-service/internal/season/repository"
+	seasonservice "leaderboard-service/internal/season/service"
+	"leaderboard-service/internal/service"
+	sessionhandler "leaderboard-service/internal/session/handler"
+	sessionrepository "leaderboard-service/internal/session/repository"
+	sessionservice "leaderboard-service/internal/session/service"
+	"leaderboard-service/internal/shared/command"
 	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/metrics"
 	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/repository"
 	"leaderboard-service/internal/shared/repository/decorators"
+	"leaderboard-service/internal/strategy"
 	"leaderboard-service/internal/websocket"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/acme/autocert"
 )
 
+// commandBusWorkers - число очередей шины команд; отправки разных игроков идут параллельно
+const commandBusWorkers = 16
+
 func main() {
 	// Load configuration
 	cfg, err := config.Load()
@@ -76,40 +95,132 @@ func main() {
 		ctx,
 		cfg.GetWebSocketBroadcastInterval(),
 		cfg.WebSocket.DefaultLimit,
-	)
+	).WithLogger(log.With().Str("component", "websocket_hub").Logger()).
+		WithMaxClientLimit(cfg.WebSocket.MaxClientLimit).
+		WithMaxClientsPerSeason(cfg.WebSocket.MaxClientsPerSeason).
+		WithSSEBufferSize(cfg.WebSocket.SSEBufferSize).
+		WithPolling(!cfg.WebSocket.UseDBNotify)
 	go wsHub.Run() // Start hub in background goroutine
 
-	// Create shared cache for decorators
-	cache := decorators.NewSimpleCache()
-
-	// Initialize base repositories
-	baseUserRepo := authrepo.NewPostgresUserRepository(db)
-	baseScoreRepo := leaderboardrepo.NewPostgresScoreRepository(db)
+	// Score changes of every replica (and of direct SQL writes) reach clients without the polling delay
+	if cfg.WebSocket.UseDBNotify {
+		listener := database.NewPostgresNotifyListener(cfg.Database.URL, database.ScoreUpdatesChannel, wsHub.NotifySeasonChanged)
+		go listener.Run(ctx)
+	}
 
-	// Wrap repositories with decorators (Decorator Pattern)
-	// Order: base → cached (Redis for scores, SimpleCache for users) → logged (outermost)
-	userRepo := decorators.NewLoggedUserRepository(
-		decorators.NewCachedUserRepository(baseUserRepo, cache),
-	)
-	scoreRepo := decorators.NewLoggedScoreRepository(
-		decorators.NewRedisCachedScoreRepository(baseScoreRepo, redis), // Use Redis for shared cache between containers
-	)
+	// Build repositories via factory: base → cached (Redis if available, SimpleCache otherwise) → logged
+	repoBuilder := factory.NewRepositoryFactoryBuilder(db, nil).
+		WithAdaptiveCache(redis).
+		WithRankingMethod(cfg.Leaderboard.RankingMethod).
+		WithSlowQueryThreshold(cfg.GetSlowQueryThreshold())
+	// The format is checked by config.Validate, so NewSerializer cannot fail here
+	cacheSerializer, _ := decorators.NewSerializer(cfg.Cache.RedisSerializationFormat)
+	repoBuilder.WithCacheSerializer(cacheSerializer)
+	if cfg.Scoring.EncryptMetadata {
+		// Key format is checked by config.Validate, so the error is unreachable here
+		key, _ := cfg.GetMetadataEncryptionKey()
+		repoBuilder.WithMetadataEncryption(strategy.NewAESGCMEncryptionStrategy(key))
+	}
+	repoFactory := repoBuilder.Build()
+	userRepo := repoFactory.CreateUserRepository()
+	scoreRepo := repoFactory.CreateScoreRepository()
 
-	log.Info().Msg("✅ Repositories initialized with Redis caching (scores) and logging decorators")
+	log.Info().Bool("redis", redis != nil).Msg("✅ Repositories initialized with adaptive caching and logging decorators")
 
 	// Initialize services with decorated repositories
 	authService := authservice.NewAuthService(userRepo, jwtMiddleware, cfg)
-	leaderboardService := leaderboardservice.NewLeaderboardService(scoreRepo, userRepo, redis, cfg)
+	leaderboardService := leaderboardservice.NewLeaderboardService(scoreRepo, userRepo, redis, cfg, leaderboardservice.WithContext(ctx))
 	leaderboardService.SetHub(wsHub) // Connect WebSocket broadcasting
 
-	// Initialize handlers (wsHandler needs leaderboardService for initial snapshots)
-	wsHandler := handlers.NewWebSocketHandler(wsHub, jwtMiddleware, cfg, leaderboardService)
+	// Score submissions of one player and season are executed one at a time, with retries on transient DB errors
+	commandBus := command.NewCommandBus(commandBusWorkers, 256, 1000,
+		strategy.NewExponentialBackoffRetryStrategy(3, 50*time.Millisecond, time.Second, database.IsTransientError))
+	go commandBus.Run(ctx)
+	leaderboardService.SetCommandBus(commandBus)
+
+	// Challenges are settled on every stored score
+	challengeService := challengeservice.NewChallengeService(challengerepository.NewPostgresChallengeRepository(db), userRepo)
+	challengeService.SetDefaultSeason(cfg.GetDefaultSeason())
+	challengeService.SetTenantScoped(cfg.Multitenancy.Enabled)
+	leaderboardService.SetChallengeChecker(challengeService)
+
+	// Rank milestones (SCORING_RANK_MILESTONES) and badges are announced through the log until a push channel exists
+	if len(cfg.Scoring.RankMilestones) > 0 || len(cfg.Scoring.AchievementThresholds) > 0 {
+		leaderboardService.SetNotificationService(
+			leaderboardservice.NewNotificationService(strategy.NewLogNotificationStrategy(), cfg.Scoring.RankMilestones))
+	}
+
+	// Badges for score thresholds (SCORING_ACHIEVEMENT_THRESHOLDS)
+	if len(cfg.Scoring.AchievementThresholds) > 0 {
+		leaderboardService.SetAchievementRepository(
+			leaderboardrepository.NewPostgresAchievementRepository(db), cfg.Scoring.AchievementThresholds)
+	}
+
+	// Season cloning copies scores in one transaction
+	leaderboardService.SetUnitOfWork(repoFactory.CreateUnitOfWork())
+
+	// Score limits from scoring_configs override VALIDATION_* and are reloaded every minute
+	leaderboardService.SetScoringConfigRepository(leaderboardrepository.NewPostgresScoringConfigEntryRepository(db))
+	if err := leaderboardService.ReloadScoringConfig(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to load scoring config, using static validation limits")
+	}
+
+	// Periodic maintenance runs in the job scheduler; state is visible at GET /admin/jobs
+	scheduler := jobs.NewScheduler()
+	mustRegisterJob(scheduler, jobs.NewFuncJob("scoring_config_reload",
+		leaderboardservice.ScoringConfigRefreshInterval, leaderboardService.ReloadScoringConfig))
+	if cfg.Leaderboard.UseMaterializedView {
+		mustRegisterJob(scheduler, jobs.NewFuncJob("leaderboard_view_refresh",
+			cfg.GetLeaderboardViewRefreshInterval(), leaderboardService.RefreshLeaderboardView))
+	}
+	go scheduler.Run(ctx)
+
+	// /ready answers 503 until the score caches are warm (at most CacheWarmupTimeout)
+	leaderboardService.StartCacheWarmup(ctx, leaderboardservice.CacheWarmupTimeout, cfg.GetDefaultSeason())
+
+	// Season metadata is cached in memory; updates go through this process and drop the entry
+	seasonService := seasonservice.NewSeasonService(decorators.NewCachedSeasonRepository(
+		seasonrepository.NewPostgresSeasonRepository(db), decorators.NewSimpleCache()))
+
+	// Unit of Work uses undecorated repositories inside the transaction
+	userManagementService := service.NewUserManagementService(repoFactory.CreateUnitOfWork())
+	userManagementService.SetDefaultSeason(cfg.GetDefaultSeason())
+
+	// Initialize handlers
 	authHandler := authhandler.NewAuthHandler(authService)
-	leaderboardHandler := leaderboardhandler.NewLeaderboardHandler(leaderboardService)
+	var leaderboardAPI leaderboardhandler.LeaderboardServiceInterface = leaderboardService
+	var streamService handlers.LeaderboardStreamService = leaderboardService
+	if cfg.Multitenancy.Enabled {
+		// Seasons are stored as "{tenant}:{season}"; the tenant comes from the JWT claim or X-API-Key
+		multiTenant := leaderboardservice.NewMultiTenantLeaderboardService(leaderboardService)
+		leaderboardAPI, streamService = multiTenant, multiTenant
+		log.Info().Int("api_keys", len(cfg.Multitenancy.APIKeys)).Msg("Multitenancy enabled")
+	}
+	// wsHandler needs the stream service for initial snapshots and tenant seasons
+	wsHandler := handlers.NewWebSocketHandler(wsHub, jwtMiddleware, cfg, streamService)
+	leaderboardHandler := leaderboardhandler.NewLeaderboardHandler(leaderboardAPI)
+
+	// Every strategy the factory can build, by name; listed at GET /admin/strategies
+	strategyRegistry := strategy.NewDefaultStrategyRegistry()
+
+	// Game sessions live in Redis; without it the session endpoints answer 503
+	var sessionRepo repository.GameSessionRepository
+	if redis != nil {
+		sessionRepo = sessionrepository.NewRedisGameSessionRepository(redis)
+	}
+	sessionHandler := sessionhandler.NewSessionHandler(
+		sessionservice.NewGameSessionService(sessionRepo, leaderboardAPI, strategyRegistry, cfg.GetSessionTTL()))
+	strategiesHandler := handlers.NewStrategiesHandler(strategyRegistry)
+	leaderboardHandler.SetDefaultSeason(cfg.GetDefaultSeason())
 	healthHandler := handlers.NewHealthHandler(db, redis)
+	healthHandler.SetCacheReadiness(leaderboardService)
+	userAdminHandler := handlers.NewUserAdminHandler(userManagementService)
+	challengeHandler := challengehandler.NewChallengeHandler(challengeService)
+	seasonHandler := seasonhandler.NewSeasonHandler(seasonService)
+	jobsHandler := handlers.NewJobsHandler(scheduler)
 
 	// Setup router
-	r := setupRouter(cfg, jwtMiddleware, rateLimiter, authHandler, leaderboardHandler, healthHandler, wsHandler)
+	r := setupRouter(cfg, jwtMiddleware, rateLimiter, authHandler, leaderboardHandler, healthHandler, wsHandler, userAdminHandler, challengeHandler, seasonHandler, jobsHandler, sessionHandler, strategiesHandler)
 
 	// Create HTTP server
 	addr := fmt.Sprintf(":%s", cfg.Server.Port)
@@ -120,11 +231,12 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	// srv.Shutdown waits for active handlers, so SSE streams must end when it starts
+	srv.RegisterOnShutdown(wsHub.CloseEventStreams)
 
 	// Start server in a goroutine
 	go func() {
-		log.Info().Str("address", addr).Msg("Server listening")
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := serve(srv, cfg.Server); err != nil && err != http.ErrServerClosed {
 			log.Fatal().Err(err).Msg("Server failed to start")
 		}
 	}()
@@ -143,20 +255,86 @@ func main() {
 		log.Error().Err(err).Msg("Server forced to shutdown")
 	}
 
+	// srv.Shutdown does not wait for hijacked WebSocket connections, so drain them separately
+	if err := wsHub.Shutdown(shutdownCtx); err != nil {
+		log.Warn().Err(err).Msg("WebSocket clients did not disconnect in time")
+	}
+
+	// Stop background jobs and broadcasts, let a running job finish
+	cancel()
+	select {
+	case <-scheduler.Done():
+	case <-shutdownCtx.Done():
+		log.Warn().Msg("Background jobs did not stop in time")
+	}
+
 	log.Info().Msg("Server stopped")
 }
 
+// serve starts the server over HTTPS when TLS is configured (Let's Encrypt or certificate files), plain HTTP otherwise
+func serve(srv *http.Server, cfg config.ServerConfig) error {
+	if cfg.AutoTLS {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.TLSDomain),
+			Cache:      autocert.DirCache(cfg.TLSCacheDir),
+		}
+		srv.TLSConfig = manager.TLSConfig()
+		log.Info().Str("address", srv.Addr).Str("domain", cfg.TLSDomain).Msg("Server listening (HTTPS, Let's Encrypt)")
+		return srv.ListenAndServeTLS("", "")
+	}
+
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" {
+		certFile, keyFile, ok := tlsCertificateFiles(cfg)
+		if !ok {
+			return fmt.Errorf("TLS certificate %q or key %q does not exist", cfg.TLSCertFile, cfg.TLSKeyFile)
+		}
+		log.Info().Str("address", srv.Addr).Msg("Server listening (HTTPS)")
+		return srv.ListenAndServeTLS(certFile, keyFile)
+	}
+
+	log.Info().Str("address", srv.Addr).Msg("Server listening")
+	return srv.ListenAndServe()
+}
+
+// tlsCertificateFiles returns the configured certificate and key files when both are set and exist on disk
+func tlsCertificateFiles(cfg config.ServerConfig) (certFile, keyFile string, ok bool) {
+	if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+		return "", "", false
+	}
+	for _, path := range []string{cfg.TLSCertFile, cfg.TLSKeyFile} {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			return "", "", false
+		}
+	}
+	return cfg.TLSCertFile, cfg.TLSKeyFile, true
+}
+
+// mustRegisterJob adds a job to the scheduler; a bad schedule or duplicate name is a programming error
+func mustRegisterJob(scheduler *jobs.Scheduler, job jobs.Job) {
+	if err := scheduler.Register(job); err != nil {
+		log.Fatal().Err(err).Msg("Failed to register background job")
+	}
+}
+
 // setupRouter configures all routes and middleware
 func setupRouter(
-	_ *config.Config,
+	cfg *config.Config,
 	jwtMiddleware *middleware.JWTMiddleware,
 	rateLimiter *middleware.RateLimiter,
 	authHandler *authhandler.AuthHandler,
 	leaderboardHandler *leaderboardhandler.LeaderboardHandler,
 	healthHandler *handlers.HealthHandler,
 	wsHandler *handlers.WebSocketHandler,
+	userAdminHandler *handlers.UserAdminHandler,
+	challengeHandler *challengehandler.ChallengeHandler,
+	seasonHandler *seasonhandler.SeasonHandler,
+	jobsHandler *handlers.JobsHandler,
+	sessionHandler *sessionhandler.SessionHandler,
+	strategiesHandler *handlers.StrategiesHandler,
 ) *chi.Mux {
 	r := chi.NewRouter()
+	tenants := middleware.NewTenantMiddleware(cfg) // no-op unless multitenancy is enabled
 
 	// Global middleware
 	r.Use(chimiddleware.RequestID)
@@ -170,6 +348,7 @@ func setupRouter(
 	r.Get("/health", healthHandler.Health)
 	r.Get("/ready", healthHandler.Readiness)
 	r.Get("/live", healthHandler.Liveness)
+	r.Method(http.MethodGet, "/metrics", metrics.Handler())
 
 	// API v1 routes
 	r.Route("/api/v1", func(r chi.Router) {
@@ -180,27 +359,89 @@ func setupRouter(
 			r.Post("/auth/login", authHandler.Login)
 		})
 
+		// Public leaderboard shorthand (publicly cacheable)
+		r.Group(func(r chi.Router) {
+			r.Use(rateLimiter.Limit)
+			r.Use(tenants.Resolve)
+			r.Get("/leaderboard/top", leaderboardHandler.GetTop)
+		})
+
 		// Protected leaderboard endpoints
 		r.Group(func(r chi.Router) {
 			r.Use(jwtMiddleware.Authenticate) // Require JWT
 			r.Use(rateLimiter.Limit)          // Apply rate limiting
+			r.Use(tenants.Resolve)            // Scope seasons to the caller's tenant
 
 			// Leaderboard operations
 			r.Post("/submit-score", leaderboardHandler.SubmitScore)
 			r.Get("/leaderboard", leaderboardHandler.GetLeaderboard)
+			r.Get("/leaderboard/nearby", leaderboardHandler.GetNearby)
+			r.Get("/leaderboard/global-standings", leaderboardHandler.GetGlobalStandings)
+			r.Get("/leaderboard/cross-season", leaderboardHandler.GetCrossSeasonRankings)
+			r.Get("/leaderboard/country/{countryCode}", leaderboardHandler.GetByCountry)
+			r.Get("/leaderboard/distribution", leaderboardHandler.GetDistribution)
+			r.Get("/leaderboard/leagues", leaderboardHandler.GetLeagues)
 			r.Get("/leaderboard/user/{userID}", leaderboardHandler.GetUserRank)
+			r.Get("/leaderboard/user/{userID}/league", leaderboardHandler.GetUserLeague)
+			r.Get("/leaderboard/summary/{userID}", leaderboardHandler.GetSummary)
+			r.Get("/users/{userID}/achievements", leaderboardHandler.GetAchievements)
+			r.Patch("/users/{userID}/password", authHandler.ChangePassword)
+			r.Delete("/leaderboard/user/{userID}/season/{season}", leaderboardHandler.DeleteScore)
+		})
+
+		// Player challenges
+		r.Group(func(r chi.Router) {
+			r.Use(jwtMiddleware.Authenticate)
+			r.Use(rateLimiter.Limit)
+			r.Use(tenants.Resolve) // Challenges live in the tenant's seasons
+			r.Post("/challenges", challengeHandler.Create)
+			r.Get("/challenges", challengeHandler.List)
+			r.Get("/challenges/{id}", challengeHandler.Get)
+			r.Post("/challenges/{id}/accept", challengeHandler.Accept)
+			r.Delete("/challenges/{id}", challengeHandler.Cancel)
+		})
+
+		// Game sessions: scores are recalculated with the session's scoring strategy
+		r.Group(func(r chi.Router) {
+			r.Use(jwtMiddleware.Authenticate)
+			r.Use(rateLimiter.Limit)
+			r.Use(tenants.Resolve)
+			r.Post("/sessions", sessionHandler.Create)
+			r.Get("/sessions/{sessionID}", sessionHandler.Get)
+			r.Patch("/sessions/{sessionID}", sessionHandler.Update)
+			r.Delete("/sessions/{sessionID}", sessionHandler.Delete)
+			r.Post("/sessions/{sessionID}/submit-score", sessionHandler.SubmitScore)
+		})
+
+		// Admin endpoints (JWT with admin role)
+		r.Group(func(r chi.Router) {
+			r.Use(jwtMiddleware.Authenticate)
+			r.Use(middleware.RequireRole(middleware.RoleAdmin))
+			r.With(tenants.Resolve).Post("/admin/seasons/{name}/reset", leaderboardHandler.ResetSeason)
+			r.Patch("/admin/seasons/{name}", seasonHandler.Update)
+			r.With(tenants.Resolve).Put("/admin/scoring-configs/{key}", leaderboardHandler.UpdateScoringConfig)
+			r.With(tenants.Resolve).Get("/admin/scores", leaderboardHandler.FindScoresByMetadata)
+			r.Post("/admin/users/bulk", userAdminHandler.BulkRegister)
+			r.Get("/admin/jobs", jobsHandler.List)
+			r.Get("/admin/strategies", strategiesHandler.List)
 		})
 
 		// WebSocket endpoints (NO middleware - validates token from query param)
 		r.Get("/ws/leaderboard", wsHandler.HandleLeaderboard)
+		r.Get("/sse/leaderboard", wsHandler.HandleLeaderboardSSE)
 
-		// Test/Debug endpoints
+		// Test/Debug endpoints (JWT can be switched off for local development)
 		r.Group(func(r chi.Router) {
-			r.Use(jwtMiddleware.Authenticate)
-			r.Post("/test/broadcast", leaderboardHandler.TestBroadcast)
+			if cfg.TestEndpointsAuthDisabled() {
+				log.Warn().Msg("⚠️ Authentication disabled on test endpoints (development mode)")
+			} else {
+				r.Use(jwtMiddleware.Authenticate)
+			}
+			r.With(tenants.Resolve).Post("/test/broadcast", leaderboardHandler.TestBroadcast)
 		})
 
-		// WebSocket stats endpoint
+		// WebSocket stats endpoints: totals are public, per-season counts require JWT
+		r.With(rateLimiter.Limit).Get("/ws/stats/public", wsHandler.HandlePublicStats)
 		r.Group(func(r chi.Router) {
 			r.Use(jwtMiddleware.Authenticate) // Stats requires JWT header
 			r.Get("/ws/stats", wsHandler.HandleStats)
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	authhandler "leaderboard-service/internal/auth/handler"
+	challengehandler "leaderboard-service/internal/challenge/handler"
+	"leaderboard-service/internal/handlers"
+	"leaderboard-service/internal/jobs"
+	leaderboardhandler "leaderboard-service/internal/leaderboard/handler"
+	seasonhandler "leaderboard-service/internal/season/handler"
+	sessionhandler "leaderboard-service/internal/session/handler"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLeaderboardService implements only what the test endpoints call
+type stubLeaderboardService struct {
+	leaderboardhandler.LeaderboardServiceInterface
+}
+
+func (s *stubLeaderboardService) BroadcastLeaderboard(ctx context.Context, season string) error {
+	return nil
+}
+
+func newTestRouter(cfg *config.Config) http.Handler {
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+	return setupRouter(
+		cfg,
+		jwtMiddleware,
+		middleware.NewRateLimiter(cfg),
+		authhandler.NewAuthHandler(nil),
+		leaderboardhandler.NewLeaderboardHandler(&stubLeaderboardService{}),
+		handlers.NewHealthHandler(nil, nil),
+		handlers.NewWebSocketHandler(nil, jwtMiddleware, cfg, nil),
+		handlers.NewUserAdminHandler(nil),
+		challengehandler.NewChallengeHandler(nil),
+		seasonhandler.NewSeasonHandler(nil),
+		handlers.NewJobsHandler(jobs.NewScheduler()),
+		sessionhandler.NewSessionHandler(nil),
+		handlers.NewStrategiesHandler(strategy.NewStrategyRegistry()),
+	)
+}
+
+func TestSetupRouter_TestEndpointsAuth(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		disableAuth bool
+		expected    int
+	}{
+		{"production requires auth", "production", false, http.StatusUnauthorized},
+		{"production ignores flag", "production", true, http.StatusUnauthorized},
+		{"development requires auth by default", "development", false, http.StatusUnauthorized},
+		{"development with flag skips auth", "development", true, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Server: config.ServerConfig{
+					Env:                        tt.env,
+					DisableAuthOnTestEndpoints: tt.disableAuth,
+				},
+				JWT:       config.JWTConfig{Secret: "test-secret-key", ExpiryHours: 24},
+				RateLimit: config.RateLimitConfig{Requests: 100, WindowSeconds: 60},
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/test/broadcast?season=global", nil)
+			rr := httptest.NewRecorder()
+
+			newTestRouter(cfg).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected, rr.Code)
+		})
+	}
+}
+
+func TestTLSCertificateFiles(t *testing.T) {
+	dir := t.TempDir()
+	cert := filepath.Join(dir, "server.crt")
+	key := filepath.Join(dir, "server.key")
+	require.NoError(t, os.WriteFile(cert, []byte("cert"), 0o600))
+	require.NoError(t, os.WriteFile(key, []byte("key"), 0o600))
+
+	tests := []struct {
+		name      string
+		cert, key string
+		wantOK    bool
+	}{
+		{"both files exist", cert, key, true},
+		{"not configured", "", "", false},
+		{"key missing from config", cert, "", false},
+		{"key file missing on disk", cert, filepath.Join(dir, "missing.key"), false},
+		{"cert is a directory", dir, key, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certFile, keyFile, ok := tlsCertificateFiles(config.ServerConfig{TLSCertFile: tt.cert, TLSKeyFile: tt.key})
+
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.cert, certFile)
+				assert.Equal(t, tt.key, keyFile)
+			}
+		})
+	}
+}
+
+func TestServe_MissingCertificateFilesFailFast(t *testing.T) {
+	srv := &http.Server{Addr: "127.0.0.1:0"}
+
+	err := serve(srv, config.ServerConfig{TLSCertFile: "/nonexistent/server.crt", TLSKeyFile: "/nonexistent/server.key"})
+
+	assert.Error(t, err, "a configured but missing certificate must not fall back to plain HTTP")
+}
@@ -2,33 +2,28 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
 	"math/rand"
 	"os"
 	"os/signal"
+	"sort"
 	"syscall"
 	"time"
 
-	authrepo "leaderboard-service/internal/auth/repository"
+	"leaderboard-service/internal/factory"
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
-	leaderboardrepo "leaderboard-service/internal/leaderboard/repository"
 	leaderboardservice "leaderboard-service/internal/leaderboard/service"
 	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/database"
 	"leaderboard-service/internal/shared/middleware"
-	"leaderboard-service/internal/shared/repository/decorators"
+	"leaderboard-service/internal/shared/repository"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
-const (
-	updateInterval   = 5 * time.Second
-	minScore         = 100
-	maxScore         = 10000
-	scoreIncrement   = 50
-	minUsersRequired = 2
-)
+// minUsersRequired is the smallest player pool that makes a leaderboard worth simulating
+const minUsersRequired = 2
 
 type existingUser struct {
 	ID   uuid.UUID
@@ -36,6 +31,11 @@ type existingUser struct {
 }
 
 func main() {
+	stress := flag.Bool("stress", false, "submit scores from concurrent workers as fast as possible and print throughput and latency")
+	concurrency := flag.Int("concurrency", 50, "number of stress workers")
+	duration := flag.Duration("duration", 60*time.Second, "length of the stress run")
+	flag.Parse()
+
 	log.Info().Msg("🎮 Starting Leaderboard Score Simulator")
 
 	// Load configuration
@@ -47,6 +47,12 @@ func main() {
 	// Setup logger
 	middleware.SetupLogger(cfg.Log.Level)
 
+	// Simulation settings come from SIMULATION_* variables
+	if err := cfg.ValidateSimulation(); err != nil {
+		log.Fatal().Err(err).Msg("Invalid simulation configuration")
+	}
+	sim := cfg.Simulation
+
 	// Initialize database
 	db, err := database.NewPostgresDB(cfg)
 	if err != nil {
@@ -71,44 +77,61 @@ func main() {
 		}()
 	}
 
-	// Create cache for decorators
-	cache := decorators.NewSimpleCache()
-
-	// Initialize repositories with decorators (use Redis for scores to share cache with API)
-	baseUserRepo := authrepo.NewPostgresUserRepository(db)
-	baseScoreRepo := leaderboardrepo.NewPostgresScoreRepository(db)
-
-	userRepo := decorators.NewCachedUserRepository(baseUserRepo, cache)
-	scoreRepo := decorators.NewRedisCachedScoreRepository(baseScoreRepo, redis) // Use Redis for shared cache
+	// Initialize repositories via factory (Redis for scores when available to share cache with API)
+	repoFactory := factory.NewRepositoryFactoryBuilder(db, nil).
+		WithAdaptiveCache(redis).
+		WithoutLogging().
+		Build()
+	userRepo := repoFactory.CreateUserRepository()
+	scoreRepo := repoFactory.CreateScoreRepository()
 
 	// Initialize services
 	leaderboardService := leaderboardservice.NewLeaderboardService(scoreRepo, userRepo, redis, cfg)
 
 	// Load existing users from database
-	users := loadExistingUsers(db)
+	users := loadExistingUsers(context.Background(), userRepo)
 	if len(users) < minUsersRequired {
 		log.Fatal().Int("found", len(users)).Int("required", minUsersRequired).Msg("Not enough users in database. Please register users first!")
 	}
 
 	log.Info().Int("count", len(users)).Msg("✅ Loaded existing users from database")
 
+	if *stress {
+		if *concurrency < 1 || *duration <= 0 {
+			log.Fatal().Int("concurrency", *concurrency).Dur("duration", *duration).Msg("Stress test needs a positive concurrency and duration")
+		}
+		// Ctrl+C прерывает прогон, но сводка все равно печатается
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		log.Info().Int("concurrency", *concurrency).Dur("duration", *duration).Msg("🔥 Starting stress test")
+		report := runStressTest(ctx, leaderboardService, users, sim, *concurrency, *duration)
+		report.Print(os.Stdout)
+		return
+	}
+
 	// Setup graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	// Start simulation ticker
-	ticker := time.NewTicker(updateInterval)
+	ticker := time.NewTicker(cfg.GetSimulationUpdateInterval())
 	defer ticker.Stop()
 
-	log.Info().Dur("interval", updateInterval).Msg("🚀 Starting score simulation loop")
+	log.Info().
+		Dur("interval", cfg.GetSimulationUpdateInterval()).
+		Int64("min_score", sim.MinScore).
+		Int64("max_score", sim.MaxScore).
+		Interface("season_weights", sim.SeasonWeights).
+		Msg("🚀 Starting score simulation loop")
 
 	// Initial scores
-	simulateScoreUpdates(leaderboardService, users)
+	simulateScoreUpdates(leaderboardService, users, sim, cfg.GetDefaultSeason())
 
 	for {
 		select {
 		case <-ticker.C:
-			simulateScoreUpdates(leaderboardService, users)
+			simulateScoreUpdates(leaderboardService, users, sim, cfg.GetDefaultSeason())
 		case <-quit:
 			log.Info().Msg("🛑 Shutting down simulator...")
 			return
@@ -116,36 +139,36 @@ func main() {
 	}
 }
 
-// loadExistingUsers loads all existing users from the database
-func loadExistingUsers(db *database.PostgresDB) []existingUser {
+// userPageSize is how many users loadExistingUsers reads per repository call
+const userPageSize = 500
+
+// loadExistingUsers loads all existing users from the database, newest first
+func loadExistingUsers(ctx context.Context, userRepo repository.UserRepository) []existingUser {
 	users := make([]existingUser, 0)
-	ctx := context.Background()
 
-	// Use GORM to query users
-	var dbUsers []struct {
-		ID   uuid.UUID `gorm:"column:id"`
-		Name string    `gorm:"column:name"`
-	}
+	for offset := 0; ; offset += userPageSize {
+		page, total, err := userRepo.FindAll(ctx, userPageSize, offset)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to query users from database")
+			return users
+		}
 
-	err := db.DB.WithContext(ctx).Table("users").Select("id, name").Order("created_at DESC").Find(&dbUsers).Error
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to query users from database")
-		return users
-	}
+		for _, u := range page {
+			users = append(users, existingUser{
+				ID:   u.ID,
+				Name: u.Name,
+			})
+			log.Debug().Str("name", u.Name).Str("id", u.ID.String()).Msg("Loaded user")
+		}
 
-	for _, u := range dbUsers {
-		users = append(users, existingUser{
-			ID:   u.ID,
-			Name: u.Name,
-		})
-		log.Debug().Str("name", u.Name).Str("id", u.ID.String()).Msg("Loaded user")
+		if len(page) < userPageSize || int64(offset+len(page)) >= total {
+			return users
+		}
 	}
-
-	return users
 }
 
 // simulateScoreUpdates randomly updates scores for existing users
-func simulateScoreUpdates(leaderboardService *leaderboardservice.LeaderboardService, users []existingUser) {
+func simulateScoreUpdates(leaderboardService *leaderboardservice.LeaderboardService, users []existingUser, sim config.SimulationConfig, defaultSeason string) {
 	ctx := context.Background()
 
 	// Pick 30-50% of users to update (minimum 2, maximum all)
@@ -173,20 +196,15 @@ func simulateScoreUpdates(leaderboardService *leaderboardservice.LeaderboardServ
 		var newScore int64
 		if rand.Float32() < 0.7 {
 			// 70% chance: increment score
-			increment := int64(rand.Intn(5)+1) * scoreIncrement
+			increment := int64(rand.Intn(5)+1) * sim.ScoreIncrement
 			newScore = increment
 		} else {
 			// 30% chance: set random score
-			newScore = int64(rand.Intn(maxScore-minScore) + minScore)
+			newScore = rand.Int63n(sim.MaxScore-sim.MinScore) + sim.MinScore
 		}
 
-		// Choose season: 60% global, 40% random season1-5
-		season := "global"
-		if rand.Float32() < 0.4 {
-			// Pick random season from season1-5
-			seasonNum := rand.Intn(5) + 1
-			season = fmt.Sprintf("season%d", seasonNum)
-		}
+		// Choose season according to SIMULATION_SEASON_WEIGHTS
+		season := pickSeason(sim.SeasonWeights, rand.Float64())
 
 		// Submit score
 		submitReq := &leaderboardmodels.SubmitScoreRequest{
@@ -223,7 +241,34 @@ func simulateScoreUpdates(leaderboardService *leaderboardservice.LeaderboardServ
 	}
 
 	// Show current top 5
-	showTopPlayers(leaderboardService, "global")
+	showTopPlayers(leaderboardService, defaultSeason)
+}
+
+// pickSeason maps r in [0, 1) onto the seasons in proportion to their weights.
+// Seasons are walked in name order so the same r always picks the same season.
+func pickSeason(weights map[string]float64, r float64) string {
+	seasons := make([]string, 0, len(weights))
+	total := 0.0
+	for season, weight := range weights {
+		if weight > 0 {
+			seasons = append(seasons, season)
+			total += weight
+		}
+	}
+	if len(seasons) == 0 {
+		// Пустой сезон сервис заменит на Leaderboard.DefaultSeason
+		return ""
+	}
+	sort.Strings(seasons)
+
+	target := r * total
+	for _, season := range seasons {
+		target -= weights[season]
+		if target < 0 {
+			return season
+		}
+	}
+	return seasons[len(seasons)-1]
 }
 
 // showTopPlayers displays the current top 5 leaderboard
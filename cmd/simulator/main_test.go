@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"leaderboard-service/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickSeason(t *testing.T) {
+	weights := map[string]float64{"global": 3, "season1": 1, "disabled": 0}
+
+	// Seasons are laid out by name: global covers [0, 0.75), season1 [0.75, 1)
+	assert.Equal(t, "global", pickSeason(weights, 0))
+	assert.Equal(t, "global", pickSeason(weights, 0.74))
+	assert.Equal(t, "season1", pickSeason(weights, 0.75))
+	assert.Equal(t, "season1", pickSeason(weights, 0.999))
+
+	assert.Empty(t, pickSeason(nil, 0.5), "no weights leaves the season to the service default")
+}
+
+func TestLoadExistingUsers_ReadsEveryPage(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	for i := 0; i < userPageSize+1; i++ {
+		store.AddUser(fmt.Sprintf("player%d", i))
+	}
+
+	users := loadExistingUsers(context.Background(), store.Users)
+
+	assert.Len(t, users, userPageSize+1)
+}
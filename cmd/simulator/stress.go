@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+
+	"github.com/google/uuid"
+)
+
+// scoreSubmitter is the part of LeaderboardService the stress test drives
+type scoreSubmitter interface {
+	SubmitScore(ctx context.Context, userID uuid.UUID, req *leaderboardmodels.SubmitScoreRequest) (*leaderboardmodels.Score, error)
+}
+
+// Границы корзин гистограммы растут в histogramGrowth раз: от 100µs до минуты,
+// погрешность перцентиля не больше 20%
+const (
+	histogramMinLatency = 100 * time.Microsecond
+	histogramMaxLatency = time.Minute
+	histogramGrowth     = 1.2
+)
+
+// histogramBounds are the upper bounds of the latency buckets; the last bucket is unbounded
+var histogramBounds = func() []time.Duration {
+	var bounds []time.Duration
+	for bound := float64(histogramMinLatency); bound < float64(histogramMaxLatency); bound *= histogramGrowth {
+		bounds = append(bounds, time.Duration(bound))
+	}
+	return bounds
+}()
+
+// latencyHistogram counts latencies in exponentially growing buckets.
+// Each worker fills its own histogram; they are merged once the run is over.
+type latencyHistogram struct {
+	counts []int64
+	total  int64
+	max    time.Duration
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{counts: make([]int64, len(histogramBounds)+1)}
+}
+
+func (h *latencyHistogram) Record(latency time.Duration) {
+	i := 0
+	for i < len(histogramBounds) && latency > histogramBounds[i] {
+		i++
+	}
+	h.counts[i]++
+	h.total++
+	if latency > h.max {
+		h.max = latency
+	}
+}
+
+func (h *latencyHistogram) Merge(other *latencyHistogram) {
+	for i, n := range other.counts {
+		h.counts[i] += n
+	}
+	h.total += other.total
+	if other.max > h.max {
+		h.max = other.max
+	}
+}
+
+// Percentile returns the upper bound of the bucket holding the q-th latency (0 < q <= 1).
+// Latencies above histogramMaxLatency report the maximum seen.
+func (h *latencyHistogram) Percentile(q float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	rank := int64(math.Ceil(q * float64(h.total)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int64
+	for i, n := range h.counts {
+		seen += n
+		if seen >= rank {
+			if i == len(histogramBounds) || histogramBounds[i] > h.max {
+				return h.max
+			}
+			return histogramBounds[i]
+		}
+	}
+	return h.max
+}
+
+// workerStats is what one stress worker did
+type workerStats struct {
+	submissions int64
+	errors      int64
+	lastError   error
+	latencies   *latencyHistogram
+}
+
+// stressReport summarizes a stress run
+type stressReport struct {
+	duration  time.Duration
+	workers   []workerStats
+	latencies *latencyHistogram
+}
+
+func (r *stressReport) Submissions() int64 {
+	var total int64
+	for _, w := range r.workers {
+		total += w.submissions
+	}
+	return total
+}
+
+func (r *stressReport) Errors() int64 {
+	var total int64
+	for _, w := range r.workers {
+		total += w.errors
+	}
+	return total
+}
+
+// Print writes the summary table operators read the throughput ceiling from
+func (r *stressReport) Print(out io.Writer) {
+	submissions, errors := r.Submissions(), r.Errors()
+	seconds := r.duration.Seconds()
+	if seconds <= 0 {
+		seconds = 1
+	}
+
+	fmt.Fprintf(out, "\nStress test: %d workers for %s\n", len(r.workers), r.duration.Round(time.Millisecond))
+	fmt.Fprintf(out, "  submissions: %d (%.1f/s)\n", submissions, float64(submissions)/seconds)
+	fmt.Fprintf(out, "  errors:      %d\n", errors)
+	fmt.Fprintf(out, "  latency:     p50 %s  p95 %s  p99 %s  max %s\n",
+		r.latencies.Percentile(0.50), r.latencies.Percentile(0.95), r.latencies.Percentile(0.99), r.latencies.max)
+	fmt.Fprintln(out, "  worker  submissions  errors  last error")
+	for i, w := range r.workers {
+		lastError := "-"
+		if w.lastError != nil {
+			lastError = w.lastError.Error()
+		}
+		fmt.Fprintf(out, "  %6d  %11d  %6d  %s\n", i, w.submissions, w.errors, lastError)
+	}
+}
+
+// runStressTest submits scores from concurrency goroutines as fast as the service accepts them
+// until duration passes or ctx is cancelled. Every submission, failed or not, is timed.
+func runStressTest(ctx context.Context, submitter scoreSubmitter, users []existingUser, sim config.SimulationConfig, concurrency int, duration time.Duration) *stressReport {
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	report := &stressReport{
+		workers:   make([]workerStats, concurrency),
+		latencies: newLatencyHistogram(),
+	}
+	seed := time.Now().UnixNano()
+	started := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(stats *workerStats, rng *rand.Rand) {
+			defer wg.Done()
+			stats.latencies = newLatencyHistogram()
+
+			for ctx.Err() == nil {
+				user := users[rng.Intn(len(users))]
+				req := &leaderboardmodels.SubmitScoreRequest{
+					Score:    rng.Int63n(sim.MaxScore-sim.MinScore+1) + sim.MinScore,
+					Season:   pickSeason(sim.SeasonWeights, rng.Float64()),
+					Metadata: map[string]interface{}{"simulated": true, "stress": true},
+				}
+
+				begin := time.Now()
+				_, err := submitter.SubmitScore(ctx, user.ID, req)
+				latency := time.Since(begin)
+
+				// Отмена контекста в конце прогона обрывает последний запрос; это не ошибка сервиса
+				if err != nil && ctx.Err() != nil {
+					break
+				}
+				stats.latencies.Record(latency)
+				stats.submissions++
+				if err != nil {
+					stats.errors++
+					stats.lastError = err
+				}
+			}
+		}(&report.workers[i], rand.New(rand.NewSource(seed+int64(i))))
+	}
+	wg.Wait()
+
+	report.duration = time.Since(started)
+	for _, w := range report.workers {
+		report.latencies.Merge(w.latencies)
+	}
+	return report
+}
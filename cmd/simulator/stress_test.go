@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyHistogram_Percentiles(t *testing.T) {
+	h := newLatencyHistogram()
+	for i := 1; i <= 100; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	// Bucket bounds overestimate by at most histogramGrowth
+	for _, tt := range []struct {
+		q    float64
+		want time.Duration
+	}{{0.50, 50 * time.Millisecond}, {0.95, 95 * time.Millisecond}, {0.99, 99 * time.Millisecond}} {
+		got := h.Percentile(tt.q)
+		assert.GreaterOrEqual(t, got, tt.want)
+		assert.LessOrEqual(t, float64(got), float64(tt.want)*histogramGrowth)
+	}
+	assert.Equal(t, 100*time.Millisecond, h.Percentile(1), "the top bucket reports the real maximum")
+	assert.Zero(t, newLatencyHistogram().Percentile(0.5))
+}
+
+func TestLatencyHistogram_Merge(t *testing.T) {
+	a, b := newLatencyHistogram(), newLatencyHistogram()
+	a.Record(time.Millisecond)
+	b.Record(2 * time.Hour)
+
+	a.Merge(b)
+
+	assert.Equal(t, int64(2), a.total)
+	assert.Equal(t, 2*time.Hour, a.Percentile(0.99))
+}
+
+// flakySubmitter fails every tenth submission
+type flakySubmitter struct {
+	calls atomic.Int64
+}
+
+func (f *flakySubmitter) SubmitScore(ctx context.Context, userID uuid.UUID, req *leaderboardmodels.SubmitScoreRequest) (*leaderboardmodels.Score, error) {
+	if f.calls.Add(1)%10 == 0 {
+		return nil, errors.New("connection reset")
+	}
+	return &leaderboardmodels.Score{UserID: userID, Score: req.Score, Season: req.Season}, nil
+}
+
+func TestRunStressTest_CountsSubmissionsAndErrors(t *testing.T) {
+	submitter := &flakySubmitter{}
+	users := []existingUser{{ID: uuid.New(), Name: "alice"}, {ID: uuid.New(), Name: "bob"}}
+	sim := config.SimulationConfig{MinScore: 100, MaxScore: 200}
+
+	report := runStressTest(context.Background(), submitter, users, sim, 4, 50*time.Millisecond)
+
+	require.Len(t, report.workers, 4)
+	// A failure caused by the end of the run is not counted, at most one per worker
+	assert.InDelta(t, submitter.calls.Load(), report.Submissions(), 4)
+	assert.InDelta(t, submitter.calls.Load()/10, report.Errors(), 4)
+	assert.Equal(t, report.Submissions(), report.latencies.total)
+	// With GOMAXPROCS=1 the instant stub lets some workers finish the run unscheduled,
+	// so only the total is checked
+	assert.Positive(t, report.Submissions())
+
+	var out bytes.Buffer
+	report.Print(&out)
+	assert.Contains(t, out.String(), "Stress test: 4 workers")
+	assert.Contains(t, out.String(), "p99")
+	assert.Contains(t, out.String(), "connection reset")
+}
+
+func TestRunStressTest_AgainstService(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	users := []existingUser{{ID: store.AddUser("alice")}, {ID: store.AddUser("bob")}}
+	sim := config.SimulationConfig{MinScore: 100, MaxScore: 200, SeasonWeights: map[string]float64{"global": 1}}
+
+	report := runStressTest(context.Background(), store.LeaderboardService(nil), users, sim, 8, 50*time.Millisecond)
+
+	assert.Positive(t, report.Submissions())
+	assert.Zero(t, report.Errors())
+}
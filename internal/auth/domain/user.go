@@ -13,6 +13,10 @@ type User struct {
 	Name      string
 	Email     string
 	Password  string // Хэшированный пароль
+	AvatarURL string
+	Country   string // Код страны ISO 3166-1 alpha-2
+	Tier      string
+	IsAdmin   bool // Может управлять другими пользователями (UserManagementService.PromoteToAdmin)
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
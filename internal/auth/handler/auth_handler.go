@@ -8,8 +8,11 @@ import (
 	"leaderboard-service/internal/auth/service"
 	authservice "leaderboard-service/internal/auth/service"
 	sharedhandlers "leaderboard-service/internal/shared/handlers"
+	"leaderboard-service/internal/shared/middleware"
 	sharedmodels "leaderboard-service/internal/shared/models"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
@@ -48,7 +51,7 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	user, err := h.authService.Register(r.Context(), &req)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to register user")
-		sharedhandlers.RespondError(w, "failed to register user", http.StatusInternalServerError)
+		sharedhandlers.RespondAppError(w, err)
 		return
 	}
 
@@ -76,7 +79,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	loginResp, err := h.authService.Login(r.Context(), &req)
 	if err != nil {
 		log.Error().Err(err).Msg("Login failed")
-		sharedhandlers.RespondError(w, "invalid credentials", http.StatusUnauthorized)
+		sharedhandlers.RespondAppError(w, err)
 		return
 	}
 
@@ -86,3 +89,47 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		Data:    loginResp,
 	}, http.StatusOK)
 }
+
+// ChangePassword replaces the caller's password after checking the current one
+// PATCH /users/{userID}/password
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	requesterID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		sharedhandlers.RespondError(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	// The current password is required, so even admins can only change their own
+	if requesterID != userID {
+		sharedhandlers.RespondError(w, "users can only change their own password", http.StatusForbidden)
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhandlers.RespondError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		sharedhandlers.RespondError(w, "current_password and new_password are required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authService.UpdatePassword(r.Context(), userID, req.CurrentPassword, req.NewPassword); err != nil {
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Password change failed")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "password updated successfully",
+	}, http.StatusOK)
+}
@@ -15,6 +15,10 @@ type UserEntity struct {
 	Name      string    `gorm:"type:varchar(255);not null"`
 	Email     string    `gorm:"type:varchar(255);uniqueIndex;not null"`
 	Password  string    `gorm:"column:password_hash;type:varchar(255);not null"`
+	AvatarURL string    `gorm:"type:text"`
+	Country   string    `gorm:"type:varchar(2)"`
+	Tier      string    `gorm:"type:varchar(32)"`
+	IsAdmin   bool      `gorm:"not null;default:false"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
@@ -31,6 +35,10 @@ func (e *UserEntity) ToDomain() *domain.User {
 		Name:      e.Name,
 		Email:     e.Email,
 		Password:  e.Password,
+		AvatarURL: e.AvatarURL,
+		Country:   e.Country,
+		Tier:      e.Tier,
+		IsAdmin:   e.IsAdmin,
 		CreatedAt: e.CreatedAt,
 		UpdatedAt: e.UpdatedAt,
 	}
@@ -43,6 +51,10 @@ func FromDomainUser(u *domain.User) *UserEntity {
 		Name:      u.Name,
 		Email:     u.Email,
 		Password:  u.Password,
+		AvatarURL: u.AvatarURL,
+		Country:   u.Country,
+		Tier:      u.Tier,
+		IsAdmin:   u.IsAdmin,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
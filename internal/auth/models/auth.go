@@ -7,6 +7,8 @@ type AuthClaims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
 	Role   string    `json:"role"`
+	// TenantID is set only on tokens issued for a tenant's game client
+	TenantID string `json:"tenant_id,omitempty"`
 }
 
 // LoginRequest is the payload for user login
@@ -27,4 +29,12 @@ type RegisterRequest struct {
 	Name     string `json:"name" validate:"required,min=3,max=50"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
+	// Country is an optional ISO 3166-1 alpha-2 code used by country leaderboards
+	Country string `json:"country,omitempty"`
+}
+
+// ChangePasswordRequest is the payload for changing a user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8"`
 }
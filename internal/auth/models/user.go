@@ -12,6 +12,10 @@ type User struct {
 	Name      string    `json:"name" db:"name" gorm:"type:varchar(255);not null"`
 	Email     string    `json:"email,omitempty" db:"email" gorm:"type:varchar(255);uniqueIndex;not null"`
 	Password  string    `json:"-" db:"password_hash" gorm:"column:password_hash;type:varchar(255);not null"`
+	AvatarURL string    `json:"avatar_url,omitempty" db:"avatar_url" gorm:"type:text"`
+	Country   string    `json:"country,omitempty" db:"country" gorm:"type:varchar(2)"` // ISO 3166-1 alpha-2
+	Tier      string    `json:"tier,omitempty" db:"tier" gorm:"type:varchar(32)"`
+	IsAdmin   bool      `json:"is_admin" db:"is_admin" gorm:"not null;default:false"`
 	CreatedAt time.Time `json:"created_at" db:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at" gorm:"autoUpdateTime"`
 }
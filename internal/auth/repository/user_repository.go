@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"leaderboard-service/internal/auth/domain"
 	"leaderboard-service/internal/auth/infrastructure"
@@ -10,37 +13,46 @@ import (
 	"leaderboard-service/internal/shared/repository"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // PostgresUserRepository is a PostgreSQL implementation of UserRepository
 // Использует чистую domain модель и отдельные entities для персистентности
 // Реализует Clean Architecture: domain не зависит от инфраструктуры
 type PostgresUserRepository struct {
-	*repository.BaseRepository[infrastructure.UserEntity]
+	repository.EntityRepository[infrastructure.UserEntity]
 	db *database.PostgresDB
 }
 
 // NewPostgresUserRepository creates a new PostgreSQL user repository
 func NewPostgresUserRepository(db *database.PostgresDB) repository.UserRepository {
 	return &PostgresUserRepository{
-		BaseRepository: repository.NewBaseRepository[infrastructure.UserEntity](db),
-		db:             db,
+		EntityRepository: repository.NewBaseRepository[infrastructure.UserEntity](db),
+		db:               db,
 	}
 }
 
+// NewPostgresUserRepositoryWithSpecCache creates a PostgreSQL user repository whose
+// FindBySpec results are cached until the next write (see repository.CachedBaseRepository)
+func NewPostgresUserRepositoryWithSpecCache(db *database.PostgresDB, cache repository.SpecCache, ttl time.Duration) repository.UserRepository {
+	base := repository.NewBaseRepository[infrastructure.UserEntity](db)
+	return &PostgresUserRepository{
+		EntityRepository: repository.NewCachedBaseRepository[infrastructure.UserEntity](base, cache, ttl),
+		db:               db,
+	}
+}
+
+// InvalidateUserSpecCache drops the cached FindBySpec results of user repositories
+// created with NewPostgresUserRepositoryWithSpecCache over the same cache
+func InvalidateUserSpecCache(cache repository.SpecCache) {
+	cache.DeleteByPrefix(repository.SpecCachePrefix[infrastructure.UserEntity]())
+}
+
 // Create creates a new user in the database
 func (r *PostgresUserRepository) Create(ctx context.Context, user *models.User) error {
 	// Конвертируем domain -> entity для персистентности
-	domainUser := &domain.User{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}
-	entity := infrastructure.FromDomainUser(domainUser)
-	if err := r.BaseRepository.Create(ctx, entity); err != nil {
+	entity := infrastructure.FromDomainUser(toDomainUser(user))
+	if err := r.EntityRepository.Create(ctx, entity); err != nil {
 		return err
 	}
 	// Обновляем ID если он был сгенерирован БД
@@ -48,73 +60,258 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *models.User)
 	return nil
 }
 
+// CreateBatch inserts users in chunks of batchSize
+// Один INSERT на пачку вместо запроса на каждого пользователя
+func (r *PostgresUserRepository) CreateBatch(ctx context.Context, users []*models.User, batchSize int) error {
+	if len(users) == 0 {
+		return nil
+	}
+	entities := make([]*infrastructure.UserEntity, len(users))
+	for i, user := range users {
+		entities[i] = infrastructure.FromDomainUser(toDomainUser(user))
+	}
+	// Через EntityRepository, чтобы пачка тоже сбрасывала кэш спецификаций
+	if err := r.EntityRepository.CreateBatch(ctx, entities, batchSize); err != nil {
+		return fmt.Errorf("failed to create users batch: %w", err)
+	}
+	// Переносим сгенерированные БД ID обратно в модели
+	for i, entity := range entities {
+		users[i].ID = entity.ID
+	}
+	return nil
+}
+
+// CreateBatchSkippingExisting inserts users in chunks of batchSize with ON CONFLICT (email) DO NOTHING
+// Users whose email is already taken keep uuid.Nil as their ID
+func (r *PostgresUserRepository) CreateBatchSkippingExisting(ctx context.Context, users []*models.User, batchSize int) error {
+	if len(users) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = len(users)
+	}
+	// INSERT идет мимо EntityRepository, поэтому кэш спецификаций сбрасывается явно
+	if cached, ok := r.EntityRepository.(interface{ Invalidate() }); ok {
+		defer cached.Invalidate()
+	}
+
+	for start := 0; start < len(users); start += batchSize {
+		batch := users[start:min(start+batchSize, len(users))]
+
+		rows := make([]string, len(batch))
+		args := make([]interface{}, 0, len(batch)*7)
+		for i, user := range batch {
+			rows[i] = "(?, ?, ?, ?, ?, ?, ?)"
+			args = append(args, user.Name, user.Email, user.Password, user.AvatarURL, user.Country, user.Tier, user.IsAdmin)
+		}
+
+		// RETURNING отдает только вставленные строки, сопоставляем их с пользователями по email
+		var inserted []struct {
+			ID    uuid.UUID
+			Email string
+		}
+		query := "INSERT INTO users (name, email, password_hash, avatar_url, country, tier, is_admin) VALUES " +
+			strings.Join(rows, ", ") + " ON CONFLICT (email) DO NOTHING RETURNING id, email"
+		if err := r.db.DB.WithContext(ctx).Raw(query, args...).Scan(&inserted).Error; err != nil {
+			return fmt.Errorf("failed to create users batch: %w", err)
+		}
+
+		ids := make(map[string]uuid.UUID, len(inserted))
+		for _, row := range inserted {
+			ids[row.Email] = row.ID
+		}
+		for _, user := range batch {
+			user.ID = ids[user.Email]
+		}
+	}
+	return nil
+}
+
 // FindByID retrieves a user by their UUID
 func (r *PostgresUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
-	entity, err := r.BaseRepository.FindOne(ctx, "id = ?", id)
+	entity, err := r.EntityRepository.FindOne(ctx, "id = ?", id)
 	if err != nil {
 		return nil, err
 	}
-	domainUser := entity.ToDomain()
-	return &models.User{
-		ID:        domainUser.ID,
-		Name:      domainUser.Name,
-		Email:     domainUser.Email,
-		Password:  domainUser.Password,
-		CreatedAt: domainUser.CreatedAt,
-		UpdatedAt: domainUser.UpdatedAt,
-	}, nil
+	return toUserModel(entity), nil
+}
+
+// FindByIDs retrieves users by their UUIDs with a single IN query
+func (r *PostgresUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.User, error) {
+	users := make(map[uuid.UUID]*models.User, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+	entities, err := r.EntityRepository.FindAll(ctx, "id IN (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		users[entity.ID] = toUserModel(entity)
+	}
+	return users, nil
+}
+
+// FindAll retrieves a page of users ordered by creation date, newest first, and the total count
+// Метод перекрывает EntityRepository.FindAll с условием; тот по-прежнему доступен через r.EntityRepository
+func (r *PostgresUserRepository) FindAll(ctx context.Context, limit, offset int) ([]*models.User, int64, error) {
+	var entities []*infrastructure.UserEntity
+	err := r.db.DB.WithContext(ctx).Order("created_at DESC").Offset(offset).Limit(limit).Find(&entities).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var total int64
+	if err := r.db.DB.WithContext(ctx).Model(&infrastructure.UserEntity{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	users := make([]*models.User, len(entities))
+	for i, entity := range entities {
+		users[i] = toUserModel(entity)
+	}
+	return users, total, nil
 }
 
 // FindByEmail retrieves a user by their email address
 func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
-	entity, err := r.BaseRepository.FindOne(ctx, "email = ?", email)
+	entity, err := r.EntityRepository.FindOne(ctx, "email = ?", email)
 	if err != nil {
 		return nil, err
 	}
-	domainUser := entity.ToDomain()
-	return &models.User{
-		ID:        domainUser.ID,
-		Name:      domainUser.Name,
-		Email:     domainUser.Email,
-		Password:  domainUser.Password,
-		CreatedAt: domainUser.CreatedAt,
-		UpdatedAt: domainUser.UpdatedAt,
-	}, nil
+	return toUserModel(entity), nil
+}
+
+// ExistsByEmail reports whether the email is registered without loading the user
+func (r *PostgresUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	var found []int
+	err := r.db.DB.WithContext(ctx).Raw("SELECT 1 FROM users WHERE email = ? LIMIT 1", email).Scan(&found).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check email: %w", err)
+	}
+	return len(found) > 0, nil
 }
 
 // Update updates an existing user's information
 func (r *PostgresUserRepository) Update(ctx context.Context, user *models.User) error {
-	domainUser := &domain.User{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+	entity := infrastructure.FromDomainUser(toDomainUser(user))
+	return r.EntityRepository.Update(ctx, entity)
+}
+
+// SetAdmin grants or revokes admin rights of a user
+func (r *PostgresUserRepository) SetAdmin(ctx context.Context, userID uuid.UUID, isAdmin bool) error {
+	// UPDATE идет мимо EntityRepository, поэтому кэш спецификаций сбрасывается явно
+	if cached, ok := r.EntityRepository.(interface{ Invalidate() }); ok {
+		defer cached.Invalidate()
 	}
-	entity := infrastructure.FromDomainUser(domainUser)
-	return r.BaseRepository.Update(ctx, entity)
+
+	result := r.db.DB.WithContext(ctx).Model(&infrastructure.UserEntity{}).
+		Where("id = ?", userID).
+		Update("is_admin", isAdmin)
+	if result.Error != nil {
+		return fmt.Errorf("failed to set admin flag: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
 }
 
 // Delete removes a user from the database
 func (r *PostgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.BaseRepository.Delete(ctx, "id = ?", id)
+	return r.EntityRepository.Delete(ctx, "id = ?", id)
 }
 
 // FindBySpec finds users matching a specification
+// Спецификация применяется к запросу по UserEntity через BaseRepository
 func (r *PostgresUserRepository) FindBySpec(ctx context.Context, spec repository.Specification[models.User]) ([]*models.User, error) {
-	// Временно используем старый подход до полной миграции спецификаций
-	return nil, nil
+	entities, err := r.EntityRepository.FindBySpec(ctx, newUserEntitySpec(spec))
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*models.User, len(entities))
+	for i, entity := range entities {
+		users[i] = toUserModel(entity)
+	}
+	return users, nil
 }
 
 // FindOneBySpec finds first user matching a specification
 func (r *PostgresUserRepository) FindOneBySpec(ctx context.Context, spec repository.Specification[models.User]) (*models.User, error) {
-	// Временно используем старый подход до полной миграции спецификаций
-	return nil, nil
+	entity, err := r.EntityRepository.FindOneBySpec(ctx, newUserEntitySpec(spec))
+	if err != nil {
+		return nil, err
+	}
+	return toUserModel(entity), nil
 }
 
 // CountBySpec counts users matching a specification
 func (r *PostgresUserRepository) CountBySpec(ctx context.Context, spec repository.Specification[models.User]) (int64, error) {
-	// Временно используем старый подход до полной миграции спецификаций
-	return 0, nil
+	return r.EntityRepository.CountBySpec(ctx, newUserEntitySpec(spec))
+}
+
+// toDomainUser конвертирует API модель -> domain для персистентности
+func toDomainUser(user *models.User) *domain.User {
+	return &domain.User{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Password:  user.Password,
+		AvatarURL: user.AvatarURL,
+		Country:   user.Country,
+		Tier:      user.Tier,
+		IsAdmin:   user.IsAdmin,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// toUserModel конвертирует entity -> domain -> API модель
+func toUserModel(entity *infrastructure.UserEntity) *models.User {
+	domainUser := entity.ToDomain()
+	return &models.User{
+		ID:        domainUser.ID,
+		Name:      domainUser.Name,
+		Email:     domainUser.Email,
+		Password:  domainUser.Password,
+		AvatarURL: domainUser.AvatarURL,
+		Country:   domainUser.Country,
+		Tier:      domainUser.Tier,
+		IsAdmin:   domainUser.IsAdmin,
+		CreatedAt: domainUser.CreatedAt,
+		UpdatedAt: domainUser.UpdatedAt,
+	}
+}
+
+// userEntitySpec адаптирует спецификацию над models.User к UserEntity,
+// чтобы переиспользовать generic методы BaseRepository
+type userEntitySpec struct {
+	spec repository.Specification[models.User]
+}
+
+func newUserEntitySpec(spec repository.Specification[models.User]) repository.Specification[infrastructure.UserEntity] {
+	return &userEntitySpec{spec: spec}
+}
+
+// Apply применяет исходную спецификацию к запросу по таблице users
+func (s *userEntitySpec) Apply(db *gorm.DB) *gorm.DB {
+	db = db.Model(&infrastructure.UserEntity{})
+	if s.spec == nil {
+		return db
+	}
+	return s.spec.Apply(db)
+}
+
+// Describe возвращает описание исходной спецификации
+func (s *userEntitySpec) Describe() string {
+	return repository.DescribeSpec(s.spec)
+}
+
+// IsSatisfiedBy проверяет entity через исходную спецификацию
+func (s *userEntitySpec) IsSatisfiedBy(entity infrastructure.UserEntity) bool {
+	if s.spec == nil {
+		return true
+	}
+	return s.spec.IsSatisfiedBy(*toUserModel(&entity))
 }
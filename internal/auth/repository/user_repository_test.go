@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/auth/infrastructure"
+	"leaderboard-service/internal/auth/models"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/repository/decorators"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDryRunUserRepository создает репозиторий поверх GORM в режиме DryRun
+// и возвращает указатель на последний сгенерированный SQL
+func newDryRunUserRepository(t *testing.T) (*PostgresUserRepository, *string) {
+	t.Helper()
+
+	sqlDB, err := sql.Open("pgx", "postgres://localhost:5432/dryrun")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	var lastSQL string
+	capture := func(tx *gorm.DB) { lastSQL = tx.Statement.SQL.String() }
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture_query", capture))
+	require.NoError(t, db.Callback().Row().After("gorm:row").Register("test:capture_row", capture))
+
+	repo := NewPostgresUserRepository(&database.PostgresDB{DB: db}).(*PostgresUserRepository)
+	return repo, &lastSQL
+}
+
+func TestPostgresUserRepository_FindBySpec_EmailDomain(t *testing.T) {
+	repo, lastSQL := newDryRunUserRepository(t)
+
+	users, err := repo.FindBySpec(context.Background(), repository.NewUserByEmailDomainSpec("example.com"))
+
+	require.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Contains(t, *lastSQL, `FROM "users"`)
+	assert.Contains(t, *lastSQL, "email LIKE $1")
+}
+
+func TestPostgresUserRepository_FindAll(t *testing.T) {
+	repo, _ := newDryRunUserRepository(t)
+
+	var queries []string
+	require.NoError(t, repo.db.DB.Callback().Query().After("gorm:query").Register("test:collect_queries", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	}))
+
+	users, total, err := repo.FindAll(context.Background(), 20, 40)
+
+	require.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Zero(t, total)
+	require.Len(t, queries, 2, "one page query and one count query")
+	assert.Contains(t, queries[0], `ORDER BY created_at DESC LIMIT $1 OFFSET $2`)
+	assert.Contains(t, queries[1], `SELECT count(*) FROM "users"`)
+}
+
+func TestPostgresUserRepository_FindBySpec_AndNameLimit(t *testing.T) {
+	repo, lastSQL := newDryRunUserRepository(t)
+
+	spec := repository.And(
+		repository.NewUserByNameSpec("alex"),
+		repository.NewUserLimitSpec(10),
+	)
+	_, err := repo.FindBySpec(context.Background(), spec)
+
+	require.NoError(t, err)
+	assert.Contains(t, *lastSQL, `FROM "users"`)
+	assert.Contains(t, *lastSQL, "LOWER(name) LIKE $1")
+	assert.Contains(t, *lastSQL, "LIMIT $2")
+}
+
+func TestPostgresUserRepository_FindBySpec_OrNameEmail(t *testing.T) {
+	repo, lastSQL := newDryRunUserRepository(t)
+
+	spec := repository.Or(
+		repository.NewUserByNameSpec("alex"),
+		repository.NewUserByEmailSpec("alex@example.com"),
+	)
+	_, err := repo.FindBySpec(context.Background(), spec)
+
+	require.NoError(t, err)
+	assert.Contains(t, *lastSQL, `FROM "users"`)
+	assert.Contains(t, *lastSQL, "LOWER(name) LIKE $1")
+	assert.Contains(t, *lastSQL, "OR email = $2")
+}
+
+func TestUserEntitySpec_IsSatisfiedBy(t *testing.T) {
+	spec := newUserEntitySpec(repository.Or(
+		repository.NewUserByNameSpec("alex"),
+		repository.NewUserByEmailDomainSpec("example.com"),
+	))
+
+	tests := []struct {
+		name  string
+		user  models.User
+		match bool
+	}{
+		{"name matches", models.User{Name: "Alex Smith", Email: "a@other.org"}, true},
+		{"domain matches", models.User{Name: "Bob", Email: "bob@example.com"}, true},
+		{"nothing matches", models.User{Name: "Bob", Email: "bob@other.org"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entity := infrastructure.UserEntity{Name: tt.user.Name, Email: tt.user.Email}
+			assert.Equal(t, tt.match, spec.IsSatisfiedBy(entity))
+		})
+	}
+}
+
+func TestPostgresUserRepository_ExistsByEmail_Query(t *testing.T) {
+	repo, lastSQL := newDryRunUserRepository(t)
+
+	// Raw SELECT идет через Row, который DryRun не выполняет, поэтому проверяем только SQL
+	_, _ = repo.ExistsByEmail(context.Background(), "john@example.com")
+
+	assert.Equal(t, "SELECT 1 FROM users WHERE email = $1 LIMIT 1", *lastSQL)
+}
+
+func TestPostgresUserRepository_SetAdminInvalidatesSpecCache(t *testing.T) {
+	dryRun, _ := newDryRunUserRepository(t)
+	queries := 0
+	require.NoError(t, dryRun.db.DB.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) { queries++ }))
+	repo := NewPostgresUserRepositoryWithSpecCache(dryRun.db, decorators.NewSimpleCache(), time.Minute)
+	ctx := context.Background()
+	spec := repository.NewUserByEmailDomainSpec("example.com")
+
+	_, err := repo.FindBySpec(ctx, spec)
+	require.NoError(t, err)
+	_, err = repo.FindBySpec(ctx, spec)
+	require.NoError(t, err)
+	require.Equal(t, 1, queries)
+
+	// В DryRun UPDATE не затрагивает строк, но кэш сбрасывается в любом случае
+	_ = repo.SetAdmin(ctx, uuid.New(), true)
+
+	_, err = repo.FindBySpec(ctx, spec)
+	require.NoError(t, err)
+	assert.Equal(t, 2, queries)
+}
+
+func TestPostgresUserRepository_CreateBatchSkippingExisting_Query(t *testing.T) {
+	repo, lastSQL := newDryRunUserRepository(t)
+	users := []*models.User{
+		{Name: "Alice", Email: "alice@example.com", Password: "hash"},
+		{Name: "Bob", Email: "bob@example.com", Password: "hash"},
+	}
+
+	// INSERT ... RETURNING идет через Row, который DryRun не выполняет, поэтому проверяем только SQL
+	_ = repo.CreateBatchSkippingExisting(context.Background(), users, 2)
+
+	assert.Equal(t, "INSERT INTO users (name, email, password_hash, avatar_url, country, tier, is_admin) VALUES "+
+		"($1, $2, $3, $4, $5, $6, $7), ($8, $9, $10, $11, $12, $13, $14) ON CONFLICT (email) DO NOTHING RETURNING id, email", *lastSQL)
+}
@@ -4,15 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"leaderboard-service/internal/auth/models"
 	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/middleware"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+// errInvalidCredentials is returned for both unknown emails and wrong passwords
+var errInvalidCredentials = utils.Unauthorized("invalid credentials", nil)
+
+// ErrEmailAlreadyTaken is returned by Register when the email belongs to another account
+var ErrEmailAlreadyTaken = utils.Conflict("email is already registered", nil)
+
+// minNewPasswordLength is enforced on password changes; registration keeps its older 6 character minimum
+const minNewPasswordLength = 8
+
 // AuthService handles authentication operations
 type AuthService struct {
 	userRepo repository.UserRepository
@@ -31,10 +46,31 @@ func NewAuthService(userRepo repository.UserRepository, jwt *middleware.JWTMiddl
 
 // Register creates a new user
 func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest) (*models.User, error) {
+	// Name is echoed in leaderboards and WebSocket updates, so markup and quotes are rejected up front
+	if err := utils.NewValidator().NoSpecialChars("name", req.Name, "").Error(); err != nil {
+		return nil, utils.ValidationError(err.Error(), err)
+	}
+
+	country := strings.ToUpper(strings.TrimSpace(req.Country))
+	if country != "" {
+		if err := utils.NewValidator().CountryCode("country", country).Error(); err != nil {
+			return nil, utils.ValidationError(err.Error(), err)
+		}
+	}
+
+	// Checked before hashing: bcrypt is the slow part of a registration
+	taken, err := s.userRepo.ExistsByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, utils.DatabaseError("email check", err)
+	}
+	if taken {
+		return nil, ErrEmailAlreadyTaken
+	}
+
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, utils.InternalError("failed to hash password", err)
 	}
 
 	// Create user
@@ -42,10 +78,15 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: string(hashedPassword),
+		Country:  country,
 	}
 
 	if err := s.userRepo.Create(ctx, &user); err != nil {
-		return nil, err
+		// A concurrent registration can take the email between the check and the insert
+		if isUniqueViolation(err) {
+			return nil, ErrEmailAlreadyTaken
+		}
+		return nil, utils.DatabaseError("user creation", err)
 	}
 
 	return &user, nil
@@ -56,22 +97,26 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	// Fetch user by email using repository
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		// Check if error message contains "not found"
-		if errors.Is(err, fmt.Errorf("user not found")) || err.Error() == "user not found" {
-			return nil, fmt.Errorf("invalid credentials")
+		// Unknown email is reported the same way as a wrong password
+		if errors.Is(err, repository.ErrRecordNotFound) || err.Error() == "user not found" {
+			return nil, errInvalidCredentials
 		}
-		return nil, fmt.Errorf("failed to fetch user: %w", err)
+		return nil, utils.DatabaseError("user lookup", err)
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, errInvalidCredentials
 	}
 
-	// Generate JWT token
-	token, expiresAt, err := s.jwt.GenerateToken(user.ID, user.Email, "user", s.cfg.GetJWTExpiry())
+	// Generate JWT token; admins get the admin role and the "admin": true claim
+	role := middleware.RoleUser
+	if user.IsAdmin {
+		role = middleware.RoleAdmin
+	}
+	token, expiresAt, err := s.jwt.GenerateToken(user.ID, user.Email, role, s.cfg.GetJWTExpiry())
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		return nil, utils.InternalError("failed to generate token", err)
 	}
 
 	return &models.LoginResponse{
@@ -80,3 +125,91 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 		ExpiresAt: expiresAt,
 	}, nil
 }
+
+// UpdatePassword replaces a user's password after verifying the current one.
+// The new password must differ from the current one and contain at least
+// minNewPasswordLength characters with both letters and digits.
+func (s *AuthService) UpdatePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return utils.NotFound("user", err)
+		}
+		return utils.DatabaseError("user lookup", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return utils.Unauthorized("current password is incorrect", nil)
+	}
+
+	if newPassword == currentPassword {
+		return utils.ValidationError("new password must differ from the current password", nil)
+	}
+	if err := checkPasswordStrength(newPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return utils.InternalError("failed to hash password", err)
+	}
+
+	// FindByID may return the cached *User, so the change goes to a copy: a failed
+	// Update must not leave the new hash in the cache
+	updated := *user
+	updated.Password = string(hashedPassword)
+	if err := s.userRepo.Update(ctx, &updated); err != nil {
+		return utils.DatabaseError("password update", err)
+	}
+
+	return nil
+}
+
+// ListUsers returns a page of users, newest first. page starts at 1; page and pageSize
+// are normalized by utils.NewPaginationParams (default 20, at most 100 per page).
+func (s *AuthService) ListUsers(ctx context.Context, page, pageSize int) (*utils.PaginatedResponse[*models.User], error) {
+	params := utils.NewPaginationParams(page, pageSize)
+
+	users, total, err := s.userRepo.FindAll(ctx, params.Limit, params.Offset)
+	if err != nil {
+		return nil, utils.DatabaseError("user listing", err)
+	}
+	if users == nil {
+		users = []*models.User{}
+	}
+
+	return &utils.PaginatedResponse[*models.User]{
+		Data:       users,
+		Pagination: utils.NewPaginationMeta(params.Page, params.PageSize, total),
+	}, nil
+}
+
+// checkPasswordStrength rejects passwords that are too short or lack either letters or digits
+func checkPasswordStrength(password string) error {
+	if len(password) < minNewPasswordLength {
+		return utils.ValidationError(fmt.Sprintf("new password must be at least %d characters", minNewPasswordLength), nil)
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return utils.ValidationError("new password must contain both letters and digits", nil)
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation (SQLSTATE 23505)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505"
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
@@ -3,16 +3,21 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"leaderboard-service/internal/auth/models"
 	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/middleware"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -26,6 +31,16 @@ func (m *MockUserRepository) Create(ctx context.Context, user *models.User) erro
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) CreateBatch(ctx context.Context, users []*models.User, batchSize int) error {
+	args := m.Called(ctx, users, batchSize)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateBatchSkippingExisting(ctx context.Context, users []*models.User, batchSize int) error {
+	args := m.Called(ctx, users, batchSize)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -34,6 +49,14 @@ func (m *MockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*model
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.User, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
@@ -42,22 +65,32 @@ func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*mo
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
 	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) SetAdmin(ctx context.Context, userID uuid.UUID, isAdmin bool) error {
+	args := m.Called(ctx, userID, isAdmin)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) FindAll(ctx context.Context, limit, offset int) ([]*models.User, error) {
+func (m *MockUserRepository) FindAll(ctx context.Context, limit, offset int) ([]*models.User, int64, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, 0, args.Error(2)
 	}
-	return args.Get(0).([]*models.User), args.Error(1)
+	return args.Get(0).([]*models.User), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
@@ -103,6 +136,7 @@ func TestAuthService_Register_Success(t *testing.T) {
 		Password: "password123",
 	}
 
+	mockRepo.On("ExistsByEmail", mock.Anything, req.Email).Return(false, nil)
 	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
 		return u.Name == req.Name && u.Email == req.Email
 	})).Return(nil)
@@ -135,6 +169,7 @@ func TestAuthService_Register_RepositoryError(t *testing.T) {
 		Password: "password123",
 	}
 
+	mockRepo.On("ExistsByEmail", mock.Anything, req.Email).Return(false, nil)
 	mockRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("database error"))
 
 	user, err := service.Register(context.Background(), req)
@@ -144,6 +179,78 @@ func TestAuthService_Register_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAuthService_Register_EmailAlreadyTaken(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpiryHours: 24}}
+	req := &models.RegisterRequest{Name: "John Doe", Email: "john@example.com", Password: "password123"}
+
+	t.Run("found by the email check", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewAuthService(mockRepo, middleware.NewJWTMiddleware(cfg), cfg)
+		mockRepo.On("ExistsByEmail", mock.Anything, req.Email).Return(true, nil)
+
+		user, err := service.Register(context.Background(), req)
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ErrEmailAlreadyTaken)
+		assert.Equal(t, http.StatusConflict, ErrEmailAlreadyTaken.StatusCode)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("taken by a concurrent registration", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewAuthService(mockRepo, middleware.NewJWTMiddleware(cfg), cfg)
+		mockRepo.On("ExistsByEmail", mock.Anything, req.Email).Return(false, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&pgconn.PgError{Code: "23505"})
+
+		user, err := service.Register(context.Background(), req)
+
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, ErrEmailAlreadyTaken)
+	})
+
+	t.Run("email check fails", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		service := NewAuthService(mockRepo, middleware.NewJWTMiddleware(cfg), cfg)
+		mockRepo.On("ExistsByEmail", mock.Anything, req.Email).Return(false, errors.New("connection refused"))
+
+		_, err := service.Register(context.Background(), req)
+
+		var appErr *utils.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, utils.ErrCodeDatabaseError, appErr.Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthService_Register_RejectsSpecialCharsInName(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:      "test-secret",
+			ExpiryHours: 24,
+		},
+	}
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+	service := NewAuthService(mockRepo, jwtMiddleware, cfg)
+
+	for _, name := range []string{"<script>alert(1)</script>", "x'; DROP TABLE users; --", "admin%00"} {
+		t.Run(name, func(t *testing.T) {
+			user, err := service.Register(context.Background(), &models.RegisterRequest{
+				Name:     name,
+				Email:    "john@example.com",
+				Password: "password123",
+			})
+
+			assert.Nil(t, user)
+			var appErr *utils.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, utils.ErrCodeValidation, appErr.Code)
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		})
+	}
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestAuthService_Login_Success(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	cfg := &config.Config{
@@ -182,6 +289,55 @@ func TestAuthService_Login_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestAuthService_Login_AdminClaims(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:      "test-secret",
+			ExpiryHours: 24,
+		},
+	}
+	jwtMiddleware := middleware.NewJWTMiddleware(cfg)
+	service := NewAuthService(mockRepo, jwtMiddleware, cfg)
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	tests := []struct {
+		name    string
+		isAdmin bool
+		role    string
+	}{
+		{"admin", true, middleware.RoleAdmin},
+		{"regular user", false, middleware.RoleUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := uuid.NewString() + "@example.com"
+			mockRepo.On("FindByEmail", mock.Anything, email).Return(&models.User{
+				ID:       uuid.New(),
+				Email:    email,
+				Password: string(hashedPassword),
+				IsAdmin:  tt.isAdmin,
+			}, nil)
+
+			response, err := service.Login(context.Background(), &models.LoginRequest{Email: email, Password: "password123"})
+			require.NoError(t, err)
+
+			claims := jwt.MapClaims{}
+			_, err = jwt.ParseWithClaims(response.Token, claims, func(*jwt.Token) (interface{}, error) {
+				return []byte("test-secret"), nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.role, claims["role"])
+			if tt.isAdmin {
+				assert.Equal(t, true, claims["admin"])
+			} else {
+				assert.NotContains(t, claims, "admin")
+			}
+		})
+	}
+}
+
 func TestAuthService_Login_UserNotFound(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	cfg := &config.Config{
@@ -242,3 +398,127 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid credentials")
 	mockRepo.AssertExpectations(t)
 }
+
+// newPasswordTestService returns a service whose repository holds one user with the given password
+func newPasswordTestService(t *testing.T, password string) (*AuthService, *MockUserRepository, *models.User) {
+	t.Helper()
+	mockRepo := new(MockUserRepository)
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpiryHours: 24}}
+	service := NewAuthService(mockRepo, middleware.NewJWTMiddleware(cfg), cfg)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &models.User{
+		ID:       uuid.New(),
+		Name:     "John Doe",
+		Email:    "john@example.com",
+		Password: string(hashedPassword),
+	}
+	mockRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	return service, mockRepo, user
+}
+
+func TestAuthService_UpdatePassword_Success(t *testing.T) {
+	service, mockRepo, user := newPasswordTestService(t, "password123")
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil)
+
+	err := service.UpdatePassword(context.Background(), user.ID, "password123", "n3wpassword")
+	require.NoError(t, err)
+
+	updated := mockRepo.Calls[1].Arguments.Get(1).(*models.User)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(updated.Password), []byte("n3wpassword")), "the new password is stored re-hashed")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_UpdatePassword_FailedUpdateKeepsUser(t *testing.T) {
+	service, mockRepo, user := newPasswordTestService(t, "password123")
+	oldHash := user.Password
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.User")).Return(errors.New("connection refused"))
+
+	err := service.UpdatePassword(context.Background(), user.ID, "password123", "n3wpassword")
+
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, utils.ErrCodeDatabaseError, appErr.Code)
+	assert.Equal(t, oldHash, user.Password, "the user returned by FindByID (possibly cached) is not modified")
+}
+
+func TestAuthService_UpdatePassword_WrongCurrentPassword(t *testing.T) {
+	service, mockRepo, user := newPasswordTestService(t, "password123")
+
+	err := service.UpdatePassword(context.Background(), user.ID, "wrongpassword", "n3wpassword")
+
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_UpdatePassword_SamePassword(t *testing.T) {
+	service, mockRepo, user := newPasswordTestService(t, "password123")
+
+	err := service.UpdatePassword(context.Background(), user.ID, "password123", "password123")
+
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, utils.ErrCodeValidation, appErr.Code)
+	assert.Contains(t, appErr.Message, "differ")
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_UpdatePassword_WeakNewPassword(t *testing.T) {
+	for _, newPassword := range []string{"abc1", "onlyletters", "1234567890"} {
+		t.Run(newPassword, func(t *testing.T) {
+			service, mockRepo, user := newPasswordTestService(t, "password123")
+
+			err := service.UpdatePassword(context.Background(), user.ID, "password123", newPassword)
+
+			var appErr *utils.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, utils.ErrCodeValidation, appErr.Code)
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthService_ListUsers_Success(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpiryHours: 24}}
+	service := NewAuthService(mockRepo, middleware.NewJWTMiddleware(cfg), cfg)
+
+	users := []*models.User{
+		{ID: uuid.New(), Name: "Alice", Email: "alice@example.com"},
+		{ID: uuid.New(), Name: "Bob", Email: "bob@example.com"},
+	}
+	// Page 2 of 10 per page skips the first 10 users
+	mockRepo.On("FindAll", mock.Anything, 10, 10).Return(users, int64(22), nil)
+
+	result, err := service.ListUsers(context.Background(), 2, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, users, result.Data)
+	assert.Equal(t, utils.PaginationMeta{
+		Page:       2,
+		PageSize:   10,
+		TotalPages: 3,
+		TotalCount: 22,
+		HasNext:    true,
+		HasPrev:    true,
+	}, result.Pagination)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAuthService_ListUsers_RepositoryError(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpiryHours: 24}}
+	service := NewAuthService(mockRepo, middleware.NewJWTMiddleware(cfg), cfg)
+
+	mockRepo.On("FindAll", mock.Anything, 20, 0).Return(nil, int64(0), errors.New("connection refused"))
+
+	_, err := service.ListUsers(context.Background(), 0, 0)
+
+	var appErr *utils.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusInternalServerError, appErr.StatusCode)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"leaderboard-service/internal/challenge/models"
+	sharedhandlers "leaderboard-service/internal/shared/handlers"
+	"leaderboard-service/internal/shared/middleware"
+	sharedmodels "leaderboard-service/internal/shared/models"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ChallengeServiceInterface defines the challenge operations used by ChallengeHandler
+type ChallengeServiceInterface interface {
+	Create(ctx context.Context, challengerID uuid.UUID, req *models.CreateChallengeRequest) (*models.Challenge, error)
+	Get(ctx context.Context, challengeID, userID uuid.UUID) (*models.Challenge, error)
+	List(ctx context.Context, userID uuid.UUID) ([]*models.Challenge, error)
+	Accept(ctx context.Context, challengeID, userID uuid.UUID) (*models.Challenge, error)
+	Cancel(ctx context.Context, challengeID, userID uuid.UUID) error
+}
+
+// ChallengeHandler handles challenge endpoints
+type ChallengeHandler struct {
+	challengeService ChallengeServiceInterface
+}
+
+// NewChallengeHandler creates a new challenge handler
+func NewChallengeHandler(challengeService ChallengeServiceInterface) *ChallengeHandler {
+	return &ChallengeHandler{
+		challengeService: challengeService,
+	}
+}
+
+// Create opens a challenge against another player
+// POST /challenges
+func (h *ChallengeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateChallengeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhandlers.RespondError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	challenge, err := h.challengeService.Create(r.Context(), userID, &req)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create challenge")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "challenge created",
+		Data:    challenge,
+	}, http.StatusCreated)
+}
+
+// List returns challenges sent or received by the current user
+// GET /challenges
+func (h *ChallengeHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	challenges, err := h.challengeService.List(r.Context(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list challenges")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+	if challenges == nil {
+		challenges = []*models.Challenge{}
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    challenges,
+	}, http.StatusOK)
+}
+
+// Get returns a single challenge
+// GET /challenges/{id}
+func (h *ChallengeHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, challengeID, ok := h.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	challenge, err := h.challengeService.Get(r.Context(), challengeID, userID)
+	if err != nil {
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    challenge,
+	}, http.StatusOK)
+}
+
+// Accept accepts a pending challenge
+// POST /challenges/{id}/accept
+func (h *ChallengeHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID, challengeID, ok := h.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	challenge, err := h.challengeService.Accept(r.Context(), challengeID, userID)
+	if err != nil {
+		log.Error().Err(err).Str("challenge_id", challengeID.String()).Msg("Failed to accept challenge")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "challenge accepted",
+		Data:    challenge,
+	}, http.StatusOK)
+}
+
+// Cancel withdraws a challenge that has not been accepted yet
+// DELETE /challenges/{id}
+func (h *ChallengeHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	userID, challengeID, ok := h.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.challengeService.Cancel(r.Context(), challengeID, userID); err != nil {
+		log.Error().Err(err).Str("challenge_id", challengeID.String()).Msg("Failed to cancel challenge")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "challenge cancelled",
+	}, http.StatusOK)
+}
+
+// parseRequest extracts the authenticated user and the {id} URL parameter.
+// Writes the error response and returns ok=false when either is missing or invalid.
+func (h *ChallengeHandler) parseRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	challengeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sharedhandlers.RespondError(w, "invalid challenge ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, challengeID, true
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChallengeStatus is the lifecycle state of a challenge
+type ChallengeStatus string
+
+const (
+	// ChallengeStatusPending - challenge is open (accepted or not) and has not expired yet
+	ChallengeStatusPending ChallengeStatus = "pending"
+	// ChallengeStatusWon - challenged player reached the target score in time
+	ChallengeStatusWon ChallengeStatus = "won"
+	// ChallengeStatusLost - challenge was accepted but expired before the target was reached
+	ChallengeStatusLost ChallengeStatus = "lost"
+	// ChallengeStatusExpired - challenge expired without being accepted
+	ChallengeStatusExpired ChallengeStatus = "expired"
+)
+
+// Challenge is a dare from one player to another to reach a score in a season
+type Challenge struct {
+	ID           uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ChallengerID uuid.UUID       `json:"challenger_id" gorm:"type:uuid;not null"`
+	ChallengedID uuid.UUID       `json:"challenged_id" gorm:"type:uuid;not null"`
+	Season       string          `json:"season" gorm:"type:text;not null"`
+	TargetScore  int64           `json:"target_score" gorm:"type:bigint;not null"`
+	Status       ChallengeStatus `json:"status" gorm:"type:text;not null;default:'pending'"`
+	AcceptedAt   *time.Time      `json:"accepted_at,omitempty"`
+	ExpiresAt    time.Time       `json:"expires_at" gorm:"not null"`
+	CreatedAt    time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (Challenge) TableName() string {
+	return "challenges"
+}
+
+// CreateChallengeRequest is the payload for creating a challenge
+type CreateChallengeRequest struct {
+	ChallengedID   uuid.UUID `json:"challenged_id" validate:"required"`
+	Season         string    `json:"season" validate:"omitempty,max=50"`
+	TargetScore    int64     `json:"target_score" validate:"required,min=1"`
+	ExpiresInHours int       `json:"expires_in_hours" validate:"omitempty,min=1"`
+}
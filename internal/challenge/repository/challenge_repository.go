@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"leaderboard-service/internal/challenge/models"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PostgresChallengeRepository is a PostgreSQL implementation of ChallengeRepository
+type PostgresChallengeRepository struct {
+	*repository.BaseRepository[models.Challenge]
+	db *database.PostgresDB
+}
+
+// NewPostgresChallengeRepository creates a new PostgreSQL challenge repository
+func NewPostgresChallengeRepository(db *database.PostgresDB) repository.ChallengeRepository {
+	return &PostgresChallengeRepository{
+		BaseRepository: repository.NewBaseRepository[models.Challenge](db),
+		db:             db,
+	}
+}
+
+// Create stores a new challenge
+func (r *PostgresChallengeRepository) Create(ctx context.Context, challenge *models.Challenge) error {
+	return r.BaseRepository.Create(ctx, challenge)
+}
+
+// FindByID retrieves a challenge by its UUID
+func (r *PostgresChallengeRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Challenge, error) {
+	return r.BaseRepository.FindOne(ctx, "id = ?", id)
+}
+
+// FindByUser retrieves challenges sent or received by the user, newest first
+func (r *PostgresChallengeRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]*models.Challenge, error) {
+	var challenges []*models.Challenge
+	err := r.db.DB.WithContext(ctx).
+		Where("challenger_id = ? OR challenged_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(&challenges).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find challenges: %w", err)
+	}
+	return challenges, nil
+}
+
+// Update saves changes to an existing challenge
+func (r *PostgresChallengeRepository) Update(ctx context.Context, challenge *models.Challenge) error {
+	return r.BaseRepository.Update(ctx, challenge)
+}
+
+// Delete removes a challenge
+func (r *PostgresChallengeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.BaseRepository.Delete(ctx, "id = ?", id)
+}
+
+// MarkWon marks matching pending challenges as won in a single UPDATE
+// Условие на статус в WHERE не дает повторно закрыть уже выигранный или истекший вызов
+func (r *PostgresChallengeRepository) MarkWon(ctx context.Context, challengedID uuid.UUID, season string, score int64, now time.Time) (int64, error) {
+	result := r.db.DB.WithContext(ctx).
+		Model(&models.Challenge{}).
+		Where("challenged_id = ? AND season = ? AND status = ?", challengedID, season, models.ChallengeStatusPending).
+		Where("accepted_at IS NOT NULL AND expires_at > ? AND target_score <= ?", now, score).
+		Updates(map[string]interface{}{"status": models.ChallengeStatusWon, "updated_at": now})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark challenges won: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ExpireOverdue closes pending challenges whose deadline has passed
+func (r *PostgresChallengeRepository) ExpireOverdue(ctx context.Context, now time.Time) (int64, error) {
+	var closed int64
+	err := r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lost := tx.Model(&models.Challenge{}).
+			Where("status = ? AND expires_at <= ? AND accepted_at IS NOT NULL", models.ChallengeStatusPending, now).
+			Updates(map[string]interface{}{"status": models.ChallengeStatusLost, "updated_at": now})
+		if lost.Error != nil {
+			return lost.Error
+		}
+
+		expired := tx.Model(&models.Challenge{}).
+			Where("status = ? AND expires_at <= ? AND accepted_at IS NULL", models.ChallengeStatusPending, now).
+			Updates(map[string]interface{}{"status": models.ChallengeStatusExpired, "updated_at": now})
+		if expired.Error != nil {
+			return expired.Error
+		}
+
+		closed = lost.RowsAffected + expired.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire challenges: %w", err)
+	}
+	return closed, nil
+}
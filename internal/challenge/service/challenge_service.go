@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"leaderboard-service/internal/challenge/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultChallengeDuration applies when a request does not set expires_in_hours
+	defaultChallengeDuration = 24 * time.Hour
+	// maxChallengeDuration caps how long a challenge may stay open
+	maxChallengeDuration = 30 * 24 * time.Hour
+)
+
+// ChallengeService handles challenges between players
+type ChallengeService struct {
+	challenges    repository.ChallengeRepository
+	users         repository.UserRepository
+	now           func() time.Time
+	defaultSeason string
+	// tenantScoped stores seasons as "{tenant}:{season}", like MultiTenantLeaderboardService
+	tenantScoped bool
+}
+
+// NewChallengeService creates a new challenge service
+func NewChallengeService(challenges repository.ChallengeRepository, users repository.UserRepository) *ChallengeService {
+	return &ChallengeService{
+		challenges:    challenges,
+		users:         users,
+		now:           time.Now,
+		defaultSeason: config.FallbackSeason,
+	}
+}
+
+// SetDefaultSeason sets the season of challenges created without one (Config.GetDefaultSeason)
+func (s *ChallengeService) SetDefaultSeason(season string) {
+	s.defaultSeason = season
+}
+
+// SetTenantScoped scopes challenges to the tenant of the request when multitenancy is enabled.
+// Seasons are then stored with the tenant prefix, so Check matches the seasons of stored scores.
+func (s *ChallengeService) SetTenantScoped(enabled bool) {
+	s.tenantScoped = enabled
+}
+
+// Create opens a challenge from challengerID to the player in the request
+func (s *ChallengeService) Create(ctx context.Context, challengerID uuid.UUID, req *models.CreateChallengeRequest) (*models.Challenge, error) {
+	if req.ChallengedID == uuid.Nil {
+		return nil, utils.ValidationError("challenged_id is required", nil)
+	}
+	if req.ChallengedID == challengerID {
+		return nil, utils.ValidationError("cannot challenge yourself", nil)
+	}
+	if req.TargetScore <= 0 {
+		return nil, utils.ValidationError("target_score must be positive", nil)
+	}
+
+	duration := defaultChallengeDuration
+	if req.ExpiresInHours > 0 {
+		duration = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if duration > maxChallengeDuration {
+		return nil, utils.ValidationError("challenge cannot last longer than 30 days", nil)
+	}
+
+	season := req.Season
+	if season == "" {
+		season = s.defaultSeason
+	}
+	prefix, err := s.tenantPrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.users.FindByID(ctx, req.ChallengedID); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, utils.NotFound("user", err)
+		}
+		return nil, utils.DatabaseError("user lookup", err)
+	}
+
+	challenge := &models.Challenge{
+		ChallengerID: challengerID,
+		ChallengedID: req.ChallengedID,
+		Season:       prefix + season,
+		TargetScore:  req.TargetScore,
+		Status:       models.ChallengeStatusPending,
+		ExpiresAt:    s.now().Add(duration),
+	}
+	if err := s.challenges.Create(ctx, challenge); err != nil {
+		return nil, utils.DatabaseError("challenge creation", err)
+	}
+
+	return withoutTenant(prefix, challenge), nil
+}
+
+// Get returns a challenge visible to one of its participants
+func (s *ChallengeService) Get(ctx context.Context, challengeID, userID uuid.UUID) (*models.Challenge, error) {
+	prefix, challenge, err := s.find(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.ChallengerID != userID && challenge.ChallengedID != userID {
+		return nil, utils.Forbidden("not a participant of this challenge", nil)
+	}
+	return withoutTenant(prefix, challenge), nil
+}
+
+// List returns challenges sent or received by the user
+func (s *ChallengeService) List(ctx context.Context, userID uuid.UUID) ([]*models.Challenge, error) {
+	prefix, err := s.tenantPrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	challenges, err := s.challenges.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, utils.DatabaseError("challenge list", err)
+	}
+
+	visible := make([]*models.Challenge, 0, len(challenges))
+	for _, challenge := range challenges {
+		if strings.HasPrefix(challenge.Season, prefix) {
+			visible = append(visible, withoutTenant(prefix, challenge))
+		}
+	}
+	return visible, nil
+}
+
+// Accept lets the challenged player take up a pending challenge
+func (s *ChallengeService) Accept(ctx context.Context, challengeID, userID uuid.UUID) (*models.Challenge, error) {
+	prefix, challenge, err := s.find(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.ChallengedID != userID {
+		return nil, utils.Forbidden("only the challenged player can accept", nil)
+	}
+	if challenge.Status != models.ChallengeStatusPending || !s.now().Before(challenge.ExpiresAt) {
+		return nil, utils.Conflict("challenge is no longer open", nil)
+	}
+	if challenge.AcceptedAt != nil {
+		return nil, utils.Conflict("challenge is already accepted", nil)
+	}
+
+	now := s.now()
+	challenge.AcceptedAt = &now
+	if err := s.challenges.Update(ctx, challenge); err != nil {
+		return nil, utils.DatabaseError("challenge update", err)
+	}
+	return withoutTenant(prefix, challenge), nil
+}
+
+// Cancel withdraws a challenge the challenged player has not accepted yet
+func (s *ChallengeService) Cancel(ctx context.Context, challengeID, userID uuid.UUID) error {
+	_, challenge, err := s.find(ctx, challengeID)
+	if err != nil {
+		return err
+	}
+	if challenge.ChallengerID != userID {
+		return utils.Forbidden("only the challenger can cancel", nil)
+	}
+	if challenge.Status != models.ChallengeStatusPending || challenge.AcceptedAt != nil {
+		return utils.Conflict("only unaccepted pending challenges can be cancelled", nil)
+	}
+
+	if err := s.challenges.Delete(ctx, challengeID); err != nil {
+		return utils.DatabaseError("challenge deletion", err)
+	}
+	return nil
+}
+
+// Check settles challenges after userID stored score in season.
+// Overdue challenges are closed first so a late score cannot win an expired challenge.
+// Returns the number of challenges the user won.
+func (s *ChallengeService) Check(ctx context.Context, userID uuid.UUID, season string, score int64) (int64, error) {
+	now := s.now()
+	if _, err := s.challenges.ExpireOverdue(ctx, now); err != nil {
+		return 0, utils.DatabaseError("challenge expiry", err)
+	}
+
+	won, err := s.challenges.MarkWon(ctx, userID, season, score, now)
+	if err != nil {
+		return 0, utils.DatabaseError("challenge check", err)
+	}
+	return won, nil
+}
+
+// find loads a challenge of the caller's tenant, mapping a missing row to a 404.
+// Returns the tenant prefix of the challenge's stored season along with it.
+func (s *ChallengeService) find(ctx context.Context, challengeID uuid.UUID) (string, *models.Challenge, error) {
+	prefix, err := s.tenantPrefix(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	challenge, err := s.challenges.FindByID(ctx, challengeID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return "", nil, utils.NotFound("challenge", err)
+		}
+		return "", nil, utils.DatabaseError("challenge lookup", err)
+	}
+	// Вызов другого тенанта: для него такого вызова нет
+	if !strings.HasPrefix(challenge.Season, prefix) {
+		return "", nil, utils.NotFound("challenge", repository.ErrRecordNotFound)
+	}
+	return prefix, challenge, nil
+}
+
+// tenantPrefix returns the "{tenant}:" prefix of stored seasons, or "" when challenges are not tenant-scoped
+func (s *ChallengeService) tenantPrefix(ctx context.Context) (string, error) {
+	if !s.tenantScoped {
+		return "", nil
+	}
+	tenantID, ok := middleware.GetTenantIDFromContext(ctx)
+	if !ok {
+		return "", utils.Forbidden("tenant is required", nil)
+	}
+	// Тот же запрет, что в MultiTenantLeaderboardService: ":" сделал бы префикс неоднозначным
+	if strings.Contains(tenantID, ":") {
+		return "", utils.Forbidden("invalid tenant", nil)
+	}
+	return tenantID + ":", nil
+}
+
+// withoutTenant returns a copy of challenge with the tenant prefix removed from its season
+func withoutTenant(prefix string, challenge *models.Challenge) *models.Challenge {
+	result := *challenge
+	result.Season = strings.TrimPrefix(result.Season, prefix)
+	return &result
+}
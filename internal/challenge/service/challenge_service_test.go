@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	authmodels "leaderboard-service/internal/auth/models"
+	"leaderboard-service/internal/challenge/models"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryChallengeRepository mirrors the SQL of PostgresChallengeRepository in memory
+type memoryChallengeRepository struct {
+	challenges map[uuid.UUID]*models.Challenge
+}
+
+func newMemoryChallengeRepository() *memoryChallengeRepository {
+	return &memoryChallengeRepository{challenges: make(map[uuid.UUID]*models.Challenge)}
+}
+
+func (r *memoryChallengeRepository) Create(ctx context.Context, challenge *models.Challenge) error {
+	challenge.ID = uuid.New()
+	stored := *challenge
+	r.challenges[challenge.ID] = &stored
+	return nil
+}
+
+func (r *memoryChallengeRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Challenge, error) {
+	challenge, ok := r.challenges[id]
+	if !ok {
+		return nil, repository.ErrRecordNotFound
+	}
+	found := *challenge
+	return &found, nil
+}
+
+func (r *memoryChallengeRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]*models.Challenge, error) {
+	var result []*models.Challenge
+	for _, challenge := range r.challenges {
+		if challenge.ChallengerID == userID || challenge.ChallengedID == userID {
+			found := *challenge
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryChallengeRepository) Update(ctx context.Context, challenge *models.Challenge) error {
+	stored := *challenge
+	r.challenges[challenge.ID] = &stored
+	return nil
+}
+
+func (r *memoryChallengeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(r.challenges, id)
+	return nil
+}
+
+func (r *memoryChallengeRepository) MarkWon(ctx context.Context, challengedID uuid.UUID, season string, score int64, now time.Time) (int64, error) {
+	var won int64
+	for _, c := range r.challenges {
+		if c.ChallengedID == challengedID && c.Season == season && c.Status == models.ChallengeStatusPending &&
+			c.AcceptedAt != nil && c.ExpiresAt.After(now) && c.TargetScore <= score {
+			c.Status = models.ChallengeStatusWon
+			won++
+		}
+	}
+	return won, nil
+}
+
+func (r *memoryChallengeRepository) ExpireOverdue(ctx context.Context, now time.Time) (int64, error) {
+	var closed int64
+	for _, c := range r.challenges {
+		if c.Status != models.ChallengeStatusPending || c.ExpiresAt.After(now) {
+			continue
+		}
+		if c.AcceptedAt != nil {
+			c.Status = models.ChallengeStatusLost
+		} else {
+			c.Status = models.ChallengeStatusExpired
+		}
+		closed++
+	}
+	return closed, nil
+}
+
+// stubUserRepository knows a fixed set of user IDs
+type stubUserRepository struct {
+	repository.UserRepository
+	known map[uuid.UUID]bool
+}
+
+func (r *stubUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*authmodels.User, error) {
+	if !r.known[id] {
+		return nil, repository.ErrRecordNotFound
+	}
+	return &authmodels.User{ID: id}, nil
+}
+
+type challengeFixture struct {
+	service    *ChallengeService
+	repo       *memoryChallengeRepository
+	challenger uuid.UUID
+	challenged uuid.UUID
+	now        time.Time
+}
+
+func newChallengeFixture() *challengeFixture {
+	f := &challengeFixture{
+		repo:       newMemoryChallengeRepository(),
+		challenger: uuid.New(),
+		challenged: uuid.New(),
+		now:        time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	users := &stubUserRepository{known: map[uuid.UUID]bool{f.challenger: true, f.challenged: true}}
+	f.service = NewChallengeService(f.repo, users)
+	f.service.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *challengeFixture) create(t *testing.T, target int64) *models.Challenge {
+	t.Helper()
+	challenge, err := f.service.Create(context.Background(), f.challenger, &models.CreateChallengeRequest{
+		ChallengedID: f.challenged,
+		TargetScore:  target,
+	})
+	require.NoError(t, err)
+	return challenge
+}
+
+func assertAppErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestCreate_Defaults(t *testing.T) {
+	f := newChallengeFixture()
+
+	challenge := f.create(t, 1500)
+
+	assert.Equal(t, "global", challenge.Season)
+	assert.Equal(t, models.ChallengeStatusPending, challenge.Status)
+	assert.Equal(t, f.now.Add(defaultChallengeDuration), challenge.ExpiresAt)
+	assert.Nil(t, challenge.AcceptedAt)
+}
+
+func TestCreate_Validation(t *testing.T) {
+	f := newChallengeFixture()
+
+	tests := []struct {
+		name string
+		req  models.CreateChallengeRequest
+		code string
+	}{
+		{"self challenge", models.CreateChallengeRequest{ChallengedID: f.challenger, TargetScore: 10}, utils.ErrCodeValidation},
+		{"missing opponent", models.CreateChallengeRequest{TargetScore: 10}, utils.ErrCodeValidation},
+		{"non-positive target", models.CreateChallengeRequest{ChallengedID: f.challenged}, utils.ErrCodeValidation},
+		{"too long", models.CreateChallengeRequest{ChallengedID: f.challenged, TargetScore: 10, ExpiresInHours: 24 * 31}, utils.ErrCodeValidation},
+		{"unknown opponent", models.CreateChallengeRequest{ChallengedID: uuid.New(), TargetScore: 10}, utils.ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.Create(context.Background(), f.challenger, &tt.req)
+			assertAppErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestAccept_OnlyChallengedPlayer(t *testing.T) {
+	f := newChallengeFixture()
+	challenge := f.create(t, 1500)
+
+	_, err := f.service.Accept(context.Background(), challenge.ID, f.challenger)
+	assertAppErrorCode(t, err, utils.ErrCodeForbidden)
+
+	accepted, err := f.service.Accept(context.Background(), challenge.ID, f.challenged)
+	require.NoError(t, err)
+	require.NotNil(t, accepted.AcceptedAt)
+
+	_, err = f.service.Accept(context.Background(), challenge.ID, f.challenged)
+	assertAppErrorCode(t, err, utils.ErrCodeConflict)
+}
+
+func TestAccept_Expired(t *testing.T) {
+	f := newChallengeFixture()
+	challenge := f.create(t, 1500)
+
+	f.now = challenge.ExpiresAt
+	_, err := f.service.Accept(context.Background(), challenge.ID, f.challenged)
+	assertAppErrorCode(t, err, utils.ErrCodeConflict)
+}
+
+func TestCheck_MarksAcceptedChallengeWon(t *testing.T) {
+	f := newChallengeFixture()
+	challenge := f.create(t, 1500)
+	ctx := context.Background()
+
+	// Not accepted yet: reaching the target does not count
+	won, err := f.service.Check(ctx, f.challenged, "global", 2000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), won)
+
+	_, err = f.service.Accept(ctx, challenge.ID, f.challenged)
+	require.NoError(t, err)
+
+	won, err = f.service.Check(ctx, f.challenged, "global", 1499)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), won)
+
+	won, err = f.service.Check(ctx, f.challenged, "global", 1500)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), won)
+	assert.Equal(t, models.ChallengeStatusWon, f.repo.challenges[challenge.ID].Status)
+}
+
+func TestCheck_ExpiresOverdueFirst(t *testing.T) {
+	f := newChallengeFixture()
+	ctx := context.Background()
+	accepted := f.create(t, 1500)
+	ignored := f.create(t, 1500)
+	_, err := f.service.Accept(ctx, accepted.ID, f.challenged)
+	require.NoError(t, err)
+
+	f.now = f.now.Add(defaultChallengeDuration + time.Minute)
+	won, err := f.service.Check(ctx, f.challenged, "global", 5000)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), won)
+	assert.Equal(t, models.ChallengeStatusLost, f.repo.challenges[accepted.ID].Status)
+	assert.Equal(t, models.ChallengeStatusExpired, f.repo.challenges[ignored.ID].Status)
+}
+
+func TestCancel(t *testing.T) {
+	f := newChallengeFixture()
+	ctx := context.Background()
+	challenge := f.create(t, 1500)
+
+	err := f.service.Cancel(ctx, challenge.ID, f.challenged)
+	assertAppErrorCode(t, err, utils.ErrCodeForbidden)
+
+	require.NoError(t, f.service.Cancel(ctx, challenge.ID, f.challenger))
+
+	_, err = f.service.Get(ctx, challenge.ID, f.challenger)
+	assertAppErrorCode(t, err, utils.ErrCodeNotFound)
+}
+
+func TestGet_NonParticipant(t *testing.T) {
+	f := newChallengeFixture()
+	challenge := f.create(t, 1500)
+
+	_, err := f.service.Get(context.Background(), challenge.ID, uuid.New())
+	assertAppErrorCode(t, err, utils.ErrCodeForbidden)
+}
+
+func TestTenantScoped_ChallengesLiveInTenantSeasons(t *testing.T) {
+	f := newChallengeFixture()
+	f.service.SetTenantScoped(true)
+	studioA := middleware.WithTenantID(context.Background(), "studio_a")
+	studioB := middleware.WithTenantID(context.Background(), "studio_b")
+
+	challenge, err := f.service.Create(studioA, f.challenger, &models.CreateChallengeRequest{
+		ChallengedID: f.challenged,
+		TargetScore:  1500,
+		Season:       "summer",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "summer", challenge.Season, "prefix is stripped from the response")
+	assert.Equal(t, "studio_a:summer", f.repo.challenges[challenge.ID].Season)
+
+	// Another tenant sees neither the challenge nor its ID
+	_, err = f.service.Accept(studioB, challenge.ID, f.challenged)
+	assertAppErrorCode(t, err, utils.ErrCodeNotFound)
+	listed, err := f.service.List(studioB, f.challenged)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	_, err = f.service.Accept(studioA, challenge.ID, f.challenged)
+	require.NoError(t, err)
+
+	// LeaderboardService reports stored scores under the namespaced season
+	won, err := f.service.Check(context.Background(), f.challenged, "studio_a:summer", 1500)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), won)
+
+	_, err = f.service.Create(context.Background(), f.challenger, &models.CreateChallengeRequest{ChallengedID: f.challenged, TargetScore: 1})
+	assertAppErrorCode(t, err, utils.ErrCodeForbidden)
+}
@@ -8,6 +8,8 @@ import (
 
 	"leaderboard-service/internal/shared/database"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/repository/decorators"
+	"leaderboard-service/internal/strategy"
 )
 
 // RepositoryFactory создает репозитории с автоматической конфигурацией
@@ -53,16 +55,39 @@ type RepositoryConfig struct {
 
 	// EnableLogging включить логирование
 	EnableLogging bool
+
+	// AdaptiveCache выбирать кэш счетов по доступности Redis:
+	// RedisCachedScoreRepository при наличии CacheRedis, иначе SimpleCache
+	AdaptiveCache bool
+
+	// CacheRedis клиент Redis для RedisCachedScoreRepository (nil если Redis недоступен)
+	CacheRedis *database.RedisClient
+
+	// CacheSerializer формат значений в Redis-кэше счетов (nil - JSON)
+	CacheSerializer decorators.Serializer
+
+	// MetadataEncryption шифрование Metadata счетов (nil - хранить открытым текстом)
+	MetadataEncryption strategy.EncryptionStrategy
+
+	// RankingMethod метод ранжирования таблицы лидеров (пусто - dense)
+	RankingMethod string
+
+	// SlowQueryThreshold порог медленных вызовов репозитория счетов в логирующем декораторе (0 - не отмечать)
+	SlowQueryThreshold time.Duration
+
+	// SpecCacheTTL время жизни кэша FindBySpec в базовом репозитории пользователей (0 - выключен)
+	SpecCacheTTL time.Duration
 }
 
 // DefaultRepositoryConfig возвращает конфигурацию по умолчанию
 func DefaultRepositoryConfig(db *database.PostgresDB, redis *redis.Client) *RepositoryConfig {
 	return &RepositoryConfig{
-		DB:            db,
-		Redis:         redis,
-		EnableCache:   true,
-		CacheTTL:      5 * time.Minute,
-		EnableLogging: true,
+		DB:                 db,
+		Redis:              redis,
+		EnableCache:        true,
+		CacheTTL:           5 * time.Minute,
+		EnableLogging:      true,
+		SlowQueryThreshold: decorators.DefaultSlowQueryThreshold,
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"leaderboard-service/internal/shared/database"
 	"leaderboard-service/internal/shared/repository"
 	"leaderboard-service/internal/shared/repository/decorators"
+	"leaderboard-service/internal/strategy"
 )
 
 // DefaultRepositoryFactory стандартная фабрика репозиториев
@@ -29,7 +30,7 @@ func NewRepositoryFactory(config *RepositoryConfig) RepositoryFactory {
 // CreateUserRepository создает репозиторий пользователей с декораторами
 func (f *DefaultRepositoryFactory) CreateUserRepository() repository.UserRepository {
 	// 1. Создаем базовый репозиторий
-	baseRepo := authrepository.NewPostgresUserRepository(f.config.DB)
+	baseRepo := newPostgresUserRepository(f.config, f.cache)
 
 	// 2. Оборачиваем в декораторы в правильном порядке
 	var repo repository.UserRepository = baseRepo
@@ -47,27 +48,59 @@ func (f *DefaultRepositoryFactory) CreateUserRepository() repository.UserReposit
 	return repo
 }
 
+// newPostgresUserRepository создает Postgres репозиторий пользователей,
+// с кэшем спецификаций на уровне BaseRepository, если он включен через WithSpecCache
+func newPostgresUserRepository(config *RepositoryConfig, cache *decorators.SimpleCache) repository.UserRepository {
+	if config.SpecCacheTTL > 0 {
+		return authrepository.NewPostgresUserRepositoryWithSpecCache(config.DB, cache, config.SpecCacheTTL)
+	}
+	return authrepository.NewPostgresUserRepository(config.DB)
+}
+
 // CreateScoreRepository создает репозиторий счетов с декораторами
 func (f *DefaultRepositoryFactory) CreateScoreRepository() repository.ScoreRepository {
 	// 1. Создаем базовый репозиторий
-	baseRepo := leaderboardrepository.NewPostgresScoreRepository(f.config.DB)
+	baseRepo := newPostgresScoreRepository(f.config.DB, f.config)
 
 	// 2. Оборачиваем в декораторы в правильном порядке
 	var repo repository.ScoreRepository = baseRepo
 
 	// Сначала кэширование (ближе к базе данных)
 	if f.config.EnableCache {
-		repo = decorators.NewCachedScoreRepository(repo, f.cache)
+		repo = newCachedScoreRepository(repo, f.config, f.cache)
+	}
+
+	// Шифрование поверх кэша: в кэш (в том числе Redis) попадает только шифротекст
+	if f.config.MetadataEncryption != nil {
+		repo = decorators.NewEncryptingScoreRepository(repo, f.config.MetadataEncryption)
 	}
 
 	// Потом логирование (ближе к бизнес-логике)
 	if f.config.EnableLogging {
-		repo = decorators.NewLoggedScoreRepository(repo)
+		repo = decorators.NewLoggedScoreRepositoryWithThreshold(repo, f.config.SlowQueryThreshold)
 	}
 
 	return repo
 }
 
+// newPostgresScoreRepository создает Postgres репозиторий счетов с методом ранжирования из конфигурации
+func newPostgresScoreRepository(db *database.PostgresDB, config *RepositoryConfig) repository.ScoreRepository {
+	if config.RankingMethod != "" {
+		return leaderboardrepository.NewPostgresScoreRepositoryWithRanking(db, config.RankingMethod)
+	}
+	return leaderboardrepository.NewPostgresScoreRepository(db)
+}
+
+// newCachedScoreRepository оборачивает репозиторий счетов в кэширующий декоратор.
+// В адаптивном режиме Redis используется, когда он доступен (общий кэш между инстансами),
+// иначе — локальный SimpleCache, чтобы не остаться совсем без кэша
+func newCachedScoreRepository(repo repository.ScoreRepository, config *RepositoryConfig, cache *decorators.SimpleCache) repository.ScoreRepository {
+	if config.AdaptiveCache && config.CacheRedis != nil {
+		return decorators.NewRedisCachedScoreRepository(repo, config.CacheRedis, config.CacheSerializer)
+	}
+	return decorators.NewCachedScoreRepository(repo, cache)
+}
+
 // CreateUnitOfWork создает Unit of Work с настроенными репозиториями
 func (f *DefaultRepositoryFactory) CreateUnitOfWork() repository.UnitOfWork {
 	// Передаем простые фабрики без декораторов для транзакционного контекста
@@ -76,10 +109,20 @@ func (f *DefaultRepositoryFactory) CreateUnitOfWork() repository.UnitOfWork {
 		return authrepo
 	}
 	scoreFactory := func(db *database.PostgresDB) repository.ScoreRepository {
-		leaderboardrepo := leaderboardrepository.NewPostgresScoreRepository(db)
-		return leaderboardrepo
+		return newPostgresScoreRepository(db, f.config)
 	}
-	return repository.NewUnitOfWork(f.config.DB, userFactory, scoreFactory)
+	return repository.NewUnitOfWork(f.config.DB, userFactory, scoreFactory,
+		repository.WithAfterCommit(func() { invalidateUserCaches(f.cache) }))
+}
+
+// invalidateUserCaches сбрасывает кэши пользователей после коммита Unit of Work:
+// репозитории внутри транзакции без декораторов и сами кэш не трогают.
+// Закэшированные пользователи тоже удаляются, иначе после PromoteToAdmin
+// Login прочитал бы из кэша старый IsAdmin
+func invalidateUserCaches(cache *decorators.SimpleCache) {
+	authrepository.InvalidateUserSpecCache(cache)
+	decorators.InvalidateUserSpecs(cache)
+	decorators.InvalidateUsers(cache)
 }
 
 // CustomRepositoryFactory позволяет создавать репозитории с кастомной логикой
@@ -133,7 +176,7 @@ func (f *CustomRepositoryFactory) CreateUserRepository() repository.UserReposito
 	if f.userRepoBuilder != nil {
 		repo = f.userRepoBuilder(f.config.DB)
 	} else {
-		repo = authrepository.NewPostgresUserRepository(f.config.DB)
+		repo = newPostgresUserRepository(f.config, f.cache)
 	}
 
 	// Применяем стандартные декораторы
@@ -163,16 +206,20 @@ func (f *CustomRepositoryFactory) CreateScoreRepository() repository.ScoreReposi
 	if f.scoreRepoBuilder != nil {
 		repo = f.scoreRepoBuilder(f.config.DB)
 	} else {
-		repo = leaderboardrepository.NewPostgresScoreRepository(f.config.DB)
+		repo = newPostgresScoreRepository(f.config.DB, f.config)
 	}
 
 	// Применяем стандартные декораторы
 	if f.config.EnableCache {
-		repo = decorators.NewCachedScoreRepository(repo, f.cache)
+		repo = newCachedScoreRepository(repo, f.config, f.cache)
+	}
+
+	if f.config.MetadataEncryption != nil {
+		repo = decorators.NewEncryptingScoreRepository(repo, f.config.MetadataEncryption)
 	}
 
 	if f.config.EnableLogging {
-		repo = decorators.NewLoggedScoreRepository(repo)
+		repo = decorators.NewLoggedScoreRepositoryWithThreshold(repo, f.config.SlowQueryThreshold)
 	}
 
 	// Применяем кастомные декораторы
@@ -187,11 +234,11 @@ func (f *CustomRepositoryFactory) CreateScoreRepository() repository.ScoreReposi
 
 // CreateUnitOfWork создает Unit of Work
 func (f *CustomRepositoryFactory) CreateUnitOfWork() repository.UnitOfWork {
-	return repository.NewUnitOfWork(
-		f.config.DB,
-		authrepository.NewPostgresUserRepository,
-		leaderboardrepository.NewPostgresScoreRepository,
-	)
+	scoreFactory := func(db *database.PostgresDB) repository.ScoreRepository {
+		return newPostgresScoreRepository(db, f.config)
+	}
+	return repository.NewUnitOfWork(f.config.DB, authrepository.NewPostgresUserRepository, scoreFactory,
+		repository.WithAfterCommit(func() { invalidateUserCaches(f.cache) }))
 }
 
 // RepositoryFactoryBuilder builder для фабрики репозиториев (fluent interface)
@@ -199,6 +246,7 @@ type RepositoryFactoryBuilder struct {
 	config           *RepositoryConfig
 	enableCache      bool
 	cacheTTL         time.Duration
+	specCacheTTL     time.Duration
 	enableLogging    bool
 	customDecorators []DecoratorBuilder
 	userRepoBuilder  func(*database.PostgresDB) repository.UserRepository
@@ -209,8 +257,9 @@ type RepositoryFactoryBuilder struct {
 func NewRepositoryFactoryBuilder(db *database.PostgresDB, redis *redis.Client) *RepositoryFactoryBuilder {
 	return &RepositoryFactoryBuilder{
 		config: &RepositoryConfig{
-			DB:    db,
-			Redis: redis,
+			DB:                 db,
+			Redis:              redis,
+			SlowQueryThreshold: decorators.DefaultSlowQueryThreshold,
 		},
 		enableCache:   true,
 		cacheTTL:      5 * time.Minute,
@@ -225,6 +274,49 @@ func (b *RepositoryFactoryBuilder) WithCache(ttl time.Duration) *RepositoryFacto
 	return b
 }
 
+// WithAdaptiveCache включает кэширование с выбором хранилища по доступности Redis:
+// Redis для счетов, если клиент не nil, иначе in-memory SimpleCache
+func (b *RepositoryFactoryBuilder) WithAdaptiveCache(redis *database.RedisClient) *RepositoryFactoryBuilder {
+	b.enableCache = true
+	b.config.AdaptiveCache = true
+	b.config.CacheRedis = redis
+	if redis != nil {
+		b.config.Redis = redis.Client
+	}
+	return b
+}
+
+// WithCacheSerializer задает формат значений, которые RedisCachedScoreRepository хранит в Redis
+func (b *RepositoryFactoryBuilder) WithCacheSerializer(serializer decorators.Serializer) *RepositoryFactoryBuilder {
+	b.config.CacheSerializer = serializer
+	return b
+}
+
+// WithMetadataEncryption включает шифрование Metadata счетов перед сохранением
+func (b *RepositoryFactoryBuilder) WithMetadataEncryption(encryption strategy.EncryptionStrategy) *RepositoryFactoryBuilder {
+	b.config.MetadataEncryption = encryption
+	return b
+}
+
+// WithRankingMethod задает метод ранжирования таблицы лидеров (dense, competition, ordinal)
+func (b *RepositoryFactoryBuilder) WithRankingMethod(method string) *RepositoryFactoryBuilder {
+	b.config.RankingMethod = method
+	return b
+}
+
+// WithSlowQueryThreshold задает порог, выше которого вызовы репозитория счетов логируются как медленные
+func (b *RepositoryFactoryBuilder) WithSlowQueryThreshold(threshold time.Duration) *RepositoryFactoryBuilder {
+	b.config.SlowQueryThreshold = threshold
+	return b
+}
+
+// WithSpecCache включает кэш результатов FindBySpec в BaseRepository пользователей
+// Кэш сбрасывается целиком при любой записи в таблицу
+func (b *RepositoryFactoryBuilder) WithSpecCache(ttl time.Duration) *RepositoryFactoryBuilder {
+	b.specCacheTTL = ttl
+	return b
+}
+
 // WithoutCache выключает кэширование
 func (b *RepositoryFactoryBuilder) WithoutCache() *RepositoryFactoryBuilder {
 	b.enableCache = false
@@ -269,6 +361,7 @@ func (b *RepositoryFactoryBuilder) WithScoreRepositoryBuilder(
 func (b *RepositoryFactoryBuilder) Build() RepositoryFactory {
 	b.config.EnableCache = b.enableCache
 	b.config.CacheTTL = b.cacheTTL
+	b.config.SpecCacheTTL = b.specCacheTTL
 	b.config.EnableLogging = b.enableLogging
 
 	if len(b.customDecorators) > 0 || b.userRepoBuilder != nil || b.scoreRepoBuilder != nil {
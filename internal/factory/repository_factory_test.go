@@ -0,0 +1,95 @@
+package factory
+
+import (
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/auth/infrastructure"
+	authrepository "leaderboard-service/internal/auth/repository"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/repository/decorators"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAdaptiveCache_UsesRedisWhenAvailable(t *testing.T) {
+	redis := &database.RedisClient{}
+
+	repo := NewRepositoryFactoryBuilder(&database.PostgresDB{}, nil).
+		WithAdaptiveCache(redis).
+		WithoutLogging().
+		Build().
+		CreateScoreRepository()
+
+	assert.IsType(t, &decorators.RedisCachedScoreRepository{}, repo)
+}
+
+func TestWithAdaptiveCache_FallsBackToSimpleCache(t *testing.T) {
+	repo := NewRepositoryFactoryBuilder(&database.PostgresDB{}, nil).
+		WithAdaptiveCache(nil).
+		WithoutLogging().
+		Build().
+		CreateScoreRepository()
+
+	assert.IsType(t, &decorators.CachedScoreRepository{}, repo)
+}
+
+func TestWithMetadataEncryption_WrapsCachedRepository(t *testing.T) {
+	repo := NewRepositoryFactoryBuilder(&database.PostgresDB{}, nil).
+		WithAdaptiveCache(nil).
+		WithMetadataEncryption(strategy.NewAESGCMEncryptionStrategy([32]byte{})).
+		WithoutLogging().
+		Build().
+		CreateScoreRepository()
+
+	encrypting, ok := repo.(*decorators.EncryptingScoreRepository)
+	if assert.True(t, ok) {
+		assert.IsType(t, &decorators.CachedScoreRepository{}, encrypting.ScoreRepository)
+	}
+}
+
+func TestWithSpecCache_WrapsUserBaseRepository(t *testing.T) {
+	repo := NewRepositoryFactoryBuilder(&database.PostgresDB{}, nil).
+		WithoutCache().
+		WithoutLogging().
+		WithSpecCache(time.Minute).
+		Build().
+		CreateUserRepository()
+
+	postgresRepo, ok := repo.(*authrepository.PostgresUserRepository)
+	if assert.True(t, ok) {
+		assert.IsType(t, &repository.CachedBaseRepository[infrastructure.UserEntity]{}, postgresRepo.EntityRepository)
+	}
+}
+
+func TestInvalidateUserCaches_DropsSpecResults(t *testing.T) {
+	cache := decorators.NewSimpleCache()
+	baseKey := repository.SpecCachePrefix[infrastructure.UserEntity]() + "users_by_domain(example.com)"
+	cache.Set(baseKey, []*infrastructure.UserEntity{}, time.Minute)
+	cache.Set("user_spec:find:users_by_domain(example.com)", nil, time.Minute)
+	cache.Set("score:keep", 1, time.Minute)
+
+	invalidateUserCaches(cache)
+
+	_, ok := cache.Get(baseKey)
+	assert.False(t, ok)
+	_, ok = cache.Get("user_spec:find:users_by_domain(example.com)")
+	assert.False(t, ok)
+	_, ok = cache.Get("score:keep")
+	assert.True(t, ok, "other entries stay cached")
+}
+
+func TestInvalidateUserCaches_DropsCachedUsers(t *testing.T) {
+	cache := decorators.NewSimpleCache()
+	cache.Set("user:id:42", "stale", time.Minute)
+	cache.Set("user:email:john@example.com", "stale", time.Minute)
+
+	invalidateUserCaches(cache)
+
+	_, ok := cache.Get("user:id:42")
+	assert.False(t, ok, "a promoted user must be reloaded with the new admin flag")
+	_, ok = cache.Get("user:email:john@example.com")
+	assert.False(t, ok)
+}
@@ -48,14 +48,18 @@ func (f *DefaultServiceFactory) CreateLeaderboardService() interface{} {
 // CreateUserManagementService создает сервис управления пользователями
 func (f *DefaultServiceFactory) CreateUserManagementService() interface{} {
 	uow := f.repoFactory.CreateUnitOfWork()
-	return userservice.NewUserManagementService(uow)
+	svc := userservice.NewUserManagementService(uow)
+	svc.SetDefaultSeason(f.config.GetDefaultSeason())
+	return svc
 }
 
 // CreateQueryService создает сервис для запросов
 func (f *DefaultServiceFactory) CreateQueryService() interface{} {
 	userRepo := f.repoFactory.CreateUserRepository()
 	scoreRepo := f.repoFactory.CreateScoreRepository()
-	return leaderboardservice.NewQueryService(userRepo, scoreRepo)
+	svc := leaderboardservice.NewQueryService(userRepo, scoreRepo)
+	svc.SetDefaultSeason(f.config.GetDefaultSeason())
+	return svc
 }
 
 // TypedServiceFactory типизированная фабрика сервисов (без interface{})
@@ -96,14 +100,18 @@ func (f *TypedServiceFactory) CreateLeaderboardService() *leaderboardservice.Lea
 // CreateUserManagementService создает UserManagementService
 func (f *TypedServiceFactory) CreateUserManagementService() *userservice.UserManagementService {
 	uow := f.repoFactory.CreateUnitOfWork()
-	return userservice.NewUserManagementService(uow)
+	svc := userservice.NewUserManagementService(uow)
+	svc.SetDefaultSeason(f.config.GetDefaultSeason())
+	return svc
 }
 
 // CreateQueryService создает QueryService
 func (f *TypedServiceFactory) CreateQueryService() *leaderboardservice.QueryService {
 	userRepo := f.repoFactory.CreateUserRepository()
 	scoreRepo := f.repoFactory.CreateScoreRepository()
-	return leaderboardservice.NewQueryService(userRepo, scoreRepo)
+	svc := leaderboardservice.NewQueryService(userRepo, scoreRepo)
+	svc.SetDefaultSeason(f.config.GetDefaultSeason())
+	return svc
 }
 
 // ServiceFactoryBuilder builder для создания фабрики сервисов
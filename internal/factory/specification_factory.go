@@ -213,10 +213,7 @@ func (b *UserSearchSpecBuilder) Build() repository.Specification[authmodels.User
 
 	// Поиск по имени или email
 	if b.query != "" {
-		specs = append(specs, repository.Or(
-			repository.NewUserByNameSpec(b.query),
-			repository.NewUserByEmailSpec(b.query),
-		))
+		specs = append(specs, repository.SearchUsersSpec(b.query))
 	}
 
 	// Фильтр по домену
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authhandler "leaderboard-service/internal/auth/handler"
+	authmodels "leaderboard-service/internal/auth/models"
+	authservice "leaderboard-service/internal/auth/service"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// failingUserRepository fails every email lookup with err
+type failingUserRepository struct {
+	repository.UserRepository
+	err error
+}
+
+func (r *failingUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return false, r.err
+}
+
+func (r *failingUserRepository) FindByEmail(ctx context.Context, email string) (*authmodels.User, error) {
+	return nil, r.err
+}
+
+func newTestAuthHandler(repo repository.UserRepository) *authhandler.AuthHandler {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpiryHours: 24}}
+	return authhandler.NewAuthHandler(authservice.NewAuthService(repo, middleware.NewJWTMiddleware(cfg), cfg))
+}
+
+// existingUserRepository holds john@example.com with the password "password123"
+func existingUserRepository(t *testing.T) repository.UserRepository {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	repo := testutil.NewInMemoryUserRepository()
+	require.NoError(t, repo.Create(context.Background(), &authmodels.User{Name: "John", Email: "john@example.com", Password: string(hash)}))
+	return repo
+}
+
+// TestAuthHandler_ServiceErrors tests that Register and Login map the AppErrors of AuthService to status and code
+func TestAuthHandler_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		repo           func(t *testing.T) repository.UserRepository
+		call           func(h *authhandler.AuthHandler, rr *httptest.ResponseRecorder)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "Register/validation",
+			repo: existingUserRepository,
+			call: func(h *authhandler.AuthHandler, rr *httptest.ResponseRecorder) {
+				h.Register(rr, httptest.NewRequest(http.MethodPost, "/auth/register",
+					bytes.NewBufferString(`{"name":"<b>John</b>","email":"new@example.com","password":"password123"}`)))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   utils.ErrCodeValidation,
+		},
+		{
+			name: "Register/conflict",
+			repo: existingUserRepository,
+			call: func(h *authhandler.AuthHandler, rr *httptest.ResponseRecorder) {
+				h.Register(rr, httptest.NewRequest(http.MethodPost, "/auth/register",
+					bytes.NewBufferString(`{"name":"John","email":"john@example.com","password":"password123"}`)))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   utils.ErrCodeConflict,
+		},
+		{
+			name: "Register/database",
+			repo: func(t *testing.T) repository.UserRepository {
+				return &failingUserRepository{err: errors.New("connection reset")}
+			},
+			call: func(h *authhandler.AuthHandler, rr *httptest.ResponseRecorder) {
+				h.Register(rr, httptest.NewRequest(http.MethodPost, "/auth/register",
+					bytes.NewBufferString(`{"name":"John","email":"john@example.com","password":"password123"}`)))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   utils.ErrCodeDatabaseError,
+		},
+		{
+			name: "Login/unknown email",
+			repo: existingUserRepository,
+			call: func(h *authhandler.AuthHandler, rr *httptest.ResponseRecorder) {
+				h.Login(rr, httptest.NewRequest(http.MethodPost, "/auth/login",
+					bytes.NewBufferString(`{"email":"nobody@example.com","password":"password123"}`)))
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   utils.ErrCodeUnauthorized,
+		},
+		{
+			name: "Login/wrong password",
+			repo: existingUserRepository,
+			call: func(h *authhandler.AuthHandler, rr *httptest.ResponseRecorder) {
+				h.Login(rr, httptest.NewRequest(http.MethodPost, "/auth/login",
+					bytes.NewBufferString(`{"email":"john@example.com","password":"wrongpassword"}`)))
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   utils.ErrCodeUnauthorized,
+		},
+		{
+			name: "Login/database",
+			repo: func(t *testing.T) repository.UserRepository {
+				return &failingUserRepository{err: errors.New("connection reset")}
+			},
+			call: func(h *authhandler.AuthHandler, rr *httptest.ResponseRecorder) {
+				h.Login(rr, httptest.NewRequest(http.MethodPost, "/auth/login",
+					bytes.NewBufferString(`{"email":"john@example.com","password":"password123"}`)))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   utils.ErrCodeDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tt.call(newTestAuthHandler(tt.repo(t)), rr)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			var response models.ErrorResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			assert.Equal(t, tt.expectedCode, response.ErrorCode)
+			assert.Equal(t, tt.expectedStatus, response.Code)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	challengehandler "leaderboard-service/internal/challenge/handler"
+	challengemodels "leaderboard-service/internal/challenge/models"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/models"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockChallengeService is a mock for ChallengeService
+type MockChallengeService struct {
+	mock.Mock
+}
+
+func (m *MockChallengeService) Create(ctx context.Context, challengerID uuid.UUID, req *challengemodels.CreateChallengeRequest) (*challengemodels.Challenge, error) {
+	args := m.Called(ctx, challengerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*challengemodels.Challenge), args.Error(1)
+}
+
+func (m *MockChallengeService) Get(ctx context.Context, challengeID, userID uuid.UUID) (*challengemodels.Challenge, error) {
+	args := m.Called(ctx, challengeID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*challengemodels.Challenge), args.Error(1)
+}
+
+func (m *MockChallengeService) List(ctx context.Context, userID uuid.UUID) ([]*challengemodels.Challenge, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*challengemodels.Challenge), args.Error(1)
+}
+
+func (m *MockChallengeService) Accept(ctx context.Context, challengeID, userID uuid.UUID) (*challengemodels.Challenge, error) {
+	args := m.Called(ctx, challengeID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*challengemodels.Challenge), args.Error(1)
+}
+
+func (m *MockChallengeService) Cancel(ctx context.Context, challengeID, userID uuid.UUID) error {
+	args := m.Called(ctx, challengeID, userID)
+	return args.Error(0)
+}
+
+// withChallengeRequest adds the authenticated user and the {id} URL param to a request
+func withChallengeRequest(req *http.Request, userID uuid.UUID, challengeID string) *http.Request {
+	ctx := context.WithValue(req.Context(), middleware.UserIDKey, userID)
+	if challengeID != "" {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", challengeID)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	}
+	return req.WithContext(ctx)
+}
+
+func TestCreateChallenge_Success(t *testing.T) {
+	mockService := new(MockChallengeService)
+	handler := challengehandler.NewChallengeHandler(mockService)
+
+	userID := uuid.New()
+	createReq := &challengemodels.CreateChallengeRequest{ChallengedID: uuid.New(), TargetScore: 1500}
+	mockService.On("Create", mock.Anything, userID, createReq).
+		Return(&challengemodels.Challenge{ID: uuid.New(), Status: challengemodels.ChallengeStatusPending}, nil)
+
+	body, _ := json.Marshal(createReq)
+	req := withChallengeRequest(httptest.NewRequest(http.MethodPost, "/challenges", bytes.NewReader(body)), userID, "")
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response models.SuccessResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.True(t, response.Success)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateChallenge_Unauthorized(t *testing.T) {
+	handler := challengehandler.NewChallengeHandler(new(MockChallengeService))
+
+	req := httptest.NewRequest(http.MethodPost, "/challenges", bytes.NewReader([]byte(`{}`)))
+	rr := httptest.NewRecorder()
+
+	handler.Create(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAcceptChallenge_InvalidID(t *testing.T) {
+	handler := challengehandler.NewChallengeHandler(new(MockChallengeService))
+
+	req := withChallengeRequest(httptest.NewRequest(http.MethodPost, "/challenges/bad/accept", nil), uuid.New(), "bad")
+	rr := httptest.NewRecorder()
+
+	handler.Accept(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestAcceptChallenge_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"not found", utils.NotFound("challenge", nil), http.StatusNotFound},
+		{"not the challenged player", utils.Forbidden("only the challenged player can accept", nil), http.StatusForbidden},
+		{"already closed", utils.Conflict("challenge is no longer open", nil), http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockChallengeService)
+			handler := challengehandler.NewChallengeHandler(mockService)
+
+			userID, challengeID := uuid.New(), uuid.New()
+			mockService.On("Accept", mock.Anything, challengeID, userID).Return(nil, tt.err)
+
+			req := withChallengeRequest(httptest.NewRequest(http.MethodPost, "/challenges/"+challengeID.String()+"/accept", nil), userID, challengeID.String())
+			rr := httptest.NewRecorder()
+
+			handler.Accept(rr, req)
+
+			assert.Equal(t, tt.expected, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListChallenges_EmptyIsArray(t *testing.T) {
+	mockService := new(MockChallengeService)
+	handler := challengehandler.NewChallengeHandler(mockService)
+
+	userID := uuid.New()
+	mockService.On("List", mock.Anything, userID).Return(nil, nil)
+
+	req := withChallengeRequest(httptest.NewRequest(http.MethodGet, "/challenges", nil), userID, "")
+	rr := httptest.NewRecorder()
+
+	handler.List(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"success":true,"data":[]}`, rr.Body.String())
+}
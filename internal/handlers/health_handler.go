@@ -1,16 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"leaderboard-service/internal/shared/database"
 	"leaderboard-service/internal/shared/models"
 )
 
+// ReadinessChecker reports whether a component has finished starting up (e.g. cache warm-up)
+type ReadinessChecker interface {
+	IsReady() bool
+}
+
 // HealthHandler handles health check endpoints
 type HealthHandler struct {
 	db    *database.PostgresDB
 	redis *database.RedisClient
+	cache ReadinessChecker // Optional; nil skips the cache warm-up check
 }
 
 // NewHealthHandler creates a new health handler
@@ -21,6 +28,11 @@ func NewHealthHandler(db *database.PostgresDB, redis *database.RedisClient) *Hea
 	}
 }
 
+// SetCacheReadiness makes /ready return 503 until the checker reports the cache is warm
+func (h *HealthHandler) SetCacheReadiness(checker ReadinessChecker) {
+	h.cache = checker
+}
+
 // Health performs a health check
 // GET /health
 func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
@@ -61,8 +73,20 @@ func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
 func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if h.cache != nil && !h.cache.IsReady() {
+		respondJSON(w, map[string]string{
+			"status": "not_ready",
+			"reason": "cache_warming",
+		}, http.StatusServiceUnavailable)
+		return
+	}
+
 	// Check critical dependencies
-	if err := h.db.Health(ctx); err != nil {
+	if err := h.db.HealthCheck(ctx); err != nil {
+		if errors.Is(err, database.ErrPoolExhausted) {
+			respondError(w, "database connection pool exhausted", http.StatusServiceUnavailable)
+			return
+		}
 		respondError(w, "database not ready", http.StatusServiceUnavailable)
 		return
 	}
@@ -73,9 +97,14 @@ func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
 	}, http.StatusOK)
 }
 
-// Liveness checks if the service is alive
+// Liveness checks if the service is alive: the database answers and its connection pool is not exhausted
 // GET /live
 func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	if err := h.db.HealthCheck(r.Context()); err != nil {
+		respondError(w, "database not available", http.StatusServiceUnavailable)
+		return
+	}
+
 	respondJSON(w, models.SuccessResponse{
 		Success: true,
 		Message: "service is alive",
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leaderboard-service/internal/shared/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// pingDriver is a database/sql driver whose connections only answer Ping
+type pingDriver struct{}
+
+func (pingDriver) Open(string) (driver.Conn, error) { return pingConn{}, nil }
+
+type pingConn struct{}
+
+func (pingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (pingConn) Close() error                        { return nil }
+func (pingConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func init() {
+	sql.Register("health_ping", pingDriver{})
+}
+
+// newReachableDB returns a PostgresDB whose Health check succeeds without a server
+func newReachableDB(t *testing.T) *database.PostgresDB {
+	t.Helper()
+
+	sqlDB, err := sql.Open("health_ping", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	return &database.PostgresDB{DB: db}
+}
+
+type staticReadiness bool
+
+func (r staticReadiness) IsReady() bool { return bool(r) }
+
+func TestReadiness_CacheWarming(t *testing.T) {
+	handler := NewHealthHandler(newReachableDB(t), nil)
+	handler.SetCacheReadiness(staticReadiness(false))
+
+	rr := httptest.NewRecorder()
+	handler.Readiness(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	assert.Equal(t, map[string]string{"status": "not_ready", "reason": "cache_warming"}, body)
+}
+
+func TestReadiness_CacheWarm(t *testing.T) {
+	handler := NewHealthHandler(newReachableDB(t), nil)
+	handler.SetCacheReadiness(staticReadiness(true))
+
+	rr := httptest.NewRecorder()
+	handler.Readiness(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestProbes_PoolExhausted(t *testing.T) {
+	db := newReachableDB(t)
+	handler := NewHealthHandler(db, nil)
+
+	sqlDB, err := db.DB.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(2)
+
+	// Both connections are checked out, as under a burst of slow queries
+	ctx := context.Background()
+	first, err := sqlDB.Conn(ctx)
+	require.NoError(t, err)
+	second, err := sqlDB.Conn(ctx)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, db.HealthCheck(ctx), database.ErrPoolExhausted)
+
+	rr := httptest.NewRecorder()
+	handler.Readiness(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Contains(t, rr.Body.String(), "pool exhausted")
+
+	rr = httptest.NewRecorder()
+	handler.Liveness(rr, httptest.NewRequest(http.MethodGet, "/live", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	// A released connection stays open but idle and does not count
+	require.NoError(t, first.Close())
+	require.NoError(t, db.HealthCheck(ctx))
+
+	rr = httptest.NewRecorder()
+	handler.Liveness(rr, httptest.NewRequest(http.MethodGet, "/live", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, second.Close())
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+
+	"leaderboard-service/internal/jobs"
+	"leaderboard-service/internal/shared/models"
+)
+
+// JobStatusReporter exposes the state of background jobs
+type JobStatusReporter interface {
+	Statuses() []jobs.Status
+}
+
+// JobsHandler handles the background job admin endpoint
+type JobsHandler struct {
+	scheduler JobStatusReporter
+}
+
+// NewJobsHandler creates a new jobs handler
+func NewJobsHandler(scheduler JobStatusReporter) *JobsHandler {
+	return &JobsHandler{scheduler: scheduler}
+}
+
+// List returns every registered job with its last run time and last error
+// GET /admin/jobs
+func (h *JobsHandler) List(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, models.SuccessResponse{
+		Success: true,
+		Data:    h.scheduler.Statuses(),
+	}, http.StatusOK)
+}
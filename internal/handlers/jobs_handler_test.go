@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/jobs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticJobStatuses returns fixed job statuses
+type staticJobStatuses []jobs.Status
+
+func (s staticJobStatuses) Statuses() []jobs.Status { return s }
+
+func TestJobsHandler_List(t *testing.T) {
+	lastRun := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	handler := NewJobsHandler(staticJobStatuses{
+		{Name: "leaderboard_view_refresh", IntervalSeconds: 30, Runs: 4, LastRunAt: &lastRun, LastError: "connection refused"},
+		{Name: "scoring_config_reload", IntervalSeconds: 60},
+	})
+
+	rr := httptest.NewRecorder()
+	handler.List(rr, httptest.NewRequest(http.MethodGet, "/admin/jobs", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Success bool          `json:"success"`
+		Data    []jobs.Status `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.True(t, response.Success)
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, "connection refused", response.Data[0].LastError)
+	assert.True(t, lastRun.Equal(*response.Data[0].LastRunAt))
+	assert.Nil(t, response.Data[1].LastRunAt, "never-run jobs have no last run time")
+}
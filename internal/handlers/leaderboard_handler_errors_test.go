@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	leaderboardhandler "leaderboard-service/internal/leaderboard/handler"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/models"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// serviceErrorCases covers each kind of error a service can return
+var serviceErrorCases = []struct {
+	name           string
+	err            error
+	expectedStatus int
+	expectedCode   string
+}{
+	{"validation", utils.ValidationError("score exceeds maximum", nil), http.StatusBadRequest, utils.ErrCodeValidation},
+	{"bad request", utils.BadRequest("season is required", nil), http.StatusBadRequest, utils.ErrCodeBadRequest},
+	{"not found", utils.NotFound("user", nil), http.StatusNotFound, utils.ErrCodeNotFound},
+	{"database", utils.DatabaseError("query", errors.New("connection reset")), http.StatusInternalServerError, utils.ErrCodeDatabaseError},
+	{"unavailable", utils.ServiceUnavailable("WebSocket", nil), http.StatusServiceUnavailable, utils.ErrCodeServiceUnavailable},
+	{"plain error", errors.New("boom"), http.StatusInternalServerError, utils.ErrCodeInternalError},
+}
+
+// handlerCall invokes one handler method with the mock configured to return err
+type handlerCall func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder
+
+var leaderboardHandlerCalls = map[string]handlerCall{
+	"SubmitScore": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		userID := uuid.New()
+		mockService.On("SubmitScore", mock.Anything, userID, mock.Anything).Return(nil, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/submit-score", bytes.NewBufferString(`{"score":100,"season":"global"}`))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, userID))
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).SubmitScore(rr, req)
+		return rr
+	},
+	"GetLeaderboard": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		mockService.On("GetLeaderboard", mock.Anything, mock.Anything).Return(nil, err)
+
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).GetLeaderboard(rr, httptest.NewRequest(http.MethodGet, "/leaderboard", nil))
+		return rr
+	},
+	"GetUserRank": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		userID := uuid.New()
+		mockService.On("GetUserRank", mock.Anything, userID, "global").Return(nil, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/leaderboard/user/"+userID.String(), nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("userID", userID.String())
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).GetUserRank(rr, req)
+		return rr
+	},
+	"GetUserLeague": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		userID := uuid.New()
+		mockService.On("GetLeagueForUser", mock.Anything, userID, "global").Return(nil, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/leaderboard/user/"+userID.String()+"/league", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("userID", userID.String())
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).GetUserLeague(rr, req)
+		return rr
+	},
+	"GetNearby": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		userID := uuid.New()
+		mockService.On("GetNeighbors", mock.Anything, userID, "global", 5).Return(nil, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/leaderboard/nearby", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, userID))
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).GetNearby(rr, req)
+		return rr
+	},
+	"GetByCountry": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		mockService.On("GetLeaderboardByCountry", mock.Anything, "FR", "global", 50, 0).Return(nil, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/leaderboard/country/FR", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("countryCode", "FR")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).GetByCountry(rr, req)
+		return rr
+	},
+	"FindScoresByMetadata": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		mockService.On("FindScoresByMetadata", mock.Anything, "global", "simulated", "true", 100).Return(nil, err)
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/admin/scores?metadata_key=simulated&metadata_value=true", nil)
+		leaderboardhandler.NewLeaderboardHandler(mockService).FindScoresByMetadata(rr, req)
+		return rr
+	},
+	"TestBroadcast": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		mockService.On("BroadcastLeaderboard", mock.Anything, "global").Return(err)
+
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).TestBroadcast(rr, httptest.NewRequest(http.MethodPost, "/test/broadcast", nil))
+		return rr
+	},
+	"ResetSeason": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		adminID := uuid.New()
+		mockService.On("ResetSeason", mock.Anything, "2024-spring", adminID.String()).Return(err)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/seasons/2024-spring/reset", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("name", "2024-spring")
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		req = req.WithContext(context.WithValue(ctx, middleware.UserIDKey, adminID))
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).ResetSeason(rr, req)
+		return rr
+	},
+	"UpdateScoringConfig": func(t *testing.T, mockService *MockLeaderboardService, err error) *httptest.ResponseRecorder {
+		mockService.On("UpdateScoringConfig", mock.Anything, "max_score", mock.Anything).Return(nil, err)
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/scoring-configs/max_score", bytes.NewBufferString(`{"value":"5000"}`))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("key", "max_score")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+		leaderboardhandler.NewLeaderboardHandler(mockService).UpdateScoringConfig(rr, req)
+		return rr
+	},
+}
+
+// TestLeaderboardHandler_ServiceErrors tests that every handler maps AppError to its status and code
+func TestLeaderboardHandler_ServiceErrors(t *testing.T) {
+	for method, call := range leaderboardHandlerCalls {
+		for _, tc := range serviceErrorCases {
+			t.Run(method+"/"+tc.name, func(t *testing.T) {
+				mockService := new(MockLeaderboardService)
+
+				rr := call(t, mockService, tc.err)
+
+				assert.Equal(t, tc.expectedStatus, rr.Code)
+
+				var response models.ErrorResponse
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+				assert.Equal(t, tc.expectedCode, response.ErrorCode)
+				assert.Equal(t, tc.expectedStatus, response.Code)
+				mockService.AssertExpectations(t)
+			})
+		}
+	}
+}
+
+// TestLeaderboardHandler_WrappedAppError tests that AppError is found inside a wrapped error
+func TestLeaderboardHandler_WrappedAppError(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	wrapped := errors.Join(errors.New("context"), utils.NotFound("season", nil))
+
+	rr := leaderboardHandlerCalls["GetLeaderboard"](t, mockService, wrapped)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	leaderboardhandler "leaderboard-service/internal/leaderboard/handler"
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// submitViaHandler posts a score as the given user and returns the recorded response
+func submitViaHandler(t *testing.T, handler *leaderboardhandler.LeaderboardHandler, userID uuid.UUID, score int64) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(leaderboardmodels.SubmitScoreRequest{Score: score, Season: "global"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/submit-score", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, userID))
+
+	rr := httptest.NewRecorder()
+	handler.SubmitScore(rr, req)
+	return rr
+}
+
+// decodeData unwraps SuccessResponse.Data into out
+func decodeData(t *testing.T, rr *httptest.ResponseRecorder, out interface{}) {
+	t.Helper()
+	var envelope struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&envelope))
+	require.True(t, envelope.Success)
+	require.NoError(t, json.Unmarshal(envelope.Data, out))
+}
+
+// TestInMemory_SubmitThenReadLeaderboard runs the real service end to end without Postgres or Redis
+func TestInMemory_SubmitThenReadLeaderboard(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	handler := leaderboardhandler.NewLeaderboardHandler(store.LeaderboardService(nil))
+
+	alice := store.AddUser("alice")
+	bob := store.AddUser("bob")
+
+	require.Equal(t, http.StatusCreated, submitViaHandler(t, handler, alice, 300).Code)
+	require.Equal(t, http.StatusCreated, submitViaHandler(t, handler, bob, 700).Code)
+
+	rr := httptest.NewRecorder()
+	handler.GetLeaderboard(rr, httptest.NewRequest(http.MethodGet, "/leaderboard?season=global&limit=10", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var board leaderboardmodels.LeaderboardResponse
+	decodeData(t, rr, &board)
+	require.Len(t, board.Entries, 2)
+	assert.Equal(t, bob, board.Entries[0].UserID)
+	assert.Equal(t, "alice", board.Entries[1].UserName)
+	assert.Equal(t, int64(2), board.TotalCount)
+	assert.WithinDuration(t, time.Now(), board.GeneratedAt, time.Minute)
+
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard/user/"+alice.String()+"?season=global", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("userID", alice.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr = httptest.NewRecorder()
+	handler.GetUserRank(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var entry leaderboardmodels.LeaderboardEntry
+	decodeData(t, rr, &entry)
+	assert.Equal(t, 2, entry.Rank)
+	assert.Equal(t, int64(300), entry.Score)
+}
+
+// TestInMemory_ScoreOutOfRange checks that limits from the config are enforced by the real service
+func TestInMemory_ScoreOutOfRange(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	handler := leaderboardhandler.NewLeaderboardHandler(store.LeaderboardService(nil))
+
+	rr := submitViaHandler(t, handler, store.AddUser("alice"), testutil.TestConfig().Validation.MaxScore+1)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+// TestInMemory_UnknownUserRank returns 404 when the player has no score
+func TestInMemory_UnknownUserRank(t *testing.T) {
+	handler := leaderboardhandler.NewLeaderboardHandler(testutil.NewInMemoryLeaderboardService(nil))
+
+	userID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard/user/"+userID.String(), nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("userID", userID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	handler.GetUserRank(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -7,16 +7,19 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	leaderboardhandler "leaderboard-service/internal/leaderboard/handler"
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
 	"leaderboard-service/internal/shared/middleware"
 	"leaderboard-service/internal/shared/models"
+	"leaderboard-service/internal/shared/utils"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockLeaderboardService is a mock for LeaderboardService
@@ -48,11 +51,106 @@ func (m *MockLeaderboardService) GetUserRank(ctx context.Context, userID uuid.UU
 	return args.Get(0).(*leaderboardmodels.LeaderboardEntry), args.Error(1)
 }
 
+func (m *MockLeaderboardService) FindScoresByMetadata(ctx context.Context, season, key, value string, limit int) ([]*leaderboardmodels.Score, error) {
+	args := m.Called(ctx, season, key, value, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*leaderboardmodels.Score), args.Error(1)
+}
+
+func (m *MockLeaderboardService) GetLeagues() []leaderboardmodels.League {
+	args := m.Called()
+	return args.Get(0).([]leaderboardmodels.League)
+}
+
+func (m *MockLeaderboardService) GetLeagueForUser(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.League, error) {
+	args := m.Called(ctx, userID, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*leaderboardmodels.League), args.Error(1)
+}
+
+func (m *MockLeaderboardService) GetNeighbors(ctx context.Context, userID uuid.UUID, season string, radius int) (*leaderboardmodels.NeighborsResponse, error) {
+	args := m.Called(ctx, userID, season, radius)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*leaderboardmodels.NeighborsResponse), args.Error(1)
+}
+
+func (m *MockLeaderboardService) GetGlobalStandings(ctx context.Context, limit int) (*leaderboardmodels.LeaderboardResponse, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*leaderboardmodels.LeaderboardResponse), args.Error(1)
+}
+
+func (m *MockLeaderboardService) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]leaderboardmodels.CrossSeasonEntry, error) {
+	args := m.Called(ctx, seasons, normalizeByMax, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]leaderboardmodels.CrossSeasonEntry), args.Error(1)
+}
+
+func (m *MockLeaderboardService) GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) (*leaderboardmodels.LeaderboardResponse, error) {
+	args := m.Called(ctx, country, season, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*leaderboardmodels.LeaderboardResponse), args.Error(1)
+}
+
+func (m *MockLeaderboardService) GetSummary(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.ScoreSummary, error) {
+	args := m.Called(ctx, userID, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*leaderboardmodels.ScoreSummary), args.Error(1)
+}
+
+func (m *MockLeaderboardService) GetAchievements(ctx context.Context, userID uuid.UUID, season string) ([]leaderboardmodels.Achievement, error) {
+	args := m.Called(ctx, userID, season)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]leaderboardmodels.Achievement), args.Error(1)
+}
+
+func (m *MockLeaderboardService) GetScoreDistribution(ctx context.Context, season string, buckets int) ([]leaderboardmodels.ScoreBucket, error) {
+	args := m.Called(ctx, season, buckets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]leaderboardmodels.ScoreBucket), args.Error(1)
+}
+
 func (m *MockLeaderboardService) BroadcastLeaderboard(ctx context.Context, season string) error {
 	args := m.Called(ctx, season)
 	return args.Error(0)
 }
 
+func (m *MockLeaderboardService) ResetSeason(ctx context.Context, season, adminUserID string) error {
+	args := m.Called(ctx, season, adminUserID)
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardService) DeleteScore(ctx context.Context, userID uuid.UUID, season string, requestedBy uuid.UUID) error {
+	args := m.Called(ctx, userID, season, requestedBy)
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardService) UpdateScoringConfig(ctx context.Context, key string, req *leaderboardmodels.UpdateScoringConfigRequest) (*leaderboardmodels.ScoringConfigEntry, error) {
+	args := m.Called(ctx, key, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*leaderboardmodels.ScoringConfigEntry), args.Error(1)
+}
+
 // TestSubmitScore_Success tests successful score submission
 func TestSubmitScore_Success(t *testing.T) {
 	mockService := new(MockLeaderboardService)
@@ -88,7 +186,7 @@ func TestSubmitScore_Success(t *testing.T) {
 	handler.SubmitScore(rr, req)
 
 	// Assert response
-	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, http.StatusCreated, rr.Code)
 
 	var response models.SuccessResponse
 	err := json.NewDecoder(rr.Body).Decode(&response)
@@ -98,6 +196,50 @@ func TestSubmitScore_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestSubmitScore_ReturnsCorrelationID tests that the correlation ID reaches the client
+func TestSubmitScore_ReturnsCorrelationID(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	userID := uuid.New()
+	mockService.On("SubmitScore", mock.Anything, userID, mock.MatchedBy(func(req *leaderboardmodels.SubmitScoreRequest) bool {
+		return req.CorrelationID == "client-42"
+	})).Return(&leaderboardmodels.Score{UserID: userID, Score: 1000, Season: "global", CorrelationID: "client-42"}, nil)
+
+	body := []byte(`{"score":1000,"correlation_id":"client-42"}`)
+	req := httptest.NewRequest(http.MethodPost, "/submit-score", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, userID))
+	rr := httptest.NewRecorder()
+
+	handler.SubmitScore(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"correlation_id":"client-42"`)
+	mockService.AssertExpectations(t)
+}
+
+// TestSubmitScore_DeduplicatedReturnsOK tests that a replayed idempotent submission answers 200 instead of 201
+func TestSubmitScore_DeduplicatedReturnsOK(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	userID := uuid.New()
+	mockService.On("SubmitScore", mock.Anything, userID, mock.MatchedBy(func(req *leaderboardmodels.SubmitScoreRequest) bool {
+		return req.IdempotencyKey == "retry-1"
+	})).Return(&leaderboardmodels.Score{UserID: userID, Score: 1000, Season: "global", Deduplicated: true}, nil)
+
+	body := []byte(`{"score":1000,"idempotency_key":"retry-1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/submit-score", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, userID))
+	rr := httptest.NewRecorder()
+
+	handler.SubmitScore(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"deduplicated":true`)
+	mockService.AssertExpectations(t)
+}
+
 // TestGetLeaderboard_Success tests successful leaderboard retrieval
 func TestGetLeaderboard_Success(t *testing.T) {
 	mockService := new(MockLeaderboardService)
@@ -108,13 +250,11 @@ func TestGetLeaderboard_Success(t *testing.T) {
 			{Rank: 1, UserID: uuid.New(), UserName: "Player1", Score: 1000, Season: "global"},
 			{Rank: 2, UserID: uuid.New(), UserName: "Player2", Score: 800, Season: "global"},
 		},
-		TotalCount: 2,
-		Page:       0,
-		Limit:      50,
-		HasNext:    false,
+		PaginationMeta: utils.NewPaginationMeta(1, 50, 2),
+		GeneratedAt:    time.Now(),
 	}
 
-	mockService.On("GetLeaderboard", mock.Anything, mock.AnythingOfType("*leaderboardmodels.LeaderboardQuery")).
+	mockService.On("GetLeaderboard", mock.Anything, mock.AnythingOfType("*models.LeaderboardQuery")).
 		Return(expectedResponse, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/leaderboard?season=global&limit=50", nil)
@@ -132,6 +272,300 @@ func TestGetLeaderboard_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+// TestGetTop_Success tests that the top endpoint returns only entries with public caching
+func TestGetTop_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	entries := []leaderboardmodels.LeaderboardEntry{
+		{Rank: 1, UserID: uuid.New(), UserName: "Player1", Score: 1000, Season: "winter"},
+		{Rank: 2, UserID: uuid.New(), UserName: "Player2", Score: 800, Season: "winter"},
+	}
+	mockService.On("GetLeaderboard", mock.Anything, mock.MatchedBy(func(q *leaderboardmodels.LeaderboardQuery) bool {
+		return q.Season == "winter" && q.Limit == 2 && q.Page == 0 && q.SortOrder == "desc"
+	})).Return(&leaderboardmodels.LeaderboardResponse{Entries: entries, PaginationMeta: utils.NewPaginationMeta(1, 2, 40), GeneratedAt: time.Now()}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard/top?n=2&season=winter", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetTop(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "public, max-age=5", rr.Header().Get("Cache-Control"))
+
+	var response struct {
+		Success bool                                 `json:"success"`
+		Data    []leaderboardmodels.LeaderboardEntry `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.True(t, response.Success)
+	assert.Len(t, response.Data, 2)
+	assert.Equal(t, "Player1", response.Data[0].UserName)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetTop_Defaults tests that n and season fall back to 10 and global
+func TestGetTop_Defaults(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	mockService.On("GetLeaderboard", mock.Anything, mock.MatchedBy(func(q *leaderboardmodels.LeaderboardQuery) bool {
+		return q.Season == "global" && q.Limit == 10
+	})).Return(&leaderboardmodels.LeaderboardResponse{GeneratedAt: time.Now()}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetTop(rr, httptest.NewRequest(http.MethodGet, "/leaderboard/top", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"success":true,"data":[]}`, rr.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+// TestGetTop_InvalidN tests that n outside 1..100 is rejected
+func TestGetTop_InvalidN(t *testing.T) {
+	for _, n := range []string{"0", "101", "-5", "ten"} {
+		t.Run(n, func(t *testing.T) {
+			mockService := new(MockLeaderboardService)
+			handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+			rr := httptest.NewRecorder()
+			handler.GetTop(rr, httptest.NewRequest(http.MethodGet, "/leaderboard/top?n="+n, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockService.AssertNotCalled(t, "GetLeaderboard", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// TestGetGlobalStandings_Success tests the all-time standings endpoint
+func TestGetGlobalStandings_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	expected := &leaderboardmodels.LeaderboardResponse{
+		Entries: []leaderboardmodels.LeaderboardEntry{
+			{Rank: 1, UserID: uuid.New(), UserName: "Veteran", Score: 9000, Season: "2024_01"},
+			{Rank: 2, UserID: uuid.New(), UserName: "Rookie", Score: 4000, Season: "global"},
+		},
+		PaginationMeta: utils.NewPaginationMeta(1, 20, 2),
+		GeneratedAt:    time.Now(),
+	}
+	mockService.On("GetGlobalStandings", mock.Anything, 20).Return(expected, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetGlobalStandings(rr, httptest.NewRequest(http.MethodGet, "/leaderboard/global-standings?limit=20", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response struct {
+		Success bool                                  `json:"success"`
+		Data    leaderboardmodels.LeaderboardResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.True(t, response.Success)
+	assert.Len(t, response.Data.Entries, 2)
+	assert.Equal(t, "2024_01", response.Data.Entries[0].Season)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetCrossSeasonRankings_Success tests that the season list and options reach the service
+func TestGetCrossSeasonRankings_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	expected := []leaderboardmodels.CrossSeasonEntry{
+		{Rank: 1, UserID: uuid.New(), UserName: "Steady", NormalizedScore: 87.5, SeasonScores: map[string]int64{"2024_01": 800, "2024_02": 950}},
+	}
+	mockService.On("GetCrossSeasonRankings", mock.Anything, []string{"2024_01", "2024_02"}, false, 20).Return(expected, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetCrossSeasonRankings(rr, httptest.NewRequest(http.MethodGet, "/leaderboard/cross-season?seasons=2024_01,2024_02&normalize_by_max=false&limit=20", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Data []leaderboardmodels.CrossSeasonEntry `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, 87.5, response.Data[0].NormalizedScore)
+	assert.Equal(t, int64(950), response.Data[0].SeasonScores["2024_02"])
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetCrossSeasonRankings_InvalidQuery tests that a missing season list or a bad flag is rejected
+func TestGetCrossSeasonRankings_InvalidQuery(t *testing.T) {
+	for _, target := range []string{
+		"/leaderboard/cross-season",
+		"/leaderboard/cross-season?seasons=2024_01&normalize_by_max=maybe",
+		"/leaderboard/cross-season?seasons=2024_01&limit=0",
+	} {
+		mockService := new(MockLeaderboardService)
+		handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+		rr := httptest.NewRecorder()
+		handler.GetCrossSeasonRankings(rr, httptest.NewRequest(http.MethodGet, target, nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, target)
+		mockService.AssertNotCalled(t, "GetCrossSeasonRankings", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+// TestGetByCountry_Success tests that the country, season and page reach the service
+func TestGetByCountry_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	expected := &leaderboardmodels.LeaderboardResponse{
+		Entries:        []leaderboardmodels.LeaderboardEntry{{Rank: 1, UserID: uuid.New(), UserName: "Local", Score: 700, Season: "winter"}},
+		PaginationMeta: utils.NewPaginationMeta(3, 10, 21),
+		GeneratedAt:    time.Now(),
+	}
+	mockService.On("GetLeaderboardByCountry", mock.Anything, "de", "winter", 10, 20).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard/country/de?season=winter&limit=10&page=2", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("countryCode", "de")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+	handler.GetByCountry(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Data leaderboardmodels.LeaderboardResponse `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	require.Len(t, response.Data.Entries, 1)
+	assert.Equal(t, "Local", response.Data.Entries[0].UserName)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetGlobalStandings_InvalidLimit tests that out-of-range limits are rejected
+func TestGetGlobalStandings_InvalidLimit(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	rr := httptest.NewRecorder()
+	handler.GetGlobalStandings(rr, httptest.NewRequest(http.MethodGet, "/leaderboard/global-standings?limit=500", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "GetGlobalStandings", mock.Anything, mock.Anything)
+}
+
+// TestGetDistribution_DefaultBuckets tests that the histogram defaults to the global season and 10 buckets
+func TestGetDistribution_DefaultBuckets(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	expected := []leaderboardmodels.ScoreBucket{{Min: 0, Max: 49, Count: 3}, {Min: 50, Max: 99, Count: 1}}
+	mockService.On("GetScoreDistribution", mock.Anything, "global", 10).Return(expected, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetDistribution(rr, httptest.NewRequest(http.MethodGet, "/leaderboard/distribution", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response struct {
+		Success bool                            `json:"success"`
+		Data    []leaderboardmodels.ScoreBucket `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, expected, response.Data)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetDistribution_InvalidBuckets tests that a non-numeric bucket count is rejected
+func TestGetDistribution_InvalidBuckets(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	rr := httptest.NewRecorder()
+	handler.GetDistribution(rr, httptest.NewRequest(http.MethodGet, "/leaderboard/distribution?buckets=ten", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "GetScoreDistribution", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetLeagues_ReturnsDefinitions tests the league table endpoint
+func TestGetLeagues_ReturnsDefinitions(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	leagues := []leaderboardmodels.League{
+		{Name: "Bronze", MinPercentile: 0, MaxPercentile: 50, Color: "#CD7F32"},
+		{Name: "Gold", MinPercentile: 50, MaxPercentile: 100, Color: "#FFD700"},
+	}
+	mockService.On("GetLeagues").Return(leagues)
+
+	rr := httptest.NewRecorder()
+	handler.GetLeagues(rr, httptest.NewRequest(http.MethodGet, "/leaderboard/leagues", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Data []leaderboardmodels.League `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, leagues, response.Data)
+}
+
+// TestGetUserLeague_Success tests league placement of a player in a season
+func TestGetUserLeague_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	userID := uuid.New()
+	gold := &leaderboardmodels.League{Name: "Gold", MinPercentile: 75, MaxPercentile: 95}
+	mockService.On("GetLeagueForUser", mock.Anything, userID, "winter").Return(gold, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard/user/"+userID.String()+"/league?season=winter", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("userID", userID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	handler.GetUserLeague(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Data leaderboardmodels.League `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, *gold, response.Data)
+	mockService.AssertExpectations(t)
+}
+
+// TestFindScoresByMetadata_PassesQuery tests the admin metadata search parameters
+func TestFindScoresByMetadata_PassesQuery(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	scores := []*leaderboardmodels.Score{{UserID: uuid.New(), Score: 300, Season: "winter", Metadata: map[string]interface{}{"simulated": true}}}
+	mockService.On("FindScoresByMetadata", mock.Anything, "winter", "simulated", "true", 20).Return(scores, nil)
+
+	rr := httptest.NewRecorder()
+	handler.FindScoresByMetadata(rr, httptest.NewRequest(http.MethodGet, "/admin/scores?metadata_key=simulated&metadata_value=true&season=winter&limit=20", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestFindScoresByMetadata_RequiresKeyAndValue tests that the search is not run without both parameters
+func TestFindScoresByMetadata_RequiresKeyAndValue(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	for _, query := range []string{"", "?metadata_key=simulated", "?metadata_value=true", "?metadata_key=simulated&metadata_value=true&limit=ten"} {
+		rr := httptest.NewRecorder()
+		handler.FindScoresByMetadata(rr, httptest.NewRequest(http.MethodGet, "/admin/scores"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+	mockService.AssertNotCalled(t, "FindScoresByMetadata", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 // TestGetUserRank_Success tests successful user rank retrieval
 func TestGetUserRank_Success(t *testing.T) {
 	mockService := new(MockLeaderboardService)
@@ -167,3 +601,417 @@ func TestGetUserRank_Success(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+// TestGetLeaderboard_ExcludeUsers tests that ?exclude= is parsed into the query
+func TestGetLeaderboard_ExcludeUsers(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	banned1, banned2 := uuid.New(), uuid.New()
+	mockService.On("GetLeaderboard", mock.Anything, mock.MatchedBy(func(q *leaderboardmodels.LeaderboardQuery) bool {
+		return len(q.ExcludeUserIDs) == 2 && q.ExcludeUserIDs[0] == banned1 && q.ExcludeUserIDs[1] == banned2
+	})).Return(&leaderboardmodels.LeaderboardResponse{PaginationMeta: utils.NewPaginationMeta(1, 50, 0), GeneratedAt: time.Now()}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard?season=global&exclude="+banned1.String()+",not-a-uuid,"+banned2.String(), nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetLeaderboard(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetLeaderboard_SortBy tests that ?sort_by= is passed to the service
+func TestGetLeaderboard_SortBy(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	mockService.On("GetLeaderboard", mock.Anything, mock.MatchedBy(func(q *leaderboardmodels.LeaderboardQuery) bool {
+		return q.SortBy == leaderboardmodels.SortByGamesPlayed && q.SortOrder == "asc"
+	})).Return(&leaderboardmodels.LeaderboardResponse{PaginationMeta: utils.NewPaginationMeta(1, 50, 0), GeneratedAt: time.Now()}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetLeaderboard(rr, httptest.NewRequest(http.MethodGet, "/leaderboard?sort_by=games_played&sort=asc", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetLeaderboard_InvalidSortBy tests that the service's validation error becomes a 400
+func TestGetLeaderboard_InvalidSortBy(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	mockService.On("GetLeaderboard", mock.Anything, mock.MatchedBy(func(q *leaderboardmodels.LeaderboardQuery) bool {
+		return q.SortBy == "rank"
+	})).Return(nil, utils.ValidationError("sort_by must be one of score, timestamp, games_played", nil))
+
+	rr := httptest.NewRecorder()
+	handler.GetLeaderboard(rr, httptest.NewRequest(http.MethodGet, "/leaderboard?sort_by=rank", nil))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetLeaderboard_ETagNotModified tests that a matching If-None-Match returns 304
+func TestGetLeaderboard_ETagNotModified(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	expectedResponse := &leaderboardmodels.LeaderboardResponse{
+		Entries: []leaderboardmodels.LeaderboardEntry{
+			{Rank: 1, UserID: uuid.New(), UserName: "Player1", Score: 1000, Season: "global"},
+		},
+		PaginationMeta: utils.NewPaginationMeta(1, 50, 1),
+		GeneratedAt:    time.Now(),
+	}
+
+	mockService.On("GetLeaderboard", mock.Anything, mock.AnythingOfType("*models.LeaderboardQuery")).
+		Return(expectedResponse, nil).Once()
+
+	// First request populates the ETag
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard?season=global&limit=50", nil)
+	rr := httptest.NewRecorder()
+	handler.GetLeaderboard(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	etag := rr.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "max-age=3, must-revalidate", rr.Header().Get("Cache-Control"))
+
+	// Second request with the same ETag is served without hitting the service
+	req = httptest.NewRequest(http.MethodGet, "/leaderboard?season=global&limit=50", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	handler.GetLeaderboard(rr, req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Code)
+	assert.Equal(t, etag, rr.Header().Get("ETag"))
+	assert.Empty(t, rr.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetLeaderboard_ETagInvalidatedBySubmit tests that a score submitted through the handler
+// makes the next conditional request query the service again
+func TestGetLeaderboard_ETagInvalidatedBySubmit(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	userID := uuid.New()
+	before := &leaderboardmodels.LeaderboardResponse{
+		Entries:        []leaderboardmodels.LeaderboardEntry{{Rank: 1, UserID: userID, UserName: "Player1", Score: 1000, Season: "global"}},
+		PaginationMeta: utils.NewPaginationMeta(1, 50, 1),
+	}
+	after := &leaderboardmodels.LeaderboardResponse{
+		Entries:        []leaderboardmodels.LeaderboardEntry{{Rank: 1, UserID: userID, UserName: "Player1", Score: 2000, Season: "global"}},
+		PaginationMeta: utils.NewPaginationMeta(1, 50, 1),
+	}
+	mockService.On("GetLeaderboard", mock.Anything, mock.AnythingOfType("*models.LeaderboardQuery")).Return(before, nil).Once()
+	mockService.On("GetLeaderboard", mock.Anything, mock.AnythingOfType("*models.LeaderboardQuery")).Return(after, nil).Once()
+	mockService.On("SubmitScore", mock.Anything, userID, mock.Anything).
+		Return(&leaderboardmodels.Score{UserID: userID, Score: 2000, Season: "global"}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.GetLeaderboard(rr, httptest.NewRequest(http.MethodGet, "/leaderboard?season=global", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	etag := rr.Header().Get("ETag")
+
+	// The season defaults to global for the submission as well
+	body, _ := json.Marshal(leaderboardmodels.SubmitScoreRequest{Score: 2000})
+	req := httptest.NewRequest(http.MethodPost, "/submit-score", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, userID))
+	rr = httptest.NewRecorder()
+	handler.SubmitScore(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/leaderboard?season=global", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	handler.GetLeaderboard(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NotEqual(t, etag, rr.Header().Get("ETag"))
+	mockService.AssertExpectations(t)
+}
+
+// TestGetLeaderboard_ETagPerTenant tests that a stored ETag only short-circuits requests of its own tenant
+func TestGetLeaderboard_ETagPerTenant(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	response := &leaderboardmodels.LeaderboardResponse{
+		Entries:        []leaderboardmodels.LeaderboardEntry{{Rank: 1, UserID: uuid.New(), UserName: "Player1", Score: 1000, Season: "global"}},
+		PaginationMeta: utils.NewPaginationMeta(1, 50, 1),
+	}
+	mockService.On("GetLeaderboard", mock.Anything, mock.AnythingOfType("*models.LeaderboardQuery")).Return(response, nil).Twice()
+
+	request := func(tenantID, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/leaderboard?season=global", nil)
+		req = req.WithContext(middleware.WithTenantID(req.Context(), tenantID))
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rr := httptest.NewRecorder()
+		handler.GetLeaderboard(rr, req)
+		return rr
+	}
+
+	etag := request("acme", "").Header().Get("ETag")
+	// Same data, so the ETag matches, but the other tenant's request still reaches the service
+	assert.Equal(t, http.StatusNotModified, request("globex", etag).Code)
+	assert.Equal(t, http.StatusNotModified, request("acme", etag).Code)
+
+	mockService.AssertNumberOfCalls(t, "GetLeaderboard", 2)
+}
+
+// TestGetLeaderboard_ETagIgnoresGeneratedAt tests that a rebuilt response with the same data keeps its ETag
+func TestGetLeaderboard_ETagIgnoresGeneratedAt(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	entries := []leaderboardmodels.LeaderboardEntry{
+		{Rank: 1, UserID: uuid.New(), UserName: "Player1", Score: 1000, Season: "global"},
+	}
+	generatedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	mockService.On("GetLeaderboard", mock.Anything, mock.MatchedBy(func(q *leaderboardmodels.LeaderboardQuery) bool { return q.Page == 0 })).
+		Return(&leaderboardmodels.LeaderboardResponse{Entries: entries, PaginationMeta: utils.NewPaginationMeta(1, 50, 1), GeneratedAt: generatedAt}, nil).Once()
+	mockService.On("GetLeaderboard", mock.Anything, mock.MatchedBy(func(q *leaderboardmodels.LeaderboardQuery) bool { return q.Page == 1 })).
+		Return(&leaderboardmodels.LeaderboardResponse{Entries: entries, PaginationMeta: utils.NewPaginationMeta(1, 50, 1), GeneratedAt: generatedAt.Add(time.Minute)}, nil).Once()
+
+	first := httptest.NewRecorder()
+	handler.GetLeaderboard(first, httptest.NewRequest(http.MethodGet, "/leaderboard?season=global&limit=50", nil))
+	second := httptest.NewRecorder()
+	handler.GetLeaderboard(second, httptest.NewRequest(http.MethodGet, "/leaderboard?season=global&limit=50&page=1", nil))
+
+	require.Equal(t, http.StatusOK, first.Code)
+	require.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, first.Header().Get("ETag"), second.Header().Get("ETag"))
+	assert.Contains(t, first.Body.String(), `"generated_at":"2024-01-01T12:00:00Z"`)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetNearby_UsesUserFromContext tests that the JWT user ID is passed to the service
+func TestGetNearby_UsesUserFromContext(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	userID := uuid.New()
+	expected := &leaderboardmodels.NeighborsResponse{
+		Season: "global",
+		User:   leaderboardmodels.LeaderboardEntry{Rank: 3, UserID: userID, Score: 500, Season: "global"},
+	}
+	mockService.On("GetNeighbors", mock.Anything, userID, "global", 2).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard/nearby?season=global&radius=2", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, userID))
+	rr := httptest.NewRecorder()
+
+	handler.GetNearby(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestGetNearby_Unauthorized tests that the endpoint requires a user in context
+func TestGetNearby_Unauthorized(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard/nearby", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetNearby(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	mockService.AssertNotCalled(t, "GetNeighbors", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestResetSeason_Success tests that an admin can reset a season
+func TestResetSeason_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	adminID := uuid.New()
+	mockService.On("ResetSeason", mock.Anything, "2024-spring", adminID.String()).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/seasons/2024-spring/reset", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("name", "2024-spring")
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, middleware.UserIDKey, adminID)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+	handler.ResetSeason(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// TestUpdateScoringConfig_Success tests that an admin can change a scoring rule
+func TestUpdateScoringConfig_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	updateReq := &leaderboardmodels.UpdateScoringConfigRequest{Value: "5000", Season: "2024-spring"}
+	mockService.On("UpdateScoringConfig", mock.Anything, "max_score", updateReq).
+		Return(&leaderboardmodels.ScoringConfigEntry{Key: "max_score", Season: "2024-spring", Value: "5000"}, nil)
+
+	body, _ := json.Marshal(updateReq)
+	req := httptest.NewRequest(http.MethodPut, "/admin/scoring-configs/max_score", bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("key", "max_score")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	handler.UpdateScoringConfig(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+// newDeleteScoreRequest builds a DELETE request for a score with the requester's JWT claims in context
+func newDeleteScoreRequest(ownerID uuid.UUID, season string, requesterID uuid.UUID, role string) *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/leaderboard/user/"+ownerID.String()+"/season/"+season, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("userID", ownerID.String())
+	rctx.URLParams.Add("season", season)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, middleware.UserIDKey, requesterID)
+	ctx = context.WithValue(ctx, middleware.RoleKey, role)
+	return req.WithContext(ctx)
+}
+
+// TestDeleteScore_Authorization tests that only the owner or an admin may delete a score
+func TestDeleteScore_Authorization(t *testing.T) {
+	ownerID := uuid.New()
+	adminID := uuid.New()
+
+	tests := []struct {
+		name        string
+		requesterID uuid.UUID
+		role        string
+		expected    int
+	}{
+		{"owner", ownerID, "user", http.StatusNoContent},
+		{"admin", adminID, "admin", http.StatusNoContent},
+		{"other player", uuid.New(), "user", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockLeaderboardService)
+			handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+			if tt.expected == http.StatusNoContent {
+				mockService.On("DeleteScore", mock.Anything, ownerID, "2024-spring", tt.requesterID).Return(nil)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.DeleteScore(rr, newDeleteScoreRequest(ownerID, "2024-spring", tt.requesterID, tt.role))
+
+			assert.Equal(t, tt.expected, rr.Code)
+			mockService.AssertExpectations(t)
+			if tt.expected == http.StatusForbidden {
+				mockService.AssertNotCalled(t, "DeleteScore")
+			}
+		})
+	}
+}
+
+// TestDeleteScore_NotFound tests that a missing score maps to 404
+func TestDeleteScore_NotFound(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	ownerID := uuid.New()
+	mockService.On("DeleteScore", mock.Anything, ownerID, "global", ownerID).Return(utils.NotFound("score", nil))
+
+	rr := httptest.NewRecorder()
+	handler.DeleteScore(rr, newDeleteScoreRequest(ownerID, "global", ownerID, "user"))
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+// TestGetSummary_Success tests the single-call game-end summary
+func TestGetSummary_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	userID := uuid.New()
+	expected := &leaderboardmodels.ScoreSummary{
+		UserID:             userID,
+		Season:             "winter",
+		UserRank:           2,
+		UserScore:          700,
+		SeasonMax:          900,
+		SeasonMin:          100,
+		TotalPlayers:       3,
+		PersonalBestSeason: "winter",
+	}
+	mockService.On("GetSummary", mock.Anything, userID, "winter").Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/leaderboard/summary/"+userID.String()+"?season=winter", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("userID", userID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	handler.GetSummary(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Data leaderboardmodels.ScoreSummary `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, *expected, response.Data)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetAchievements_Success tests the badge list of a player
+func TestGetAchievements_Success(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	userID := uuid.New()
+	expected := []leaderboardmodels.Achievement{
+		{UserID: userID, BadgeName: "score_10000", Season: "global", AwardedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	mockService.On("GetAchievements", mock.Anything, userID, "global").Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+userID.String()+"/achievements", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("userID", userID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	handler.GetAchievements(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Data []leaderboardmodels.Achievement `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, expected, response.Data)
+
+	mockService.AssertExpectations(t)
+}
+
+// TestGetAchievements_InvalidUserID tests that a malformed user ID is rejected before the service
+func TestGetAchievements_InvalidUserID(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/not-a-uuid/achievements", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("userID", "not-a-uuid")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	handler.GetAchievements(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "GetAchievements", mock.Anything, mock.Anything, mock.Anything)
+}
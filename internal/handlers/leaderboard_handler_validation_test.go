@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	leaderboardhandler "leaderboard-service/internal/leaderboard/handler"
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSubmitScoreRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    leaderboardmodels.SubmitScoreRequest
+		fields []string
+	}{
+		{"valid", leaderboardmodels.SubmitScoreRequest{Score: 100, Season: "global"}, nil},
+		{"zero score and default season", leaderboardmodels.SubmitScoreRequest{Score: 0}, nil},
+		{"negative score", leaderboardmodels.SubmitScoreRequest{Score: -1}, []string{"score"}},
+		{"season too long", leaderboardmodels.SubmitScoreRequest{Score: 1, Season: strings.Repeat("s", 51)}, []string{"season"}},
+		{"correlation id too long", leaderboardmodels.SubmitScoreRequest{Score: 1, CorrelationID: strings.Repeat("c", 65)}, []string{"correlation_id"}},
+		{"idempotency key too long", leaderboardmodels.SubmitScoreRequest{Score: 1, IdempotencyKey: strings.Repeat("k", 129)}, []string{"idempotency_key"}},
+		{"all rules fail", leaderboardmodels.SubmitScoreRequest{Score: -5, Season: strings.Repeat("s", 51)}, []string{"score", "season"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := leaderboardhandler.ValidateSubmitScoreRequest(&tt.req)
+			if tt.fields == nil {
+				assert.Nil(t, errs)
+				return
+			}
+
+			require.NotNil(t, errs)
+			var fields []string
+			for _, fieldErr := range *errs {
+				fields = append(fields, fieldErr.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestSubmitScore_ValidationErrorsReturn422(t *testing.T) {
+	mockService := new(MockLeaderboardService)
+	handler := leaderboardhandler.NewLeaderboardHandler(mockService)
+
+	body, _ := json.Marshal(leaderboardmodels.SubmitScoreRequest{Score: -10, Season: strings.Repeat("s", 51)})
+	req := httptest.NewRequest(http.MethodPost, "/submit-score", bytes.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, uuid.New()))
+	rr := httptest.NewRecorder()
+
+	handler.SubmitScore(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	var response struct {
+		ErrorCode string             `json:"error_code"`
+		Fields    []utils.FieldError `json:"fields"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, utils.ErrCodeValidation, response.ErrorCode)
+	require.Len(t, response.Fields, 2)
+	assert.Equal(t, "score", response.Fields[0].Field)
+	assert.Equal(t, "season", response.Fields[1].Field)
+
+	// Invalid requests never reach the service
+	mockService.AssertNotCalled(t, "SubmitScore")
+}
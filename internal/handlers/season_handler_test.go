@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	seasonhandler "leaderboard-service/internal/season/handler"
+	seasonmodels "leaderboard-service/internal/season/models"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSeasonService is a mock for SeasonService
+type MockSeasonService struct {
+	mock.Mock
+}
+
+func (m *MockSeasonService) Update(ctx context.Context, name string, req *seasonmodels.UpdateSeasonRequest) (*seasonmodels.Season, error) {
+	args := m.Called(ctx, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*seasonmodels.Season), args.Error(1)
+}
+
+// newSeasonPatchRequest builds an authenticated PATCH /admin/seasons/{name} request
+func newSeasonPatchRequest(name, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/admin/seasons/"+name, bytes.NewBufferString(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("name", name)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, middleware.UserIDKey, uuid.New())
+	return req.WithContext(ctx)
+}
+
+func TestUpdateSeason_Success(t *testing.T) {
+	mockService := new(MockSeasonService)
+	handler := seasonhandler.NewSeasonHandler(mockService)
+
+	endsAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	updated := &seasonmodels.Season{Name: "2024_01", DisplayName: "Season 1 - Spring", EndsAt: &endsAt}
+	mockService.On("Update", mock.Anything, "2024_01", mock.MatchedBy(func(req *seasonmodels.UpdateSeasonRequest) bool {
+		return req.DisplayName != nil && *req.DisplayName == "Season 1 - Spring" &&
+			req.EndsAt != nil && req.EndsAt.Equal(endsAt) && req.StartsAt == nil
+	})).Return(updated, nil)
+
+	rr := httptest.NewRecorder()
+	handler.Update(rr, newSeasonPatchRequest("2024_01", `{"display_name":"Season 1 - Spring","ends_at":"2024-06-01T00:00:00Z"}`))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"display_name":"Season 1 - Spring"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateSeason_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"malformed body", `{"ends_at":"tomorrow"}`, nil, http.StatusBadRequest},
+		{"ends before start", `{"ends_at":"2020-01-01T00:00:00Z"}`, utils.ValidationError("ends_at cannot be before starts_at", nil), http.StatusBadRequest},
+		{"unknown season", `{"display_name":"x"}`, utils.NotFound("season", nil), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSeasonService)
+			handler := seasonhandler.NewSeasonHandler(mockService)
+			if tt.err != nil {
+				mockService.On("Update", mock.Anything, "2024_01", mock.Anything).Return(nil, tt.err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.Update(rr, newSeasonPatchRequest("2024_01", tt.body))
+
+			assert.Equal(t, tt.expected, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"leaderboard-service/internal/shared/models"
+)
+
+// StrategyLister lists the names of registered strategies; implemented by strategy.StrategyRegistry
+type StrategyLister interface {
+	ListScoringStrategies() []string
+	ListRankingStrategies() []string
+}
+
+// StrategiesHandler handles the strategy admin endpoint
+type StrategiesHandler struct {
+	registry StrategyLister
+}
+
+// NewStrategiesHandler creates a new strategies handler
+func NewStrategiesHandler(registry StrategyLister) *StrategiesHandler {
+	return &StrategiesHandler{registry: registry}
+}
+
+// List returns the scoring and ranking strategies game sessions can use
+// GET /admin/strategies
+func (h *StrategiesHandler) List(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, models.SuccessResponse{
+		Success: true,
+		Data: map[string][]string{
+			"scoring": h.registry.ListScoringStrategies(),
+			"ranking": h.registry.ListRankingStrategies(),
+		},
+	}, http.StatusOK)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leaderboard-service/internal/strategy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrategiesHandler_List(t *testing.T) {
+	registry := strategy.NewStrategyRegistry()
+	registry.RegisterScoringStrategy("simple", strategy.NewSimpleScoringStrategy())
+	registry.RegisterScoringStrategy("bonus", strategy.NewBonusScoringStrategy(true, 100, 1000))
+	registry.RegisterRankingStrategy("dense", strategy.NewDenseRankingStrategy())
+	handler := NewStrategiesHandler(registry)
+
+	rr := httptest.NewRecorder()
+	handler.List(rr, httptest.NewRequest(http.MethodGet, "/admin/strategies", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response struct {
+		Success bool                `json:"success"`
+		Data    map[string][]string `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.True(t, response.Success)
+	assert.Equal(t, []string{"bonus", "simple"}, response.Data["scoring"])
+	assert.Equal(t, []string{"dense"}, response.Data["ranking"])
+}
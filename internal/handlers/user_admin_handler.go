@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"leaderboard-service/internal/service"
+	"leaderboard-service/internal/shared/models"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxBulkUploadSize limits the size of an uploaded CSV file (10 MB)
+const maxBulkUploadSize = 10 << 20
+
+// BulkUserImporter defines the user management operations used by UserAdminHandler
+type BulkUserImporter interface {
+	BulkRegisterUsers(ctx context.Context, reader io.Reader) ([]service.BulkRegisterResult, error)
+}
+
+// UserAdminHandler handles admin user management endpoints
+type UserAdminHandler struct {
+	userService BulkUserImporter
+}
+
+// NewUserAdminHandler creates a new user admin handler
+func NewUserAdminHandler(userService BulkUserImporter) *UserAdminHandler {
+	return &UserAdminHandler{
+		userService: userService,
+	}
+}
+
+// BulkRegister imports users from a CSV file uploaded in the "file" form field
+// POST /admin/users/bulk
+func (h *UserAdminHandler) BulkRegister(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBulkUploadSize)
+	if err := r.ParseMultipartForm(maxBulkUploadSize); err != nil {
+		respondError(w, "invalid multipart form", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	results, err := h.userService.BulkRegisterUsers(r.Context(), file)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to bulk register users")
+		respondError(w, "failed to import users", http.StatusInternalServerError)
+		return
+	}
+
+	created := 0
+	for _, result := range results {
+		if result.Error == "" {
+			created++
+		}
+	}
+
+	log.Info().
+		Int("rows", len(results)).
+		Int("created", created).
+		Msg("📥 Bulk user import completed")
+
+	respondJSON(w, models.SuccessResponse{
+		Success: true,
+		Message: "bulk import completed",
+		Data:    results,
+	}, http.StatusOK)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"leaderboard-service/internal/service"
+	"leaderboard-service/internal/shared/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockBulkUserImporter is a mock for BulkUserImporter
+type MockBulkUserImporter struct {
+	mock.Mock
+}
+
+func (m *MockBulkUserImporter) BulkRegisterUsers(ctx context.Context, reader io.Reader) ([]service.BulkRegisterResult, error) {
+	data, _ := io.ReadAll(reader)
+	args := m.Called(ctx, string(data))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.BulkRegisterResult), args.Error(1)
+}
+
+func newBulkUploadRequest(t *testing.T, csvContent string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "users.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(csvContent))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/bulk", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// TestBulkRegister_Success tests that the uploaded CSV is passed to the service
+func TestBulkRegister_Success(t *testing.T) {
+	mockService := new(MockBulkUserImporter)
+	handler := NewUserAdminHandler(mockService)
+
+	csvContent := "name,email,password\nalice,alice@example.com,secret123\n"
+	userID := uuid.New()
+	mockService.On("BulkRegisterUsers", mock.Anything, csvContent).Return([]service.BulkRegisterResult{
+		{Email: "alice@example.com", UserID: &userID},
+	}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.BulkRegister(rr, newBulkUploadRequest(t, csvContent))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response models.SuccessResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.True(t, response.Success)
+	mockService.AssertExpectations(t)
+}
+
+// TestBulkRegister_MissingFile tests that a form without a file is rejected
+func TestBulkRegister_MissingFile(t *testing.T) {
+	mockService := new(MockBulkUserImporter)
+	handler := NewUserAdminHandler(mockService)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("note", "no file"))
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/bulk", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	rr := httptest.NewRecorder()
+	handler.BulkRegister(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "BulkRegisterUsers", mock.Anything, mock.Anything)
+}
@@ -1,12 +1,20 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/utils"
 	ws "leaderboard-service/internal/websocket"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog/log"
 )
@@ -20,14 +28,25 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// sseDeadlineMargin is how long before the request deadline an SSE stream is ended
+const sseDeadlineMargin = time.Second
+
 // WebSocketHandler handles WebSocket connections
 type WebSocketHandler struct {
 	hub     *ws.Hub
 	jwt     *middleware.JWTMiddleware
 	config  *config.Config
-	service interface {
-		SendInitialSnapshot(season string, requestedLimit int, clientSend chan []byte)
-	}
+	service LeaderboardStreamService
+}
+
+// LeaderboardStreamService provides the leaderboard data behind the streaming endpoints.
+// Seasons are the names clients ask for; the tenant, if any, is read from ctx.
+type LeaderboardStreamService interface {
+	// StreamSeason returns the season the hub streams for a requested season
+	// (the tenant's namespaced season when multitenancy is enabled)
+	StreamSeason(ctx context.Context, season string) (string, error)
+	SendInitialSnapshot(ctx context.Context, season string, requestedLimit int, clientSend chan []byte, writeDirect func(message []byte) error)
+	SeasonExists(ctx context.Context, season string) (bool, error)
 }
 
 // NewWebSocketHandler creates a new WebSocket handler
@@ -35,9 +54,7 @@ func NewWebSocketHandler(
 	hub *ws.Hub,
 	jwt *middleware.JWTMiddleware,
 	cfg *config.Config,
-	service interface {
-		SendInitialSnapshot(season string, requestedLimit int, clientSend chan []byte)
-	},
+	service LeaderboardStreamService,
 ) *WebSocketHandler {
 	return &WebSocketHandler{
 		hub:     hub,
@@ -50,35 +67,19 @@ func NewWebSocketHandler(
 // HandleLeaderboard handles WebSocket connections for leaderboard updates
 // ws://localhost:8080/api/v1/ws/leaderboard?season=global&token=JWT
 func (h *WebSocketHandler) HandleLeaderboard(w http.ResponseWriter, r *http.Request) {
-	// Try to get user ID from context (set by JWT middleware)
-	userID, ok := middleware.GetUserIDFromContext(r.Context())
-
+	userID, ctx, ok := h.authenticate(w, r)
 	if !ok {
-		// Fallback: try token from query parameter (for browser WebSocket)
-		tokenString := r.URL.Query().Get("token")
-		if tokenString == "" {
-			log.Warn().Msg("WebSocket connection attempt without valid JWT")
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
-			return
-		}
-
-		// Validate token from query param
-		log.Debug().Msg("🔑 Validating token from query parameter")
-		claims, err := h.jwt.ValidateTokenString(tokenString)
-		if err != nil {
-			log.Warn().Err(err).Msg("❌ Invalid token from query parameter")
-			http.Error(w, "Unauthorized - invalid token", http.StatusUnauthorized)
-			return
-		}
-
-		userID = claims.UserID
-		log.Info().Str("user_id", userID.String()).Msg("✅ Token validated from query parameter")
+		return
 	}
 
 	// Get season from query parameter
 	season := r.URL.Query().Get("season")
 	if season == "" {
-		season = "global"
+		season = h.config.GetDefaultSeason()
+	}
+	hubSeason, ok := h.streamSeason(ctx, w, season)
+	if !ok {
+		return
 	}
 
 	log.Info().
@@ -87,6 +88,13 @@ func (h *WebSocketHandler) HandleLeaderboard(w http.ResponseWriter, r *http.Requ
 		Str("remote_addr", r.RemoteAddr).
 		Msg("🔌 WebSocket connection request")
 
+	// The limit is checked before the upgrade so a bad request gets a plain HTTP 400
+	limit, hasLimit, err := parseClientLimit(r.URL.Query().Get("limit"), h.config.WebSocket.MaxClientLimit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -96,25 +104,30 @@ func (h *WebSocketHandler) HandleLeaderboard(w http.ResponseWriter, r *http.Requ
 
 	// Create new client with configuration from config
 	clientConfig := ws.ClientConfig{
-		WriteWait:      h.config.GetWebSocketWriteWait(),
-		PongWait:       h.config.GetWebSocketPongWait(),
-		PingPeriod:     h.config.GetWebSocketPingPeriod(),
-		MaxMessageSize: h.config.WebSocket.MaxMessageSize,
+		WriteWait:         h.config.GetWebSocketWriteWait(),
+		PongWait:          h.config.GetWebSocketPongWait(),
+		PingPeriod:        h.config.GetWebSocketPingPeriod(),
+		MaxMessageSize:    h.config.WebSocket.MaxMessageSize,
+		MaxRequestedLimit: h.config.WebSocket.MaxClientLimit,
+	}
+	client := ws.NewClient(h.hub, conn, userID, hubSeason, clientConfig)
+	if hasLimit {
+		client.RequestedLimit = limit
 	}
-	client := ws.NewClient(h.hub, conn, userID, season, clientConfig)
 
 	// Register client with hub
 	h.hub.Register <- client
 
 	log.Info().
 		Str("user_id", userID.String()).
-		Str("season", season).
+		Str("season", hubSeason).
 		Str("remote_addr", r.RemoteAddr).
 		Msg("🔌 New WebSocket connection established")
 
 	// Send initial leaderboard snapshot to client
+	// The request context ends with this handler, but its tenant is still needed by the snapshot
 	if h.service != nil {
-		go h.service.SendInitialSnapshot(season, client.RequestedLimit, client.Send)
+		go h.service.SendInitialSnapshot(context.WithoutCancel(ctx), season, client.RequestedLimit, client.Send, client.WriteDirect)
 	}
 
 	// Start client goroutines
@@ -123,8 +136,213 @@ func (h *WebSocketHandler) HandleLeaderboard(w http.ResponseWriter, r *http.Requ
 	go client.ReadPump()
 }
 
-// HandleStats returns WebSocket hub statistics
+// authenticate returns the user from the JWT middleware context or from the ?token= query
+// parameter, which browsers use because WebSocket and EventSource cannot set headers.
+// The returned context carries the tenant of the token, like the one JWTMiddleware.Authenticate sets.
+// Writes 401 and returns false when neither holds a valid token.
+func (h *WebSocketHandler) authenticate(w http.ResponseWriter, r *http.Request) (uuid.UUID, context.Context, bool) {
+	// Try to get user ID from context (set by JWT middleware)
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if ok {
+		return userID, r.Context(), true
+	}
+
+	// Fallback: try token from query parameter (for browser WebSocket and EventSource)
+	tokenString := r.URL.Query().Get("token")
+	if tokenString == "" {
+		log.Warn().Msg("Streaming connection attempt without valid JWT")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return uuid.Nil, nil, false
+	}
+
+	// Validate token from query param
+	log.Debug().Msg("🔑 Validating token from query parameter")
+	claims, err := h.jwt.ValidateTokenString(tokenString)
+	if err != nil {
+		log.Warn().Err(err).Msg("❌ Invalid token from query parameter")
+		http.Error(w, "Unauthorized - invalid token", http.StatusUnauthorized)
+		return uuid.Nil, nil, false
+	}
+
+	log.Info().Str("user_id", claims.UserID.String()).Msg("✅ Token validated from query parameter")
+	ctx := context.WithValue(r.Context(), middleware.UserIDKey, claims.UserID)
+	if claims.TenantID != "" {
+		ctx = middleware.WithTenantID(ctx, claims.TenantID)
+	}
+	return claims.UserID, ctx, true
+}
+
+// streamSeason resolves the hub season for a requested season; without a service it is the season itself.
+// Writes the service's error status and returns false when the season cannot be streamed to this caller.
+func (h *WebSocketHandler) streamSeason(ctx context.Context, w http.ResponseWriter, season string) (string, bool) {
+	if h.service == nil {
+		return season, true
+	}
+	hubSeason, err := h.service.StreamSeason(ctx, season)
+	if err != nil {
+		var appErr *utils.AppError
+		if errors.As(err, &appErr) {
+			respondError(w, appErr.Message, appErr.StatusCode)
+			return "", false
+		}
+		log.Error().Err(err).Str("season", season).Msg("Failed to resolve stream season")
+		respondError(w, "failed to resolve season", http.StatusInternalServerError)
+		return "", false
+	}
+	return hubSeason, true
+}
+
+// HandleLeaderboardSSE streams the leaderboard updates of a season as server-sent events.
+// Every event carries an id: a client reconnecting with Last-Event-ID first receives the
+// events it missed that are still buffered (WS_SSE_BUFFER_SIZE per season).
+// GET /api/v1/sse/leaderboard?season=global&token=JWT
+func (h *WebSocketHandler) HandleLeaderboardSSE(w http.ResponseWriter, r *http.Request) {
+	userID, ctx, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	season := r.URL.Query().Get("season")
+	if season == "" {
+		season = h.config.GetDefaultSeason()
+	}
+
+	var lastEventID uint64
+	raw := r.Header.Get("Last-Event-ID")
+	resume := raw != ""
+	if resume {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			http.Error(w, "Last-Event-ID must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		lastEventID = id
+	}
+
+	hubSeason, ok := h.streamSeason(ctx, w, season)
+	if !ok {
+		return
+	}
+
+	// Every subscribed season keeps a replay buffer in the hub, so only seasons that
+	// have scores (or the default one) can be streamed
+	if season != h.config.GetDefaultSeason() {
+		exists, err := h.service.SeasonExists(ctx, season)
+		if err != nil {
+			log.Error().Err(err).Str("season", season).Msg("Failed to look up SSE season")
+			http.Error(w, "failed to look up season", http.StatusInternalServerError)
+			return
+		}
+		if !exists {
+			http.Error(w, "unknown season", http.StatusNotFound)
+			return
+		}
+	}
+
+	sub, replay := h.hub.SubscribeSSE(hubSeason, lastEventID, resume)
+	defer h.hub.UnsubscribeSSE(sub)
+
+	log.Info().
+		Str("user_id", userID.String()).
+		Str("season", hubSeason).
+		Str("remote_addr", r.RemoteAddr).
+		Msg("🔌 New SSE connection established")
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// The server WriteTimeout would cut the stream, so every write gets its own deadline
+	rc := http.NewResponseController(w)
+	writeWait := h.config.GetWebSocketWriteWait()
+	send := func(write func() error) bool {
+		if writeWait > 0 {
+			_ = rc.SetWriteDeadline(time.Now().Add(writeWait))
+		}
+		if err := write(); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	// Flush the headers right away so EventSource opens before the first event
+	if !send(func() error { return nil }) {
+		return
+	}
+	for _, event := range replay {
+		if !send(func() error { return writeSSEEvent(w, event) }) {
+			return
+		}
+	}
+
+	pingPeriod := h.config.GetWebSocketPingPeriod()
+	if pingPeriod <= 0 {
+		pingPeriod = time.Minute
+	}
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	// The router's request timeout would answer 504 on top of the stream, so end it just
+	// before the deadline; the browser reconnects with Last-Event-ID and misses nothing buffered
+	var expire <-chan time.Time
+	if deadline, ok := r.Context().Deadline(); ok {
+		timer := time.NewTimer(time.Until(deadline) - sseDeadlineMargin)
+		defer timer.Stop()
+		expire = timer.C
+	}
+
+	for {
+		select {
+		case event, ok := <-sub.Events:
+			if !ok {
+				// Hub dropped the subscription; the browser reconnects with Last-Event-ID
+				return
+			}
+			if !send(func() error { return writeSSEEvent(w, event) }) {
+				return
+			}
+		case <-ticker.C:
+			// A comment line keeps proxies from closing an idle stream
+			if !send(func() error { _, err := io.WriteString(w, ": ping\n\n"); return err }) {
+				return
+			}
+		case <-expire:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeSSEEvent writes one event with its id; the data is single-line JSON
+func writeSSEEvent(w io.Writer, event ws.SSEEvent) error {
+	_, err := fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.ID, event.Data)
+	return err
+}
+
+// parseClientLimit parses the ?limit= query parameter, clamped to maxLimit
+// Returns false when the parameter is absent (the hub default applies)
+func parseClientLimit(raw string, maxLimit int) (int, bool, error) {
+	if raw == "" {
+		return 0, false, nil
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		return 0, false, errors.New("limit must be a positive integer")
+	}
+	return ws.ClampLimit(limit, maxLimit), true, nil
+}
+
+// HandleStats returns WebSocket hub statistics with per-season connection counts
+// GET /api/v1/ws/stats (JWT required)
 func (h *WebSocketHandler) HandleStats(w http.ResponseWriter, r *http.Request) {
 	stats := h.hub.GetStats()
 	respondJSON(w, stats, http.StatusOK)
 }
+
+// HandlePublicStats returns only connection totals; season names stay private
+// GET /api/v1/ws/stats/public
+func (h *WebSocketHandler) HandlePublicStats(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, h.hub.GetTotals(), http.StatusOK)
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/utils"
+	ws "leaderboard-service/internal/websocket"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClientLimit(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected int
+		present  bool
+		wantErr  bool
+	}{
+		{raw: "", present: false},
+		{raw: "1", expected: 1, present: true},
+		{raw: "999", expected: 999, present: true},
+		{raw: "1000", expected: 1000, present: true},
+		{raw: "1001", expected: 1000, present: true},
+		{raw: "0", wantErr: true},
+		{raw: "-5", wantErr: true},
+		{raw: "ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			limit, present, err := parseClientLimit(tt.raw, 1000)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.present, present)
+			assert.Equal(t, tt.expected, limit)
+		})
+	}
+}
+
+// TestHandleLeaderboard_RejectsZeroLimit tests that limit=0 is refused before the WebSocket upgrade
+func TestHandleLeaderboard_RejectsZeroLimit(t *testing.T) {
+	cfg := &config.Config{WebSocket: config.WebSocketConfig{MaxClientLimit: 1000}}
+	handler := NewWebSocketHandler(nil, nil, cfg, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/ws/leaderboard?season=global&limit=0", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, uuid.New()))
+
+	rr := httptest.NewRecorder()
+	handler.HandleLeaderboard(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandleStats_PublicOmitsSeasons(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := ws.NewHub(ctx, time.Hour, 10)
+	go hub.Run()
+	hub.Register <- &ws.Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10}
+	hub.Register <- &ws.Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 10}
+	require.Eventually(t, func() bool { return hub.GetSeasonClientCount("winter") == 1 }, time.Second, 5*time.Millisecond)
+
+	handler := NewWebSocketHandler(hub, nil, &config.Config{}, nil)
+
+	rr := httptest.NewRecorder()
+	handler.HandleStats(rr, httptest.NewRequest(http.MethodGet, "/ws/stats", nil))
+	var detailed map[string]interface{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&detailed))
+	assert.Equal(t, map[string]interface{}{"global": float64(1), "winter": float64(1)}, detailed["seasons"])
+
+	rr = httptest.NewRecorder()
+	handler.HandlePublicStats(rr, httptest.NewRequest(http.MethodGet, "/ws/stats/public", nil))
+	var totals map[string]interface{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&totals))
+	assert.Equal(t, map[string]interface{}{"total_clients": float64(2), "active_seasons": float64(2)}, totals)
+}
+
+func TestHandleLeaderboardSSE_ReplaysMissedEventsOnReconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := ws.NewHub(ctx, time.Hour, 10).WithSSEBufferSize(10)
+	go hub.Run()
+
+	// The first connection saw event 1, then events 2 and 3 happened while it was away
+	sub, _ := hub.SubscribeSSE("global", 0, false)
+	hub.BroadcastSeason("global", ws.NewSeasonEventMessage(ws.SeasonEventOpened, "global"))
+	require.Equal(t, uint64(1), (<-sub.Events).ID)
+	hub.UnsubscribeSSE(sub)
+	hub.BroadcastSeason("global", ws.NewSeasonEventMessage(ws.SeasonEventClosed, "global"))
+	hub.BroadcastSeason("global", ws.NewSeasonEventMessage(ws.SeasonEventReset, "global"))
+	require.Eventually(t, func() bool { return hub.LastEventID("global") == 3 }, time.Second, 5*time.Millisecond)
+
+	handler := NewWebSocketHandler(hub, nil, &config.Config{}, nil)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(context.WithValue(r.Context(), middleware.UserIDKey, uuid.New()))
+		handler.HandleLeaderboardSSE(w, r)
+	}))
+	defer server.Close()
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer reqCancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, server.URL+"?season=global", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	var ids []string
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	for len(events) < 2 && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			ids = append(ids, strings.TrimPrefix(line, "id: "))
+		case strings.HasPrefix(line, "data: "):
+			var message map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &message))
+			events = append(events, message["event"].(string))
+		}
+	}
+	assert.Equal(t, []string{"2", "3"}, ids)
+	assert.Equal(t, []string{"closed", "reset"}, events)
+}
+
+func TestHandleLeaderboardSSE_RejectsInvalidLastEventID(t *testing.T) {
+	handler := NewWebSocketHandler(nil, nil, &config.Config{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/sse/leaderboard?season=global", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, uuid.New()))
+	req.Header.Set("Last-Event-ID", "abc")
+
+	rr := httptest.NewRecorder()
+	handler.HandleLeaderboardSSE(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+// stubStreamService reports the seasons in known as existing; with tenantRequired it streams
+// "{tenant}:{season}" and rejects callers without a tenant, like MultiTenantLeaderboardService
+type stubStreamService struct {
+	known          map[string]bool
+	tenantRequired bool
+	lookedUp       []string
+}
+
+func (s *stubStreamService) StreamSeason(ctx context.Context, season string) (string, error) {
+	if !s.tenantRequired {
+		return season, nil
+	}
+	tenantID, ok := middleware.GetTenantIDFromContext(ctx)
+	if !ok {
+		return "", utils.Forbidden("tenant is required", nil)
+	}
+	return tenantID + ":" + season, nil
+}
+
+func (s *stubStreamService) SendInitialSnapshot(ctx context.Context, season string, requestedLimit int, clientSend chan []byte, writeDirect func(message []byte) error) {
+}
+
+func (s *stubStreamService) SeasonExists(ctx context.Context, season string) (bool, error) {
+	hubSeason, err := s.StreamSeason(ctx, season)
+	if err != nil {
+		return false, err
+	}
+	s.lookedUp = append(s.lookedUp, hubSeason)
+	return s.known[hubSeason], nil
+}
+
+func TestHandleLeaderboardSSE_RejectsUnknownSeason(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := ws.NewHub(ctx, time.Hour, 10)
+	handler := NewWebSocketHandler(hub, nil, &config.Config{}, &stubStreamService{known: map[string]bool{"winter": true}})
+
+	req := httptest.NewRequest(http.MethodGet, "/sse/leaderboard?season=made-up", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, uuid.New()))
+
+	rr := httptest.NewRecorder()
+	handler.HandleLeaderboardSSE(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, uint64(0), hub.LastEventID("made-up"))
+}
+
+func TestHandleLeaderboardSSE_TenantFromQueryToken(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret-key"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := ws.NewHub(ctx, time.Hour, 10)
+	service := &stubStreamService{tenantRequired: true}
+	handler := NewWebSocketHandler(hub, middleware.NewJWTMiddleware(cfg), cfg, service)
+
+	claims := jwt.MapClaims{
+		"user_id":   uuid.New().String(),
+		"email":     "game@example.com",
+		"role":      "user",
+		"tenant_id": "studio_a",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWT.Secret))
+	require.NoError(t, err)
+
+	// A season named after another tenant is still looked up inside the caller's tenant
+	rr := httptest.NewRecorder()
+	handler.HandleLeaderboardSSE(rr, httptest.NewRequest(http.MethodGet, "/sse/leaderboard?season=studio_b:global&token="+token, nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, []string{"studio_a:studio_b:global"}, service.lookedUp)
+
+	// A token without a tenant cannot stream when tenants are required
+	plain, _, err := middleware.NewJWTMiddleware(cfg).GenerateToken(uuid.New(), "test@example.com", "user", time.Hour)
+	require.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.HandleLeaderboardSSE(rr, httptest.NewRequest(http.MethodGet, "/sse/leaderboard?season=global&token="+plain, nil))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
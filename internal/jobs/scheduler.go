@@ -0,0 +1,183 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Job - периодическая фоновая задача
+type Job interface {
+	// Name возвращает уникальное имя задачи (для логов и /admin/jobs)
+	Name() string
+
+	// Run выполняет задачу один раз
+	Run(ctx context.Context) error
+
+	// Schedule возвращает интервал между запусками
+	Schedule() time.Duration
+}
+
+// Status - состояние задачи для /admin/jobs
+type Status struct {
+	Name            string     `json:"name"`
+	IntervalSeconds float64    `json:"interval_seconds"`
+	Runs            int64      `json:"runs"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastDurationMs  int64      `json:"last_duration_ms"`
+	LastError       string     `json:"last_error,omitempty"`
+}
+
+// funcJob адаптирует функцию к интерфейсу Job
+type funcJob struct {
+	name     string
+	schedule time.Duration
+	run      func(ctx context.Context) error
+}
+
+// NewFuncJob создает задачу из функции
+func NewFuncJob(name string, schedule time.Duration, run func(ctx context.Context) error) Job {
+	return &funcJob{name: name, schedule: schedule, run: run}
+}
+
+func (j *funcJob) Name() string                  { return j.name }
+func (j *funcJob) Run(ctx context.Context) error { return j.run(ctx) }
+func (j *funcJob) Schedule() time.Duration       { return j.schedule }
+
+// Scheduler запускает зарегистрированные задачи по расписанию
+// Каждая задача работает в своей горутине: медленная задача не задерживает остальные,
+// а один и тот же Job никогда не выполняется параллельно сам с собой
+type Scheduler struct {
+	mu       sync.RWMutex
+	jobs     []Job
+	statuses map[string]*Status
+	done     chan struct{}
+}
+
+// NewScheduler создает пустой планировщик
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		statuses: make(map[string]*Status),
+		done:     make(chan struct{}),
+	}
+}
+
+// Register добавляет задачу; вызывать до Run
+func (s *Scheduler) Register(job Job) error {
+	if job.Schedule() <= 0 {
+		return fmt.Errorf("job %s: schedule must be positive", job.Name())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.statuses[job.Name()]; exists {
+		return fmt.Errorf("job %s is already registered", job.Name())
+	}
+	s.jobs = append(s.jobs, job)
+	s.statuses[job.Name()] = &Status{
+		Name:            job.Name(),
+		IntervalSeconds: job.Schedule().Seconds(),
+	}
+	return nil
+}
+
+// Run запускает все задачи (сразу, затем по расписанию) и блокируется до отмены ctx
+// Возвращается только после завершения всех выполняющихся задач
+func (s *Scheduler) Run(ctx context.Context) {
+	defer close(s.done)
+
+	s.mu.RLock()
+	jobs := append([]Job(nil), s.jobs...)
+	s.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+
+	log.Info().Int("jobs", len(jobs)).Msg("⏱️ Job scheduler started")
+	wg.Wait()
+	log.Info().Msg("Job scheduler stopped")
+}
+
+// Done закрывается, когда Run вернул управление
+func (s *Scheduler) Done() <-chan struct{} {
+	return s.done
+}
+
+// loop выполняет задачу до отмены ctx
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Schedule())
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce выполняет задачу и записывает результат; паника задачи не роняет сервис
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	start := time.Now()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return job.Run(ctx)
+	}()
+	elapsed := time.Since(start)
+
+	s.mu.Lock()
+	status := s.statuses[job.Name()]
+	status.Runs++
+	status.LastRunAt = &start
+	status.LastDurationMs = elapsed.Milliseconds()
+	status.LastError = ""
+	if err != nil {
+		status.LastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		log.Error().Err(err).Str("job", job.Name()).Dur("duration", elapsed).Msg("Background job failed")
+		return
+	}
+	log.Debug().Str("job", job.Name()).Dur("duration", elapsed).Msg("Background job finished")
+}
+
+// Statuses возвращает копию состояния всех задач, отсортированную по имени
+func (s *Scheduler) Statuses() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Status, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		copied := *status
+		if status.LastRunAt != nil {
+			lastRun := *status.LastRunAt
+			copied.LastRunAt = &lastRun
+		}
+		result = append(result, copied)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
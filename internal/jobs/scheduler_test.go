@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_RunsJobsAndRecordsStatus(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var okRuns atomic.Int64
+	require.NoError(t, scheduler.Register(NewFuncJob("ok", 10*time.Millisecond, func(ctx context.Context) error {
+		okRuns.Add(1)
+		return nil
+	})))
+	require.NoError(t, scheduler.Register(NewFuncJob("failing", time.Hour, func(ctx context.Context) error {
+		return errors.New("db down")
+	})))
+	require.NoError(t, scheduler.Register(NewFuncJob("panicking", time.Hour, func(ctx context.Context) error {
+		panic("boom")
+	})))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go scheduler.Run(ctx)
+
+	require.Eventually(t, func() bool { return okRuns.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-scheduler.Done():
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancellation")
+	}
+
+	statuses := scheduler.Statuses()
+	require.Len(t, statuses, 3)
+	assert.Equal(t, []string{"failing", "ok", "panicking"}, []string{statuses[0].Name, statuses[1].Name, statuses[2].Name})
+
+	assert.Equal(t, "db down", statuses[0].LastError)
+	assert.Equal(t, int64(1), statuses[0].Runs, "first run happens at start, the next one only after an hour")
+	require.NotNil(t, statuses[1].LastRunAt)
+	assert.Empty(t, statuses[1].LastError)
+	assert.GreaterOrEqual(t, statuses[1].Runs, int64(3))
+	assert.Contains(t, statuses[2].LastError, "panic: boom")
+}
+
+func TestScheduler_RegisterValidation(t *testing.T) {
+	scheduler := NewScheduler()
+	noop := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, scheduler.Register(NewFuncJob("cleanup", time.Minute, noop)))
+	assert.Error(t, scheduler.Register(NewFuncJob("cleanup", time.Minute, noop)), "names are unique")
+	assert.Error(t, scheduler.Register(NewFuncJob("never", 0, noop)))
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+)
+
+// etagTTL is how long a stored ETag is trusted without asking the service again.
+// Matches the max-age advertised in the Cache-Control header, and bounds how long a write
+// that bypasses this handler (another replica, the command API) can go unnoticed.
+const etagTTL = 3 * time.Second
+
+// maxETagsPerSeason caps the stored queries of one season; once reached, expired entries
+// are dropped and, if that is not enough, the season starts over
+const maxETagsPerSeason = 256
+
+// etagEntry is the last ETag served for a leaderboard query
+type etagEntry struct {
+	etag      string
+	expiresAt time.Time
+}
+
+// etagStore keeps the last ETag per (season, query) so polling clients get a 304 without a
+// leaderboard query. Writes handled here invalidate their season right away.
+type etagStore struct {
+	mu      sync.Mutex
+	seasons map[string]map[string]etagEntry // season key -> query key -> entry
+}
+
+func newETagStore() *etagStore {
+	return &etagStore{seasons: make(map[string]map[string]etagEntry)}
+}
+
+// lookup returns the stored ETag of a query if it has not expired
+func (s *etagStore) lookup(season, query string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.seasons[season][query]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.seasons[season], query)
+		return "", false
+	}
+	return entry.etag, true
+}
+
+// store remembers the ETag just served for a query
+func (s *etagStore) store(season, query, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	queries, ok := s.seasons[season]
+	if !ok {
+		queries = make(map[string]etagEntry)
+		s.seasons[season] = queries
+	}
+	if _, exists := queries[query]; !exists && len(queries) >= maxETagsPerSeason {
+		for key, entry := range queries {
+			if now.After(entry.expiresAt) {
+				delete(queries, key)
+			}
+		}
+		if len(queries) >= maxETagsPerSeason {
+			queries = make(map[string]etagEntry)
+			s.seasons[season] = queries
+		}
+	}
+	queries[query] = etagEntry{etag: etag, expiresAt: now.Add(etagTTL)}
+}
+
+// invalidate forgets every stored ETag of a season
+func (s *etagStore) invalidate(season string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.seasons, season)
+}
+
+// etagSeasonKey scopes a season to its tenant, so tenants with the same season name do not share ETags
+func etagSeasonKey(tenantID, season string) string {
+	if tenantID == "" {
+		return season
+	}
+	return tenantID + ":" + season
+}
+
+// etagQueryKey identifies a leaderboard query within its season
+func etagQueryKey(query *leaderboardmodels.LeaderboardQuery) string {
+	userID := ""
+	if query.UserID != nil {
+		userID = query.UserID.String()
+	}
+	excluded := make([]string, len(query.ExcludeUserIDs))
+	for i, id := range query.ExcludeUserIDs {
+		excluded[i] = id.String()
+	}
+	sort.Strings(excluded)
+	return fmt.Sprintf("%d|%d|%s|%s|%s|%s|%s", query.Limit, query.Page, query.SortBy, query.SortOrder, userID, query.Cursor, strings.Join(excluded, ","))
+}
@@ -2,11 +2,17 @@ package handlers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
 	sharedhandlers "leaderboard-service/internal/shared/handlers"
 	"leaderboard-service/internal/shared/middleware"
 	sharedmodels "leaderboard-service/internal/shared/models"
@@ -21,21 +27,43 @@ type LeaderboardServiceInterface interface {
 	SubmitScore(ctx context.Context, userID uuid.UUID, req *leaderboardmodels.SubmitScoreRequest) (*leaderboardmodels.Score, error)
 	GetLeaderboard(ctx context.Context, query *leaderboardmodels.LeaderboardQuery) (*leaderboardmodels.LeaderboardResponse, error)
 	GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.LeaderboardEntry, error)
+	GetSummary(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.ScoreSummary, error)
+	GetAchievements(ctx context.Context, userID uuid.UUID, season string) ([]leaderboardmodels.Achievement, error)
+	GetNeighbors(ctx context.Context, userID uuid.UUID, season string, radius int) (*leaderboardmodels.NeighborsResponse, error)
+	GetGlobalStandings(ctx context.Context, limit int) (*leaderboardmodels.LeaderboardResponse, error)
+	GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]leaderboardmodels.CrossSeasonEntry, error)
+	GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) (*leaderboardmodels.LeaderboardResponse, error)
+	GetScoreDistribution(ctx context.Context, season string, buckets int) ([]leaderboardmodels.ScoreBucket, error)
+	GetLeagues() []leaderboardmodels.League
+	FindScoresByMetadata(ctx context.Context, season, key, value string, limit int) ([]*leaderboardmodels.Score, error)
+	GetLeagueForUser(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.League, error)
 	BroadcastLeaderboard(ctx context.Context, season string) error
+	ResetSeason(ctx context.Context, season, adminUserID string) error
+	DeleteScore(ctx context.Context, userID uuid.UUID, season string, requestedBy uuid.UUID) error
+	UpdateScoringConfig(ctx context.Context, key string, req *leaderboardmodels.UpdateScoringConfigRequest) (*leaderboardmodels.ScoringConfigEntry, error)
 }
 
 // LeaderboardHandler handles leaderboard endpoints
 type LeaderboardHandler struct {
 	leaderboardService LeaderboardServiceInterface
+	etags              *etagStore
+	defaultSeason      string // used when ?season= is omitted
 }
 
 // NewLeaderboardHandler creates a new leaderboard handler
 func NewLeaderboardHandler(leaderboardService LeaderboardServiceInterface) *LeaderboardHandler {
 	return &LeaderboardHandler{
 		leaderboardService: leaderboardService,
+		etags:              newETagStore(),
+		defaultSeason:      config.FallbackSeason,
 	}
 }
 
+// SetDefaultSeason sets the season used when a request omits ?season= (Config.GetDefaultSeason)
+func (h *LeaderboardHandler) SetDefaultSeason(season string) {
+	h.defaultSeason = season
+}
+
 // SubmitScore handles score submission
 // POST /submit-score
 func (h *LeaderboardHandler) SubmitScore(w http.ResponseWriter, r *http.Request) {
@@ -52,36 +80,223 @@ func (h *LeaderboardHandler) SubmitScore(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Validate score
-	if req.Score < 0 {
-		sharedhandlers.RespondError(w, "score must be non-negative", http.StatusBadRequest)
+	if errs := ValidateSubmitScoreRequest(&req); errs != nil {
+		sharedhandlers.RespondValidationErrors(w, *errs)
 		return
 	}
 
 	score, err := h.leaderboardService.SubmitScore(r.Context(), userID, &req)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to submit score")
-		sharedhandlers.RespondError(w, "failed to submit score", http.StatusInternalServerError)
+		sharedhandlers.RespondAppError(w, err)
 		return
 	}
+	h.invalidateETags(r, req.Season)
 
+	// A replayed idempotent submission created nothing this time
+	status := http.StatusCreated
+	if score.Deduplicated {
+		status = http.StatusOK
+	}
 	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
 		Success: true,
 		Message: "score submitted successfully",
 		Data:    score,
-	}, http.StatusOK)
+	}, status)
 }
 
 // GetLeaderboard retrieves the leaderboard with pagination
 // GET /leaderboard
 func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters
-	query := parseLeaderboardQuery(r)
+	query := parseLeaderboardQuery(r, h.defaultSeason)
+
+	tenantID, _ := middleware.GetTenantIDFromContext(r.Context())
+	seasonKey := etagSeasonKey(tenantID, query.Season)
+	queryKey := etagQueryKey(query)
+	ifNoneMatch := r.Header.Get("If-None-Match")
+
+	// Short-circuit polling clients while the last ETag for this query is still fresh
+	if ifNoneMatch != "" {
+		if etag, ok := h.etags.lookup(seasonKey, queryKey); ok && etagMatches(ifNoneMatch, etag) {
+			writeNotModified(w, etag)
+			return
+		}
+	}
 
 	leaderboard, err := h.leaderboardService.GetLeaderboard(r.Context(), query)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get leaderboard")
-		sharedhandlers.RespondError(w, "failed to retrieve leaderboard", http.StatusInternalServerError)
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	body, err := json.Marshal(sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    leaderboard,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode leaderboard")
+		sharedhandlers.RespondError(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	etag, err := leaderboardETag(leaderboard)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode leaderboard")
+		sharedhandlers.RespondError(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	h.etags.store(seasonKey, queryKey, etag)
+
+	if ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		writeNotModified(w, etag)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "max-age=3, must-revalidate")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// defaultTopN and maxTopN bound the number of entries returned by GetTop
+const (
+	defaultTopN = 10
+	maxTopN     = 100
+)
+
+// GetTop returns the first n entries of a season's leaderboard without pagination metadata
+// GET /leaderboard/top?n=10&season=global
+func (h *LeaderboardHandler) GetTop(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	n := defaultTopN
+	if nStr := params.Get("n"); nStr != "" {
+		parsed, err := strconv.Atoi(nStr)
+		if err != nil || parsed < 1 || parsed > maxTopN {
+			sharedhandlers.RespondError(w, fmt.Sprintf("n must be between 1 and %d", maxTopN), http.StatusBadRequest)
+			return
+		}
+		n = parsed
+	}
+
+	season := params.Get("season")
+	if season == "" {
+		season = h.defaultSeason
+	}
+
+	leaderboard, err := h.leaderboardService.GetLeaderboard(r.Context(), &leaderboardmodels.LeaderboardQuery{
+		Season:    season,
+		SortOrder: "desc",
+		Limit:     n,
+		Page:      0,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("season", season).Msg("Failed to get top entries")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	entries := leaderboard.Entries
+	if entries == nil {
+		entries = []leaderboardmodels.LeaderboardEntry{}
+	}
+
+	// Same answer for every caller, so shared caches may store it
+	w.Header().Set("Cache-Control", "public, max-age=5")
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    entries,
+	}, http.StatusOK)
+}
+
+// defaultGlobalStandingsLimit and maxGlobalStandingsLimit bound the size of the all-time and cross-season rankings
+const (
+	defaultGlobalStandingsLimit = 50
+	maxGlobalStandingsLimit     = 100
+)
+
+// GetGlobalStandings returns the all-time ranking across every season
+// GET /leaderboard/global-standings?limit=50
+func (h *LeaderboardHandler) GetGlobalStandings(w http.ResponseWriter, r *http.Request) {
+	limit := defaultGlobalStandingsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 || l > maxGlobalStandingsLimit {
+			sharedhandlers.RespondError(w, fmt.Sprintf("limit must be between 1 and %d", maxGlobalStandingsLimit), http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+
+	standings, err := h.leaderboardService.GetGlobalStandings(r.Context(), limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get global standings")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    standings,
+	}, http.StatusOK)
+}
+
+// GetCrossSeasonRankings ranks players over several seasons by score normalized to 0..100 per season.
+// normalize_by_max=false maps each season's min..max range to 0..100 instead of dividing by the best score.
+// GET /leaderboard/cross-season?seasons=2024_01,2024_02&normalize_by_max=true&limit=50
+func (h *LeaderboardHandler) GetCrossSeasonRankings(w http.ResponseWriter, r *http.Request) {
+	seasonsParam := r.URL.Query().Get("seasons")
+	if seasonsParam == "" {
+		sharedhandlers.RespondError(w, "seasons is required", http.StatusBadRequest)
+		return
+	}
+
+	normalizeByMax := true
+	if value := r.URL.Query().Get("normalize_by_max"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			sharedhandlers.RespondError(w, "normalize_by_max must be true or false", http.StatusBadRequest)
+			return
+		}
+		normalizeByMax = parsed
+	}
+
+	limit := defaultGlobalStandingsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 || l > maxGlobalStandingsLimit {
+			sharedhandlers.RespondError(w, fmt.Sprintf("limit must be between 1 and %d", maxGlobalStandingsLimit), http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+
+	rankings, err := h.leaderboardService.GetCrossSeasonRankings(r.Context(), strings.Split(seasonsParam, ","), normalizeByMax, limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get cross-season rankings")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    rankings,
+	}, http.StatusOK)
+}
+
+// GetByCountry returns a season's leaderboard of the players registered in one country
+// GET /leaderboard/country/{countryCode}?season=global&limit=50&page=0
+func (h *LeaderboardHandler) GetByCountry(w http.ResponseWriter, r *http.Request) {
+	query := parseLeaderboardQuery(r, h.defaultSeason)
+
+	leaderboard, err := h.leaderboardService.GetLeaderboardByCountry(r.Context(), chi.URLParam(r, "countryCode"), query.Season, query.Limit, query.Page*query.Limit)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get country leaderboard")
+		sharedhandlers.RespondAppError(w, err)
 		return
 	}
 
@@ -91,6 +306,82 @@ func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Reque
 	}, http.StatusOK)
 }
 
+// defaultDistributionBuckets is the number of histogram bars when the client does not ask for a count
+const defaultDistributionBuckets = 10
+
+// GetDistribution returns a histogram of a season's scores for analytics charts
+// GET /leaderboard/distribution?season=global&buckets=10
+func (h *LeaderboardHandler) GetDistribution(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	season := params.Get("season")
+	if season == "" {
+		season = h.defaultSeason
+	}
+
+	buckets := defaultDistributionBuckets
+	if bucketsStr := params.Get("buckets"); bucketsStr != "" {
+		b, err := strconv.Atoi(bucketsStr)
+		if err != nil {
+			sharedhandlers.RespondError(w, "buckets must be an integer", http.StatusBadRequest)
+			return
+		}
+		buckets = b
+	}
+
+	distribution, err := h.leaderboardService.GetScoreDistribution(r.Context(), season, buckets)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get score distribution")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    distribution,
+	}, http.StatusOK)
+}
+
+// leaderboardETag hashes the response without GeneratedAt, which differs on every call
+// and would otherwise make every ETag unique
+func leaderboardETag(leaderboard *leaderboardmodels.LeaderboardResponse) (string, error) {
+	stable := *leaderboard
+	stable.GeneratedAt = time.Time{}
+	data, err := json.Marshal(stable)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`, nil
+}
+
+// etagMatches reports whether an If-None-Match header value matches the given ETag
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidateETags drops the stored leaderboard ETags of a season the request just changed;
+// an empty season is the default one, as for reads
+func (h *LeaderboardHandler) invalidateETags(r *http.Request, season string) {
+	if season == "" {
+		season = h.defaultSeason
+	}
+	tenantID, _ := middleware.GetTenantIDFromContext(r.Context())
+	h.etags.invalidate(etagSeasonKey(tenantID, season))
+}
+
+// writeNotModified sends a 304 response carrying the current ETag
+func writeNotModified(w http.ResponseWriter, etag string) {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "max-age=3, must-revalidate")
+	w.WriteHeader(http.StatusNotModified)
+}
+
 // GetUserRank retrieves a specific user's rank
 // GET /leaderboard/user/{userID}
 func (h *LeaderboardHandler) GetUserRank(w http.ResponseWriter, r *http.Request) {
@@ -103,13 +394,13 @@ func (h *LeaderboardHandler) GetUserRank(w http.ResponseWriter, r *http.Request)
 
 	season := r.URL.Query().Get("season")
 	if season == "" {
-		season = "global"
+		season = h.defaultSeason
 	}
 
 	rank, err := h.leaderboardService.GetUserRank(r.Context(), userID, season)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to get user rank")
-		sharedhandlers.RespondError(w, "user not found in leaderboard", http.StatusNotFound)
+		sharedhandlers.RespondAppError(w, err)
 		return
 	}
 
@@ -119,15 +410,149 @@ func (h *LeaderboardHandler) GetUserRank(w http.ResponseWriter, r *http.Request)
 	}, http.StatusOK)
 }
 
+// GetLeagues returns the league definitions
+// GET /leaderboard/leagues
+func (h *LeaderboardHandler) GetLeagues(w http.ResponseWriter, r *http.Request) {
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    h.leaderboardService.GetLeagues(),
+	}, http.StatusOK)
+}
+
+// GetUserLeague returns the league a player belongs to by percentile rank
+// GET /leaderboard/user/{userID}/league?season=global
+func (h *LeaderboardHandler) GetUserLeague(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		sharedhandlers.RespondError(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	season := r.URL.Query().Get("season")
+	if season == "" {
+		season = h.defaultSeason
+	}
+
+	league, err := h.leaderboardService.GetLeagueForUser(r.Context(), userID, season)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get user league")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    league,
+	}, http.StatusOK)
+}
+
+// GetSummary returns a player's rank and score with the season's min, max and size in one call
+// GET /leaderboard/summary/{userID}?season=global
+func (h *LeaderboardHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		sharedhandlers.RespondError(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	season := r.URL.Query().Get("season")
+	if season == "" {
+		season = h.defaultSeason
+	}
+
+	summary, err := h.leaderboardService.GetSummary(r.Context(), userID, season)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get score summary")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    summary,
+	}, http.StatusOK)
+}
+
+// GetAchievements returns the badges a player has earned in a season
+// GET /users/{userID}/achievements?season=global
+func (h *LeaderboardHandler) GetAchievements(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		sharedhandlers.RespondError(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	season := r.URL.Query().Get("season")
+	if season == "" {
+		season = h.defaultSeason
+	}
+
+	achievements, err := h.leaderboardService.GetAchievements(r.Context(), userID, season)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get achievements")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    achievements,
+	}, http.StatusOK)
+}
+
+// defaultNearbyRadius and maxNearbyRadius bound the number of neighbors returned on each side
+const (
+	defaultNearbyRadius = 5
+	maxNearbyRadius     = 50
+)
+
+// GetNearby retrieves the authenticated user's rank with surrounding players
+// GET /leaderboard/nearby?season=global&radius=5
+func (h *LeaderboardHandler) GetNearby(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	params := r.URL.Query()
+	season := params.Get("season")
+	if season == "" {
+		season = h.defaultSeason
+	}
+
+	radius := defaultNearbyRadius
+	if radiusStr := params.Get("radius"); radiusStr != "" {
+		rd, err := strconv.Atoi(radiusStr)
+		if err != nil || rd < 0 || rd > maxNearbyRadius {
+			sharedhandlers.RespondError(w, fmt.Sprintf("radius must be between 0 and %d", maxNearbyRadius), http.StatusBadRequest)
+			return
+		}
+		radius = rd
+	}
+
+	neighbors, err := h.leaderboardService.GetNeighbors(r.Context(), userID, season, radius)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get nearby players")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    neighbors,
+	}, http.StatusOK)
+}
+
 // parseLeaderboardQuery parses query parameters into LeaderboardQuery
-func parseLeaderboardQuery(r *http.Request) *leaderboardmodels.LeaderboardQuery {
+func parseLeaderboardQuery(r *http.Request, defaultSeason string) *leaderboardmodels.LeaderboardQuery {
 	params := r.URL.Query()
 
 	// Default values
 	limit := 50
 	page := 0
 	sortOrder := "desc"
-	season := "global"
+	season := defaultSeason
 
 	// Parse limit
 	if limitStr := params.Get("limit"); limitStr != "" {
@@ -148,6 +573,9 @@ func parseLeaderboardQuery(r *http.Request) *leaderboardmodels.LeaderboardQuery
 		sortOrder = sort
 	}
 
+	// Parse sort dimension; validated by the service so an unknown value is a 400
+	sortBy := params.Get("sort_by")
+
 	// Parse season
 	if s := params.Get("season"); s != "" {
 		season = s
@@ -164,28 +592,81 @@ func parseLeaderboardQuery(r *http.Request) *leaderboardmodels.LeaderboardQuery
 	// Parse cursor (for cursor-based pagination)
 	cursor := params.Get("cursor")
 
+	// Parse excluded players (?exclude=uuid1,uuid2); invalid IDs are ignored
+	var excludeUserIDs []uuid.UUID
+	if excludeStr := params.Get("exclude"); excludeStr != "" {
+		for _, idStr := range strings.Split(excludeStr, ",") {
+			if uid, err := uuid.Parse(strings.TrimSpace(idStr)); err == nil {
+				excludeUserIDs = append(excludeUserIDs, uid)
+			}
+		}
+	}
+
 	return &leaderboardmodels.LeaderboardQuery{
-		Season:    season,
-		UserID:    userID,
-		SortOrder: sortOrder,
-		Limit:     limit,
-		Page:      page,
-		Cursor:    cursor,
+		Season:         season,
+		UserID:         userID,
+		SortBy:         sortBy,
+		SortOrder:      sortOrder,
+		Limit:          limit,
+		Page:           page,
+		Cursor:         cursor,
+		ExcludeUserIDs: excludeUserIDs,
 	}
 }
 
+// defaultMetadataSearchLimit is used when /admin/scores is called without ?limit=
+const defaultMetadataSearchLimit = 100
+
+// FindScoresByMetadata lists a season's scores with a metadata key/value pair (admin)
+// GET /admin/scores?metadata_key=simulated&metadata_value=true&season=global&limit=100
+func (h *LeaderboardHandler) FindScoresByMetadata(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	season := params.Get("season")
+	if season == "" {
+		season = h.defaultSeason
+	}
+
+	key := params.Get("metadata_key")
+	if key == "" || !params.Has("metadata_value") {
+		sharedhandlers.RespondError(w, "metadata_key and metadata_value are required", http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultMetadataSearchLimit
+	if limitStr := params.Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil {
+			sharedhandlers.RespondError(w, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+
+	scores, err := h.leaderboardService.FindScoresByMetadata(r.Context(), season, key, params.Get("metadata_value"), limit)
+	if err != nil {
+		log.Error().Err(err).Str("season", season).Str("metadata_key", key).Msg("Failed to search scores by metadata")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    scores,
+	}, http.StatusOK)
+}
+
 // TestBroadcast manually triggers a WebSocket broadcast (for testing)
 // POST /test/broadcast?season=global
 func (h *LeaderboardHandler) TestBroadcast(w http.ResponseWriter, r *http.Request) {
 	season := r.URL.Query().Get("season")
 	if season == "" {
-		season = "global"
+		season = h.defaultSeason
 	}
 
 	err := h.leaderboardService.BroadcastLeaderboard(r.Context(), season)
 	if err != nil {
 		log.Error().Err(err).Str("season", season).Msg("Failed to broadcast")
-		sharedhandlers.RespondError(w, err.Error(), http.StatusInternalServerError)
+		sharedhandlers.RespondAppError(w, err)
 		return
 	}
 
@@ -194,3 +675,93 @@ func (h *LeaderboardHandler) TestBroadcast(w http.ResponseWriter, r *http.Reques
 		"season":  season,
 	}, http.StatusOK)
 }
+
+// ResetSeason clears all scores of a season (admin only)
+// POST /admin/seasons/{name}/reset
+func (h *LeaderboardHandler) ResetSeason(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	season := chi.URLParam(r, "name")
+	if season == "" {
+		sharedhandlers.RespondError(w, "season name is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.leaderboardService.ResetSeason(r.Context(), season, adminID.String()); err != nil {
+		log.Error().Err(err).Str("season", season).Msg("Failed to reset season")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+	h.invalidateETags(r, season)
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "season reset successfully",
+		Data:    map[string]string{"season": season},
+	}, http.StatusOK)
+}
+
+// DeleteScore removes a player's score from a season; allowed for admins and the score's owner
+// DELETE /leaderboard/user/{userID}/season/{season}
+func (h *LeaderboardHandler) DeleteScore(w http.ResponseWriter, r *http.Request) {
+	requesterID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		sharedhandlers.RespondError(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+	season := chi.URLParam(r, "season")
+
+	role, _ := middleware.GetRoleFromContext(r.Context())
+	if requesterID != userID && role != middleware.RoleAdmin {
+		sharedhandlers.RespondError(w, "only the score owner or an admin can delete a score", http.StatusForbidden)
+		return
+	}
+
+	if err := h.leaderboardService.DeleteScore(r.Context(), userID, season, requesterID); err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Str("season", season).Msg("Failed to delete score")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+	h.invalidateETags(r, season)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateScoringConfig changes a runtime scoring rule (admin only)
+// PUT /admin/scoring-configs/{key}
+func (h *LeaderboardHandler) UpdateScoringConfig(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+	if key == "" {
+		sharedhandlers.RespondError(w, "config key is required", http.StatusBadRequest)
+		return
+	}
+
+	var req leaderboardmodels.UpdateScoringConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhandlers.RespondError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.leaderboardService.UpdateScoringConfig(r.Context(), key, &req)
+	if err != nil {
+		log.Error().Err(err).Str("key", key).Msg("Failed to update scoring config")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "scoring config updated",
+		Data:    entry,
+	}, http.StatusOK)
+}
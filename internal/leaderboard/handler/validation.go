@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/utils"
+)
+
+// maxSeasonNameLength is the width of the scores.season column
+const maxSeasonNameLength = 50
+
+// maxCorrelationIDLength bounds client-supplied correlation IDs, which end up in every log line
+const maxCorrelationIDLength = 64
+
+// maxIdempotencyKeyLength bounds idempotency keys, which become part of a Redis key
+const maxIdempotencyKeyLength = 128
+
+// ValidateSubmitScoreRequest checks a score submission before it reaches the service.
+// Returns nil when the request is valid, otherwise every failed rule.
+func ValidateSubmitScoreRequest(req *leaderboardmodels.SubmitScoreRequest) *utils.FieldErrors {
+	v := utils.NewValidator().
+		Min("score", req.Score, 0).
+		MaxLength("season", req.Season, maxSeasonNameLength).
+		MaxLength("correlation_id", req.CorrelationID, maxCorrelationIDLength).
+		MaxLength("idempotency_key", req.IdempotencyKey, maxIdempotencyKeyLength)
+	if v.IsValid() {
+		return nil
+	}
+	errs := v.Errors()
+	return &errs
+}
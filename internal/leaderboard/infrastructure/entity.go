@@ -6,6 +6,7 @@ import (
 	"leaderboard-service/internal/leaderboard/domain"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // ScoreEntity - persistence модель с GORM тегами
@@ -17,6 +18,11 @@ type ScoreEntity struct {
 	Season    string                 `gorm:"type:varchar(50);not null;default:'global';index:idx_scores_season_score"`
 	Metadata  map[string]interface{} `gorm:"type:jsonb"`
 	Timestamp time.Time              `gorm:"autoCreateTime"`
+	// GamesPlayed увеличивается при каждом upsert; в домен не переносится
+	GamesPlayed int `gorm:"not null;default:1"`
+	// DeletedAt скрывает мягко удаленные счета из запросов, которые GORM строит по модели.
+	// Сырые запросы фильтруются меткой database.SoftDeleteScoresTag
+	DeletedAt gorm.DeletedAt
 }
 
 // TableName для GORM
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Achievement is a badge a player earned by reaching a score threshold in a season
+type Achievement struct {
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	BadgeName string    `json:"badge_name" gorm:"type:text;primaryKey"`
+	Season    string    `json:"season" gorm:"type:text;primaryKey"`
+	AwardedAt time.Time `json:"awarded_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (Achievement) TableName() string {
+	return "achievements"
+}
@@ -0,0 +1,10 @@
+package models
+
+// League is a tier of players grouped by percentile rank within a season
+type League struct {
+	Name string `json:"name"`
+	// MinPercentile is inclusive; MaxPercentile is exclusive except for a top tier ending at 100
+	MinPercentile float64 `json:"min_percentile"`
+	MaxPercentile float64 `json:"max_percentile"`
+	Color         string  `json:"color,omitempty"`
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"time"
 
+	"leaderboard-service/internal/shared/utils"
+
 	"github.com/google/uuid"
 )
 
@@ -14,6 +16,14 @@ type Score struct {
 	Season    string                 `json:"season" db:"season" gorm:"type:varchar(50);not null;default:'global';index:idx_scores_season_score"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty" db:"metadata" gorm:"type:jsonb"`
 	Timestamp time.Time              `json:"timestamp" db:"timestamp" gorm:"autoCreateTime"`
+	// Rank is filled in by SubmitScore when Scoring.ReturnRankOnSubmit is set; it is never stored
+	Rank int `json:"rank,omitempty" gorm:"-"`
+	// Percentile is the share of the season's players at or below Rank, in percent; filled in with Rank
+	Percentile float64 `json:"percentile,omitempty" gorm:"-"`
+	// CorrelationID echoes the submission's correlation ID so clients can quote it; it is never stored
+	CorrelationID string `json:"correlation_id,omitempty" gorm:"-"`
+	// Deduplicated marks a response replayed for a repeated idempotency key; it is never stored
+	Deduplicated bool `json:"deduplicated,omitempty" gorm:"-"`
 }
 
 // TableName specifies the table name for GORM
@@ -26,6 +36,10 @@ type SubmitScoreRequest struct {
 	Score    int64                  `json:"score" validate:"required,min=0"`
 	Season   string                 `json:"season" validate:"omitempty,max=50"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// CorrelationID tags every log line of the submission; generated by SubmitScore when empty
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// IdempotencyKey lets a client retry a submission safely: a repeated key returns the first response
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // LeaderboardEntry represents a leaderboard row with user info
@@ -36,24 +50,124 @@ type LeaderboardEntry struct {
 	Score     int64     `json:"score"`
 	Season    string    `json:"season"`
 	Timestamp time.Time `json:"timestamp"`
+	// GamesPlayed counts the player's submissions to the season; not available from the materialized view
+	GamesPlayed int `json:"games_played,omitempty"`
+	// StreakCurrent and StreakLongest count consecutive days (UTC) with a submission; not available from the materialized view
+	StreakCurrent int `json:"streak_current,omitempty"`
+	StreakLongest int `json:"streak_longest,omitempty"`
 }
 
-// LeaderboardResponse is the paginated leaderboard response
+// LeaderboardResponse is the paginated leaderboard response; the embedded PaginationMeta
+// holds a 1-based page number and is flattened into the JSON object
 type LeaderboardResponse struct {
-	Entries    []LeaderboardEntry `json:"entries"`
-	TotalCount int64              `json:"total_count"`
-	Page       int                `json:"page"`
-	Limit      int                `json:"limit"`
-	HasNext    bool               `json:"has_next"`
-	NextCursor string             `json:"next_cursor,omitempty"`
+	Entries []LeaderboardEntry `json:"entries"`
+	utils.PaginationMeta
+	NextCursor string `json:"next_cursor,omitempty"`
+	// GeneratedAt is when the server built this response; clients compare it with their clock to spot stale data
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// CrossSeasonEntry ranks a player across several seasons by a score normalized per season.
+// NormalizedScore is the mean of the player's normalized season scores (0..100), a season
+// without a score counting as 0; SeasonScores holds the raw scores of the seasons played.
+type CrossSeasonEntry struct {
+	Rank            int              `json:"rank"`
+	UserID          uuid.UUID        `json:"user_id"`
+	UserName        string           `json:"user_name"`
+	NormalizedScore float64          `json:"normalized_score"`
+	SeasonScores    map[string]int64 `json:"season_scores"`
+}
+
+// EnrichedLeaderboardEntry is a leaderboard row with the player's public profile
+type EnrichedLeaderboardEntry struct {
+	LeaderboardEntry
+	AvatarURL string `json:"avatar_url,omitempty"`
+	Country   string `json:"country,omitempty"`
+	Tier      string `json:"tier,omitempty"`
+}
+
+// EnrichedLeaderboardResponse is the paginated leaderboard response with player profiles
+type EnrichedLeaderboardResponse struct {
+	Entries     []EnrichedLeaderboardEntry `json:"entries"`
+	TotalCount  int64                      `json:"total_count"`
+	Page        int                        `json:"page"`
+	Limit       int                        `json:"limit"`
+	HasNext     bool                       `json:"has_next"`
+	GeneratedAt time.Time                  `json:"generated_at"`
 }
 
 // LeaderboardQuery represents query parameters for fetching leaderboard
 type LeaderboardQuery struct {
 	Season    string
 	UserID    *uuid.UUID
+	SortBy    string // One of SortByScore, SortByTimestamp, SortByGamesPlayed; empty means score
 	SortOrder string // "asc" or "desc"
 	Limit     int
 	Page      int
 	Cursor    string // For cursor-based pagination
+	// ExcludeUserIDs hides specific players (e.g. banned ones) from the leaderboard
+	ExcludeUserIDs []uuid.UUID
+}
+
+// Leaderboard sort dimensions accepted by LeaderboardQuery.SortBy
+const (
+	SortByScore       = "score"
+	SortByTimestamp   = "timestamp"
+	SortByGamesPlayed = "games_played"
+)
+
+// ValidSortBy reports whether sortBy is a supported sort dimension; empty defaults to score
+func ValidSortBy(sortBy string) bool {
+	switch sortBy {
+	case "", SortByScore, SortByTimestamp, SortByGamesPlayed:
+		return true
+	}
+	return false
+}
+
+// NeighborsResponse is a user's leaderboard position with the players ranked around them
+type NeighborsResponse struct {
+	Season  string             `json:"season"`
+	User    LeaderboardEntry   `json:"user"`
+	Entries []LeaderboardEntry `json:"entries"` // Ordered window including the user
+}
+
+// ScoreBucket is one bar of a score histogram: Count players scored between Min and Max inclusive
+type ScoreBucket struct {
+	Min   int64 `json:"min"`
+	Max   int64 `json:"max"`
+	Count int64 `json:"count"`
+}
+
+// NewScoreBuckets splits [minScore, maxScore] into equal integer ranges with zero counts.
+// Fewer buckets are returned when the range holds fewer distinct scores than requested.
+// A score s falls into bucket (s-minScore)*len/(maxScore-minScore+1).
+func NewScoreBuckets(minScore, maxScore int64, buckets int) []ScoreBucket {
+	width := maxScore - minScore + 1
+	n := int64(buckets)
+	if n > width {
+		n = width
+	}
+
+	result := make([]ScoreBucket, n)
+	for i := int64(0); i < n; i++ {
+		// Ceiling division mirrors the floor in the bucket formula
+		result[i] = ScoreBucket{
+			Min: minScore + (i*width+n-1)/n,
+			Max: minScore + ((i+1)*width+n-1)/n - 1,
+		}
+	}
+	return result
+}
+
+// ScoreSummary is what a game-end screen shows for one player in one season
+type ScoreSummary struct {
+	UserID             uuid.UUID `json:"user_id"`
+	Season             string    `json:"season"`
+	UserRank           int       `json:"user_rank"`
+	UserScore          int64     `json:"user_score"`
+	SeasonMax          int64     `json:"season_max"`
+	SeasonMin          int64     `json:"season_min"`
+	TotalPlayers       int64     `json:"total_players"`
+	PersonalBestSeason string    `json:"personal_best_season"` // Season of the player's highest score
 }
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// Scoring config keys that can be changed at runtime
+const (
+	ScoringConfigMinScore = "min_score"
+	ScoringConfigMaxScore = "max_score"
+)
+
+// ScoringConfigEntry is a runtime-editable scoring rule.
+// An empty Season applies the rule to every season without its own row.
+type ScoringConfigEntry struct {
+	Key       string    `json:"key" gorm:"type:text;primaryKey"`
+	Season    string    `json:"season" gorm:"type:text;primaryKey"`
+	Value     string    `json:"value" gorm:"type:text;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (ScoringConfigEntry) TableName() string {
+	return "scoring_configs"
+}
+
+// ValidationConfig holds a season's score limits from scoring_configs; nil fields are not overridden
+type ValidationConfig struct {
+	MinScore *int64 `json:"min_score,omitempty"`
+	MaxScore *int64 `json:"max_score,omitempty"`
+}
+
+// NewValidationConfig merges the rows of a season over the rows that apply to every season (Season "").
+// Rows of other seasons, other keys and non-numeric values are ignored.
+func NewValidationConfig(season string, entries []*ScoringConfigEntry) *ValidationConfig {
+	cfg := &ValidationConfig{}
+	// Общие строки применяются первыми, чтобы строки сезона их перекрыли
+	for _, pass := range []string{"", season} {
+		for _, entry := range entries {
+			if entry.Season != pass {
+				continue
+			}
+			value, err := strconv.ParseInt(entry.Value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch entry.Key {
+			case ScoringConfigMinScore:
+				cfg.MinScore = &value
+			case ScoringConfigMaxScore:
+				cfg.MaxScore = &value
+			}
+		}
+	}
+	return cfg
+}
+
+// UpdateScoringConfigRequest is the payload for changing a scoring rule
+type UpdateScoringConfigRequest struct {
+	Value  string `json:"value" validate:"required"`
+	Season string `json:"season" validate:"omitempty,max=50"`
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// PostgresAchievementRepository is a PostgreSQL implementation of AchievementRepository
+// Значки хранятся в таблице achievements, по одной строке на игрока, сезон и значок
+type PostgresAchievementRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresAchievementRepository creates a new PostgreSQL achievement repository
+func NewPostgresAchievementRepository(db *database.PostgresDB) repository.AchievementRepository {
+	return &PostgresAchievementRepository{db: db}
+}
+
+// Award stores a badge; an already awarded badge is left untouched
+// ON CONFLICT DO NOTHING вместо проверки перед вставкой: параллельные отправки не выдадут значок дважды
+func (r *PostgresAchievementRepository) Award(ctx context.Context, achievement *models.Achievement) (bool, error) {
+	result := r.db.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(achievement)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to award achievement: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindByUserAndSeason retrieves a player's badges in a season, oldest first
+func (r *PostgresAchievementRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) ([]*models.Achievement, error) {
+	var achievements []*models.Achievement
+	err := r.db.DB.WithContext(ctx).
+		Where("user_id = ? AND season = ?", userID, season).
+		Order("awarded_at ASC, badge_name ASC").
+		Find(&achievements).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find achievements: %w", err)
+	}
+	return achievements, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresAchievementRepository_Award_SQL(t *testing.T) {
+	scores, lastSQL := newDryRunScoreRepository(t)
+	repo := NewPostgresAchievementRepository(scores.db)
+
+	_, err := repo.Award(context.Background(), &models.Achievement{
+		UserID:    uuid.New(),
+		BadgeName: "score_10000",
+		Season:    "global",
+		AwardedAt: time.Now(),
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, *lastSQL, `INSERT INTO "achievements"`)
+	assert.Contains(t, *lastSQL, "ON CONFLICT DO NOTHING")
+}
@@ -2,31 +2,55 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"leaderboard-service/internal/leaderboard/domain"
 	"leaderboard-service/internal/leaderboard/infrastructure"
 	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/database"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+const (
+	// scoreLockRetries - сколько раз Upsert повторяет попытку взять advisory lock после первой неудачи
+	scoreLockRetries = 3
+	// scoreLockRetryDelay - пауза между попытками
+	scoreLockRetryDelay = 10 * time.Millisecond
+)
+
 // PostgresScoreRepository is a PostgreSQL implementation of ScoreRepository
 // Использует чистую domain модель и отдельные entities для персистентности
 type PostgresScoreRepository struct {
 	*repository.BaseRepository[infrastructure.ScoreEntity]
-	db *database.PostgresDB
+	db            *database.PostgresDB
+	rankingMethod string
+	// tryLock пытается взять блокировку строки (user_id, season) внутри транзакции tx
+	tryLock func(tx *gorm.DB, userID uuid.UUID, season string) (bool, error)
 }
 
-// NewPostgresScoreRepository creates a new PostgreSQL score repository
+// NewPostgresScoreRepository creates a new PostgreSQL score repository with dense ranking
 func NewPostgresScoreRepository(db *database.PostgresDB) repository.ScoreRepository {
+	return NewPostgresScoreRepositoryWithRanking(db, config.RankingMethodDense)
+}
+
+// NewPostgresScoreRepositoryWithRanking creates a PostgreSQL score repository that ranks
+// leaderboard entries with the given method (see config.RankingMethod*)
+func NewPostgresScoreRepositoryWithRanking(db *database.PostgresDB, rankingMethod string) repository.ScoreRepository {
 	return &PostgresScoreRepository{
 		BaseRepository: repository.NewBaseRepository[infrastructure.ScoreEntity](db),
 		db:             db,
+		rankingMethod:  rankingMethod,
+		tryLock:        tryAdvisoryXactLock,
 	}
 }
 
@@ -42,69 +66,317 @@ func (r *PostgresScoreRepository) Upsert(ctx context.Context, score *models.Scor
 	}
 	entity := infrastructure.FromDomainScore(domainScore)
 
-	result := r.db.DB.WithContext(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "user_id"}, {Name: "season"}},
-		DoUpdates: clause.AssignmentColumns([]string{"score", "metadata", "timestamp"}),
-	}).Create(entity)
+	// Блокировка транзакционная: снимается при COMMIT/ROLLBACK, поэтому upsert и история идут в одной транзакции
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockUserSeason(ctx, tx, score.UserID, score.Season); err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "season"}},
+			DoUpdates: upsertAssignments(),
+		}).Create(entity)
 
-	if result.Error != nil {
-		return fmt.Errorf("failed to upsert score: %w", result.Error)
+		if result.Error != nil {
+			return fmt.Errorf("failed to upsert score: %w", result.Error)
+		}
+		score.ID = entity.ID
+		return recordSubmission(tx, score)
+	})
+}
+
+// lockUserSeason берет блокировку на пару (user_id, season), чтобы параллельные отправки
+// одного пользователя не гонялись за одной строкой. Не ждет блокировку бесконечно:
+// после scoreLockRetries повторов возвращает repository.ErrScoreLocked
+func (r *PostgresScoreRepository) lockUserSeason(ctx context.Context, tx *gorm.DB, userID uuid.UUID, season string) error {
+	for attempt := 0; ; attempt++ {
+		acquired, err := r.tryLock(tx, userID, season)
+		if err != nil {
+			return fmt.Errorf("failed to acquire score lock: %w", err)
+		}
+		if acquired {
+			return nil
+		}
+		if attempt == scoreLockRetries {
+			return repository.ErrScoreLocked
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(scoreLockRetryDelay):
+		}
+	}
+}
+
+// tryAdvisoryXactLock - pg_try_advisory_xact_lock по hashtext(user_id || season)
+func tryAdvisoryXactLock(tx *gorm.DB, userID uuid.UUID, season string) (bool, error) {
+	var acquired bool
+	err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?::text || ?))", userID, season).
+		Scan(&acquired).Error
+	return acquired, err
+}
+
+// UpsertOnlyIfHigher inserts a new score or updates it only if the new score is higher (personal best)
+// Условие в DO UPDATE ... WHERE оставляет строку нетронутой, и RowsAffected будет 0
+func (r *PostgresScoreRepository) UpsertOnlyIfHigher(ctx context.Context, score *models.Score) (bool, error) {
+	domainScore := &domain.Score{
+		ID:        score.ID,
+		UserID:    score.UserID,
+		Score:     score.Score,
+		Season:    score.Season,
+		Metadata:  score.Metadata,
+		Timestamp: score.Timestamp,
+	}
+	entity := infrastructure.FromDomainScore(domainScore)
+
+	// Та же блокировка и транзакция, что в Upsert: иначе параллельная отправка могла бы
+	// вклиниться между upsert, счетчиком игр и записью истории
+	improved := false
+	err := r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockUserSeason(ctx, tx, score.UserID, score.Season); err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "season"}},
+			DoUpdates: upsertAssignments(),
+			Where: clause.Where{Exprs: []clause.Expression{
+				// Мягко удаленная строка заменяется любым результатом: ее рекорд больше не действует
+				clause.Expr{SQL: "EXCLUDED.score > scores.score OR scores.deleted_at IS NOT NULL"},
+			}},
+		}).Create(entity)
+
+		if result.Error != nil {
+			return fmt.Errorf("failed to upsert score: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			// Результат не лучше сохраненного, но игра все равно засчитывается
+			err := tx.Model(&infrastructure.ScoreEntity{}).
+				Where("user_id = ? AND season = ?", score.UserID, score.Season).
+				UpdateColumn("games_played", gorm.Expr("games_played + 1")).Error
+			if err != nil {
+				return fmt.Errorf("failed to count game: %w", err)
+			}
+			return recordSubmission(tx, score)
+		}
+		improved = true
+		score.ID = entity.ID
+		return recordSubmission(tx, score)
+	})
+	if err != nil {
+		return false, err
+	}
+	return improved, nil
+}
+
+// recordSubmission пишет отправку в score_history, по которой считаются серии дней (GetStreak).
+// История пишется и для результата, не побившего рекорд: для серии важен сам факт игры
+func recordSubmission(db *gorm.DB, score *models.Score) error {
+	err := db.Exec(
+		"INSERT INTO score_history (user_id, season, score) VALUES (?, ?, ?)",
+		score.UserID, score.Season, score.Score,
+	).Error
+	if err != nil {
+		return fmt.Errorf("failed to record score history: %w", err)
 	}
-	score.ID = entity.ID
 	return nil
 }
 
+// streaksCTE строит CTE streaks(user_id, streak_current, streak_longest) по дням (UTC) с отправками
+// из score_history, отфильтрованным условием where. LAG() отмечает день, не продолжающий предыдущий,
+// накопительная сумма этих отметок нумерует серии. Текущая серия жива, если ее последний день - сегодня или вчера
+func streaksCTE(where string) string {
+	return "WITH " + streakCTEs(where)
+}
+
+// streakCTEs - те же CTE без WITH, чтобы поставить их после других CTE запроса
+func streakCTEs(where string) string {
+	return `days AS (
+			SELECT DISTINCT user_id, (submitted_at AT TIME ZONE 'UTC')::date AS day
+			FROM score_history
+			WHERE ` + where + `
+		), marked AS (
+			SELECT user_id, day,
+				CASE WHEN day - LAG(day) OVER (PARTITION BY user_id ORDER BY day) = 1 THEN 0 ELSE 1 END AS new_run
+			FROM days
+		), runs AS (
+			SELECT user_id, day, SUM(new_run) OVER (PARTITION BY user_id ORDER BY day) AS run
+			FROM marked
+		), run_lengths AS (
+			SELECT user_id, COUNT(*) AS length, MAX(day) AS last_day
+			FROM runs
+			GROUP BY user_id, run
+		), streaks AS (
+			SELECT user_id,
+				COALESCE(MAX(length) FILTER (WHERE last_day >= (now() AT TIME ZONE 'UTC')::date - 1), 0) AS streak_current,
+				MAX(length) AS streak_longest
+			FROM run_lengths
+			GROUP BY user_id
+		)`
+}
+
+// upsertAssignments - колонки, перезаписываемые при конфликте, плюс счетчик сыгранных игр.
+// Конфликт возможен и с мягко удаленной строкой: отправка восстанавливает ее (deleted_at = NULL),
+// и счетчик игр начинается заново, как у новой строки
+func upsertAssignments() clause.Set {
+	return append(clause.AssignmentColumns([]string{"score", "metadata", "timestamp"}),
+		clause.Assignment{
+			Column: clause.Column{Name: "games_played"},
+			Value:  gorm.Expr("CASE WHEN scores.deleted_at IS NULL THEN scores.games_played + 1 ELSE 1 END"),
+		},
+		clause.Assignment{Column: clause.Column{Name: "deleted_at"}, Value: gorm.Expr("NULL")},
+	)
+}
+
+// leaderboardOrder возвращает порядок для DENSE_RANK и для выдачи страницы.
+// Ранг всегда считается по убыванию выбранного поля; sortOrder=asc меняет только порядок выдачи
+func leaderboardOrder(sortBy, sortOrder string) (rankOrder, pageOrder string) {
+	var column, tieBreak string
+	switch sortBy {
+	case models.SortByTimestamp:
+		column, tieBreak = "s.timestamp", "s.score DESC"
+	case models.SortByGamesPlayed:
+		column, tieBreak = "s.games_played", "s.score DESC, s.timestamp ASC"
+	default:
+		column, tieBreak = "s.score", "s.timestamp ASC"
+	}
+
+	// user_id в порядке выдачи делает страницы OFFSET детерминированными при полных
+	// совпадениях и совпадает с порядком keyset-курсора
+	rankOrder = column + " DESC, " + tieBreak
+	if sortOrder == "asc" {
+		return rankOrder, column + " ASC, " + tieBreak + ", s.user_id ASC"
+	}
+	return rankOrder, rankOrder + ", s.user_id ASC"
+}
+
+// keysetPageRank возвращает выражение ранга строки p страницы, следующей за курсором, и его
+// аргументы. Окно видит только страницу, поэтому ранг достраивается от курсора:
+// ordinal - позиция курсора плюс номер строки; competition - строки, равные курсору по
+// (score, timestamp), получают его ранг, остальные - позицию курсора плюс RANK на странице
+// (все строки до курсора строго лучше них); dense - ранг курсора плюс DENSE_RANK на странице,
+// минус единица, если на страницу попала группа курсора: она уже посчитана в его ранге
+func keysetPageRank(method string, after utils.CursorPosition) (string, []interface{}, error) {
+	const order = "ORDER BY p.score DESC, p.timestamp ASC"
+	switch method {
+	case config.RankingMethodDense:
+		return `? + DENSE_RANK() OVER (` + order + `)
+			- CASE WHEN EXISTS (SELECT 1 FROM page t WHERE t.score = ? AND t.timestamp = ?) THEN 1 ELSE 0 END`,
+			[]interface{}{after.Rank, after.Score, after.Timestamp}, nil
+	case config.RankingMethodCompetition:
+		return `CASE WHEN p.score = ? AND p.timestamp = ? THEN ? ELSE ? + RANK() OVER (` + order + `) END`,
+			[]interface{}{after.Score, after.Timestamp, after.Rank, after.Offset}, nil
+	case config.RankingMethodOrdinal:
+		return `? + ROW_NUMBER() OVER (` + order + `, p.user_id ASC)`, []interface{}{after.Offset}, nil
+	}
+	return "", nil, config.ValidateRankingMethod(method)
+}
+
+// rankAheadCount возвращает подзапрос, считающий для строки s число мест перед ней так же,
+// как rankWindowFunction по порядку score DESC, timestamp ASC: dense - различные лучшие пары
+// (score, timestamp), competition - все лучшие строки, ordinal - еще и равные с меньшим user_id
+func rankAheadCount(method string) (string, error) {
+	better := "o.score > s.score OR (o.score = s.score AND o.timestamp < s.timestamp)"
+	from := "FROM scores o WHERE o.season = s.season " + database.SoftDeleteScoresTagAs("o")
+	switch method {
+	case config.RankingMethodDense:
+		return "SELECT COUNT(*) FROM (SELECT DISTINCT o.score, o.timestamp " + from + " AND (" + better + ")) better", nil
+	case config.RankingMethodCompetition:
+		return "SELECT COUNT(*) " + from + " AND (" + better + ")", nil
+	case config.RankingMethodOrdinal:
+		return "SELECT COUNT(*) " + from + " AND (" + better +
+			" OR (o.score = s.score AND o.timestamp = s.timestamp AND o.user_id < s.user_id))", nil
+	}
+	return "", config.ValidateRankingMethod(method)
+}
+
+// rankWindowFunction возвращает оконную функцию ранжирования для метода из конфигурации.
+// Значение подставляется в SQL как есть, поэтому допускаются только известные методы
+func rankWindowFunction(method string) (string, error) {
+	switch method {
+	case config.RankingMethodDense:
+		return "DENSE_RANK()", nil
+	case config.RankingMethodCompetition:
+		return "RANK()", nil
+	case config.RankingMethodOrdinal:
+		return "ROW_NUMBER()", nil
+	}
+	return "", config.ValidateRankingMethod(method)
+}
+
 // FindByUserAndSeason retrieves a user's score for a specific season
 func (r *PostgresScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*models.Score, error) {
 	entity, err := r.BaseRepository.FindOne(ctx, "user_id = ? AND season = ?", userID, season)
 	if err != nil {
 		return nil, err
 	}
-	domainScore := entity.ToDomain()
-	return &models.Score{
-		ID:        domainScore.ID,
-		UserID:    domainScore.UserID,
-		Score:     domainScore.Score,
-		Season:    domainScore.Season,
-		Metadata:  domainScore.Metadata,
-		Timestamp: domainScore.Timestamp,
-	}, nil
+	return toScoreModel(entity), nil
 }
 
 // GetLeaderboard retrieves paginated leaderboard entries for a season with user details
-func (r *PostgresScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortOrder string) ([]models.LeaderboardEntry, int64, error) {
-	// Sort by score directly, not by rank (which is computed)
-	// desc = highest scores first (default leaderboard view)
-	// asc = lowest scores first (rare case)
-	var orderBy string
-	if sortOrder == "asc" {
-		orderBy = "s.score ASC, s.timestamp ASC"
-	} else {
-		orderBy = "s.score DESC, s.timestamp ASC"
+func (r *PostgresScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]models.LeaderboardEntry, int64, error) {
+	// Sort by the requested column directly, not by rank (which is computed)
+	// desc = highest values first (default leaderboard view)
+	// asc = lowest values first (rare case)
+	rankOrder, orderBy := leaderboardOrder(sortBy, sortOrder)
+	rankFunc, err := rankWindowFunction(r.rankingMethod)
+	if err != nil {
+		return nil, 0, err
+	}
+	if r.rankingMethod == config.RankingMethodOrdinal {
+		// Номера при полных совпадениях раздаются по user_id, как в GetUserRank и keyset-странице
+		rankOrder += ", s.user_id ASC"
 	}
 
+	// Исключенные игроки отфильтровываются до ранжирования, чтобы не занимать места
+	where := "s.season = ? " + database.SoftDeleteScoresTag
+	args := []interface{}{season}
+	if len(excludeUserIDs) > 0 {
+		where += " AND s.user_id NOT IN (?)"
+		args = append(args, excludeUserIDs)
+	}
+	// Последний аргумент - сезон для CTE серий
+	args = append(args, limit, offset, season)
+
 	var entries []models.LeaderboardEntry
 	// Force fresh query without prepared statement cache
 	// Use a new connection to avoid transaction isolation issues
-	err := r.db.DB.WithContext(ctx).
+	// Серии считаются только для игроков страницы: CTE по всему сезону читал бы всю его историю
+	err = r.db.DB.WithContext(ctx).
 		Session(&gorm.Session{
 			PrepareStmt:            false,
 			SkipDefaultTransaction: true,
 		}).
-		Raw(`
-			SELECT 
-				DENSE_RANK() OVER (ORDER BY s.score DESC, s.timestamp ASC) as rank,
-				s.user_id,
-				u.name as user_name,
-				s.score,
-				s.season,
-				s.timestamp
-			FROM scores s
-			JOIN users u ON s.user_id = u.id
-			WHERE s.season = ?
-			ORDER BY `+orderBy+`
-			LIMIT ? OFFSET ?
-		`, season, limit, offset).Scan(&entries).Error
+		Raw(`WITH page AS (
+				SELECT 
+					`+rankFunc+` OVER (ORDER BY `+rankOrder+`) as rank,
+					s.user_id,
+					u.name as user_name,
+					s.score,
+					s.season,
+					s.timestamp,
+					s.games_played
+				FROM scores s
+				JOIN users u ON s.user_id = u.id
+				WHERE `+where+`
+				ORDER BY `+orderBy+`
+				LIMIT ? OFFSET ?
+			), `+streakCTEs("season = ? AND user_id IN (SELECT user_id FROM page)")+`
+			SELECT
+				p.rank,
+				p.user_id,
+				p.user_name,
+				p.score,
+				p.season,
+				p.timestamp,
+				p.games_played,
+				COALESCE(st.streak_current, 0) as streak_current,
+				COALESCE(st.streak_longest, 0) as streak_longest
+			FROM page p
+			LEFT JOIN streaks st ON st.user_id = p.user_id
+			ORDER BY `+strings.ReplaceAll(orderBy, "s.", "p.")+`
+		`, args...).Scan(&entries).Error
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to query leaderboard: %w", err)
 	}
@@ -124,7 +396,12 @@ func (r *PostgresScoreRepository) GetLeaderboard(ctx context.Context, season str
 	_ = top3Debug // unused for now, kept for debugging
 
 	// Get total count for pagination
-	totalCount, err := r.CountBySeason(ctx, season)
+	var totalCount int64
+	if len(excludeUserIDs) > 0 {
+		totalCount, err = r.BaseRepository.Count(ctx, "season = ? AND user_id NOT IN (?)", season, excludeUserIDs)
+	} else {
+		totalCount, err = r.CountBySeason(ctx, season)
+	}
 	if err != nil {
 		// If count fails, use entries length as fallback
 		totalCount = int64(len(entries))
@@ -133,15 +410,605 @@ func (r *PostgresScoreRepository) GetLeaderboard(ctx context.Context, season str
 	return entries, totalCount, nil
 }
 
+// GetLeaderboardPage retrieves the leaderboard page after the given entry with keyset pagination:
+// the index on (season, score) is entered at after.Score instead of skipping OFFSET rows
+func (r *PostgresScoreRepository) GetLeaderboardPage(ctx context.Context, season, sortOrder string, after utils.CursorPosition, limit int) ([]models.LeaderboardEntry, error) {
+	if sortOrder == "asc" {
+		return nil, fmt.Errorf("keyset pagination supports only descending order")
+	}
+	rankExpr, rankArgs, err := keysetPageRank(r.rankingMethod, after)
+	if err != nil {
+		return nil, err
+	}
+
+	// Порядок лидерборда - score DESC, timestamp ASC, user_id ASC, поэтому в кортеже timestamp
+	// и user_id стоят справа: (score, ?, ?) < (?, timestamp, user_id) значит меньший счет,
+	// либо равный счет и более поздний timestamp, либо полное совпадение и больший user_id.
+	// Отдельное условие score <= ? дает планировщику диапазон по индексу
+	where := "s.season = ? " + database.SoftDeleteScoresTag +
+		" AND s.score <= ? AND (s.score, ?::timestamptz, ?::uuid) < (?, s.timestamp, s.user_id)"
+	order := "s.score DESC, s.timestamp ASC, s.user_id ASC"
+	keysetArgs := []interface{}{season, after.Score, after.Timestamp, after.UserID, after.Score}
+
+	// Серии считаются только для игроков страницы, иначе CTE прочитал бы всю историю сезона
+	args := []interface{}{season}
+	args = append(args, keysetArgs...)
+	args = append(args, limit)
+	args = append(args, keysetArgs...)
+	args = append(args, limit)
+	args = append(args, rankArgs...)
+
+	var entries []models.LeaderboardEntry
+	err = r.db.DB.WithContext(ctx).Raw(streaksCTE(`season = ? AND user_id IN (
+				SELECT s.user_id FROM scores s
+				JOIN users u ON s.user_id = u.id
+				WHERE `+where+`
+				ORDER BY `+order+`
+				LIMIT ?
+			)`)+`, page AS (
+			SELECT s.user_id, u.name AS user_name, s.score, s.season, s.timestamp, s.games_played
+			FROM scores s
+			JOIN users u ON s.user_id = u.id
+			WHERE `+where+`
+			ORDER BY `+order+`
+			LIMIT ?
+		)
+		SELECT
+			`+rankExpr+` as rank,
+			p.user_id,
+			p.user_name,
+			p.score,
+			p.season,
+			p.timestamp,
+			p.games_played,
+			COALESCE(st.streak_current, 0) as streak_current,
+			COALESCE(st.streak_longest, 0) as streak_longest
+		FROM page p
+		LEFT JOIN streaks st ON st.user_id = p.user_id
+		ORDER BY p.score DESC, p.timestamp ASC, p.user_id ASC
+	`, args...).Scan(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leaderboard page: %w", err)
+	}
+	return entries, nil
+}
+
+// GetLeaderboardWithProfiles retrieves paginated leaderboard entries together with player profile fields
+// Профиль берется тем же JOIN с users, отдельный запрос к пользователям не нужен
+func (r *PostgresScoreRepository) GetLeaderboardWithProfiles(ctx context.Context, season string, limit, offset int, sortOrder string, excludeUserIDs []uuid.UUID) ([]models.EnrichedLeaderboardEntry, int64, error) {
+	orderBy := "s.score DESC, s.timestamp ASC"
+	if sortOrder == "asc" {
+		orderBy = "s.score ASC, s.timestamp ASC"
+	}
+	rankFunc, err := rankWindowFunction(r.rankingMethod)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := "s.season = ? " + database.SoftDeleteScoresTag
+	args := []interface{}{season}
+	if len(excludeUserIDs) > 0 {
+		where += " AND s.user_id NOT IN (?)"
+		args = append(args, excludeUserIDs)
+	}
+	countArgs := append([]interface{}{}, args...)
+	args = append(args, limit, offset)
+
+	var entries []models.EnrichedLeaderboardEntry
+	err = r.db.DB.WithContext(ctx).Raw(`
+		SELECT
+			`+rankFunc+` OVER (ORDER BY s.score DESC, s.timestamp ASC) as rank,
+			s.user_id,
+			u.name as user_name,
+			s.score,
+			s.season,
+			s.timestamp,
+			COALESCE(u.avatar_url, '') as avatar_url,
+			COALESCE(u.country, '') as country,
+			COALESCE(u.tier, '') as tier
+		FROM scores s
+		JOIN users u ON s.user_id = u.id
+		WHERE `+where+`
+		ORDER BY `+orderBy+`
+		LIMIT ? OFFSET ?
+	`, args...).Scan(&entries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query leaderboard with profiles: %w", err)
+	}
+
+	// Считаем так же, как выборку: только строки с существующим пользователем
+	var totalCount int64
+	err = r.db.DB.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM scores s JOIN users u ON s.user_id = u.id WHERE `+where,
+		countArgs...).Scan(&totalCount).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count leaderboard with profiles: %w", err)
+	}
+
+	return entries, totalCount, nil
+}
+
+// GetLeaderboardByCountry retrieves paginated leaderboard entries of one country's players.
+// Фильтр по стране стоит до ранжирования, поэтому ранг - место внутри страны
+func (r *PostgresScoreRepository) GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) ([]models.LeaderboardEntry, int64, error) {
+	rankFunc, err := rankWindowFunction(r.rankingMethod)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := "s.season = ? " + database.SoftDeleteScoresTag + " AND u.country = ?"
+
+	var entries []models.LeaderboardEntry
+	err = r.db.DB.WithContext(ctx).Raw(`
+		SELECT
+			`+rankFunc+` OVER (ORDER BY s.score DESC, s.timestamp ASC) as rank,
+			s.user_id,
+			u.name as user_name,
+			s.score,
+			s.season,
+			s.timestamp,
+			s.games_played
+		FROM scores s
+		JOIN users u ON s.user_id = u.id
+		WHERE `+where+`
+		ORDER BY s.score DESC, s.timestamp ASC
+		LIMIT ? OFFSET ?
+	`, season, country, limit, offset).Scan(&entries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query country leaderboard: %w", err)
+	}
+
+	var totalCount int64
+	err = r.db.DB.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM scores s JOIN users u ON s.user_id = u.id WHERE `+where,
+		season, country).Scan(&totalCount).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count country leaderboard: %w", err)
+	}
+
+	return entries, totalCount, nil
+}
+
+// FindByMetadata retrieves the most recent scores of a season with metadata->>key = value
+// Ключ передается параметром, поэтому в SQL не подставляется пользовательский текст
+func (r *PostgresScoreRepository) FindByMetadata(ctx context.Context, season, key, value string, limit int) ([]*models.Score, error) {
+	var entities []*infrastructure.ScoreEntity
+	err := r.db.DB.WithContext(ctx).Raw(`
+		SELECT s.* FROM scores s
+		WHERE s.season = ? `+database.SoftDeleteScoresTag+` AND s.metadata->>? = ?
+		ORDER BY s.timestamp DESC
+		LIMIT ?
+	`, season, key, value, limit).Scan(&entities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find scores by metadata: %w", err)
+	}
+
+	scores := make([]*models.Score, len(entities))
+	for i, entity := range entities {
+		scores[i] = toScoreModel(entity)
+	}
+	return scores, nil
+}
+
 // CountBySeason returns the total number of scores for a given season
 // Использует переиспользуемый метод из BaseRepository
 func (r *PostgresScoreRepository) CountBySeason(ctx context.Context, season string) (int64, error) {
 	return r.BaseRepository.Count(ctx, "season = ?", season)
 }
 
+// FindAll retrieves a page of raw scores of a season ordered by score
+// Методы BaseRepository с теми же именами доступны через r.BaseRepository
+func (r *PostgresScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*models.Score, error) {
+	orderBy := "score DESC, timestamp ASC"
+	if sortOrder == "asc" {
+		orderBy = "score ASC, timestamp ASC"
+	}
+
+	var entities []*infrastructure.ScoreEntity
+	err := r.db.DB.WithContext(ctx).
+		Where("season = ?", season).
+		Order(orderBy).
+		Limit(limit).
+		Offset(offset).
+		Find(&entities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find scores: %w", err)
+	}
+
+	scores := make([]*models.Score, len(entities))
+	for i, entity := range entities {
+		scores[i] = toScoreModel(entity)
+	}
+	return scores, nil
+}
+
+// Count returns the number of scores across all seasons
+func (r *PostgresScoreRepository) Count(ctx context.Context) (int64, error) {
+	return r.BaseRepository.Count(ctx, "TRUE")
+}
+
 // DeleteByUserAndSeason removes a user's score for a specific season
+// Unscoped: у ScoreEntity есть DeletedAt, и без него GORM только пометил бы строку удаленной.
+// История отправок удаляется в той же транзакции, иначе серия пережила бы удаление результата
 func (r *PostgresScoreRepository) DeleteByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) error {
-	return r.BaseRepository.Delete(ctx, "user_id = ? AND season = ?", userID, season)
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Where("user_id = ? AND season = ?", userID, season).
+			Delete(&infrastructure.ScoreEntity{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrRecordNotFound
+		}
+		if err := tx.Exec("DELETE FROM score_history WHERE user_id = ? AND season = ?", userID, season).Error; err != nil {
+			return fmt.Errorf("failed to delete score history: %w", err)
+		}
+		return nil
+	})
+}
+
+// RevertSubmission undoes the player's latest submission in a season without recording a new one
+// Строка возвращается к previous как есть (или удаляется), игра вычитается из games_played,
+// а последняя запись score_history удаляется, чтобы откат не продлевал серию
+func (r *PostgresScoreRepository) RevertSubmission(ctx context.Context, userID uuid.UUID, season string, previous *models.Score) error {
+	return r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := r.lockUserSeason(ctx, tx, userID, season); err != nil {
+			return err
+		}
+
+		err := tx.Exec(`
+			DELETE FROM score_history
+			WHERE id = (SELECT MAX(id) FROM score_history WHERE user_id = ? AND season = ?)
+		`, userID, season).Error
+		if err != nil {
+			return fmt.Errorf("failed to revert score history: %w", err)
+		}
+
+		var result *gorm.DB
+		if previous == nil {
+			result = tx.Unscoped().
+				Where("user_id = ? AND season = ?", userID, season).
+				Delete(&infrastructure.ScoreEntity{})
+		} else {
+			metadata, err := metadataJSON(previous.Metadata)
+			if err != nil {
+				return err
+			}
+			result = tx.Exec(`
+				UPDATE scores
+				SET score = ?, metadata = ?::jsonb, timestamp = ?, games_played = GREATEST(games_played - 1, 1)
+				WHERE user_id = ? AND season = ? AND deleted_at IS NULL
+			`, previous.Score, metadata, previous.Timestamp, userID, season)
+		}
+		if result.Error != nil {
+			return fmt.Errorf("failed to revert score: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// metadataJSON кодирует metadata для параметра ?::jsonb; nil остается NULL
+func metadataJSON(metadata map[string]interface{}) (interface{}, error) {
+	if metadata == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// DeleteBySeason removes all scores for a season inside a transaction
+// Вместе с результатами удаляется история отправок сезона (по ней считаются серии)
+func (r *PostgresScoreRepository) DeleteBySeason(ctx context.Context, season string) (int64, error) {
+	var deleted int64
+	err := r.db.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("season = ?", season).Delete(&infrastructure.ScoreEntity{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		return tx.Exec("DELETE FROM score_history WHERE season = ?", season).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete season scores: %w", err)
+	}
+	return deleted, nil
+}
+
+// CopySeasonScores copies the top limit scores of fromSeason into toSeason with one INSERT ... SELECT.
+// Это простая вставка: без advisory lock, счетчика игр и записи в score_history, как при Upsert
+func (r *PostgresScoreRepository) CopySeasonScores(ctx context.Context, fromSeason, toSeason string, limit int) (int64, error) {
+	result := r.db.DB.WithContext(ctx).Exec(`
+		INSERT INTO scores (user_id, score, season, metadata, timestamp, games_played)
+		SELECT s.user_id, s.score, ?, s.metadata, s.timestamp, s.games_played
+		FROM scores s
+		WHERE s.season = ? `+database.SoftDeleteScoresTag+`
+		ORDER BY s.score DESC, s.timestamp ASC, s.user_id ASC
+		LIMIT ?
+	`, toSeason, fromSeason, limit)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to copy season scores: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetMedianScore calculates the season median with PERCENTILE_CONT, rounded to the nearest integer
+func (r *PostgresScoreRepository) GetMedianScore(ctx context.Context, season string) (int64, error) {
+	var median float64
+	err := r.db.DB.WithContext(ctx).
+		Raw(`
+			SELECT COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY s.score), 0)
+			FROM scores s
+			WHERE s.season = ? `+database.SoftDeleteScoresTag+`
+		`, season).Scan(&median).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate median score: %w", err)
+	}
+	return int64(math.Round(median)), nil
+}
+
+// GetScoreDistribution builds a score histogram of a season
+// Границы берутся из MIN/MAX, затем один GROUP BY по номеру корзины.
+// Номер считается целочисленным делением, а не width_bucket: на float границы корзин
+// для больших счетов расходились бы с NewScoreBuckets
+func (r *PostgresScoreRepository) GetScoreDistribution(ctx context.Context, season string, buckets int) ([]models.ScoreBucket, error) {
+	var bounds struct {
+		Players  int64
+		MinScore int64
+		MaxScore int64
+	}
+	err := r.db.DB.WithContext(ctx).
+		Raw(`
+			SELECT COUNT(*) AS players, COALESCE(MIN(s.score), 0) AS min_score, COALESCE(MAX(s.score), 0) AS max_score
+			FROM scores s
+			WHERE s.season = ? `+database.SoftDeleteScoresTag+`
+		`, season).Scan(&bounds).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get score range: %w", err)
+	}
+	if bounds.Players == 0 {
+		return []models.ScoreBucket{}, nil
+	}
+
+	result := models.NewScoreBuckets(bounds.MinScore, bounds.MaxScore, buckets)
+	width := bounds.MaxScore - bounds.MinScore + 1
+
+	var counts []struct {
+		Bucket int
+		Count  int64
+	}
+	err = r.db.DB.WithContext(ctx).
+		Raw(`
+			SELECT ((s.score - ?) * ?) / ? AS bucket, COUNT(*) AS count
+			FROM scores s
+			WHERE s.season = ? `+database.SoftDeleteScoresTag+` AND s.score BETWEEN ? AND ?
+			GROUP BY bucket
+		`, bounds.MinScore, len(result), width, season, bounds.MinScore, bounds.MaxScore).Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count score distribution: %w", err)
+	}
+
+	for _, c := range counts {
+		if c.Bucket >= 0 && c.Bucket < len(result) {
+			result[c.Bucket].Count = c.Count
+		}
+	}
+	return result, nil
+}
+
+// GetLeaderboardFromView retrieves paginated leaderboard entries from the leaderboard_view materialized view
+// Ранги уже посчитаны при REFRESH, поэтому запрос не использует оконные функции
+func (r *PostgresScoreRepository) GetLeaderboardFromView(ctx context.Context, season string, limit, offset int, sortOrder string) ([]models.LeaderboardEntry, int64, error) {
+	orderBy := "rank ASC, timestamp ASC"
+	if sortOrder == "asc" {
+		orderBy = "score ASC, timestamp ASC"
+	}
+
+	var entries []models.LeaderboardEntry
+	err := r.db.DB.WithContext(ctx).
+		Raw(`
+			SELECT rank, user_id, user_name, score, season, timestamp
+			FROM leaderboard_view
+			WHERE season = ?
+			ORDER BY `+orderBy+`
+			LIMIT ? OFFSET ?
+		`, season, limit, offset).Scan(&entries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query leaderboard view: %w", err)
+	}
+
+	var totalCount int64
+	err = r.db.DB.WithContext(ctx).
+		Raw(`SELECT COUNT(*) FROM leaderboard_view WHERE season = ?`, season).
+		Scan(&totalCount).Error
+	if err != nil {
+		totalCount = int64(len(entries))
+	}
+
+	return entries, totalCount, nil
+}
+
+// RefreshLeaderboardView refreshes the leaderboard_view materialized view
+// PostgreSQL не умеет обновлять materialized view частично, поэтому season не сужает REFRESH;
+// CONCURRENTLY позволяет читать view во время обновления (требует уникальный индекс)
+func (r *PostgresScoreRepository) RefreshLeaderboardView(ctx context.Context, season string) error {
+	if err := r.db.DB.WithContext(ctx).Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY leaderboard_view").Error; err != nil {
+		return fmt.Errorf("failed to refresh leaderboard view: %w", err)
+	}
+	return nil
+}
+
+// GetGlobalStandings retrieves the all-time top players across every season
+// DISTINCT ON оставляет одну лучшую строку на игрока, поэтому игрок не появляется в рейтинге дважды
+func (r *PostgresScoreRepository) GetGlobalStandings(ctx context.Context, limit int) ([]models.LeaderboardEntry, int64, error) {
+	rankFunc, err := rankWindowFunction(r.rankingMethod)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var entries []models.LeaderboardEntry
+	err = r.db.DB.WithContext(ctx).
+		Raw(`
+			SELECT
+				`+rankFunc+` OVER (ORDER BY b.score DESC, b.timestamp ASC) as rank,
+				b.user_id,
+				u.name as user_name,
+				b.score,
+				b.season,
+				b.timestamp
+			FROM (
+				SELECT DISTINCT ON (s.user_id) s.user_id, s.score, s.season, s.timestamp
+				FROM scores s
+				WHERE TRUE `+database.SoftDeleteScoresTag+`
+				ORDER BY s.user_id, s.score DESC, s.timestamp ASC
+			) b
+			JOIN users u ON b.user_id = u.id
+			ORDER BY b.score DESC, b.timestamp ASC
+			LIMIT ?
+		`, limit).Scan(&entries).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query global standings: %w", err)
+	}
+
+	var totalCount int64
+	err = r.db.DB.WithContext(ctx).
+		Raw(`SELECT COUNT(DISTINCT s.user_id) FROM scores s WHERE TRUE ` + database.SoftDeleteScoresTag).
+		Scan(&totalCount).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count global standings: %w", err)
+	}
+
+	return entries, totalCount, nil
+}
+
+// GetCrossSeasonRankings ranks players over several seasons by normalized score
+// stats считает максимум и минимум каждого сезона, normalized переводит счет в 0..100 внутри сезона,
+// totals усредняет по числу запрошенных сезонов: сезон без счета дает игроку 0
+func (r *PostgresScoreRepository) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]models.CrossSeasonEntry, error) {
+	if len(seasons) == 0 {
+		return []models.CrossSeasonEntry{}, nil
+	}
+	rankFunc, err := rankWindowFunction(r.rankingMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Rank            int
+		UserID          uuid.UUID
+		UserName        string
+		NormalizedScore float64
+		SeasonScores    string
+	}
+	err = r.db.DB.WithContext(ctx).
+		Raw(`
+			WITH stats AS (
+				SELECT s.season, MAX(s.score) AS max_score, MIN(s.score) AS min_score
+				FROM scores s
+				WHERE s.season IN ? `+database.SoftDeleteScoresTag+`
+				GROUP BY s.season
+			), normalized AS (
+				SELECT s.user_id, s.season, s.score,
+					CASE
+						WHEN ? THEN
+							CASE WHEN st.max_score > 0 THEN s.score * 100.0 / st.max_score ELSE 0 END
+						ELSE
+							CASE WHEN st.max_score > st.min_score
+								THEN (s.score - st.min_score) * 100.0 / (st.max_score - st.min_score)
+								ELSE 100 END
+					END AS normalized
+				FROM scores s
+				JOIN stats st ON st.season = s.season
+				WHERE s.season IN ? `+database.SoftDeleteScoresTag+`
+			), totals AS (
+				SELECT user_id,
+					ROUND((SUM(normalized) / ?)::numeric, 2)::float8 AS normalized_score,
+					jsonb_object_agg(season, score) AS season_scores
+				FROM normalized
+				GROUP BY user_id
+			)
+			SELECT
+				`+rankFunc+` OVER (ORDER BY t.normalized_score DESC) as rank,
+				t.user_id,
+				u.name as user_name,
+				t.normalized_score,
+				t.season_scores::text as season_scores
+			FROM totals t
+			JOIN users u ON t.user_id = u.id
+			ORDER BY t.normalized_score DESC, t.user_id
+			LIMIT ?
+		`, seasons, normalizeByMax, seasons, len(seasons), limit).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cross-season rankings: %w", err)
+	}
+
+	entries := make([]models.CrossSeasonEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = models.CrossSeasonEntry{
+			Rank:            row.Rank,
+			UserID:          row.UserID,
+			UserName:        row.UserName,
+			NormalizedScore: row.NormalizedScore,
+		}
+		if err := json.Unmarshal([]byte(row.SeasonScores), &entries[i].SeasonScores); err != nil {
+			return nil, fmt.Errorf("failed to decode season scores: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+// GetUserRank computes one player's rank with the same ordering as GetLeaderboard
+// Ранг = число различных (score, timestamp) выше игрока + 1, что совпадает с DENSE_RANK в GetLeaderboard,
+// но использует индекс по (season, score) вместо ранжирования всего сезона
+func (r *PostgresScoreRepository) GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*models.LeaderboardEntry, error) {
+	ahead, err := rankAheadCount(r.rankingMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []models.LeaderboardEntry
+	err = r.db.DB.WithContext(ctx).
+		Raw(`
+			SELECT
+				(`+ahead+`) + 1 as rank,
+				s.user_id,
+				u.name as user_name,
+				s.score,
+				s.season,
+				s.timestamp
+			FROM scores s
+			JOIN users u ON s.user_id = u.id
+			WHERE s.season = ? AND s.user_id = ? `+database.SoftDeleteScoresTag+`
+		`, season, userID).Scan(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user rank: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, repository.ErrRecordNotFound
+	}
+	return &entries[0], nil
+}
+
+// GetStreak computes the player's current and longest daily submission streak in a season from score_history
+func (r *PostgresScoreRepository) GetStreak(ctx context.Context, userID uuid.UUID, season string) (int, int, error) {
+	var streak struct {
+		StreakCurrent int
+		StreakLongest int
+	}
+	err := r.db.DB.WithContext(ctx).
+		Raw(streaksCTE("user_id = ? AND season = ?")+`
+			SELECT streak_current, streak_longest FROM streaks
+		`, userID, season).Scan(&streak).Error
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query streak: %w", err)
+	}
+	return streak.StreakCurrent, streak.StreakLongest, nil
 }
 
 // FindBySpec finds scores matching a specification
@@ -161,3 +1028,16 @@ func (r *PostgresScoreRepository) CountBySpec(ctx context.Context, spec reposito
 	// Временно возвращаем 0 до полной миграции спецификаций
 	return 0, nil
 }
+
+// toScoreModel конвертирует entity -> domain -> API модель
+func toScoreModel(entity *infrastructure.ScoreEntity) *models.Score {
+	domainScore := entity.ToDomain()
+	return &models.Score{
+		ID:        domainScore.ID,
+		UserID:    domainScore.UserID,
+		Score:     domainScore.Score,
+		Season:    domainScore.Season,
+		Metadata:  domainScore.Metadata,
+		Timestamp: domainScore.Timestamp,
+	}
+}
@@ -0,0 +1,470 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDryRunScoreRepository создает репозиторий поверх GORM в режиме DryRun
+// и возвращает указатель на последний сгенерированный SQL
+func newDryRunScoreRepository(t *testing.T) (*PostgresScoreRepository, *string) {
+	t.Helper()
+
+	sqlDB, err := sql.Open("pgx", "postgres://localhost:5432/dryrun")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: dryRunPool{sqlDB}}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true, // Create иначе открывает транзакцию и идет в БД
+	})
+	require.NoError(t, err)
+
+	var lastSQL string
+	capture := func(tx *gorm.DB) { lastSQL = tx.Statement.SQL.String() }
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:capture_create", capture))
+
+	repo := NewPostgresScoreRepository(&database.PostgresDB{DB: db}).(*PostgresScoreRepository)
+	// DryRun не возвращает результат SELECT, поэтому блокировка считается взятой
+	repo.tryLock = func(tx *gorm.DB, userID uuid.UUID, season string) (bool, error) { return true, nil }
+	return repo, &lastSQL
+}
+
+// dryRunPool открывает "транзакции" без обращения к БД, чтобы Transaction работал в DryRun
+type dryRunPool struct {
+	*sql.DB
+}
+
+func (p dryRunPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return &dryRunTx{p.DB}, nil
+}
+
+// dryRunTx - "транзакция" dryRunPool; COMMIT и ROLLBACK ничего не делают
+type dryRunTx struct {
+	*sql.DB
+}
+
+func (t *dryRunTx) Commit() error   { return nil }
+func (t *dryRunTx) Rollback() error { return nil }
+
+func TestPostgresScoreRepository_UpsertOnlyIfHigher_SQL(t *testing.T) {
+	repo, lastSQL := newDryRunScoreRepository(t)
+
+	_, err := repo.UpsertOnlyIfHigher(context.Background(), &models.Score{
+		UserID: uuid.New(),
+		Score:  500,
+		Season: "global",
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, *lastSQL, `ON CONFLICT ("user_id","season") DO UPDATE SET`)
+	assert.Contains(t, *lastSQL, "WHERE EXCLUDED.score > scores.score OR scores.deleted_at IS NOT NULL")
+}
+
+func TestPostgresScoreRepository_Upsert_CountsGames(t *testing.T) {
+	repo, lastSQL := newDryRunScoreRepository(t)
+
+	err := repo.Upsert(context.Background(), &models.Score{UserID: uuid.New(), Score: 500, Season: "global"})
+
+	require.NoError(t, err)
+	assert.Contains(t, *lastSQL, `"games_played"=CASE WHEN scores.deleted_at IS NULL THEN scores.games_played + 1 ELSE 1 END`)
+	// Отправка в мягко удаленную строку восстанавливает ее
+	assert.Contains(t, *lastSQL, `"deleted_at"=NULL`)
+}
+
+func TestPostgresScoreRepository_Upsert_RetriesLockThenFails(t *testing.T) {
+	repo, lastSQL := newDryRunScoreRepository(t)
+	attempts := 0
+	repo.tryLock = func(tx *gorm.DB, userID uuid.UUID, season string) (bool, error) {
+		attempts++
+		return false, nil
+	}
+
+	err := repo.Upsert(context.Background(), &models.Score{UserID: uuid.New(), Score: 500, Season: "global"})
+
+	assert.ErrorIs(t, err, repository.ErrScoreLocked)
+	assert.Equal(t, scoreLockRetries+1, attempts)
+	assert.Empty(t, *lastSQL, "the score must not be written without the lock")
+}
+
+func TestPostgresScoreRepository_Upsert_TakesLockBeforeWrite(t *testing.T) {
+	repo, lastSQL := newDryRunScoreRepository(t)
+	var lockedSeason string
+	repo.tryLock = func(tx *gorm.DB, userID uuid.UUID, season string) (bool, error) {
+		lockedSeason = season
+		return true, nil
+	}
+
+	require.NoError(t, repo.Upsert(context.Background(), &models.Score{UserID: uuid.New(), Score: 500, Season: "winter"}))
+	assert.Equal(t, "winter", lockedSeason)
+	assert.Contains(t, *lastSQL, "ON CONFLICT")
+}
+
+func TestLeaderboardOrder(t *testing.T) {
+	tests := []struct {
+		sortBy, sortOrder string
+		rank, page        string
+	}{
+		{"", "desc", "s.score DESC, s.timestamp ASC", "s.score DESC, s.timestamp ASC, s.user_id ASC"},
+		{models.SortByScore, "asc", "s.score DESC, s.timestamp ASC", "s.score ASC, s.timestamp ASC, s.user_id ASC"},
+		{models.SortByTimestamp, "desc", "s.timestamp DESC, s.score DESC", "s.timestamp DESC, s.score DESC, s.user_id ASC"},
+		{models.SortByGamesPlayed, "asc", "s.games_played DESC, s.score DESC, s.timestamp ASC", "s.games_played ASC, s.score DESC, s.timestamp ASC, s.user_id ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy+"_"+tt.sortOrder, func(t *testing.T) {
+			rank, page := leaderboardOrder(tt.sortBy, tt.sortOrder)
+			assert.Equal(t, tt.rank, rank)
+			assert.Equal(t, tt.page, page)
+		})
+	}
+}
+
+func TestPostgresScoreRepository_GetLeaderboard_RankingMethod(t *testing.T) {
+	tests := []struct {
+		method, window, ahead string
+	}{
+		{config.RankingMethodDense, "DENSE_RANK() OVER", "SELECT DISTINCT o.score, o.timestamp"},
+		{config.RankingMethodCompetition, "RANK() OVER", "SELECT COUNT(*) FROM scores o"},
+		{config.RankingMethodOrdinal, "ROW_NUMBER() OVER", "o.user_id < s.user_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			repo, _ := newDryRunScoreRepository(t)
+			repo.rankingMethod = tt.method
+			var querySQL string
+			require.NoError(t, repo.db.DB.Callback().Row().After("gorm:row").Register("test:capture_row", func(tx *gorm.DB) {
+				querySQL = tx.Statement.SQL.String()
+			}))
+
+			// DryRun не возвращает строк, поэтому проверяется только сгенерированный SQL
+			_, _, _ = repo.GetLeaderboard(context.Background(), "global", 10, 0, "", "desc", nil)
+
+			assert.Contains(t, querySQL, tt.window)
+			if tt.method != config.RankingMethodDense {
+				assert.NotContains(t, querySQL, "DENSE_RANK")
+			}
+
+			// Общий зачет и ранг одного игрока считаются тем же методом
+			_, _, _ = repo.GetGlobalStandings(context.Background(), 10)
+			assert.Contains(t, querySQL, tt.window)
+
+			_, _ = repo.GetUserRank(context.Background(), uuid.New(), "global")
+			assert.Contains(t, querySQL, tt.ahead)
+			if tt.method != config.RankingMethodDense {
+				assert.NotContains(t, querySQL, "DISTINCT")
+			}
+		})
+	}
+}
+
+func TestPostgresScoreRepository_GetCrossSeasonRankings_Query(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var querySQL string
+	require.NoError(t, repo.db.DB.Callback().Row().After("gorm:row").Register("test:capture_row", func(tx *gorm.DB) {
+		querySQL = tx.Statement.SQL.String()
+	}))
+
+	_, _ = repo.GetCrossSeasonRankings(context.Background(), []string{"2024_01", "2024_02"}, true, 10)
+
+	// Средний балл считается по всем запрошенным сезонам, сезоны одного игрока собираются в JSON
+	assert.Contains(t, querySQL, "jsonb_object_agg")
+	assert.Contains(t, querySQL, "DENSE_RANK() OVER")
+	assert.Contains(t, querySQL, database.SoftDeleteScoresTag)
+}
+
+func TestPostgresScoreRepository_GetLeaderboardPage_Keyset(t *testing.T) {
+	after := utils.CursorPosition{
+		Rank:      38,
+		Offset:    40,
+		Score:     500,
+		Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		UserID:    uuid.New(),
+	}
+
+	// Ранг страницы достраивается от курсора: позиция для ordinal, ранг группы курсора
+	// для competition и ранг курсора с поправкой на его группу для dense
+	tests := []struct {
+		method   string
+		rankExpr string
+		rankVars []interface{}
+	}{
+		{config.RankingMethodDense, "DENSE_RANK() OVER", []interface{}{38, int64(500), after.Timestamp}},
+		{config.RankingMethodCompetition, "THEN $16 ELSE $17 + RANK() OVER", []interface{}{int64(500), after.Timestamp, 38, 40}},
+		{config.RankingMethodOrdinal, "ROW_NUMBER() OVER (ORDER BY p.score DESC, p.timestamp ASC, p.user_id ASC)", []interface{}{40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			repo, _ := newDryRunScoreRepository(t)
+			repo.rankingMethod = tt.method
+			var querySQL string
+			var vars []interface{}
+			require.NoError(t, repo.db.DB.Callback().Row().After("gorm:row").Register("test:capture_row", func(tx *gorm.DB) {
+				querySQL = tx.Statement.SQL.String()
+				vars = tx.Statement.Vars
+			}))
+
+			_, _ = repo.GetLeaderboardPage(context.Background(), "global", "desc", after, 20)
+
+			// Полные совпадения (score, timestamp) разделяются по user_id, иначе они выпали бы со страниц
+			assert.Contains(t, querySQL, "(s.score, $4::timestamptz, $5::uuid) < ($6, s.timestamp, s.user_id)")
+			assert.Contains(t, querySQL, "ORDER BY s.score DESC, s.timestamp ASC, s.user_id ASC")
+			assert.NotContains(t, querySQL, "OFFSET")
+			assert.Contains(t, querySQL, tt.rankExpr)
+			require.GreaterOrEqual(t, len(vars), len(tt.rankVars))
+			assert.Equal(t, tt.rankVars, vars[len(vars)-len(tt.rankVars):])
+		})
+	}
+
+	repo, _ := newDryRunScoreRepository(t)
+	_, err := repo.GetLeaderboardPage(context.Background(), "global", "asc", after, 20)
+	assert.Error(t, err)
+}
+
+func TestPostgresScoreRepository_GetLeaderboard_InvalidRankingMethod(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	repo.rankingMethod = "olympic"
+
+	_, _, err := repo.GetLeaderboard(context.Background(), "global", 10, 0, "", "desc", nil)
+	assert.ErrorIs(t, err, config.ErrInvalidRankingMethod)
+
+	_, _, err = repo.GetLeaderboardWithProfiles(context.Background(), "global", 10, 0, "desc", nil)
+	assert.ErrorIs(t, err, config.ErrInvalidRankingMethod)
+}
+
+func TestPostgresScoreRepository_Upsert_RecordsHistory(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var statements []string
+	require.NoError(t, repo.db.DB.Callback().Raw().After("gorm:raw").Register("test:capture_raw", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	score := &models.Score{UserID: uuid.New(), Score: 500, Season: "global"}
+	require.NoError(t, repo.Upsert(context.Background(), score))
+	_, err := repo.UpsertOnlyIfHigher(context.Background(), score)
+	require.NoError(t, err)
+
+	require.Len(t, statements, 2, "every submission is recorded, including one that keeps the old score")
+	for _, sql := range statements {
+		assert.Contains(t, sql, "INSERT INTO score_history")
+	}
+}
+
+func TestPostgresScoreRepository_Streaks_SQL(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var querySQL string
+	require.NoError(t, repo.db.DB.Callback().Row().After("gorm:row").Register("test:capture_row", func(tx *gorm.DB) {
+		querySQL = tx.Statement.SQL.String()
+	}))
+
+	// DryRun не возвращает строк, проверяется только сгенерированный SQL
+	_, _, _ = repo.GetStreak(context.Background(), uuid.New(), "global")
+	assert.Contains(t, querySQL, "LAG(day) OVER (PARTITION BY user_id ORDER BY day)")
+	assert.Contains(t, querySQL, "WHERE user_id = $1 AND season = $2")
+
+	_, _, _ = repo.GetLeaderboard(context.Background(), "global", 10, 0, "", "desc", nil)
+	// История читается только для игроков страницы
+	assert.Contains(t, querySQL, "LEFT JOIN streaks st ON st.user_id = p.user_id")
+	assert.Contains(t, querySQL, "WHERE season = $4 AND user_id IN (SELECT user_id FROM page)")
+	assert.Contains(t, querySQL, "ORDER BY p.score DESC, p.timestamp ASC, p.user_id ASC")
+}
+
+func TestPostgresScoreRepository_SoftDeletePlugin_SQL(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var querySQL string
+	require.NoError(t, repo.db.DB.Callback().Row().After("gorm:row").Register("test:capture_row", func(tx *gorm.DB) {
+		querySQL = tx.Statement.SQL.String()
+	}))
+	excluded := []uuid.UUID{uuid.New()}
+
+	// Без плагина метка остается комментарием
+	_, _, _ = repo.GetLeaderboard(context.Background(), "global", 10, 0, "", "desc", excluded)
+	assert.Contains(t, querySQL, "s.season = $1 "+database.SoftDeleteScoresTag+" AND s.user_id NOT IN ($2)")
+
+	require.NoError(t, repo.db.DB.Use(database.SoftDeleteScorePlugin{}))
+
+	_, _, _ = repo.GetLeaderboard(context.Background(), "global", 10, 0, "", "desc", excluded)
+	assert.Contains(t, querySQL, "s.season = $1 AND s.deleted_at IS NULL AND s.user_id NOT IN ($2)")
+
+	_, _, _ = repo.GetLeaderboardWithProfiles(context.Background(), "global", 10, 0, "desc", nil)
+	assert.Contains(t, querySQL, "WHERE s.season = $1 AND s.deleted_at IS NULL")
+
+	_, _ = repo.GetUserRank(context.Background(), uuid.New(), "global")
+	assert.Contains(t, querySQL, "WHERE s.season = $1 AND s.user_id = $2 AND s.deleted_at IS NULL")
+}
+
+func TestPostgresScoreRepository_SoftDeleteCoversAllReads(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	require.NoError(t, repo.db.DB.Use(database.SoftDeleteScorePlugin{}))
+	var statements []string
+	collect := func(tx *gorm.DB) { statements = append(statements, tx.Statement.SQL.String()) }
+	require.NoError(t, repo.db.DB.Callback().Row().After("gorm:row").Register("test:collect_row", collect))
+	require.NoError(t, repo.db.DB.Callback().Query().After("gorm:query").Register("test:collect_query", collect))
+	ctx := context.Background()
+
+	reads := map[string]func(){
+		"GetUserRank":          func() { _, _ = repo.GetUserRank(ctx, uuid.New(), "global") },
+		"GetMedianScore":       func() { _, _ = repo.GetMedianScore(ctx, "global") },
+		"GetScoreDistribution": func() { _, _ = repo.GetScoreDistribution(ctx, "global", 10) },
+		"GetGlobalStandings":   func() { _, _, _ = repo.GetGlobalStandings(ctx, 10) },
+		"CountBySeason":        func() { _, _ = repo.CountBySeason(ctx, "global") },
+		"Count":                func() { _, _ = repo.Count(ctx) },
+		"FindAll":              func() { _, _ = repo.FindAll(ctx, "global", "desc", 10, 0) },
+		"FindByUserAndSeason":  func() { _, _ = repo.FindByUserAndSeason(ctx, uuid.New(), "global") },
+	}
+	for name, read := range reads {
+		t.Run(name, func(t *testing.T) {
+			statements = nil
+			read()
+			require.NotEmpty(t, statements)
+			for _, sql := range statements {
+				assert.Regexp(t, `deleted_at"? IS NULL`, sql)
+			}
+		})
+	}
+
+	// Ранг считается только среди живых счетов: фильтр есть и у подзапроса o
+	statements = nil
+	_, _ = repo.GetUserRank(ctx, uuid.New(), "global")
+	assert.Contains(t, statements[0], "o.deleted_at IS NULL")
+}
+
+func TestPostgresScoreRepository_DeletesStayHard(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var statements []string
+	require.NoError(t, repo.db.DB.Callback().Delete().After("gorm:delete").Register("test:collect_delete", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	// DryRun не удаляет строк, поэтому удаление сообщает о не найденном счете
+	assert.ErrorIs(t, repo.DeleteByUserAndSeason(context.Background(), uuid.New(), "global"), repository.ErrRecordNotFound)
+	_, _ = repo.DeleteBySeason(context.Background(), "global")
+
+	require.Len(t, statements, 2)
+	for _, sql := range statements {
+		assert.True(t, strings.HasPrefix(sql, `DELETE FROM "scores"`), sql)
+	}
+}
+
+func TestPostgresScoreRepository_DeleteBySeason_DropsHistory(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var statements []string
+	var vars []interface{}
+	require.NoError(t, repo.db.DB.Callback().Raw().After("gorm:raw").Register("test:capture_raw", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+		vars = tx.Statement.Vars
+	}))
+
+	_, err := repo.DeleteBySeason(context.Background(), "s1")
+	require.NoError(t, err)
+
+	// Сброс сезона не должен оставлять историю, по которой считались бы серии
+	require.Len(t, statements, 1)
+	assert.Equal(t, "DELETE FROM score_history WHERE season = $1", statements[0])
+	assert.Equal(t, []interface{}{"s1"}, vars)
+}
+
+func TestPostgresScoreRepository_CopySeasonScores_PlainInsert(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var statements []string
+	var vars []interface{}
+	require.NoError(t, repo.db.DB.Callback().Raw().After("gorm:raw").Register("test:capture_raw", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+		vars = tx.Statement.Vars
+	}))
+
+	_, _ = repo.CopySeasonScores(context.Background(), "2024-spring", "2024-summer", 50)
+
+	// Одна вставка без ON CONFLICT и без score_history: копия не считается новой игрой
+	require.Len(t, statements, 1)
+	assert.Contains(t, statements[0], "s.metadata, s.timestamp, s.games_played")
+	assert.Contains(t, statements[0], database.SoftDeleteScoresTag)
+	assert.NotContains(t, statements[0], "ON CONFLICT")
+	assert.NotContains(t, statements[0], "score_history")
+	assert.Equal(t, []interface{}{"2024-summer", "2024-spring", 50}, vars)
+}
+
+func TestPostgresScoreRepository_RevertSubmission_RestoresWithoutUpsert(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var statements []string
+	require.NoError(t, repo.db.DB.Callback().Raw().After("gorm:raw").Register("test:capture_raw", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+
+	previous := &models.Score{Score: 100, Metadata: map[string]interface{}{"level": "1"}, Timestamp: time.Now()}
+	// DryRun не затрагивает строк, поэтому откат заканчивается ErrRecordNotFound
+	err := repo.RevertSubmission(context.Background(), uuid.New(), "global", previous)
+	assert.ErrorIs(t, err, repository.ErrRecordNotFound)
+
+	// Удаляется последняя запись истории, строка перезаписывается с вычетом игры, без ON CONFLICT
+	require.Len(t, statements, 2)
+	assert.Contains(t, statements[0], "DELETE FROM score_history")
+	assert.Contains(t, statements[1], "games_played = GREATEST(games_played - 1, 1)")
+	assert.NotContains(t, statements[1], "ON CONFLICT")
+	assert.NotContains(t, statements[1], "INSERT")
+}
+
+func TestPostgresScoreRepository_FindByMetadata_SQL(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var querySQL string
+	require.NoError(t, repo.db.DB.Callback().Row().After("gorm:row").Register("test:capture_row", func(tx *gorm.DB) {
+		querySQL = tx.Statement.SQL.String()
+	}))
+
+	_, _ = repo.FindByMetadata(context.Background(), "global", "simulated", "true", 50)
+
+	// Ключ metadata - параметр запроса, а не часть текста SQL
+	assert.Contains(t, querySQL, "WHERE s.season = $1 "+database.SoftDeleteScoresTag+" AND s.metadata->>$2 = $3")
+	assert.Contains(t, querySQL, "ORDER BY s.timestamp DESC")
+	assert.Contains(t, querySQL, "LIMIT $4")
+	assert.NotContains(t, querySQL, "simulated")
+}
+
+func TestPostgresScoreRepository_GetLeaderboardByCountry_SQL(t *testing.T) {
+	repo, _ := newDryRunScoreRepository(t)
+	var querySQL string
+	require.NoError(t, repo.db.DB.Callback().Row().After("gorm:row").Register("test:capture_row", func(tx *gorm.DB) {
+		querySQL = tx.Statement.SQL.String()
+	}))
+
+	_, _, _ = repo.GetLeaderboardByCountry(context.Background(), "DE", "global", 10, 20)
+
+	// Страна фильтруется до ранжирования, поэтому ранг считается внутри страны
+	assert.Contains(t, querySQL, "JOIN users u ON s.user_id = u.id")
+	assert.Contains(t, querySQL, "WHERE s.season = $1 "+database.SoftDeleteScoresTag+" AND u.country = $2")
+	assert.Contains(t, querySQL, "LIMIT $3 OFFSET $4")
+}
+
+func TestPostgresScoreRepository_UpsertOnlyIfHigher_TakesLock(t *testing.T) {
+	repo, lastSQL := newDryRunScoreRepository(t)
+	attempts := 0
+	repo.tryLock = func(tx *gorm.DB, userID uuid.UUID, season string) (bool, error) {
+		attempts++
+		return false, nil
+	}
+
+	improved, err := repo.UpsertOnlyIfHigher(context.Background(), &models.Score{UserID: uuid.New(), Score: 500, Season: "global"})
+
+	assert.ErrorIs(t, err, repository.ErrScoreLocked)
+	assert.False(t, improved)
+	assert.Equal(t, scoreLockRetries+1, attempts)
+	assert.Empty(t, *lastSQL, "the score must not be written without the lock")
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/strategy"
+
+	"gorm.io/gorm"
+)
+
+// scoringConfigRow - строка таблицы scoring_config
+type scoringConfigRow struct {
+	DifficultyMultiplier float64
+	ComboBonus           float64
+}
+
+// PostgresScoringConfigRepository is a PostgreSQL implementation of strategy.ScoreConfigRepository
+type PostgresScoringConfigRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresScoringConfigRepository creates a new PostgreSQL scoring config repository
+func NewPostgresScoringConfigRepository(db *database.PostgresDB) strategy.ScoreConfigRepository {
+	return &PostgresScoringConfigRepository{db: db}
+}
+
+// GetMultipliers retrieves scoring multipliers for a season and game mode
+// Отсутствие строки не считается ошибкой: стратегия сама подставит значения по умолчанию
+func (r *PostgresScoringConfigRepository) GetMultipliers(ctx context.Context, season, gameMode string) (*strategy.ScoringMultipliers, error) {
+	var row scoringConfigRow
+	err := r.db.DB.WithContext(ctx).
+		Table("scoring_config").
+		Select("difficulty_multiplier, combo_bonus").
+		Where("season = ? AND game_mode = ?", season, gameMode).
+		Take(&row).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get scoring multipliers: %w", err)
+	}
+
+	return &strategy.ScoringMultipliers{
+		DifficultyMultiplier: row.DifficultyMultiplier,
+		ComboBonus:           row.ComboBonus,
+	}, nil
+}
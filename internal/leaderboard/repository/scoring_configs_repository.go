@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+
+	"gorm.io/gorm/clause"
+)
+
+// PostgresScoringConfigEntryRepository is a PostgreSQL implementation of ScoringConfigRepository
+// Хранит правила подсчета очков в таблице scoring_configs (ключ-значение по сезонам)
+type PostgresScoringConfigEntryRepository struct {
+	*repository.BaseRepository[models.ScoringConfigEntry]
+	db *database.PostgresDB
+}
+
+// NewPostgresScoringConfigEntryRepository creates a new PostgreSQL scoring rules repository
+func NewPostgresScoringConfigEntryRepository(db *database.PostgresDB) repository.ScoringConfigRepository {
+	return &PostgresScoringConfigEntryRepository{
+		BaseRepository: repository.NewBaseRepository[models.ScoringConfigEntry](db),
+		db:             db,
+	}
+}
+
+// Get retrieves a rule for a season
+func (r *PostgresScoringConfigEntryRepository) Get(ctx context.Context, key, season string) (*models.ScoringConfigEntry, error) {
+	return r.BaseRepository.FindOne(ctx, "key = ? AND season = ?", key, season)
+}
+
+// FindAll retrieves every rule of every season
+func (r *PostgresScoringConfigEntryRepository) FindAll(ctx context.Context) ([]*models.ScoringConfigEntry, error) {
+	return r.BaseRepository.FindAll(ctx, "TRUE")
+}
+
+// GetValidationConfig retrieves the score limits of a season
+// Одним запросом читаются строки сезона и общие строки с пустым season, сливаются они в модели
+func (r *PostgresScoringConfigEntryRepository) GetValidationConfig(ctx context.Context, season string) (*models.ValidationConfig, error) {
+	entries, err := r.BaseRepository.FindAll(ctx, "key IN ? AND season IN ?",
+		[]string{models.ScoringConfigMinScore, models.ScoringConfigMaxScore}, []string{season, ""})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validation config: %w", err)
+	}
+	return models.NewValidationConfig(season, entries), nil
+}
+
+// Upsert creates or replaces a rule
+func (r *PostgresScoringConfigEntryRepository) Upsert(ctx context.Context, entry *models.ScoringConfigEntry) error {
+	entry.UpdatedAt = time.Now()
+	err := r.db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}, {Name: "season"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(entry).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert scoring config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// AchievementBadgeName is the badge awarded for reaching a score threshold, e.g. "score_10000"
+func AchievementBadgeName(threshold int64) string {
+	return fmt.Sprintf("score_%d", threshold)
+}
+
+// SetAchievementRepository enables score milestone badges: a stored score that reaches one of
+// the thresholds awards its badge once per player and season
+func (s *LeaderboardService) SetAchievementRepository(repo repository.AchievementRepository, thresholds []int) {
+	sorted := make([]int64, 0, len(thresholds))
+	for _, t := range thresholds {
+		if t > 0 {
+			sorted = append(sorted, int64(t))
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	s.achievements = repo
+	s.achievementThresholds = sorted
+}
+
+// GetAchievements returns the badges a player has earned in a season, oldest first.
+// Returns an empty list when achievements are not enabled.
+func (s *LeaderboardService) GetAchievements(ctx context.Context, userID uuid.UUID, season string) ([]models.Achievement, error) {
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+	if s.achievements == nil {
+		return []models.Achievement{}, nil
+	}
+
+	found, err := s.achievements.FindByUserAndSeason(ctx, userID, season)
+	if err != nil {
+		return nil, utils.DatabaseError("achievements lookup", err)
+	}
+	achievements := make([]models.Achievement, len(found))
+	for i, a := range found {
+		achievements[i] = *a
+	}
+	return achievements, nil
+}
+
+// awardAchievements awards the badges of every threshold the stored score reaches and announces
+// the new ones. Errors are logged: a failed award must not fail a score that is already stored.
+func (s *LeaderboardService) awardAchievements(ctx context.Context, logger *zerolog.Logger, userID uuid.UUID, season string, score int64) {
+	if s.achievements == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, threshold := range s.achievementThresholds {
+		if score < threshold {
+			break
+		}
+
+		achievement := &models.Achievement{
+			UserID:    userID,
+			BadgeName: AchievementBadgeName(threshold),
+			Season:    season,
+			AwardedAt: now,
+		}
+		awarded, err := s.achievements.Award(ctx, achievement)
+		if err != nil {
+			logger.Error().Err(err).Str("user_id", userID.String()).Str("badge", achievement.BadgeName).Msg("Failed to award achievement")
+			continue
+		}
+		if !awarded {
+			continue
+		}
+
+		logger.Info().Str("user_id", userID.String()).Str("badge", achievement.BadgeName).Str("season", season).Msg("🏅 Achievement awarded")
+		if s.notifications != nil {
+			if err := s.notifications.NotifyAchievement(ctx, achievement); err != nil {
+				logger.Error().Err(err).Str("user_id", userID.String()).Str("badge", achievement.BadgeName).Msg("Failed to send achievement notification")
+			}
+		}
+	}
+}
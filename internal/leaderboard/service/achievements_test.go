@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAchievementRepository keeps badges in insertion order
+type memoryAchievementRepository struct {
+	awarded []*models.Achievement
+	failing bool
+}
+
+func (r *memoryAchievementRepository) Award(ctx context.Context, achievement *models.Achievement) (bool, error) {
+	if r.failing {
+		return false, errors.New("connection reset")
+	}
+	for _, a := range r.awarded {
+		if a.UserID == achievement.UserID && a.Season == achievement.Season && a.BadgeName == achievement.BadgeName {
+			return false, nil
+		}
+	}
+	stored := *achievement
+	r.awarded = append(r.awarded, &stored)
+	return true, nil
+}
+
+func (r *memoryAchievementRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) ([]*models.Achievement, error) {
+	var found []*models.Achievement
+	for _, a := range r.awarded {
+		if a.UserID == userID && a.Season == season {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+func newAchievementService(t *testing.T) (*LeaderboardService, *memoryAchievementRepository) {
+	t.Helper()
+	cfg := &config.Config{Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000}}
+	// GetUserRank is needed once a notification service is set
+	svc := NewLeaderboardService(&rankedScoreRepository{newMemoryScoreRepository()}, nil, nil, cfg)
+	achievements := &memoryAchievementRepository{}
+	svc.SetAchievementRepository(achievements, []int{100000, 10000, 50000, 0})
+	return svc, achievements
+}
+
+func badgeNames(achievements []models.Achievement) []string {
+	names := make([]string, len(achievements))
+	for i, a := range achievements {
+		names[i] = a.BadgeName
+	}
+	return names
+}
+
+func TestSubmitScore_AwardsAchievementsOnce(t *testing.T) {
+	svc, _ := newAchievementService(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// Crossing two thresholds at once awards both badges
+	_, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 60000})
+	require.NoError(t, err)
+
+	achievements, err := svc.GetAchievements(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"score_10000", "score_50000"}, badgeNames(achievements))
+
+	// Staying above a threshold does not award its badge again
+	_, err = svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 70000})
+	require.NoError(t, err)
+	_, err = svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 100000})
+	require.NoError(t, err)
+
+	achievements, err = svc.GetAchievements(ctx, userID, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"score_10000", "score_50000", "score_100000"}, badgeNames(achievements))
+
+	// Badges are per season
+	achievements, err = svc.GetAchievements(ctx, userID, "winter")
+	require.NoError(t, err)
+	assert.Empty(t, achievements)
+}
+
+func TestSubmitScore_NotifiesNewAchievements(t *testing.T) {
+	svc, _ := newAchievementService(t)
+	notifier := new(MockNotificationStrategy)
+	svc.SetNotificationService(NewNotificationService(notifier, nil))
+	ctx := context.Background()
+	userID := uuid.New()
+
+	notifier.On("Send", mock.Anything, mock.MatchedBy(func(n *strategy.Notification) bool {
+		return n.UserID == userID && n.Type == NotificationTypeAchievement && n.Data["badge_name"] == "score_10000"
+	})).Return(nil).Once()
+
+	_, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 20000})
+	require.NoError(t, err)
+	_, err = svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 30000})
+	require.NoError(t, err)
+
+	notifier.AssertExpectations(t)
+}
+
+func TestSubmitScore_AchievementFailureKeepsScore(t *testing.T) {
+	svc, achievements := newAchievementService(t)
+	achievements.failing = true
+	userID := uuid.New()
+
+	score, err := svc.SubmitScore(context.Background(), userID, &models.SubmitScoreRequest{Score: 20000})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(20000), score.Score)
+	assert.Empty(t, achievements.awarded)
+}
+
+func TestGetAchievements_Disabled(t *testing.T) {
+	cfg := &config.Config{Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000}}
+	svc := NewLeaderboardService(newMemoryScoreRepository(), nil, nil, cfg)
+
+	achievements, err := svc.GetAchievements(context.Background(), uuid.New(), "global")
+
+	require.NoError(t, err)
+	assert.Empty(t, achievements)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// warmupScoreRepository answers the warm-up aggregates, blocking until release is closed
+type warmupScoreRepository struct {
+	repository.ScoreRepository
+	release chan struct{}
+	seasons []string
+}
+
+func (r *warmupScoreRepository) CountBySeason(ctx context.Context, season string) (int64, error) {
+	<-r.release
+	r.seasons = append(r.seasons, season)
+	return 0, nil
+}
+
+func (r *warmupScoreRepository) GetMedianScore(ctx context.Context, season string) (int64, error) {
+	return 0, nil
+}
+
+func (r *warmupScoreRepository) Count(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestWarmCache_MarksReady(t *testing.T) {
+	repo := &warmupScoreRepository{release: make(chan struct{})}
+	close(repo.release)
+	svc := newTestLeaderboardService(repo)
+
+	assert.False(t, svc.IsReady())
+	require.NoError(t, svc.WarmCache(context.Background(), "global", "winter"))
+	assert.True(t, svc.IsReady())
+	assert.Equal(t, []string{"global", "winter"}, repo.seasons)
+}
+
+func TestStartCacheWarmup_ReadyAfterTimeout(t *testing.T) {
+	repo := &warmupScoreRepository{release: make(chan struct{})}
+	defer close(repo.release)
+	svc := newTestLeaderboardService(repo)
+
+	svc.StartCacheWarmup(context.Background(), 20*time.Millisecond, "global")
+
+	assert.False(t, svc.IsReady(), "warm-up is still blocked")
+	assert.Eventually(t, svc.IsReady, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneSeasonLeaderboard_CopiesTopN(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	svc.SetUnitOfWork(store.UnitOfWork())
+	ctx := context.Background()
+
+	for name, score := range map[string]int64{"alice": 900, "bob": 700, "carol": 500} {
+		_, err := svc.SubmitScore(ctx, store.AddUser(name), &models.SubmitScoreRequest{Score: score, Season: "2024-spring"})
+		require.NoError(t, err)
+	}
+
+	copied, err := svc.CloneSeasonLeaderboard(ctx, "2024-spring", "2024-summer", 2, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, copied)
+
+	entries, total, err := store.Scores.GetLeaderboard(ctx, "2024-summer", 10, 0, "", "desc", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "alice", entries[0].UserName)
+	assert.Equal(t, int64(900), entries[0].Score)
+	assert.Equal(t, "bob", entries[1].UserName)
+}
+
+func TestCloneSeasonLeaderboard_PopulatedTargetRequiresForce(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	svc.SetUnitOfWork(store.UnitOfWork())
+	ctx := context.Background()
+
+	alice, bob := store.AddUser("alice"), store.AddUser("bob")
+	_, err := svc.SubmitScore(ctx, alice, &models.SubmitScoreRequest{Score: 900, Season: "2024-spring"})
+	require.NoError(t, err)
+	_, err = svc.SubmitScore(ctx, bob, &models.SubmitScoreRequest{Score: 100, Season: "2024-summer"})
+	require.NoError(t, err)
+
+	_, err = svc.CloneSeasonLeaderboard(ctx, "2024-spring", "2024-summer", 10, false)
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+
+	copied, err := svc.CloneSeasonLeaderboard(ctx, "2024-spring", "2024-summer", 10, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, copied)
+
+	total, err := store.Scores.CountBySeason(ctx, "2024-summer")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total, "force replaces the target season instead of merging into it")
+	_, err = store.Scores.FindByUserAndSeason(ctx, bob, "2024-summer")
+	assert.Error(t, err)
+}
+
+func TestCloneSeasonLeaderboard_KeepsMetadataAndGamesPlayed(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	svc.SetUnitOfWork(store.UnitOfWork())
+	ctx := context.Background()
+
+	alice := store.AddUser("alice")
+	for _, score := range []int64{300, 900, 500} {
+		_, err := svc.SubmitScore(ctx, alice, &models.SubmitScoreRequest{Score: score, Season: "2024-spring", Metadata: map[string]interface{}{"map": "desert"}})
+		require.NoError(t, err)
+	}
+	source, err := store.Scores.FindByUserAndSeason(ctx, alice, "2024-spring")
+	require.NoError(t, err)
+
+	_, err = svc.CloneSeasonLeaderboard(ctx, "2024-spring", "2024-summer", 10, false)
+	require.NoError(t, err)
+
+	cloned, err := store.Scores.FindByUserAndSeason(ctx, alice, "2024-summer")
+	require.NoError(t, err)
+	assert.Equal(t, source.Score, cloned.Score)
+	assert.Equal(t, "desert", cloned.Metadata["map"])
+	assert.True(t, source.Timestamp.Equal(cloned.Timestamp))
+
+	entries, _, err := store.Scores.GetLeaderboard(ctx, "2024-summer", 10, 0, "", "desc", nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 3, entries[0].GamesPlayed, "the copy keeps the games played instead of counting a new one")
+}
+
+func TestCloneSeasonLeaderboard_InvalidArguments(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		from, to       string
+		topN           int
+		wantStatusCode int
+		withUnitOfWork bool
+	}{
+		{"same season", "global", "global", 10, http.StatusBadRequest, true},
+		{"missing target", "global", "", 10, http.StatusBadRequest, true},
+		{"non-positive topN", "global", "next", 0, http.StatusBadRequest, true},
+		{"no unit of work", "global", "next", 10, http.StatusInternalServerError, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.withUnitOfWork {
+				svc.SetUnitOfWork(store.UnitOfWork())
+			} else {
+				svc.SetUnitOfWork(nil)
+			}
+
+			_, err := svc.CloneSeasonLeaderboard(ctx, tt.from, tt.to, tt.topN, false)
+
+			var appErr *utils.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.wantStatusCode, appErr.StatusCode)
+		})
+	}
+}
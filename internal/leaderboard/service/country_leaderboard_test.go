@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	authmodels "leaderboard-service/internal/auth/models"
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addUserFrom registers a player with a country in the store
+func addUserFrom(t *testing.T, store *testutil.InMemoryStore, name, country string) uuid.UUID {
+	user := &authmodels.User{Name: name, Email: name + "@example.com", Password: "hashed", Country: country}
+	require.NoError(t, store.Users.Create(context.Background(), user))
+	return user.ID
+}
+
+func TestGetLeaderboardByCountry_RanksWithinCountry(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	ctx := context.Background()
+
+	scores := map[uuid.UUID]int64{
+		addUserFrom(t, store, "anna", "DE"):   900,
+		addUserFrom(t, store, "pierre", "FR"): 800,
+		addUserFrom(t, store, "jonas", "DE"):  700,
+		store.AddUser("nomad"):                600, // no country
+	}
+	for userID, score := range scores {
+		_, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: score, Season: "winter"})
+		require.NoError(t, err)
+	}
+
+	leaderboard, err := svc.GetLeaderboardByCountry(ctx, "de", "winter", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, leaderboard.Entries, 2)
+	assert.Equal(t, int64(2), leaderboard.TotalCount)
+	assert.Equal(t, "anna", leaderboard.Entries[0].UserName)
+	assert.Equal(t, 1, leaderboard.Entries[0].Rank)
+	assert.Equal(t, "jonas", leaderboard.Entries[1].UserName)
+	assert.Equal(t, 2, leaderboard.Entries[1].Rank, "players of other countries do not take places")
+
+	page, err := svc.GetLeaderboardByCountry(ctx, "DE", "winter", 1, 1)
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, "jonas", page.Entries[0].UserName)
+	assert.Equal(t, 2, page.Page, "response pages are 1-based")
+	assert.Equal(t, 2, page.TotalPages)
+	assert.False(t, page.HasNext)
+}
+
+func TestGetLeaderboardByCountry_InvalidCountry(t *testing.T) {
+	svc := testutil.NewInMemoryStore().LeaderboardService(nil)
+
+	for _, country := range []string{"", "D", "DEU", "D1"} {
+		_, err := svc.GetLeaderboardByCountry(context.Background(), country, "winter", 10, 0)
+		var appErr *utils.AppError
+		require.True(t, errors.As(err, &appErr), country)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode, country)
+	}
+}
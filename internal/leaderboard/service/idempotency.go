@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/database"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisIdempotencyPrefix prefixes the keys that remember score submissions by idempotency key
+const redisIdempotencyPrefix = "idempotency:submit-score:"
+
+// idempotencyRecord is the value stored under an idempotency key: the hash of the submission that
+// claimed the key and, once the submission is done, its response. Without Response it is still running.
+type idempotencyRecord struct {
+	PayloadHash string          `json:"payload_hash"`
+	Response    json.RawMessage `json:"response,omitempty"`
+}
+
+// submissionHash identifies what a submission asks for, so a key reused for another submission is
+// told apart from a retry. The correlation ID differs between retries and is left out; an empty
+// season is the default season. Metadata maps marshal with sorted keys, so key order does not matter.
+func submissionHash(req *models.SubmitScoreRequest, defaultSeason string) string {
+	season := req.Season
+	if season == "" {
+		season = defaultSeason
+	}
+	payload, _ := json.Marshal(struct {
+		Score    int64                  `json:"score"`
+		Season   string                 `json:"season"`
+		Metadata map[string]interface{} `json:"metadata,omitempty"`
+	}{req.Score, season, req.Metadata})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// idempotencyStore remembers score submission responses by idempotency key
+type idempotencyStore interface {
+	// Claim stores value under key unless the key exists (SET NX).
+	// Returns false and the stored value when the key was already claimed; the stored value is
+	// empty when the key expired before it could be read.
+	Claim(ctx context.Context, key, value string, ttl time.Duration) (bool, string, error)
+	// Save replaces the claimed value with the submission's record
+	Save(ctx context.Context, key, value string, ttl time.Duration) error
+	// Release drops a claim whose submission failed, so a retry executes it again
+	Release(ctx context.Context, key string) error
+}
+
+// redisIdempotencyStore хранит ключи идемпотентности в Redis, общем для всех реплик сервиса
+type redisIdempotencyStore struct {
+	client *redis.Client
+}
+
+func newRedisIdempotencyStore(redisClient *database.RedisClient) *redisIdempotencyStore {
+	return &redisIdempotencyStore{client: redisClient.Client}
+}
+
+func (r *redisIdempotencyStore) Claim(ctx context.Context, key, value string, ttl time.Duration) (bool, string, error) {
+	claimed, err := r.client.SetNX(ctx, key, value, ttl).Result()
+	if err != nil || claimed {
+		return claimed, "", err
+	}
+
+	stored, err := r.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		// Ключ истек между SET NX и GET: считаем его занятым, клиент повторит запрос
+		return false, "", nil
+	}
+	return false, stored, err
+}
+
+func (r *redisIdempotencyStore) Save(ctx context.Context, key, value string, ttl time.Duration) error {
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (r *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
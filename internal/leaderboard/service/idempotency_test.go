@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryIdempotencyStore mimics SET NX semantics of the Redis store; TTLs are recorded but not enforced
+type memoryIdempotencyStore struct {
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]time.Duration
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{values: make(map[string]string), ttls: make(map[string]time.Duration)}
+}
+
+func (m *memoryIdempotencyStore) Claim(ctx context.Context, key, value string, ttl time.Duration) (bool, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if stored, ok := m.values[key]; ok {
+		return false, stored, nil
+	}
+	m.values[key] = value
+	m.ttls[key] = ttl
+	return true, "", nil
+}
+
+func (m *memoryIdempotencyStore) Save(ctx context.Context, key, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	m.ttls[key] = ttl
+	return nil
+}
+
+func (m *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+	return nil
+}
+
+// countingScoreRepository counts the writes that reach the repository
+type countingScoreRepository struct {
+	*memoryScoreRepository
+	upserts int
+	failing bool
+}
+
+func (r *countingScoreRepository) Upsert(ctx context.Context, score *models.Score) error {
+	if r.failing {
+		return errors.New("connection reset")
+	}
+	r.upserts++
+	return r.memoryScoreRepository.Upsert(ctx, score)
+}
+
+func newIdempotentService(t *testing.T) (*LeaderboardService, *countingScoreRepository, *memoryIdempotencyStore) {
+	t.Helper()
+	repo := &countingScoreRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	cfg := &config.Config{
+		Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000},
+		Scoring:    config.ScoringConfig{IdempotencyWindowSeconds: 60},
+	}
+	svc := NewLeaderboardService(repo, nil, nil, cfg)
+	store := newMemoryIdempotencyStore()
+	svc.idempotency = store
+	return svc, repo, store
+}
+
+func TestSubmitScore_IdempotencyKeyReplaysFirstResponse(t *testing.T) {
+	svc, repo, store := newIdempotentService(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	first, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 100, IdempotencyKey: "retry-1", CorrelationID: "first"})
+	require.NoError(t, err)
+	assert.False(t, first.Deduplicated)
+
+	// The retry has its own correlation ID, which is not part of the payload
+	retry, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 100, Season: "global", IdempotencyKey: "retry-1", CorrelationID: "retry"})
+	require.NoError(t, err)
+
+	assert.True(t, retry.Deduplicated)
+	assert.Equal(t, int64(100), retry.Score)
+	assert.Equal(t, "first", retry.CorrelationID, "the stored response is returned as is")
+	assert.Equal(t, 1, repo.upserts)
+
+	stored, err := repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), stored.Score)
+
+	key := redisIdempotencyPrefix + userID.String() + ":retry-1"
+	assert.Equal(t, time.Minute, store.ttls[key])
+}
+
+func TestSubmitScore_IdempotencyKeyIsScopedToUser(t *testing.T) {
+	svc, repo, _ := newIdempotentService(t)
+	ctx := context.Background()
+
+	for _, userID := range []uuid.UUID{uuid.New(), uuid.New()} {
+		score, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 100, IdempotencyKey: "same-key"})
+		require.NoError(t, err)
+		assert.False(t, score.Deduplicated)
+	}
+	assert.Equal(t, 2, repo.upserts)
+}
+
+func TestSubmitScore_WithoutIdempotencyKeyAlwaysExecutes(t *testing.T) {
+	svc, repo, store := newIdempotentService(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	for i := 0; i < 2; i++ {
+		_, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 100})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, repo.upserts)
+	assert.Empty(t, store.values)
+}
+
+func TestSubmitScore_FailedSubmissionReleasesIdempotencyKey(t *testing.T) {
+	svc, repo, store := newIdempotentService(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	req := &models.SubmitScoreRequest{Score: 100, IdempotencyKey: "retry-1"}
+
+	repo.failing = true
+	_, err := svc.SubmitScore(ctx, userID, req)
+	require.Error(t, err)
+	assert.Empty(t, store.values)
+
+	repo.failing = false
+	score, err := svc.SubmitScore(ctx, userID, req)
+	require.NoError(t, err)
+	assert.False(t, score.Deduplicated)
+	assert.Equal(t, 1, repo.upserts)
+}
+
+func TestSubmitScore_InFlightIdempotencyKeyConflicts(t *testing.T) {
+	svc, repo, store := newIdempotentService(t)
+	userID := uuid.New()
+	req := &models.SubmitScoreRequest{Score: 100, IdempotencyKey: "retry-1"}
+
+	// The first request has claimed the key but not stored its response yet
+	pending := `{"payload_hash":"` + submissionHash(req, "global") + `"}`
+	_, _, err := store.Claim(context.Background(), redisIdempotencyPrefix+userID.String()+":retry-1", pending, time.Minute)
+	require.NoError(t, err)
+
+	_, err = svc.SubmitScore(context.Background(), userID, req)
+	assertIdempotencyStatus(t, err, http.StatusConflict)
+
+	_, err = svc.SubmitScore(context.Background(), userID, &models.SubmitScoreRequest{Score: 200, IdempotencyKey: "retry-1"})
+	assertIdempotencyStatus(t, err, http.StatusUnprocessableEntity)
+	assert.Zero(t, repo.upserts)
+}
+
+func TestSubmitScore_IdempotencyKeyReusedWithDifferentPayload(t *testing.T) {
+	ctx := context.Background()
+
+	for name, retry := range map[string]*models.SubmitScoreRequest{
+		"score":    {Score: 200, IdempotencyKey: "retry-1"},
+		"season":   {Score: 100, Season: "winter", IdempotencyKey: "retry-1"},
+		"metadata": {Score: 100, Metadata: map[string]interface{}{"level": "2"}, IdempotencyKey: "retry-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			svc, repo, _ := newIdempotentService(t)
+			userID := uuid.New()
+
+			_, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 100, Metadata: map[string]interface{}{"level": "1"}, IdempotencyKey: "retry-1"})
+			require.NoError(t, err)
+
+			_, err = svc.SubmitScore(ctx, userID, retry)
+			assertIdempotencyStatus(t, err, http.StatusUnprocessableEntity)
+			assert.Equal(t, 1, repo.upserts, "the reused key must not apply the second submission")
+		})
+	}
+}
+
+func TestSubmissionHash_IgnoresMetadataKeyOrderAndCorrelationID(t *testing.T) {
+	var first, second models.SubmitScoreRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"score":100,"metadata":{"a":1,"b":2},"correlation_id":"x"}`), &first))
+	require.NoError(t, json.Unmarshal([]byte(`{"score":100,"season":"global","metadata":{"b":2,"a":1},"correlation_id":"y"}`), &second))
+
+	assert.Equal(t, submissionHash(&first, "global"), submissionHash(&second, "global"))
+	assert.NotEqual(t, submissionHash(&first, "global"), submissionHash(&first, "winter"))
+}
+
+func assertIdempotencyStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	assert.Equal(t, status, appErr.StatusCode)
+}
@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLeaderboard_PaginationMeta(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	ctx := context.Background()
+
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		_, err := svc.SubmitScore(ctx, store.AddUser(name), &models.SubmitScoreRequest{Score: int64(100 * (i + 1)), Season: "winter"})
+		require.NoError(t, err)
+	}
+
+	first, err := svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 2, Page: 0})
+	require.NoError(t, err)
+	assert.Len(t, first.Entries, 2)
+	assert.Equal(t, 1, first.Page, "query pages are 0-based, response pages 1-based")
+	assert.Equal(t, 2, first.PageSize)
+	assert.Equal(t, 3, first.TotalPages)
+	assert.Equal(t, int64(5), first.TotalCount)
+	assert.True(t, first.HasNext)
+	assert.False(t, first.HasPrev)
+	assert.NotEmpty(t, first.NextCursor)
+
+	last, err := svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 2, Page: 2})
+	require.NoError(t, err)
+	assert.Len(t, last.Entries, 1)
+	assert.Equal(t, 3, last.Page)
+	assert.False(t, last.HasNext)
+	assert.True(t, last.HasPrev)
+	assert.Empty(t, last.NextCursor)
+}
+
+func TestGetLeaderboard_CursorWalksEveryPage(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	ctx := context.Background()
+
+	for i := 0; i < 7; i++ {
+		// Two players share each score, so pages cut through ties of the score column
+		_, err := svc.SubmitScore(ctx, store.AddUser(fmt.Sprintf("player%d", i)), &models.SubmitScoreRequest{Score: int64(100 * (i / 2)), Season: "winter"})
+		require.NoError(t, err)
+	}
+
+	all, err := svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all.Entries, 7)
+
+	var walked []models.LeaderboardEntry
+	page, err := svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 3})
+	require.NoError(t, err)
+	walked = append(walked, page.Entries...)
+	for page.NextCursor != "" {
+		page, err = svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 3, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		assert.True(t, page.HasPrev)
+		walked = append(walked, page.Entries...)
+	}
+
+	assert.Equal(t, all.Entries, walked, "keyset pages return the same entries and ranks as one offset page")
+	assert.False(t, page.HasNext)
+	assert.Equal(t, 3, page.Page)
+	assert.Equal(t, int64(7), page.TotalCount)
+}
+
+func TestGetLeaderboard_CursorWalksFullTies(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	ctx := context.Background()
+
+	// Five players share score and timestamp, so only user_id separates them, and the
+	// dense ranks (1 and 2) lag far behind the positions the page numbers come from
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		score := int64(500)
+		if i == 5 {
+			score = 100
+		}
+		require.NoError(t, store.Scores.Upsert(ctx, &models.Score{UserID: store.AddUser(fmt.Sprintf("player%d", i)), Score: score, Season: "winter", Timestamp: ts}))
+	}
+
+	all, err := svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all.Entries, 6)
+
+	page, err := svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 2})
+	require.NoError(t, err)
+	walked := page.Entries
+	pages := []int{page.Page}
+	for page.NextCursor != "" {
+		page, err = svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 2, Cursor: page.NextCursor})
+		require.NoError(t, err)
+		walked = append(walked, page.Entries...)
+		pages = append(pages, page.Page)
+	}
+
+	assert.Equal(t, all.Entries, walked, "no tied player is skipped or repeated")
+	assert.Equal(t, []int{1, 2, 3}, pages)
+	assert.Equal(t, 2, walked[5].Rank)
+}
+
+func TestGetLeaderboard_CursorValidation(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	ctx := context.Background()
+
+	for i, name := range []string{"a", "b", "c"} {
+		_, err := svc.SubmitScore(ctx, store.AddUser(name), &models.SubmitScoreRequest{Score: int64(100 * (i + 1)), Season: "winter"})
+		require.NoError(t, err)
+	}
+	first, err := svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, first.NextCursor)
+
+	asc, err := svc.GetLeaderboard(ctx, &models.LeaderboardQuery{Season: "winter", Limit: 1, SortOrder: "asc"})
+	require.NoError(t, err)
+	assert.Empty(t, asc.NextCursor, "ascending pages cannot be continued with a cursor")
+
+	for name, query := range map[string]*models.LeaderboardQuery{
+		"malformed cursor": {Season: "winter", Limit: 1, Cursor: "not-a-cursor"},
+		"ascending order":  {Season: "winter", Limit: 1, Cursor: first.NextCursor, SortOrder: "asc"},
+		"other sort":       {Season: "winter", Limit: 1, Cursor: first.NextCursor, SortBy: models.SortByGamesPlayed},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.GetLeaderboard(ctx, query)
+			var appErr *utils.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		})
+	}
+}
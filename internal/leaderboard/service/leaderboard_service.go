@@ -3,13 +3,20 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/command"
 	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/database"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
 	ws "leaderboard-service/internal/websocket"
 
 	"github.com/google/uuid"
@@ -22,13 +29,60 @@ const (
 	redisUserScorePrefix   = "user_score:"
 )
 
+// MaxCrossSeasons is the number of seasons GetCrossSeasonRankings compares at most
+const MaxCrossSeasons = 10
+
+// submitRankTimeout bounds the rank lookup SubmitScore makes when Scoring.ReturnRankOnSubmit is set
+const submitRankTimeout = 2 * time.Second
+
+// errUserNotRanked is returned when a user has no score in the requested season
+var errUserNotRanked = utils.NewAppError(utils.ErrCodeNotFound, "user not found in leaderboard", http.StatusNotFound, nil)
+
 // LeaderboardService handles leaderboard operations
 type LeaderboardService struct {
-	scoreRepo repository.ScoreRepository
-	userRepo  repository.UserRepository
-	redis     *database.RedisClient
-	hub       BroadcastHub // WebSocket hub for real-time updates
-	config    *config.Config
+	scoreRepo  repository.ScoreRepository
+	userRepo   repository.UserRepository
+	redis      *database.RedisClient
+	hub        BroadcastHub // WebSocket hub for real-time updates
+	config     *config.Config
+	cursors    *utils.CursorPaginationHelper
+	commands   *command.CommandBus // Serializes score writes; nil executes commands inline
+	challenges ChallengeChecker    // Settles player challenges after a stored score; optional
+
+	notifications *NotificationService  // Announces rank milestones after a stored score; optional
+	uow           repository.UnitOfWork // Transactions for multi-score operations such as season cloning; optional
+	idempotency   idempotencyStore      // Deduplicates retried submissions by idempotency key; nil without Redis
+	rankings      rankingCache          // O(log N) rank lookups from Redis sorted sets; nil without Redis
+	rankingSeeds  sync.Map              // Seasons whose ranking set is being seeded
+
+	achievements          repository.AchievementRepository // Score milestone badges; optional
+	achievementThresholds []int64                          // Ascending badge thresholds
+
+	scoringConfigs repository.ScoringConfigRepository // Runtime scoring rules; nil uses Config.Validation only
+	validator      *DynamicScoreValidator             // Per-season limits with a short TTL; set with scoringConfigs
+	rules          scoringRules                       // Last loaded scoring_configs snapshot, used if the validator fails
+	rulesMu        sync.RWMutex
+
+	ready atomic.Bool // Set once the cache is warm (or the warm-up timed out); see IsReady
+
+	ctx context.Context // Parent of the background broadcasts and cache seeding; see WithContext
+}
+
+// LeaderboardServiceOption configures a LeaderboardService in NewLeaderboardService
+type LeaderboardServiceOption func(*LeaderboardService)
+
+// WithContext sets the parent context of the goroutines the service starts on its own
+// (WebSocket broadcasts, ranking cache seeding). Canceling it on shutdown stops them;
+// without it they run under context.Background().
+func WithContext(ctx context.Context) LeaderboardServiceOption {
+	return func(s *LeaderboardService) {
+		s.ctx = ctx
+	}
+}
+
+// ChallengeChecker settles open challenges when a user stores a new score
+type ChallengeChecker interface {
+	Check(ctx context.Context, userID uuid.UUID, season string, score int64) (int64, error)
 }
 
 // BroadcastHub interface for WebSocket broadcasting
@@ -42,14 +96,25 @@ func NewLeaderboardService(
 	userRepo repository.UserRepository,
 	redis *database.RedisClient,
 	cfg *config.Config,
+	opts ...LeaderboardServiceOption,
 ) *LeaderboardService {
-	return &LeaderboardService{
+	s := &LeaderboardService{
 		scoreRepo: scoreRepo,
 		userRepo:  userRepo,
 		redis:     redis,
 		hub:       nil, // Will be set later via SetHub
 		config:    cfg,
+		cursors:   utils.NewCursorPaginationHelper(),
+		ctx:       context.Background(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	if redis != nil {
+		s.idempotency = newRedisIdempotencyStore(redis)
+		s.rankings = database.NewRankingSet(redis)
+	}
+	return s
 }
 
 // SetHub sets the WebSocket hub for broadcasting
@@ -58,34 +123,150 @@ func (s *LeaderboardService) SetHub(hub BroadcastHub) {
 	if hub != nil {
 		log.Info().Msg("✅ WebSocket Hub connected to LeaderboardService")
 
-		// Cast to concrete Hub type to set callback
-		if concreteHub, ok := hub.(*ws.Hub); ok {
-			log.Info().Msg("✅ Successfully cast to *ws.Hub - setting callback")
-			concreteHub.OnPeriodicUpdate = s.handlePeriodicUpdates
-			log.Info().
-				Bool("callback_set", concreteHub.OnPeriodicUpdate != nil).
-				Msg("✅✅✅ Periodic updates CALLBACK SET (every 3 seconds)")
+		// Periodic updates need a hub that accepts the callback (*ws.Hub or a test double)
+		if registrar, ok := hub.(ws.PeriodicUpdateRegistrar); ok {
+			registrar.SetPeriodicUpdateCallback(s.handlePeriodicUpdates)
+			log.Info().Msg("✅ Periodic updates callback set")
 		} else {
-			log.Error().Msg("❌❌❌ FAILED to cast hub to *ws.Hub - NO PERIODIC UPDATES!")
+			log.Warn().Msg("⚠️ Hub does not support periodic updates - only event-driven broadcasts will be sent")
 		}
 	} else {
 		log.Warn().Msg("⚠️ WebSocket Hub is nil!")
 	}
 }
 
-// SubmitScore submits or updates a user's score using GORM
+// SetCommandBus routes score submissions through a serial command queue
+func (s *LeaderboardService) SetCommandBus(bus *command.CommandBus) {
+	s.commands = bus
+}
+
+// SetChallengeChecker enables challenge win detection on score submission
+func (s *LeaderboardService) SetChallengeChecker(checker ChallengeChecker) {
+	s.challenges = checker
+}
+
+// SetNotificationService enables rank milestone notifications on score submission.
+// Each stored score then costs two rank lookups (before and after the write).
+func (s *LeaderboardService) SetNotificationService(notifications *NotificationService) {
+	s.notifications = notifications
+}
+
+// SetUnitOfWork enables operations that write several scores in one transaction (CloneSeasonLeaderboard)
+func (s *LeaderboardService) SetUnitOfWork(uow repository.UnitOfWork) {
+	s.uow = uow
+}
+
+// dispatch runs a command through the command bus, or directly when no bus is set
+func (s *LeaderboardService) dispatch(ctx context.Context, cmd command.Command) error {
+	if s.commands == nil {
+		return cmd.Execute(ctx)
+	}
+	return s.commands.Dispatch(ctx, cmd)
+}
+
+// SubmitScore submits or updates a user's score using GORM.
+// A request with an IdempotencyKey is executed once per Scoring.IdempotencyWindowSeconds: a retry with
+// the same key and payload returns the first response with Deduplicated set, and a retry that arrives
+// while the first submission is still running fails with 409 Conflict. Reusing the key for a different
+// score, season or metadata fails with 422 Unprocessable Entity. Without Redis the key is ignored.
 func (s *LeaderboardService) SubmitScore(ctx context.Context, userID uuid.UUID, req *models.SubmitScoreRequest) (*models.Score, error) {
+	window := s.config.GetIdempotencyWindow()
+	if req.IdempotencyKey == "" || s.idempotency == nil || window <= 0 {
+		return s.submitScore(ctx, userID, req)
+	}
+
+	// Ключ привязан к игроку, чтобы одинаковые ключи разных игроков не пересекались
+	key := redisIdempotencyPrefix + userID.String() + ":" + req.IdempotencyKey
+	hash := submissionHash(req, s.config.GetDefaultSeason())
+	pending, err := json.Marshal(idempotencyRecord{PayloadHash: hash})
+	if err != nil {
+		return nil, utils.InternalError("encode idempotency record", err)
+	}
+	claimed, stored, err := s.idempotency.Claim(ctx, key, string(pending), window)
+	if err != nil {
+		// Redis недоступен: принимаем отправку без защиты от повтора, а не отклоняем ее
+		log.Warn().Err(err).Str("user_id", userID.String()).Msg("Idempotency check failed, submitting without deduplication")
+		return s.submitScore(ctx, userID, req)
+	}
+	if !claimed {
+		return replayedScore(stored, hash)
+	}
+
+	score, err := s.submitScore(ctx, userID, req)
+	if err != nil {
+		// Освобождаем ключ, чтобы повтор после ошибки выполнил отправку заново
+		if releaseErr := s.idempotency.Release(ctx, key); releaseErr != nil {
+			log.Warn().Err(releaseErr).Str("key", key).Msg("Failed to release idempotency key")
+		}
+		return nil, err
+	}
+
+	response, err := json.Marshal(score)
+	if err == nil {
+		var record []byte
+		record, err = json.Marshal(idempotencyRecord{PayloadHash: hash, Response: response})
+		if err == nil {
+			err = s.idempotency.Save(ctx, key, string(record), window)
+		}
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to store idempotent submission response")
+	}
+	return score, nil
+}
+
+// replayedScore decodes the record stored for an idempotency key and checks that it was claimed
+// by the same payload (hash)
+func replayedScore(stored, hash string) (*models.Score, error) {
+	if stored == "" {
+		return nil, utils.Conflict("a submission with this idempotency key is still in progress", nil)
+	}
+	var record idempotencyRecord
+	if err := json.Unmarshal([]byte(stored), &record); err != nil {
+		return nil, utils.InternalError("decode idempotency record", err)
+	}
+	if record.PayloadHash != hash {
+		return nil, utils.Unprocessable("idempotency key was already used for a different submission", nil)
+	}
+	if len(record.Response) == 0 {
+		return nil, utils.Conflict("a submission with this idempotency key is still in progress", nil)
+	}
+	var score models.Score
+	if err := json.Unmarshal(record.Response, &score); err != nil {
+		return nil, utils.InternalError("decode idempotent submission response", err)
+	}
+	score.Deduplicated = true
+	return &score, nil
+}
+
+// submitScore stores the score and runs the follow-ups (challenges, broadcast, rank, notifications)
+func (s *LeaderboardService) submitScore(ctx context.Context, userID uuid.UUID, req *models.SubmitScoreRequest) (*models.Score, error) {
 	season := req.Season
 	if season == "" {
-		season = "global"
+		season = s.config.GetDefaultSeason()
+	}
+
+	// ID корреляции связывает логи сервиса, репозитория и broadcast одной отправки
+	correlationID := req.CorrelationID
+	if correlationID == "" {
+		correlationID = uuid.New().String()
 	}
+	ctx = utils.WithCorrelationID(ctx, correlationID)
+	logger := log.With().Str("correlation_id", correlationID).Logger()
 
-	// 1. Базовая валидация (используем config)
-	if req.Score < s.config.Validation.MinScore {
-		return nil, fmt.Errorf("score cannot be less than %d", s.config.Validation.MinScore)
+	// 1. Базовая валидация (правила из scoring_configs, иначе config)
+	minScore, maxScore := s.scoreLimits(ctx, season)
+	if req.Score < minScore {
+		return nil, utils.ValidationError(fmt.Sprintf("score cannot be less than %d", minScore), nil)
 	}
-	if req.Score > s.config.Validation.MaxScore {
-		return nil, fmt.Errorf("score exceeds maximum allowed value of %d", s.config.Validation.MaxScore)
+	if req.Score > maxScore {
+		return nil, utils.ValidationError(fmt.Sprintf("score exceeds maximum allowed value of %d", maxScore), nil)
+	}
+	// Metadata хранится в JSONB как есть, поэтому размер ограничивается до записи
+	if maxBytes := s.config.Validation.MaxMetadataBytes; maxBytes > 0 && req.Metadata != nil {
+		if err := utils.NewValidator().JSONSize("metadata", req.Metadata, maxBytes).Error(); err != nil {
+			return nil, utils.ValidationError(err.Error(), err)
+		}
 	}
 
 	// DISABLED: Redis score check disabled - using PostgreSQL as single source of truth
@@ -99,7 +280,7 @@ func (s *LeaderboardService) SubmitScore(ctx context.Context, userID uuid.UUID,
 			if err == nil {
 				// Обновляем только если новый score ЛУЧШЕ
 				if req.Score <= int64(currentScore) {
-					log.Info().
+					logger.Info().
 						Str("user_id", userID.String()).
 						Int64("current", int64(currentScore)).
 						Int64("new", req.Score).
@@ -110,54 +291,143 @@ func (s *LeaderboardService) SubmitScore(ctx context.Context, userID uuid.UUID,
 		}
 	*/
 
-	// 3. Создаём score объект
-	score := models.Score{
-		UserID:   userID,
-		Score:    req.Score,
-		Season:   season,
-		Metadata: req.Metadata,
+	// Место до записи нужно только для уведомлений о рубежах
+	previousRank := 0
+	if s.notifications != nil {
+		previousRank = s.lookupRank(ctx, userID, season)
 	}
 
-	// 4. Сохраняем в базу данных (синхронно для надежности)
-	if err := s.scoreRepo.Upsert(ctx, &score); err != nil {
-		return nil, err
+	// 3. Создаём команду сохранения
+	cmd := NewSubmitScoreCommand(s.scoreRepo, userID, req.Score, season, req.Metadata, s.config.Scoring.OnlyStorePersonalBest)
+
+	// 4. Сохраняем в базу данных через очередь команд (синхронно для надежности)
+	if err := s.dispatch(ctx, cmd); err != nil {
+		if errors.Is(err, repository.ErrScoreLocked) {
+			// Параллельная отправка того же игрока за тот же сезон еще не завершилась
+			return nil, utils.Conflict("another score submission for this season is in progress, retry later", err)
+		}
+		return nil, utils.DatabaseError("score upsert", err)
+	}
+	if cmd.Stored() == nil {
+		// Не личный рекорд: лидерборд не изменился, возвращаем сохраненный результат без broadcast
+		logger.Info().
+			Str("user_id", userID.String()).
+			Int64("score", req.Score).
+			Str("season", season).
+			Msg("⏭️ Score is not a personal best, keeping stored score")
+		best, err := s.scoreRepo.FindByUserAndSeason(ctx, userID, season)
+		if err != nil {
+			return nil, utils.DatabaseError("personal best lookup", err)
+		}
+		// Копия: декоратор кэша может вернуть тот же указатель другим вызывающим
+		result := *best
+		result.CorrelationID = correlationID
+		if s.config.Scoring.ReturnRankOnSubmit {
+			result.Rank = s.lookupRank(ctx, userID, season)
+			result.Percentile = rankPercentile(result.Rank, s.seasonPlayers(ctx, season))
+		}
+		return &result, nil
 	}
+	score := *cmd.Stored()
+	score.CorrelationID = correlationID
 
-	log.Info().
+	logger.Info().
 		Str("source", "GORM").
 		Str("user_id", userID.String()).
 		Int64("score", req.Score).
 		Str("season", season).
 		Msg("✅ Score saved to database")
 
-	// DISABLED: Redis cache sync disabled - using PostgreSQL as single source of truth
-	// Redis caching causes stale data issues with real-time WebSocket updates
-	/*
-		// 5. Обновляем Redis cache (синхронно для консистентности)
-		if s.redis != nil {
-			log.Debug().Str("action", "cache_update").Str("season", season).Msg("Updating Redis cache")
-			s.updateRedisCache(ctx, userID, season, req.Score)
-		} else {
-			log.Debug().Msg("Redis not available, skipping cache update")
+	// 5. Sorted set leaderboard:{season} для рангов обновляет RedisCachedScoreRepository.Upsert
+
+	// Проверяем вызовы игрока; ошибка не должна отменять уже сохраненный результат
+	if s.challenges != nil {
+		won, err := s.challenges.Check(ctx, userID, season, score.Score)
+		if err != nil {
+			logger.Error().Err(err).Str("user_id", userID.String()).Str("season", season).Msg("Failed to check challenges")
+		} else if won > 0 {
+			logger.Info().Str("user_id", userID.String()).Int64("won", won).Str("season", season).Msg("🏆 Challenges won")
 		}
-	*/
+	}
 
-	// 6. Broadcast к WebSocket клиентам (async, не блокируем ответ)
-	if s.hub != nil {
-		log.Info().Str("season", season).Msg("📡 Triggering WebSocket broadcast...")
-		go s.broadcastLeaderboardUpdate(context.Background(), season)
+	// 6. Broadcast к WebSocket клиентам (async, не блокируем ответ).
+	// С WS_USE_DB_NOTIFY рассылку запускает NOTIFY триггера scores, прямой broadcast был бы вторым
+	if s.config.WebSocket.UseDBNotify {
+		logger.Debug().Str("season", season).Msg("Broadcast left to the database notification")
+	} else if s.hub != nil {
+		logger.Info().Str("season", season).Msg("📡 Triggering WebSocket broadcast...")
+		// Не ctx запроса: он отменится после ответа, а broadcast идет асинхронно
+		go s.broadcastLeaderboardUpdate(utils.WithCorrelationID(s.ctx, correlationID), season)
 	} else {
-		log.Debug().Msg("Hub not available, skipping broadcast")
+		logger.Debug().Msg("Hub not available, skipping broadcast")
 	}
 
+	if s.config.Scoring.ReturnRankOnSubmit || s.notifications != nil {
+		rank := s.lookupRank(ctx, userID, season)
+		if s.config.Scoring.ReturnRankOnSubmit {
+			score.Rank = rank
+			score.Percentile = rankPercentile(rank, s.seasonPlayers(ctx, season))
+		}
+		// Ошибка уведомления, как и у вызовов, не отменяет сохраненный результат
+		if s.notifications != nil {
+			if _, err := s.notifications.CheckRankChange(ctx, userID, season, previousRank, rank); err != nil {
+				logger.Error().Err(err).Str("user_id", userID.String()).Str("season", season).Msg("Failed to send rank milestone notification")
+			}
+		}
+	}
+
+	s.awardAchievements(ctx, &logger, userID, season, score.Score)
+
 	return &score, nil
 }
 
+// lookupRank looks up the player's rank for SubmitScore (the response and milestone notifications).
+// Returns 0 (omitted from the JSON) when the player is unranked, the lookup fails or exceeds
+// submitRankTimeout, so a slow ranking query never fails a submission that is already stored.
+func (s *LeaderboardService) lookupRank(ctx context.Context, userID uuid.UUID, season string) int {
+	ctx, cancel := context.WithTimeout(ctx, submitRankTimeout)
+	defer cancel()
+
+	// ZREVRANK по набору сезона не трогает PostgreSQL; при промахе считаем ранг в базе
+	if rank, ok := s.cachedRank(ctx, userID, season); ok {
+		return rank
+	}
+
+	// Репозиторий может не учитывать ctx, поэтому ждем результат не дольше таймаута.
+	// Ранг одного игрока считается запросом в базе, без выборки всего лидерборда
+	ranks := make(chan int, 1)
+	go func() {
+		entry, err := s.scoreRepo.GetUserRank(ctx, userID, season)
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			ranks <- 0
+			return
+		}
+		if err != nil {
+			utils.LoggerFromContext(ctx).Warn().Err(err).Str("user_id", userID.String()).Str("season", season).Msg("Failed to look up rank on submission")
+			ranks <- 0
+			return
+		}
+		ranks <- entry.Rank
+	}()
+
+	select {
+	case rank := <-ranks:
+		return rank
+	case <-ctx.Done():
+		utils.LoggerFromContext(ctx).Warn().Str("user_id", userID.String()).Str("season", season).Msg("Rank lookup on submission timed out")
+		return 0
+	}
+}
+
 // GetLeaderboard retrieves the leaderboard with pagination using GORM
 func (s *LeaderboardService) GetLeaderboard(ctx context.Context, query *models.LeaderboardQuery) (*models.LeaderboardResponse, error) {
 	season := query.Season
 	if season == "" {
-		season = "global"
+		season = s.config.GetDefaultSeason()
+	}
+	if !models.ValidSortBy(query.SortBy) {
+		return nil, utils.ValidationError(fmt.Sprintf("sort_by must be one of %s, %s, %s",
+			models.SortByScore, models.SortByTimestamp, models.SortByGamesPlayed), nil)
 	}
 
 	// DISABLED: Redis cache causes stale data issues with WebSocket real-time updates
@@ -170,7 +440,7 @@ func (s *LeaderboardService) GetLeaderboard(ctx context.Context, query *models.L
 		entries, err := s.getLeaderboardFromRedis(ctx, season, query)
 		if err == nil && len(entries) > 0 {
 			log.Info().Str("source", "Redis").Str("season", season).Int("entries", len(entries)).Msg("✓ Leaderboard served from Redis cache")
-			return s.buildResponse(entries, query), nil
+			return s.buildResponse(entries, query, 0), nil
 		}
 		log.Debug().Str("season", season).Err(err).Msg("Redis cache miss or empty")
 	} else if skipCache {
@@ -179,12 +449,16 @@ func (s *LeaderboardService) GetLeaderboard(ctx context.Context, query *models.L
 		log.Debug().Msg("Redis not available, skipping cache lookup")
 	} */
 
+	if query.Cursor != "" {
+		return s.getLeaderboardPageAfterCursor(ctx, season, query)
+	}
+
 	// Fetch directly from PostgreSQL (single source of truth)
 	log.Info().Str("source", "PostgreSQL").Str("season", season).Msg("Fetching leaderboard from database")
 	entries, totalCount, err := s.getLeaderboardFromDB(ctx, season, query)
 	if err != nil {
 		log.Error().Err(err).Str("season", season).Msg("Failed to fetch leaderboard from database")
-		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+		return nil, utils.DatabaseError("leaderboard query", err)
 	}
 
 	log.Info().
@@ -206,10 +480,77 @@ func (s *LeaderboardService) GetLeaderboard(ctx context.Context, query *models.L
 		}
 	*/
 
-	response := s.buildResponse(entries, query)
-	response.TotalCount = totalCount
+	return s.buildResponse(entries, query, totalCount), nil
+}
+
+// getLeaderboardPageAfterCursor serves a page after query.Cursor with keyset pagination.
+// query.Page is ignored; the response page number is derived from the cursor's offset.
+func (s *LeaderboardService) getLeaderboardPageAfterCursor(ctx context.Context, season string, query *models.LeaderboardQuery) (*models.LeaderboardResponse, error) {
+	if !supportsCursor(query) {
+		return nil, utils.ValidationError("cursor can only be used with the default score order and without exclude", nil)
+	}
+	if query.Limit < 1 {
+		return nil, utils.ValidationError("limit must be positive", nil)
+	}
+	after, err := s.cursors.DecodePosition(query.Cursor)
+	if err != nil {
+		return nil, utils.ValidationError("invalid cursor", err)
+	}
+
+	// Лишняя запись показывает, есть ли следующая страница, без подсчета оставшихся строк
+	entries, err := s.scoreRepo.GetLeaderboardPage(ctx, season, query.SortOrder, after, query.Limit+1)
+	if err != nil {
+		return nil, utils.DatabaseError("leaderboard page query", err)
+	}
+	totalCount, err := s.scoreRepo.CountBySeason(ctx, season)
+	if err != nil {
+		return nil, utils.DatabaseError("leaderboard count", err)
+	}
+
+	hasNext := len(entries) > query.Limit
+	if hasNext {
+		entries = entries[:query.Limit]
+	}
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+
+	// Ранг при равных счетах отстает от позиции, поэтому номер страницы считается по offset
+	pagination := utils.NewPaginationMeta(after.Offset/query.Limit+1, query.Limit, totalCount)
+	pagination.HasNext = hasNext
+	pagination.HasPrev = true
+
+	var nextCursor string
+	if hasNext {
+		nextCursor = s.cursorAfter(entries, after.Offset+len(entries))
+	}
+
+	return &models.LeaderboardResponse{
+		Entries:        entries,
+		PaginationMeta: pagination,
+		NextCursor:     nextCursor,
+		GeneratedAt:    time.Now(),
+	}, nil
+}
+
+// cursorAfter encodes the cursor of the page's last entry; offset is the number of
+// leaderboard rows up to and including it
+func (s *LeaderboardService) cursorAfter(entries []models.LeaderboardEntry, offset int) string {
+	lastEntry := entries[len(entries)-1]
+	return s.cursors.EncodePosition(utils.CursorPosition{
+		Rank:      lastEntry.Rank,
+		Offset:    offset,
+		Score:     lastEntry.Score,
+		Timestamp: lastEntry.Timestamp,
+		UserID:    lastEntry.UserID,
+	})
+}
 
-	return response, nil
+// supportsCursor reports whether the query's order can be paged with a keyset cursor:
+// score descending (the rank order) without excluded players
+func supportsCursor(query *models.LeaderboardQuery) bool {
+	sortByScore := query.SortBy == "" || query.SortBy == models.SortByScore
+	return sortByScore && query.SortOrder != "asc" && len(query.ExcludeUserIDs) == 0
 }
 
 // getLeaderboardFromRedis fetches leaderboard from Redis using sorted sets
@@ -263,8 +604,15 @@ func (s *LeaderboardService) getLeaderboardFromRedis(ctx context.Context, season
 func (s *LeaderboardService) getLeaderboardFromDB(ctx context.Context, season string, query *models.LeaderboardQuery) ([]models.LeaderboardEntry, int64, error) {
 	offset := query.Page * query.Limit
 
+	// The view holds score ranks computed over every player, so exclusions and
+	// other sort dimensions still need the raw query
+	sortByScore := query.SortBy == "" || query.SortBy == models.SortByScore
+	if s.config.Leaderboard.UseMaterializedView && len(query.ExcludeUserIDs) == 0 && sortByScore {
+		return s.scoreRepo.GetLeaderboardFromView(ctx, season, query.Limit, offset, query.SortOrder)
+	}
+
 	// Use repository to fetch leaderboard
-	entries, totalCount, err := s.scoreRepo.GetLeaderboard(ctx, season, query.Limit, offset, query.SortOrder)
+	entries, totalCount, err := s.scoreRepo.GetLeaderboard(ctx, season, query.Limit, offset, query.SortBy, query.SortOrder, query.ExcludeUserIDs)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -272,23 +620,12 @@ func (s *LeaderboardService) getLeaderboardFromDB(ctx context.Context, season st
 	return entries, totalCount, nil
 }
 
-// updateRedisCache updates the Redis sorted set with a new score
-func (s *LeaderboardService) updateRedisCache(ctx context.Context, userID uuid.UUID, season string, score int64) {
-	key := redisLeaderboardPrefix + season
-
-	// Add to sorted set (score is the sort value, userID is the member)
-	err := s.redis.Client.ZAdd(ctx, key, redis.Z{
-		Score:  float64(score),
-		Member: userID.String(),
-	}).Err()
-	if err != nil {
-		log.Warn().Err(err).Str("key", key).Msg("Failed to update Redis cache")
-		return
+// RefreshLeaderboardView rebuilds the leaderboard materialized view; registered as a background job
+func (s *LeaderboardService) RefreshLeaderboardView(ctx context.Context) error {
+	if err := s.scoreRepo.RefreshLeaderboardView(ctx, ""); err != nil {
+		return utils.DatabaseError("leaderboard view refresh", err)
 	}
-
-	// Set expiry on the key
-	s.redis.Client.Expire(ctx, key, s.config.GetCacheLeaderboardTTL())
-	log.Debug().Str("source", "Redis").Str("key", key).Str("user_id", userID.String()).Int64("score", score).Msg("✓ Redis cache updated")
+	return nil
 }
 
 // cacheLeaderboardInRedis caches the entire leaderboard in Redis
@@ -354,38 +691,35 @@ func (s *LeaderboardService) getUserName(ctx context.Context, userID uuid.UUID)
 	return user.Name, nil
 }
 
-// buildResponse constructs the leaderboard response
-func (s *LeaderboardService) buildResponse(entries []models.LeaderboardEntry, query *models.LeaderboardQuery) *models.LeaderboardResponse {
-	hasNext := len(entries) == query.Limit
+// buildResponse constructs the leaderboard response; query.Page is 0-based, the response page is 1-based
+func (s *LeaderboardService) buildResponse(entries []models.LeaderboardEntry, query *models.LeaderboardQuery, totalCount int64) *models.LeaderboardResponse {
+	pagination := utils.NewPaginationMeta(query.Page+1, query.Limit, totalCount)
 
 	var nextCursor string
-	if hasNext && len(entries) > 0 {
-		// Cursor-based pagination: encode the last score and rank
-		lastEntry := entries[len(entries)-1]
-		nextCursor = fmt.Sprintf("%d:%d", lastEntry.Rank, lastEntry.Score)
+	if pagination.HasNext && len(entries) > 0 && supportsCursor(query) {
+		// Cursor-based pagination: opaque cursor with the last entry and its position
+		nextCursor = s.cursorAfter(entries, query.Page*query.Limit+len(entries))
 	}
 
 	return &models.LeaderboardResponse{
-		Entries:    entries,
-		TotalCount: 0, // Will be set by caller if from DB
-		Page:       query.Page,
-		Limit:      query.Limit,
-		HasNext:    hasNext,
-		NextCursor: nextCursor,
+		Entries:        entries,
+		PaginationMeta: pagination,
+		NextCursor:     nextCursor,
+		GeneratedAt:    time.Now(),
 	}
 }
 
 // GetUserRank gets a specific user's rank and score
 func (s *LeaderboardService) GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*models.LeaderboardEntry, error) {
 	if season == "" {
-		season = "global"
+		season = s.config.GetDefaultSeason()
 	}
 
 	// Fetch all leaderboard entries (we need to calculate rank)
 	// For large leaderboards, consider implementing a dedicated repository method
-	entries, _, err := s.scoreRepo.GetLeaderboard(ctx, season, 100000, 0, "desc")
+	entries, _, err := s.scoreRepo.GetLeaderboard(ctx, season, 100000, 0, models.SortByScore, "desc", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+		return nil, utils.DatabaseError("leaderboard query", err)
 	}
 
 	// Find the user in the leaderboard
@@ -402,7 +736,203 @@ func (s *LeaderboardService) GetUserRank(ctx context.Context, userID uuid.UUID,
 		}
 	}
 
-	return nil, fmt.Errorf("user not found in leaderboard")
+	return nil, errUserNotRanked
+}
+
+// GetNeighbors returns the user's entry together with up to radius players above and below them
+func (s *LeaderboardService) GetNeighbors(ctx context.Context, userID uuid.UUID, season string, radius int) (*models.NeighborsResponse, error) {
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+	if radius < 0 {
+		radius = 0
+	}
+
+	// Ранг берется отдельным запросом, затем читается только окно вокруг игрока
+	ranked, err := s.scoreRepo.GetUserRank(ctx, userID, season)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, errUserNotRanked
+		}
+		return nil, utils.DatabaseError("user rank query", err)
+	}
+
+	// При ничьих (dense/competition) позиция в выдаче не меньше rank-1, поэтому выше игрока
+	// в окне всегда будет radius строк. Если ничьи сдвинули игрока за окно, дочитываем следующие
+	chunk := 2*radius + 1
+	offset := ranked.Rank - 1 - radius
+	if offset < 0 {
+		offset = 0
+	}
+	var window []models.LeaderboardEntry
+	for {
+		page, _, err := s.scoreRepo.GetLeaderboard(ctx, season, chunk, offset+len(window), models.SortByScore, "desc", nil)
+		if err != nil {
+			return nil, utils.DatabaseError("leaderboard query", err)
+		}
+		window = append(window, page...)
+
+		i := neighborIndex(window, userID)
+		if i >= 0 && (i+radius < len(window) || len(page) < chunk) {
+			start := i - radius
+			if start < 0 {
+				start = 0
+			}
+			end := i + radius + 1
+			if end > len(window) {
+				end = len(window)
+			}
+			return &models.NeighborsResponse{
+				Season:  season,
+				User:    window[i],
+				Entries: window[start:end],
+			}, nil
+		}
+		if len(page) < chunk {
+			// Счет игрока изменился или удален между запросами
+			return nil, errUserNotRanked
+		}
+	}
+}
+
+// neighborIndex returns the position of userID in entries, or -1
+func neighborIndex(entries []models.LeaderboardEntry, userID uuid.UUID) int {
+	for i, entry := range entries {
+		if entry.UserID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetGlobalStandings ranks players across all seasons by their best score; each player appears once
+func (s *LeaderboardService) GetGlobalStandings(ctx context.Context, limit int) (*models.LeaderboardResponse, error) {
+	entries, totalCount, err := s.scoreRepo.GetGlobalStandings(ctx, limit)
+	if err != nil {
+		return nil, utils.DatabaseError("global standings query", err)
+	}
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+
+	return &models.LeaderboardResponse{
+		Entries:        entries,
+		PaginationMeta: utils.NewPaginationMeta(1, limit, totalCount),
+		GeneratedAt:    time.Now(),
+	}, nil
+}
+
+// GetCrossSeasonRankings ranks players over several seasons by their normalized scores (see
+// ScoreRepository.GetCrossSeasonRankings). Seasons are trimmed and deduplicated; between 1 and
+// MaxCrossSeasons are accepted.
+func (s *LeaderboardService) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]models.CrossSeasonEntry, error) {
+	unique := make([]string, 0, len(seasons))
+	seen := make(map[string]bool, len(seasons))
+	for _, season := range seasons {
+		season = strings.TrimSpace(season)
+		if season == "" || seen[season] {
+			continue
+		}
+		seen[season] = true
+		unique = append(unique, season)
+	}
+	if len(unique) == 0 {
+		return nil, utils.ValidationError("at least one season is required", nil)
+	}
+	if len(unique) > MaxCrossSeasons {
+		return nil, utils.ValidationError(fmt.Sprintf("at most %d seasons can be compared", MaxCrossSeasons), nil)
+	}
+
+	entries, err := s.scoreRepo.GetCrossSeasonRankings(ctx, unique, normalizeByMax, limit)
+	if err != nil {
+		return nil, utils.DatabaseError("cross-season rankings query", err)
+	}
+	if entries == nil {
+		entries = []models.CrossSeasonEntry{}
+	}
+	return entries, nil
+}
+
+// GetLeaderboardByCountry ranks a season's players registered in one country (ISO 3166-1 alpha-2, any case)
+func (s *LeaderboardService) GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) (*models.LeaderboardResponse, error) {
+	country, err := normalizeCountryCode(country)
+	if err != nil {
+		return nil, err
+	}
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+	if limit <= 0 {
+		return nil, utils.ValidationError("limit must be positive", nil)
+	}
+
+	entries, totalCount, err := s.scoreRepo.GetLeaderboardByCountry(ctx, country, season, limit, offset)
+	if err != nil {
+		return nil, utils.DatabaseError("country leaderboard query", err)
+	}
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+
+	return &models.LeaderboardResponse{
+		Entries:        entries,
+		PaginationMeta: utils.NewPaginationMeta(offset/limit+1, limit, totalCount),
+		GeneratedAt:    time.Now(),
+	}, nil
+}
+
+// normalizeCountryCode приводит код страны к виду, в котором он хранится в users.country
+func normalizeCountryCode(country string) (string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if err := utils.NewValidator().CountryCode("country", country).Error(); err != nil {
+		return "", utils.ValidationError(err.Error(), err)
+	}
+	return country, nil
+}
+
+// MaxDistributionBuckets bounds the number of histogram bars a client can ask for
+const MaxDistributionBuckets = 100
+
+// GetScoreDistribution returns a histogram of a season's scores split into up to buckets equal ranges
+func (s *LeaderboardService) GetScoreDistribution(ctx context.Context, season string, buckets int) ([]models.ScoreBucket, error) {
+	if buckets < 1 || buckets > MaxDistributionBuckets {
+		return nil, utils.ValidationError(fmt.Sprintf("buckets must be between 1 and %d", MaxDistributionBuckets), nil)
+	}
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+
+	distribution, err := s.scoreRepo.GetScoreDistribution(ctx, season, buckets)
+	if err != nil {
+		return nil, utils.DatabaseError("score distribution query", err)
+	}
+	return distribution, nil
+}
+
+// MaxMetadataSearchLimit caps the number of scores FindScoresByMetadata returns
+const MaxMetadataSearchLimit = 1000
+
+// FindScoresByMetadata returns up to limit scores of a season whose metadata key has the given value,
+// most recent first. Fails with 400 while metadata encryption is enabled.
+func (s *LeaderboardService) FindScoresByMetadata(ctx context.Context, season, key, value string, limit int) ([]*models.Score, error) {
+	if key == "" {
+		return nil, utils.ValidationError("metadata_key is required", nil)
+	}
+	if limit < 1 || limit > MaxMetadataSearchLimit {
+		return nil, utils.ValidationError(fmt.Sprintf("limit must be between 1 and %d", MaxMetadataSearchLimit), nil)
+	}
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+
+	scores, err := s.scoreRepo.FindByMetadata(ctx, season, key, value, limit)
+	if errors.Is(err, repository.ErrMetadataNotSearchable) {
+		return nil, utils.BadRequest(err.Error(), err)
+	}
+	if err != nil {
+		return nil, utils.DatabaseError("metadata score search", err)
+	}
+	return scores, nil
 }
 
 // broadcastLeaderboardUpdate fetches and broadcasts the current leaderboard
@@ -412,13 +942,19 @@ func (s *LeaderboardService) broadcastLeaderboardUpdate(ctx context.Context, sea
 
 // broadcastLeaderboardUpdateWithLimit fetches and broadcasts the current leaderboard with custom limit
 func (s *LeaderboardService) broadcastLeaderboardUpdateWithLimit(ctx context.Context, season string, limit int) {
-	log.Info().
+	logger := utils.LoggerFromContext(ctx)
+	logger.Info().
 		Str("season", season).
 		Int("limit", limit).
 		Msg("🔔 broadcastLeaderboardUpdateWithLimit called")
 
 	if s.hub == nil {
-		log.Error().Msg("❌ Hub is nil in broadcastLeaderboardUpdateWithLimit!")
+		logger.Error().Msg("❌ Hub is nil in broadcastLeaderboardUpdateWithLimit!")
+		return
+	}
+	// Сервис останавливается: не начинаем запрос, который уже никому не нужен
+	if err := ctx.Err(); err != nil {
+		logger.Debug().Err(err).Str("season", season).Msg("Skipping broadcast, context is done")
 		return
 	}
 
@@ -431,11 +967,11 @@ func (s *LeaderboardService) broadcastLeaderboardUpdateWithLimit(ctx context.Con
 
 	leaderboard, err := s.GetLeaderboard(ctx, query)
 	if err != nil {
-		log.Warn().Err(err).Str("season", season).Msg("Failed to fetch leaderboard for broadcast")
+		logger.Warn().Err(err).Str("season", season).Msg("Failed to fetch leaderboard for broadcast")
 		return
 	}
 
-	log.Info().
+	logger.Info().
 		Str("season", season).
 		Int("entries", len(leaderboard.Entries)).
 		Int("limit", limit).
@@ -443,7 +979,7 @@ func (s *LeaderboardService) broadcastLeaderboardUpdateWithLimit(ctx context.Con
 
 	s.hub.Broadcast(season, leaderboard)
 
-	log.Info().
+	logger.Info().
 		Str("season", season).
 		Int("limit", limit).
 		Msg("✅ Broadcast sent to Hub")
@@ -456,7 +992,7 @@ func (s *LeaderboardService) handlePeriodicUpdates(seasonLimits map[string]int)
 		Interface("season_limits", seasonLimits).
 		Msg("🔔🔔🔔 handlePeriodicUpdates CALLED - about to broadcast with dynamic limits")
 
-	// Use background context - periodic updates are not critical
+	// Periodic updates are not critical: each one gets a short timeout under the service context
 	for season, limit := range seasonLimits {
 		log.Info().
 			Str("season", season).
@@ -464,7 +1000,7 @@ func (s *LeaderboardService) handlePeriodicUpdates(seasonLimits map[string]int)
 			Msg("🚀 Launching broadcast goroutine for season with dynamic limit")
 		// Create separate context for each goroutine
 		go func(seasonName string, requestedLimit int) {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			ctx, cancel := context.WithTimeout(s.ctx, 5*time.Second)
 			defer cancel()
 			s.broadcastLeaderboardUpdateWithLimit(ctx, seasonName, requestedLimit)
 		}(season, limit)
@@ -473,14 +1009,39 @@ func (s *LeaderboardService) handlePeriodicUpdates(seasonLimits map[string]int)
 	log.Info().Msg("🔔 handlePeriodicUpdates FINISHED - all goroutines launched")
 }
 
-// SendInitialSnapshot sends the current leaderboard to a newly connected client
-func (s *LeaderboardService) SendInitialSnapshot(season string, requestedLimit int, clientSend chan []byte) {
+// SeasonExists reports whether a season has at least one score
+func (s *LeaderboardService) SeasonExists(ctx context.Context, season string) (bool, error) {
+	count, err := s.scoreRepo.CountBySeason(ctx, season)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// snapshotRetryWait is how long SendInitialSnapshot waits for room in a full client channel
+const snapshotRetryWait = 100 * time.Millisecond
+
+// snapshotDroppedMessage tells a client its initial snapshot could not be queued
+var snapshotDroppedMessage = []byte(`{"type":"error","code":"buffer_full","message":"Initial snapshot dropped. Please reconnect."}`)
+
+// StreamSeason returns the season streamed to clients that ask for season; empty means the default season
+func (s *LeaderboardService) StreamSeason(ctx context.Context, season string) (string, error) {
+	if season == "" {
+		return s.config.GetDefaultSeason(), nil
+	}
+	return season, nil
+}
+
+// SendInitialSnapshot sends the current leaderboard to a newly connected client.
+// If clientSend stays full for snapshotRetryWait, an error is written with writeDirect instead,
+// which bypasses the channel so the client learns it has to reconnect.
+func (s *LeaderboardService) SendInitialSnapshot(ctx context.Context, season string, requestedLimit int, clientSend chan []byte, writeDirect func(message []byte) error) {
 	log.Info().
 		Str("season", season).
 		Int("requested_limit", requestedLimit).
 		Msg("📸 Sending initial snapshot to new client")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	// Fetch requested number of entries (default to 50 if not specified)
@@ -530,9 +1091,259 @@ func (s *LeaderboardService) SendInitialSnapshot(season string, requestedLimit i
 			Int("entries", len(leaderboard.Entries)).
 			Int("json_size", len(jsonData)).
 			Msg("✅✅✅ Initial snapshot QUEUED in client Send channel")
+		return
 	default:
-		log.Warn().Str("season", season).Msg("⚠️ Failed to send initial snapshot: channel full")
 	}
+
+	// Канал полон - даем WritePump время разгрузить его и пробуем еще раз
+	retryCtx, retryCancel := context.WithTimeout(context.Background(), snapshotRetryWait)
+	defer retryCancel()
+	select {
+	case clientSend <- jsonData:
+		log.Info().Str("season", season).Msg("Initial snapshot queued after retry")
+		return
+	case <-retryCtx.Done():
+	}
+
+	log.Warn().Str("season", season).Msg("⚠️ Initial snapshot dropped: channel full")
+	if writeDirect == nil {
+		return
+	}
+	if err := writeDirect(snapshotDroppedMessage); err != nil {
+		log.Warn().Err(err).Str("season", season).Msg("Failed to notify client about dropped snapshot")
+	}
+}
+
+// ResetSeason deletes every score of a season, clears its caches and sends subscribed
+// WebSocket clients a "reset" season_event followed by an empty leaderboard
+func (s *LeaderboardService) ResetSeason(ctx context.Context, season, adminUserID string) error {
+	if season == "" {
+		return utils.BadRequest("season is required", nil)
+	}
+
+	// Decorators invalidate their own Redis/SimpleCache entries for the season
+	deleted, err := s.scoreRepo.DeleteBySeason(ctx, season)
+	if err != nil {
+		return utils.DatabaseError("season reset", err)
+	}
+
+	// Decorators already dropped their entries; this clears the service's own sorted set
+	if err := s.InvalidateSeasonCache(ctx, season); err != nil {
+		log.Warn().Err(err).Str("season", season).Msg("Failed to clear Redis leaderboard key")
+	}
+
+	log.Info().
+		Str("audit", "season_reset").
+		Str("admin_user_id", adminUserID).
+		Str("season", season).
+		Int64("deleted_scores", deleted).
+		Time("reset_at", time.Now().UTC()).
+		Msg("🧹 Season leaderboard reset")
+
+	if s.hub != nil {
+		// Сначала событие, чтобы клиент сбросил состояние до прихода пустого лидерборда
+		if events, ok := s.hub.(ws.SeasonEventBroadcaster); ok {
+			events.BroadcastSeason(season, ws.NewSeasonEventMessage(ws.SeasonEventReset, season))
+		}
+		s.hub.Broadcast(season, &models.LeaderboardResponse{
+			Entries:        []models.LeaderboardEntry{},
+			PaginationMeta: utils.NewPaginationMeta(1, s.config.WebSocket.DefaultLimit, 0),
+			GeneratedAt:    time.Now(),
+		})
+	}
+
+	return nil
+}
+
+// DeleteScore removes a player's score from a season, clears the season's caches and
+// pushes the updated leaderboard to WebSocket subscribers. Authorization is the caller's job.
+func (s *LeaderboardService) DeleteScore(ctx context.Context, userID uuid.UUID, season string, requestedBy uuid.UUID) error {
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+
+	if _, err := s.scoreRepo.FindByUserAndSeason(ctx, userID, season); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return utils.NotFound("score", err)
+		}
+		return utils.DatabaseError("score lookup", err)
+	}
+
+	// Декораторы сбрасывают свои записи сезона при удалении
+	if err := s.scoreRepo.DeleteByUserAndSeason(ctx, userID, season); err != nil {
+		// Счет мог удалить параллельный запрос между проверкой и удалением
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return utils.NotFound("score", err)
+		}
+		return utils.DatabaseError("score delete", err)
+	}
+	if err := s.InvalidateSeasonCache(ctx, season); err != nil {
+		log.Warn().Err(err).Str("season", season).Msg("Failed to clear Redis leaderboard key")
+	}
+
+	log.Info().
+		Str("audit", "score_deleted").
+		Str("user_id", userID.String()).
+		Str("requested_by", requestedBy.String()).
+		Str("season", season).
+		Msg("🗑️ Score deleted")
+
+	if s.hub != nil {
+		go s.broadcastLeaderboardUpdate(s.ctx, season)
+	}
+
+	return nil
+}
+
+// CloneSeasonLeaderboard copies the top N scores of fromSeason into toSeason in a single transaction,
+// e.g. to seed a new season with the previous season's best players. Copied scores keep their
+// original timestamps, metadata and games played, so ties are ranked the same way, and the copy
+// adds nothing to the players' score history. A toSeason that already has scores is rejected with
+// a conflict unless force is set, in which case the target season is cleared first.
+// Returns the number of copied scores.
+func (s *LeaderboardService) CloneSeasonLeaderboard(ctx context.Context, fromSeason, toSeason string, topN int, force bool) (int, error) {
+	if fromSeason == "" || toSeason == "" {
+		return 0, utils.BadRequest("source and target seasons are required", nil)
+	}
+	if fromSeason == toSeason {
+		return 0, utils.BadRequest("cannot clone a season into itself", nil)
+	}
+	if topN <= 0 {
+		return 0, utils.ValidationError("topN must be positive", nil)
+	}
+	if s.uow == nil {
+		return 0, utils.InternalError("season cloning requires a unit of work", nil)
+	}
+
+	var copied, cleared int64
+	err := s.uow.Do(ctx, func(uow repository.UnitOfWork) error {
+		scoreRepo := uow.GetScoreRepository()
+
+		existing, err := scoreRepo.CountBySeason(ctx, toSeason)
+		if err != nil {
+			return utils.DatabaseError("target season count", err)
+		}
+		if existing > 0 && !force {
+			return utils.Conflict(fmt.Sprintf("season %s already has %d scores; use force to overwrite", toSeason, existing), nil)
+		}
+		if force {
+			// Удаляются и мягко удаленные строки: иначе вставка копии упрется в (user_id, season)
+			if cleared, err = scoreRepo.DeleteBySeason(ctx, toSeason); err != nil {
+				return utils.DatabaseError("target season cleanup", err)
+			}
+		}
+
+		if copied, err = scoreRepo.CopySeasonScores(ctx, fromSeason, toSeason, topN); err != nil {
+			return utils.DatabaseError("season score copy", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Транзакционный репозиторий пишет мимо декораторов, поэтому кэш целевого сезона сбрасывается явно
+	if err := s.InvalidateSeasonCache(ctx, toSeason); err != nil {
+		log.Warn().Err(err).Str("season", toSeason).Msg("Failed to clear Redis leaderboard key")
+	}
+
+	log.Info().
+		Str("audit", "season_cloned").
+		Str("from_season", fromSeason).
+		Str("to_season", toSeason).
+		Int("top_n", topN).
+		Bool("force", force).
+		Int64("cleared_scores", cleared).
+		Int64("copied_scores", copied).
+		Msg("📋 Season leaderboard cloned")
+
+	if s.hub != nil {
+		go s.broadcastLeaderboardUpdate(s.ctx, toSeason)
+	}
+
+	return int(copied), nil
+}
+
+// InvalidateSeasonCache drops every cached entry of a season without writing to the database.
+// Repository decorators and the service's own Redis sorted set are cleared.
+func (s *LeaderboardService) InvalidateSeasonCache(ctx context.Context, season string) error {
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+
+	if invalidator, ok := s.scoreRepo.(repository.SeasonCacheInvalidator); ok {
+		invalidator.Invalidate(ctx, season)
+	}
+
+	if s.redis != nil {
+		if err := s.redis.Client.Del(ctx, redisLeaderboardPrefix+season).Err(); err != nil {
+			return utils.ServiceUnavailable("Redis", err)
+		}
+	}
+
+	return nil
+}
+
+// CacheWarmupTimeout is how long StartCacheWarmup waits before reporting ready with a cold cache
+const CacheWarmupTimeout = 30 * time.Second
+
+// IsReady reports whether the cache warm-up has finished or timed out
+func (s *LeaderboardService) IsReady() bool {
+	return s.ready.Load()
+}
+
+// WarmCache preloads the aggregates the repository decorators cache (counts and median)
+// for the given seasons and marks the service ready on success
+func (s *LeaderboardService) WarmCache(ctx context.Context, seasons ...string) error {
+	for _, season := range seasons {
+		if _, err := s.scoreRepo.CountBySeason(ctx, season); err != nil {
+			return utils.DatabaseError("cache warm-up count", err)
+		}
+		if _, err := s.scoreRepo.GetMedianScore(ctx, season); err != nil {
+			return utils.DatabaseError("cache warm-up median", err)
+		}
+	}
+	if _, err := s.scoreRepo.Count(ctx); err != nil {
+		return utils.DatabaseError("cache warm-up total count", err)
+	}
+
+	s.ready.Store(true)
+	return nil
+}
+
+// StartCacheWarmup runs WarmCache in the background. If it fails or takes longer than timeout,
+// the service is marked ready anyway: a cold cache is slower, not wrong.
+func (s *LeaderboardService) StartCacheWarmup(ctx context.Context, timeout time.Duration, seasons ...string) {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WarmCache(ctx, seasons...)
+	}()
+
+	go func() {
+		start := time.Now()
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case err := <-done:
+			if err == nil {
+				log.Info().Dur("duration", time.Since(start)).Msg("✅ Cache warmed")
+				return
+			}
+			log.Warn().Err(err).Msg("Cache warm-up failed, waiting for the timeout before accepting traffic")
+			// Ошибка warm-up не делает сервис неработоспособным - ждем таймаут, как и при зависании
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				return
+			}
+		case <-timer.C:
+			log.Warn().Dur("timeout", timeout).Msg("Cache warm-up timed out, accepting traffic with a cold cache")
+		case <-ctx.Done():
+			return
+		}
+		s.ready.Store(true)
+	}()
 }
 
 // BroadcastLeaderboard manually broadcasts leaderboard (for testing/admin)
@@ -540,7 +1351,7 @@ func (s *LeaderboardService) BroadcastLeaderboard(ctx context.Context, season st
 	log.Info().Str("season", season).Msg("🔔 Manual broadcast triggered")
 
 	if s.hub == nil {
-		return fmt.Errorf("WebSocket hub not initialized")
+		return utils.ServiceUnavailable("WebSocket", nil)
 	}
 
 	query := &models.LeaderboardQuery{
@@ -552,7 +1363,7 @@ func (s *LeaderboardService) BroadcastLeaderboard(ctx context.Context, season st
 
 	leaderboard, err := s.GetLeaderboard(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to fetch leaderboard: %w", err)
+		return err // already an *utils.AppError
 	}
 
 	s.hub.Broadcast(season, leaderboard)
@@ -0,0 +1,557 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingChallengeChecker records Check calls and returns a fixed error
+type recordingChallengeChecker struct {
+	calls []int64
+	err   error
+}
+
+func (c *recordingChallengeChecker) Check(ctx context.Context, userID uuid.UUID, season string, score int64) (int64, error) {
+	c.calls = append(c.calls, score)
+	return 0, c.err
+}
+
+func newTestLeaderboardService(repo repository.ScoreRepository) *LeaderboardService {
+	cfg := &config.Config{Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000}}
+	return NewLeaderboardService(repo, nil, nil, cfg)
+}
+
+func TestSubmitScore_ChecksChallenges(t *testing.T) {
+	svc := newTestLeaderboardService(newMemoryScoreRepository())
+	checker := &recordingChallengeChecker{}
+	svc.SetChallengeChecker(checker)
+
+	_, err := svc.SubmitScore(context.Background(), uuid.New(), &models.SubmitScoreRequest{Score: 1500})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{1500}, checker.calls)
+}
+
+func TestSubmitScore_RejectsOversizedMetadata(t *testing.T) {
+	repo := newMemoryScoreRepository()
+	svc := newTestLeaderboardService(repo)
+	svc.config.Validation.MaxMetadataBytes = 32
+
+	userID := uuid.New()
+	_, err := svc.SubmitScore(context.Background(), userID, &models.SubmitScoreRequest{
+		Score:    100,
+		Metadata: map[string]interface{}{"k": strings.Repeat("x", 25)},
+	})
+	require.Error(t, err)
+	var appErr *utils.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+	_, err = repo.FindByUserAndSeason(context.Background(), userID, "global")
+	assert.Error(t, err, "an oversized submission must not be stored")
+
+	_, err = svc.SubmitScore(context.Background(), userID, &models.SubmitScoreRequest{
+		Score:    100,
+		Metadata: map[string]interface{}{"k": strings.Repeat("x", 24)},
+	})
+	assert.NoError(t, err, "metadata exactly at the limit is accepted")
+}
+
+func TestSubmitScore_ChallengeErrorDoesNotFailSubmission(t *testing.T) {
+	repo := newMemoryScoreRepository()
+	svc := newTestLeaderboardService(repo)
+	svc.SetChallengeChecker(&recordingChallengeChecker{err: errors.New("db down")})
+
+	userID := uuid.New()
+	score, err := svc.SubmitScore(context.Background(), userID, &models.SubmitScoreRequest{Score: 700})
+	require.NoError(t, err)
+	assert.Equal(t, int64(700), score.Score)
+
+	stored, err := repo.FindByUserAndSeason(context.Background(), userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(700), stored.Score)
+}
+
+// memoryScoringConfigRepository keeps scoring rules keyed by key and season
+type memoryScoringConfigRepository struct {
+	entries map[string]*models.ScoringConfigEntry
+	// validationLoads counts GetValidationConfig calls; validationErr makes them fail
+	validationLoads int
+	validationErr   error
+}
+
+func newMemoryScoringConfigRepository(entries ...*models.ScoringConfigEntry) *memoryScoringConfigRepository {
+	r := &memoryScoringConfigRepository{entries: make(map[string]*models.ScoringConfigEntry)}
+	for _, entry := range entries {
+		r.entries[entry.Key+":"+entry.Season] = entry
+	}
+	return r
+}
+
+func (r *memoryScoringConfigRepository) Get(ctx context.Context, key, season string) (*models.ScoringConfigEntry, error) {
+	entry, ok := r.entries[key+":"+season]
+	if !ok {
+		return nil, repository.ErrRecordNotFound
+	}
+	return entry, nil
+}
+
+func (r *memoryScoringConfigRepository) FindAll(ctx context.Context) ([]*models.ScoringConfigEntry, error) {
+	var entries []*models.ScoringConfigEntry
+	for _, entry := range r.entries {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (r *memoryScoringConfigRepository) GetValidationConfig(ctx context.Context, season string) (*models.ValidationConfig, error) {
+	r.validationLoads++
+	if r.validationErr != nil {
+		return nil, r.validationErr
+	}
+	entries, _ := r.FindAll(ctx)
+	return models.NewValidationConfig(season, entries), nil
+}
+
+func (r *memoryScoringConfigRepository) Upsert(ctx context.Context, entry *models.ScoringConfigEntry) error {
+	r.entries[entry.Key+":"+entry.Season] = entry
+	return nil
+}
+
+func TestScoreLimits_RuntimeRulesOverrideConfig(t *testing.T) {
+	svc := newTestLeaderboardService(newMemoryScoreRepository())
+	svc.SetScoringConfigRepository(newMemoryScoringConfigRepository(
+		&models.ScoringConfigEntry{Key: models.ScoringConfigMaxScore, Season: "", Value: "5000"},
+		&models.ScoringConfigEntry{Key: models.ScoringConfigMaxScore, Season: "hardcore", Value: "9000"},
+		&models.ScoringConfigEntry{Key: models.ScoringConfigMinScore, Season: "hardcore", Value: "not-a-number"},
+	))
+	require.NoError(t, svc.ReloadScoringConfig(context.Background()))
+
+	minScore, maxScore := svc.scoreLimits(context.Background(), "global")
+	assert.Equal(t, int64(0), minScore)
+	assert.Equal(t, int64(5000), maxScore)
+
+	minScore, maxScore = svc.scoreLimits(context.Background(), "hardcore")
+	assert.Equal(t, int64(0), minScore, "invalid rows fall back to config")
+	assert.Equal(t, int64(9000), maxScore)
+}
+
+func TestSubmitScore_UsesRuntimeMaxScore(t *testing.T) {
+	svc := newTestLeaderboardService(newMemoryScoreRepository())
+	svc.SetScoringConfigRepository(newMemoryScoringConfigRepository())
+
+	_, err := svc.UpdateScoringConfig(context.Background(), models.ScoringConfigMaxScore, &models.UpdateScoringConfigRequest{Value: "100"})
+	require.NoError(t, err)
+
+	_, err = svc.SubmitScore(context.Background(), uuid.New(), &models.SubmitScoreRequest{Score: 101})
+	var appErr *utils.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, utils.ErrCodeValidation, appErr.Code)
+}
+
+// lockedScoreRepository simulates a row held by a concurrent submission
+type lockedScoreRepository struct {
+	*memoryScoreRepository
+}
+
+func (r *lockedScoreRepository) Upsert(ctx context.Context, score *models.Score) error {
+	return fmt.Errorf("upsert: %w", repository.ErrScoreLocked)
+}
+
+func TestSubmitScore_LockedScoreReturnsConflict(t *testing.T) {
+	svc := newTestLeaderboardService(&lockedScoreRepository{memoryScoreRepository: newMemoryScoreRepository()})
+
+	_, err := svc.SubmitScore(context.Background(), uuid.New(), &models.SubmitScoreRequest{Score: 100})
+	var appErr *utils.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+	assert.ErrorIs(t, err, repository.ErrScoreLocked)
+}
+
+func TestUpdateScoringConfig_Validation(t *testing.T) {
+	svc := newTestLeaderboardService(newMemoryScoreRepository())
+	svc.SetScoringConfigRepository(newMemoryScoringConfigRepository())
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		key  string
+		req  models.UpdateScoringConfigRequest
+	}{
+		{"unknown key", "combo_bonus", models.UpdateScoringConfigRequest{Value: "1"}},
+		{"non-numeric value", models.ScoringConfigMaxScore, models.UpdateScoringConfigRequest{Value: "lots"}},
+		{"min above max", models.ScoringConfigMinScore, models.UpdateScoringConfigRequest{Value: "2000000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.UpdateScoringConfig(ctx, tt.key, &tt.req)
+			var appErr *utils.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, utils.ErrCodeValidation, appErr.Code)
+		})
+	}
+}
+
+// invalidatingScoreRepository records seasons passed to Invalidate
+type invalidatingScoreRepository struct {
+	*memoryScoreRepository
+	invalidated []string
+}
+
+func (r *invalidatingScoreRepository) Invalidate(ctx context.Context, season string) {
+	r.invalidated = append(r.invalidated, season)
+}
+
+func TestInvalidateSeasonCache(t *testing.T) {
+	repo := &invalidatingScoreRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	cfg := &config.Config{Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000}}
+	svc := NewLeaderboardService(repo, nil, nil, cfg)
+
+	require.NoError(t, svc.InvalidateSeasonCache(context.Background(), "winter"))
+	require.NoError(t, svc.InvalidateSeasonCache(context.Background(), ""))
+
+	assert.Equal(t, []string{"winter", "global"}, repo.invalidated)
+}
+
+func TestInvalidateSeasonCache_UncachedRepository(t *testing.T) {
+	svc := newTestLeaderboardService(newMemoryScoreRepository())
+	assert.NoError(t, svc.InvalidateSeasonCache(context.Background(), "winter"))
+}
+
+func TestSubmitScore_ReturnsRankWhenEnabled(t *testing.T) {
+	repo := &rankedScoreRepository{newMemoryScoreRepository()}
+	cfg := &config.Config{
+		Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000},
+		Scoring:    config.ScoringConfig{ReturnRankOnSubmit: true, OnlyStorePersonalBest: true},
+	}
+	svc := NewLeaderboardService(repo, nil, nil, cfg)
+	ctx := context.Background()
+
+	_, err := svc.SubmitScore(ctx, uuid.New(), &models.SubmitScoreRequest{Score: 900})
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	score, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 500})
+	require.NoError(t, err)
+	assert.Equal(t, 2, score.Rank)
+
+	// A score that is not a personal best still reports the current rank
+	score, err = svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 100})
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), score.Score)
+	assert.Equal(t, 2, score.Rank)
+}
+
+func TestSubmitScore_OmitsRankByDefault(t *testing.T) {
+	svc := newTestLeaderboardService(&rankedScoreRepository{newMemoryScoreRepository()})
+
+	score, err := svc.SubmitScore(context.Background(), uuid.New(), &models.SubmitScoreRequest{Score: 500})
+	require.NoError(t, err)
+	assert.Zero(t, score.Rank)
+}
+
+func TestSubmitScore_CorrelationID(t *testing.T) {
+	cfg := &config.Config{
+		Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000},
+		Scoring:    config.ScoringConfig{OnlyStorePersonalBest: true},
+	}
+	svc := NewLeaderboardService(newMemoryScoreRepository(), nil, nil, cfg)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	score, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 500, CorrelationID: "client-42"})
+	require.NoError(t, err)
+	assert.Equal(t, "client-42", score.CorrelationID)
+
+	// Generated when missing, also for a score that keeps the stored personal best
+	score, err = svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 100})
+	require.NoError(t, err)
+	_, err = uuid.Parse(score.CorrelationID)
+	assert.NoError(t, err)
+}
+
+func TestSubmitScore_BroadcastLeftToDatabaseNotify(t *testing.T) {
+	for _, useDBNotify := range []bool{false, true} {
+		t.Run(fmt.Sprintf("use_db_notify=%v", useDBNotify), func(t *testing.T) {
+			svc := newTestLeaderboardService(&rankedScoreRepository{newMemoryScoreRepository()})
+			svc.config.WebSocket.UseDBNotify = useDBNotify
+			hub := &countingHub{}
+			svc.SetHub(hub)
+
+			_, err := svc.SubmitScore(context.Background(), uuid.New(), &models.SubmitScoreRequest{Score: 100})
+			require.NoError(t, err)
+
+			if !useDBNotify {
+				assert.Eventually(t, func() bool { return hub.broadcasts.Load() == 1 }, time.Second, 5*time.Millisecond)
+				return
+			}
+			// The scores trigger's NOTIFY drives the broadcast; a direct one would reach clients twice
+			time.Sleep(50 * time.Millisecond)
+			assert.Zero(t, hub.broadcasts.Load())
+		})
+	}
+}
+
+func TestDeleteScore(t *testing.T) {
+	repo := &invalidatingScoreRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	cfg := &config.Config{Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000}}
+	svc := NewLeaderboardService(repo, nil, nil, cfg)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	require.NoError(t, repo.Upsert(ctx, &models.Score{UserID: userID, Score: 300, Season: "winter"}))
+
+	require.NoError(t, svc.DeleteScore(ctx, userID, "winter", userID))
+	_, err := repo.FindByUserAndSeason(ctx, userID, "winter")
+	assert.ErrorIs(t, err, repository.ErrRecordNotFound)
+	assert.Equal(t, []string{"winter"}, repo.invalidated)
+
+	// Deleting again reports the missing score
+	err = svc.DeleteScore(ctx, userID, "winter", userID)
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
+
+func TestGetScoreDistribution_RejectsBucketCount(t *testing.T) {
+	svc := newTestLeaderboardService(newMemoryScoreRepository())
+
+	for _, buckets := range []int{0, MaxDistributionBuckets + 1} {
+		_, err := svc.GetScoreDistribution(context.Background(), "global", buckets)
+		var appErr *utils.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	}
+}
+
+// sortRecordingRepository records the sort dimension passed to GetLeaderboard
+type sortRecordingRepository struct {
+	*rankedScoreRepository
+	sortBy string
+}
+
+func (r *sortRecordingRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]models.LeaderboardEntry, int64, error) {
+	r.sortBy = sortBy
+	return r.rankedScoreRepository.GetLeaderboard(ctx, season, limit, offset, sortBy, sortOrder, excludeUserIDs)
+}
+
+func TestGetLeaderboard_SortBy(t *testing.T) {
+	repo := &sortRecordingRepository{rankedScoreRepository: &rankedScoreRepository{newMemoryScoreRepository()}}
+	svc := newTestLeaderboardService(repo)
+	// The view only holds score ranks, so other dimensions must skip it
+	svc.config.Leaderboard.UseMaterializedView = true
+
+	_, err := svc.GetLeaderboard(context.Background(), &models.LeaderboardQuery{SortBy: models.SortByGamesPlayed, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, models.SortByGamesPlayed, repo.sortBy)
+
+	_, err = svc.GetLeaderboard(context.Background(), &models.LeaderboardQuery{SortBy: "rank", Limit: 10})
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+}
+
+func TestSendInitialSnapshot_FullChannelWritesError(t *testing.T) {
+	svc := newTestLeaderboardService(&rankedScoreRepository{newMemoryScoreRepository()})
+
+	clientSend := make(chan []byte, 1)
+	clientSend <- []byte("pending")
+
+	var direct [][]byte
+	svc.SendInitialSnapshot(context.Background(), "global", 10, clientSend, func(message []byte) error {
+		direct = append(direct, message)
+		return nil
+	})
+
+	require.Len(t, direct, 1)
+	assert.JSONEq(t, `{"type":"error","code":"buffer_full","message":"Initial snapshot dropped. Please reconnect."}`, string(direct[0]))
+	assert.Equal(t, []byte("pending"), <-clientSend, "the queued message is left alone")
+}
+
+func TestSendInitialSnapshot_RetriesWhenChannelDrains(t *testing.T) {
+	svc := newTestLeaderboardService(&rankedScoreRepository{newMemoryScoreRepository()})
+
+	clientSend := make(chan []byte, 1)
+	clientSend <- []byte("pending")
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		<-clientSend
+	}()
+
+	svc.SendInitialSnapshot(context.Background(), "global", 10, clientSend, func(message []byte) error {
+		t.Error("snapshot should have been queued on retry")
+		return nil
+	})
+
+	assert.Contains(t, string(<-clientSend), `"type":"leaderboard_update"`)
+}
+
+func TestLeaderboardService_ConfiguredDefaultSeason(t *testing.T) {
+	repo := &rankedScoreRepository{newMemoryScoreRepository()}
+	cfg := &config.Config{
+		Validation:  config.ValidationConfig{MinScore: 0, MaxScore: 1000000},
+		Leaderboard: config.LeaderboardConfig{DefaultSeason: "main"},
+	}
+	svc := NewLeaderboardService(repo, nil, nil, cfg)
+	userID := uuid.New()
+
+	score, err := svc.SubmitScore(context.Background(), userID, &models.SubmitScoreRequest{Score: 700})
+	require.NoError(t, err)
+	assert.Equal(t, "main", score.Season)
+
+	stored, err := repo.FindByUserAndSeason(context.Background(), userID, "main")
+	require.NoError(t, err)
+	assert.Equal(t, int64(700), stored.Score)
+
+	leaderboard, err := svc.GetLeaderboard(context.Background(), &models.LeaderboardQuery{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, leaderboard.Entries, 1)
+	assert.Equal(t, userID, leaderboard.Entries[0].UserID)
+
+	rank, err := svc.GetUserRank(context.Background(), userID, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, rank.Rank)
+}
+
+// crossSeasonScoreRepository records the seasons passed to GetCrossSeasonRankings
+type crossSeasonScoreRepository struct {
+	*memoryScoreRepository
+	seasons []string
+}
+
+func (r *crossSeasonScoreRepository) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]models.CrossSeasonEntry, error) {
+	r.seasons = seasons
+	return nil, nil
+}
+
+func TestGetCrossSeasonRankings_NormalizesSeasonList(t *testing.T) {
+	repo := &crossSeasonScoreRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	svc := newTestLeaderboardService(repo)
+
+	entries, err := svc.GetCrossSeasonRankings(context.Background(), []string{" 2024_01", "2024_02", "", "2024_01 "}, true, 10)
+	require.NoError(t, err)
+	assert.NotNil(t, entries, "an empty result is serialized as []")
+	assert.Equal(t, []string{"2024_01", "2024_02"}, repo.seasons)
+}
+
+func TestGetCrossSeasonRankings_Validation(t *testing.T) {
+	repo := &crossSeasonScoreRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	svc := newTestLeaderboardService(repo)
+
+	tooMany := make([]string, MaxCrossSeasons+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("season_%d", i)
+	}
+
+	for name, seasons := range map[string][]string{
+		"no seasons":       {" ", ""},
+		"too many seasons": tooMany,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.GetCrossSeasonRankings(context.Background(), seasons, true, 10)
+			var appErr *utils.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		})
+	}
+	assert.Nil(t, repo.seasons, "invalid requests do not reach the repository")
+}
+
+// blockingLeaderboardRepository holds GetLeaderboard until the caller's context is done
+type blockingLeaderboardRepository struct {
+	*memoryScoreRepository
+	started chan struct{}
+	exited  chan struct{}
+}
+
+func (r *blockingLeaderboardRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]models.LeaderboardEntry, int64, error) {
+	close(r.started)
+	defer close(r.exited)
+	<-ctx.Done()
+	return nil, 0, ctx.Err()
+}
+
+// countingHub counts broadcasts
+type countingHub struct {
+	broadcasts atomic.Int32
+}
+
+func (h *countingHub) Broadcast(season string, leaderboard *models.LeaderboardResponse) {
+	h.broadcasts.Add(1)
+}
+
+func TestHandlePeriodicUpdates_StopsWhenServiceContextIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := &blockingLeaderboardRepository{
+		memoryScoreRepository: newMemoryScoreRepository(),
+		started:               make(chan struct{}),
+		exited:                make(chan struct{}),
+	}
+	cfg := &config.Config{Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000}}
+	svc := NewLeaderboardService(repo, nil, nil, cfg, WithContext(ctx))
+	hub := &countingHub{}
+	svc.SetHub(hub)
+
+	svc.handlePeriodicUpdates(map[string]int{"global": 10})
+	select {
+	case <-repo.started:
+	case <-time.After(time.Second):
+		t.Fatal("broadcast goroutine did not query the leaderboard")
+	}
+
+	cancel()
+	select {
+	case <-repo.exited:
+	case <-time.After(time.Second):
+		t.Fatal("broadcast goroutine kept running after the service context was canceled")
+	}
+	assert.Zero(t, hub.broadcasts.Load())
+}
+
+func TestBroadcastLeaderboardUpdate_SkipsCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	repo := &blockingLeaderboardRepository{
+		memoryScoreRepository: newMemoryScoreRepository(),
+		started:               make(chan struct{}),
+		exited:                make(chan struct{}),
+	}
+	svc := NewLeaderboardService(repo, nil, nil, &config.Config{}, WithContext(ctx))
+	hub := &countingHub{}
+	svc.SetHub(hub)
+
+	done := make(chan struct{})
+	go func() {
+		svc.broadcastLeaderboardUpdate(svc.ctx, "global")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("broadcast with a canceled context did not return")
+	}
+	select {
+	case <-repo.started:
+		t.Fatal("leaderboard was queried after the service context was canceled")
+	default:
+	}
+	assert.Zero(t, hub.broadcasts.Load())
+}
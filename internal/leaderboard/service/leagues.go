@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+)
+
+// GetLeagues returns the configured league table in configuration order
+func (s *LeaderboardService) GetLeagues() []models.League {
+	leagues := make([]models.League, 0, len(s.config.Leagues))
+	for _, league := range s.config.Leagues {
+		leagues = append(leagues, models.League{
+			Name:          league.Name,
+			MinPercentile: league.MinPercentile,
+			MaxPercentile: league.MaxPercentile,
+			Color:         league.Color,
+		})
+	}
+	return leagues
+}
+
+// GetPercentileRank returns the share of the season's players at or below the user's rank, in percent
+func (s *LeaderboardService) GetPercentileRank(ctx context.Context, userID uuid.UUID, season string) (float64, error) {
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+
+	entry, err := s.GetUserRank(ctx, userID, season)
+	if err != nil {
+		return 0, err
+	}
+	return rankPercentile(entry.Rank, s.seasonPlayers(ctx, season)), nil
+}
+
+// GetLeagueForUser places the user in the league whose percentile range contains their percentile rank
+func (s *LeaderboardService) GetLeagueForUser(ctx context.Context, userID uuid.UUID, season string) (*models.League, error) {
+	percentile, err := s.GetPercentileRank(ctx, userID, season)
+	if err != nil {
+		return nil, err
+	}
+
+	league, ok := leagueForPercentile(s.GetLeagues(), percentile)
+	if !ok {
+		// Таблица лиг может оставлять промежутки, например без нижней лиги
+		return nil, utils.NotFound("league", nil)
+	}
+	return league, nil
+}
+
+// leagueForPercentile finds the league with MinPercentile <= percentile < MaxPercentile;
+// a league ending at 100 also takes the season leader at exactly 100
+func leagueForPercentile(leagues []models.League, percentile float64) (*models.League, bool) {
+	for i := range leagues {
+		league := leagues[i]
+		if percentile >= league.MinPercentile &&
+			(percentile < league.MaxPercentile || (league.MaxPercentile == 100 && percentile == 100)) {
+			return &league, true
+		}
+	}
+	return nil, false
+}
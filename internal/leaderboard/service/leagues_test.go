@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLeagueForUser_MatchesPercentile(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	cfg := testutil.TestConfig()
+	cfg.Leagues = config.DefaultLeagues
+	svc := store.LeaderboardService(cfg)
+	ctx := context.Background()
+
+	// 20 players scoring 100..2000: rank r is at percentile (21-r)/20*100
+	players := make([]uuid.UUID, 20)
+	for i := range players {
+		players[i] = store.AddUser(fmt.Sprintf("player%02d", i+1))
+		_, err := svc.SubmitScore(ctx, players[i], &models.SubmitScoreRequest{Score: int64((i + 1) * 100), Season: "winter"})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name       string
+		player     uuid.UUID
+		percentile float64
+		league     string
+	}{
+		{"Leader", players[19], 100, "Diamond"},
+		{"Second place", players[18], 95, "Diamond"},
+		{"Third place", players[17], 90, "Gold"},
+		{"Upper half boundary", players[9], 50, "Silver"},
+		{"Just below half", players[8], 45, "Bronze"},
+		{"Last place", players[0], 5, "Bronze"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percentile, err := svc.GetPercentileRank(ctx, tt.player, "winter")
+			require.NoError(t, err)
+			assert.Equal(t, tt.percentile, percentile)
+
+			league, err := svc.GetLeagueForUser(ctx, tt.player, "winter")
+			require.NoError(t, err)
+			assert.Equal(t, tt.league, league.Name)
+		})
+	}
+}
+
+func TestGetLeagueForUser_NoMatchingLeague(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	cfg := testutil.TestConfig()
+	cfg.Leagues = []config.LeagueConfig{{Name: "Elite", MinPercentile: 90, MaxPercentile: 100}}
+	svc := store.LeaderboardService(cfg)
+	ctx := context.Background()
+
+	leader, last := store.AddUser("leader"), store.AddUser("last")
+	_, err := svc.SubmitScore(ctx, leader, &models.SubmitScoreRequest{Score: 200, Season: "winter"})
+	require.NoError(t, err)
+	_, err = svc.SubmitScore(ctx, last, &models.SubmitScoreRequest{Score: 100, Season: "winter"})
+	require.NoError(t, err)
+
+	_, err = svc.GetLeagueForUser(ctx, last, "winter")
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	_, err = svc.GetLeagueForUser(ctx, uuid.New(), "winter")
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode, "unranked players have no league")
+}
+
+func TestGetLeagues_ReturnsConfiguredTable(t *testing.T) {
+	cfg := testutil.TestConfig()
+	cfg.Leagues = config.DefaultLeagues
+	svc := testutil.NewInMemoryLeaderboardService(cfg)
+
+	leagues := svc.GetLeagues()
+	require.Len(t, leagues, 4)
+	assert.Equal(t, models.League{Name: "Gold", MinPercentile: 75, MaxPercentile: 95, Color: "#FFD700"}, leagues[2])
+}
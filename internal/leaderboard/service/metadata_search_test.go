@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindScoresByMetadata_MatchesValueAsText(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	ctx := context.Background()
+
+	submissions := []struct {
+		name     string
+		metadata map[string]interface{}
+	}{
+		{"bot", map[string]interface{}{"simulated": true}},
+		{"human", map[string]interface{}{"simulated": false}},
+		{"legacy", nil},
+		{"session", map[string]interface{}{"session_id": "sess-42"}},
+	}
+	for _, sub := range submissions {
+		_, err := svc.SubmitScore(ctx, store.AddUser(sub.name), &models.SubmitScoreRequest{Score: 100, Season: "winter", Metadata: sub.metadata})
+		require.NoError(t, err)
+	}
+
+	simulated, err := svc.FindScoresByMetadata(ctx, "winter", "simulated", "true", 10)
+	require.NoError(t, err)
+	require.Len(t, simulated, 1)
+	assert.Equal(t, true, simulated[0].Metadata["simulated"])
+
+	session, err := svc.FindScoresByMetadata(ctx, "winter", "session_id", "sess-42", 10)
+	require.NoError(t, err)
+	assert.Len(t, session, 1)
+
+	other, err := svc.FindScoresByMetadata(ctx, "summer", "simulated", "true", 10)
+	require.NoError(t, err)
+	assert.Empty(t, other, "other seasons are not searched")
+}
+
+func TestFindScoresByMetadata_InvalidInput(t *testing.T) {
+	svc := testutil.NewInMemoryStore().LeaderboardService(nil)
+
+	tests := []struct {
+		name  string
+		key   string
+		limit int
+	}{
+		{"Missing key", "", 10},
+		{"Zero limit", "simulated", 0},
+		{"Limit above maximum", "simulated", 1001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.FindScoresByMetadata(context.Background(), "winter", tt.key, "true", tt.limit)
+			var appErr *utils.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		})
+	}
+}
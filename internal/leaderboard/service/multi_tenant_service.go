@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// maxSeasonLength is the width of the scores.season column
+const maxSeasonLength = 50
+
+// MultiTenantLeaderboardService namespaces seasons per tenant before delegating to LeaderboardService.
+// A season "summer" of tenant "studio_a" is stored as "studio_a:summer"; clients only ever see "summer".
+// The tenant is read from the request context (see middleware.TenantMiddleware).
+type MultiTenantLeaderboardService struct {
+	inner *LeaderboardService
+}
+
+// NewMultiTenantLeaderboardService wraps a leaderboard service with tenant namespacing
+func NewMultiTenantLeaderboardService(inner *LeaderboardService) *MultiTenantLeaderboardService {
+	return &MultiTenantLeaderboardService{inner: inner}
+}
+
+// tenantSeason returns the stored season name for the request's tenant
+func (s *MultiTenantLeaderboardService) tenantSeason(ctx context.Context, season string) (string, string, error) {
+	tenantID, ok := middleware.GetTenantIDFromContext(ctx)
+	if !ok {
+		return "", "", utils.Forbidden("tenant is required", nil)
+	}
+	// ":" в ID тенанта сделал бы пространства имен неоднозначными ("a:b" + "c" == "a" + "b:c")
+	if strings.Contains(tenantID, ":") {
+		return "", "", utils.Forbidden("invalid tenant", nil)
+	}
+	if season == "" {
+		season = s.inner.config.GetDefaultSeason()
+	}
+	namespaced := tenantID + ":" + season
+	if len(namespaced) > maxSeasonLength {
+		return "", "", utils.ValidationError("season name is too long for this tenant", nil)
+	}
+	return tenantID, namespaced, nil
+}
+
+// stripTenant removes the tenant namespace from a stored season name
+func stripTenant(tenantID, season string) string {
+	return strings.TrimPrefix(season, tenantID+":")
+}
+
+// stripEntries removes the tenant namespace from every entry in place
+func stripEntries(tenantID string, entries []models.LeaderboardEntry) {
+	for i := range entries {
+		entries[i].Season = stripTenant(tenantID, entries[i].Season)
+	}
+}
+
+// SubmitScore submits a score to the tenant's season
+func (s *MultiTenantLeaderboardService) SubmitScore(ctx context.Context, userID uuid.UUID, req *models.SubmitScoreRequest) (*models.Score, error) {
+	tenantID, season, err := s.tenantSeason(ctx, req.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaced := *req
+	namespaced.Season = season
+	score, err := s.inner.SubmitScore(ctx, userID, &namespaced)
+	if err != nil {
+		return nil, err
+	}
+
+	// Копия, чтобы не менять Score, который мог остаться в кэше декоратора
+	result := *score
+	result.Season = stripTenant(tenantID, result.Season)
+	return &result, nil
+}
+
+// GetLeaderboard retrieves a page of the tenant's season
+func (s *MultiTenantLeaderboardService) GetLeaderboard(ctx context.Context, query *models.LeaderboardQuery) (*models.LeaderboardResponse, error) {
+	tenantID, season, err := s.tenantSeason(ctx, query.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaced := *query
+	namespaced.Season = season
+	response, err := s.inner.GetLeaderboard(ctx, &namespaced)
+	if err != nil {
+		return nil, err
+	}
+
+	stripEntries(tenantID, response.Entries)
+	return response, nil
+}
+
+// GetUserRank gets a user's rank in the tenant's season
+func (s *MultiTenantLeaderboardService) GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*models.LeaderboardEntry, error) {
+	tenantID, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.inner.GetUserRank(ctx, userID, namespaced)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *entry
+	result.Season = stripTenant(tenantID, result.Season)
+	return &result, nil
+}
+
+// GetLeagues returns the league table, which is shared by all tenants
+func (s *MultiTenantLeaderboardService) GetLeagues() []models.League {
+	return s.inner.GetLeagues()
+}
+
+// GetLeagueForUser places a user in a league by percentile rank in the tenant's season
+func (s *MultiTenantLeaderboardService) GetLeagueForUser(ctx context.Context, userID uuid.UUID, season string) (*models.League, error) {
+	_, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	return s.inner.GetLeagueForUser(ctx, userID, namespaced)
+}
+
+// GetNeighbors returns the players ranked around a user in the tenant's season
+func (s *MultiTenantLeaderboardService) GetNeighbors(ctx context.Context, userID uuid.UUID, season string, radius int) (*models.NeighborsResponse, error) {
+	tenantID, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.inner.GetNeighbors(ctx, userID, namespaced, radius)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Season = stripTenant(tenantID, response.Season)
+	response.User.Season = stripTenant(tenantID, response.User.Season)
+	stripEntries(tenantID, response.Entries)
+	return response, nil
+}
+
+// GetSummary returns a player's summary in the tenant's season; the personal best only
+// considers the tenant's own seasons
+func (s *MultiTenantLeaderboardService) GetSummary(ctx context.Context, userID uuid.UUID, season string) (*models.ScoreSummary, error) {
+	tenantID, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.inner.summary(ctx, userID, namespaced, tenantID+":")
+	if err != nil {
+		return nil, err
+	}
+
+	summary.Season = stripTenant(tenantID, summary.Season)
+	summary.PersonalBestSeason = stripTenant(tenantID, summary.PersonalBestSeason)
+	return summary, nil
+}
+
+// GetAchievements returns a player's badges in the tenant's season
+func (s *MultiTenantLeaderboardService) GetAchievements(ctx context.Context, userID uuid.UUID, season string) ([]models.Achievement, error) {
+	tenantID, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	achievements, err := s.inner.GetAchievements(ctx, userID, namespaced)
+	if err != nil {
+		return nil, err
+	}
+	for i := range achievements {
+		achievements[i].Season = stripTenant(tenantID, achievements[i].Season)
+	}
+	return achievements, nil
+}
+
+// GetGlobalStandings is not tenant-scoped: the standings span every season of every tenant
+func (s *MultiTenantLeaderboardService) GetGlobalStandings(ctx context.Context, limit int) (*models.LeaderboardResponse, error) {
+	return nil, utils.BadRequest("global standings are not available when multitenancy is enabled", nil)
+}
+
+// GetCrossSeasonRankings compares the tenant's seasons; SeasonScores are keyed by the tenant's season names
+func (s *MultiTenantLeaderboardService) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]models.CrossSeasonEntry, error) {
+	var tenantID string
+	namespaced := make([]string, 0, len(seasons))
+	for _, season := range seasons {
+		season = strings.TrimSpace(season)
+		if season == "" {
+			continue
+		}
+		id, scoped, err := s.tenantSeason(ctx, season)
+		if err != nil {
+			return nil, err
+		}
+		tenantID = id
+		namespaced = append(namespaced, scoped)
+	}
+
+	entries, err := s.inner.GetCrossSeasonRankings(ctx, namespaced, normalizeByMax, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		scores := make(map[string]int64, len(entries[i].SeasonScores))
+		for season, score := range entries[i].SeasonScores {
+			scores[stripTenant(tenantID, season)] = score
+		}
+		entries[i].SeasonScores = scores
+	}
+	return entries, nil
+}
+
+// GetLeaderboardByCountry gets a country's leaderboard of the tenant's season
+func (s *MultiTenantLeaderboardService) GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) (*models.LeaderboardResponse, error) {
+	tenantID, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.inner.GetLeaderboardByCountry(ctx, country, namespaced, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	stripEntries(tenantID, response.Entries)
+	return response, nil
+}
+
+// GetScoreDistribution returns the score histogram of the tenant's season
+func (s *MultiTenantLeaderboardService) GetScoreDistribution(ctx context.Context, season string, buckets int) ([]models.ScoreBucket, error) {
+	_, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	return s.inner.GetScoreDistribution(ctx, namespaced, buckets)
+}
+
+// FindScoresByMetadata searches the tenant's season by a metadata key/value pair
+func (s *MultiTenantLeaderboardService) FindScoresByMetadata(ctx context.Context, season, key, value string, limit int) ([]*models.Score, error) {
+	tenantID, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.inner.FindScoresByMetadata(ctx, namespaced, key, value, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, score := range scores {
+		score.Season = stripTenant(tenantID, score.Season)
+	}
+	return scores, nil
+}
+
+// BroadcastLeaderboard broadcasts the tenant's season to WebSocket subscribers
+func (s *MultiTenantLeaderboardService) BroadcastLeaderboard(ctx context.Context, season string) error {
+	_, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return err
+	}
+	return s.inner.BroadcastLeaderboard(ctx, namespaced)
+}
+
+// StreamSeason returns the tenant's season, which the hub streams under its stored name
+func (s *MultiTenantLeaderboardService) StreamSeason(ctx context.Context, season string) (string, error) {
+	_, namespaced, err := s.tenantSeason(ctx, season)
+	return namespaced, err
+}
+
+// SeasonExists reports whether the tenant's season has at least one score
+func (s *MultiTenantLeaderboardService) SeasonExists(ctx context.Context, season string) (bool, error) {
+	_, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return false, err
+	}
+	return s.inner.SeasonExists(ctx, namespaced)
+}
+
+// SendInitialSnapshot sends the tenant's season to a newly connected client.
+// The snapshot names the stored season, like the broadcasts that follow it.
+func (s *MultiTenantLeaderboardService) SendInitialSnapshot(ctx context.Context, season string, requestedLimit int, clientSend chan []byte, writeDirect func(message []byte) error) {
+	_, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		// Обработчик уже проверил сезон через StreamSeason, так что сюда попадать не должны
+		log.Warn().Err(err).Str("season", season).Msg("Initial snapshot skipped: no tenant season")
+		return
+	}
+	s.inner.SendInitialSnapshot(ctx, namespaced, requestedLimit, clientSend, writeDirect)
+}
+
+// ResetSeason deletes every score of the tenant's season
+func (s *MultiTenantLeaderboardService) ResetSeason(ctx context.Context, season, adminUserID string) error {
+	if season == "" {
+		return utils.BadRequest("season is required", nil)
+	}
+	_, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return err
+	}
+	return s.inner.ResetSeason(ctx, namespaced, adminUserID)
+}
+
+// DeleteScore removes a player's score from the tenant's season
+func (s *MultiTenantLeaderboardService) DeleteScore(ctx context.Context, userID uuid.UUID, season string, requestedBy uuid.UUID) error {
+	_, namespaced, err := s.tenantSeason(ctx, season)
+	if err != nil {
+		return err
+	}
+	return s.inner.DeleteScore(ctx, userID, namespaced, requestedBy)
+}
+
+// UpdateScoringConfig changes a scoring rule of the tenant's season.
+// A season is required: an empty one would change the default of every tenant.
+func (s *MultiTenantLeaderboardService) UpdateScoringConfig(ctx context.Context, key string, req *models.UpdateScoringConfigRequest) (*models.ScoringConfigEntry, error) {
+	if req.Season == "" {
+		return nil, utils.ValidationError("season is required when multitenancy is enabled", nil)
+	}
+	tenantID, season, err := s.tenantSeason(ctx, req.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaced := *req
+	namespaced.Season = season
+	entry, err := s.inner.UpdateScoringConfig(ctx, key, &namespaced)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *entry
+	result.Season = stripTenant(tenantID, result.Season)
+	return &result, nil
+}
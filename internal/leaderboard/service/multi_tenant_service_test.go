@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rankedScoreRepository adds a season leaderboard to memoryScoreRepository
+type rankedScoreRepository struct {
+	*memoryScoreRepository
+}
+
+func (r *rankedScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]models.LeaderboardEntry, int64, error) {
+	var entries []models.LeaderboardEntry
+	for _, score := range r.scores {
+		if score.Season == season {
+			entries = append(entries, models.LeaderboardEntry{UserID: score.UserID, Score: score.Score, Season: score.Season})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Score > entries[j].Score })
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries, int64(len(entries)), nil
+}
+
+func (r *rankedScoreRepository) GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*models.LeaderboardEntry, error) {
+	entries, _, _ := r.GetLeaderboard(ctx, season, 0, 0, models.SortByScore, "desc", nil)
+	for _, entry := range entries {
+		if entry.UserID == userID {
+			return &entry, nil
+		}
+	}
+	return nil, repository.ErrRecordNotFound
+}
+
+func (r *rankedScoreRepository) CountBySeason(ctx context.Context, season string) (int64, error) {
+	var count int64
+	for _, score := range r.scores {
+		if score.Season == season {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func TestMultiTenantLeaderboardService_NamespacesSeasons(t *testing.T) {
+	repo := &rankedScoreRepository{newMemoryScoreRepository()}
+	svc := NewMultiTenantLeaderboardService(newTestLeaderboardService(repo))
+
+	studioA := middleware.WithTenantID(context.Background(), "studio_a")
+	studioB := middleware.WithTenantID(context.Background(), "studio_b")
+	playerA, playerB := uuid.New(), uuid.New()
+
+	score, err := svc.SubmitScore(studioA, playerA, &models.SubmitScoreRequest{Score: 500, Season: "summer"})
+	require.NoError(t, err)
+	assert.Equal(t, "summer", score.Season, "prefix is stripped from the response")
+	_, err = svc.SubmitScore(studioB, playerB, &models.SubmitScoreRequest{Score: 900, Season: "summer"})
+	require.NoError(t, err)
+
+	stored, err := repo.FindByUserAndSeason(context.Background(), playerA, "studio_a:summer")
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), stored.Score)
+
+	// Tenants with the same season name see only their own players
+	leaderboard, err := svc.GetLeaderboard(studioA, &models.LeaderboardQuery{Season: "summer", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, leaderboard.Entries, 1)
+	assert.Equal(t, playerA, leaderboard.Entries[0].UserID)
+	assert.Equal(t, "summer", leaderboard.Entries[0].Season)
+
+	rank, err := svc.GetUserRank(studioB, playerB, "summer")
+	require.NoError(t, err)
+	assert.Equal(t, 1, rank.Rank)
+	assert.Equal(t, "summer", rank.Season)
+
+	_, err = svc.GetUserRank(studioB, playerA, "summer")
+	assert.ErrorIs(t, err, errUserNotRanked)
+}
+
+func TestMultiTenantLeaderboardService_RequiresTenant(t *testing.T) {
+	svc := NewMultiTenantLeaderboardService(newTestLeaderboardService(newMemoryScoreRepository()))
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		season   string
+		expected int
+	}{
+		{"missing tenant", context.Background(), "summer", http.StatusForbidden},
+		{"separator in tenant", middleware.WithTenantID(context.Background(), "a:b"), "summer", http.StatusForbidden},
+		{"namespaced season too long", middleware.WithTenantID(context.Background(), "studio_a"), string(make([]byte, 45)), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SubmitScore(tt.ctx, uuid.New(), &models.SubmitScoreRequest{Score: 1, Season: tt.season})
+
+			var appErr *utils.AppError
+			require.True(t, errors.As(err, &appErr))
+			assert.Equal(t, tt.expected, appErr.StatusCode)
+		})
+	}
+}
+
+func TestMultiTenantLeaderboardService_StreamsTenantSeason(t *testing.T) {
+	repo := &rankedScoreRepository{newMemoryScoreRepository()}
+	svc := NewMultiTenantLeaderboardService(newTestLeaderboardService(repo))
+	studioA := middleware.WithTenantID(context.Background(), "studio_a")
+
+	// Broadcasts go to the stored season, so streaming clients subscribe to it too
+	season, err := svc.StreamSeason(studioA, "")
+	require.NoError(t, err)
+	assert.Equal(t, "studio_a:global", season)
+
+	// Naming another tenant's season stays inside the caller's namespace
+	season, err = svc.StreamSeason(studioA, "studio_b:summer")
+	require.NoError(t, err)
+	assert.Equal(t, "studio_a:studio_b:summer", season)
+
+	_, err = svc.StreamSeason(context.Background(), "summer")
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+
+	require.NoError(t, repo.Upsert(context.Background(), &models.Score{UserID: uuid.New(), Score: 10, Season: "studio_b:summer"}))
+	exists, err := svc.SeasonExists(studioA, "summer")
+	require.NoError(t, err)
+	assert.False(t, exists, "another tenant's season with the same name")
+	exists, err = svc.SeasonExists(middleware.WithTenantID(context.Background(), "studio_b"), "summer")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestMultiTenantLeaderboardService_ScoringConfigNeedsSeason(t *testing.T) {
+	svc := NewMultiTenantLeaderboardService(newTestLeaderboardService(newMemoryScoreRepository()))
+	ctx := middleware.WithTenantID(context.Background(), "studio_a")
+
+	_, err := svc.UpdateScoringConfig(ctx, models.ScoringConfigMaxScore, &models.UpdateScoringConfigRequest{Value: "100"})
+
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+}
+
+func TestMultiTenantLeaderboardService_AchievementsAreTenantScoped(t *testing.T) {
+	inner := newTestLeaderboardService(&rankedScoreRepository{newMemoryScoreRepository()})
+	inner.SetAchievementRepository(&memoryAchievementRepository{}, []int{100})
+	svc := NewMultiTenantLeaderboardService(inner)
+
+	studioA := middleware.WithTenantID(context.Background(), "studio_a")
+	studioB := middleware.WithTenantID(context.Background(), "studio_b")
+	player := uuid.New()
+
+	_, err := svc.SubmitScore(studioA, player, &models.SubmitScoreRequest{Score: 500, Season: "summer"})
+	require.NoError(t, err)
+
+	achievements, err := svc.GetAchievements(studioA, player, "summer")
+	require.NoError(t, err)
+	require.Len(t, achievements, 1)
+	assert.Equal(t, "summer", achievements[0].Season, "prefix is stripped from the response")
+
+	achievements, err = svc.GetAchievements(studioB, player, "summer")
+	require.NoError(t, err)
+	assert.Empty(t, achievements)
+}
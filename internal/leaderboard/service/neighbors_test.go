@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	leaderboardservice "leaderboard-service/internal/leaderboard/service"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// windowRecordingScoreRepository records the limit and offset of every GetLeaderboard call
+type windowRecordingScoreRepository struct {
+	*testutil.InMemoryScoreRepository
+	windows [][2]int
+}
+
+func (r *windowRecordingScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]models.LeaderboardEntry, int64, error) {
+	r.windows = append(r.windows, [2]int{limit, offset})
+	return r.InMemoryScoreRepository.GetLeaderboard(ctx, season, limit, offset, sortBy, sortOrder, excludeUserIDs)
+}
+
+func userIDs(entries []models.LeaderboardEntry) []uuid.UUID {
+	ids := make([]uuid.UUID, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.UserID
+	}
+	return ids
+}
+
+func TestGetNeighbors_ReadsOnlyTheWindow(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	repo := &windowRecordingScoreRepository{InMemoryScoreRepository: store.Scores}
+	svc := leaderboardservice.NewLeaderboardService(repo, store.Users, nil, testutil.TestConfig())
+	ctx := context.Background()
+
+	players := make([]uuid.UUID, 10)
+	for i := range players {
+		players[i] = store.AddUser(fmt.Sprintf("player%d", i))
+		// player0 leads, player9 is last
+		require.NoError(t, store.Scores.Upsert(ctx, &models.Score{UserID: players[i], Score: int64(1000 - 10*i), Season: "winter"}))
+	}
+
+	resp, err := svc.GetNeighbors(ctx, players[5], "winter", 2)
+	require.NoError(t, err)
+	assert.Equal(t, players[5], resp.User.UserID)
+	assert.Equal(t, 6, resp.User.Rank)
+	assert.Equal(t, players[3:8], userIDs(resp.Entries))
+	assert.Equal(t, [][2]int{{5, 3}}, repo.windows, "only the radius window around rank 6 is read")
+
+	// The window is cut at both ends of the season
+	resp, err = svc.GetNeighbors(ctx, players[0], "winter", 2)
+	require.NoError(t, err)
+	assert.Equal(t, players[0:3], userIDs(resp.Entries))
+
+	resp, err = svc.GetNeighbors(ctx, players[9], "winter", 2)
+	require.NoError(t, err)
+	assert.Equal(t, players[7:10], userIDs(resp.Entries))
+}
+
+func TestGetNeighbors_TiesPushThePlayerPastTheWindow(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+	ctx := context.Background()
+
+	// Five players share rank 1 (same score and time), so the next player has dense rank 2 but sits at position 6
+	tiedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, store.Scores.Upsert(ctx, &models.Score{UserID: store.AddUser(fmt.Sprintf("tied%d", i)), Score: 900, Season: "winter", Timestamp: tiedAt}))
+	}
+	player := store.AddUser("player")
+	require.NoError(t, store.Scores.Upsert(ctx, &models.Score{UserID: player, Score: 500, Season: "winter"}))
+	below := store.AddUser("below")
+	require.NoError(t, store.Scores.Upsert(ctx, &models.Score{UserID: below, Score: 100, Season: "winter"}))
+
+	resp, err := svc.GetNeighbors(ctx, player, "winter", 1)
+	require.NoError(t, err)
+	assert.Equal(t, player, resp.User.UserID)
+	assert.Equal(t, 2, resp.User.Rank)
+	require.Len(t, resp.Entries, 3)
+	assert.Equal(t, player, resp.Entries[1].UserID)
+	assert.Equal(t, below, resp.Entries[2].UserID)
+}
+
+func TestGetNeighbors_UnrankedPlayer(t *testing.T) {
+	store := testutil.NewInMemoryStore()
+	svc := store.LeaderboardService(nil)
+
+	_, err := svc.GetNeighbors(context.Background(), uuid.New(), "winter", 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/google/uuid"
+)
+
+// Notification.Type values sent by NotificationService
+const (
+	NotificationTypeRankMilestone = "rank_milestone"
+	NotificationTypeAchievement   = "achievement"
+)
+
+// NotificationService notifies players the first time they reach a rank milestone in a season.
+// Reached milestones are remembered in memory, so a restart may repeat a notification.
+type NotificationService struct {
+	strategy   strategy.NotificationStrategy
+	milestones []int // Ascending; the best milestone first
+
+	mu      sync.Mutex
+	reached map[milestoneKey]bool
+}
+
+type milestoneKey struct {
+	userID    uuid.UUID
+	season    string
+	milestone int
+}
+
+// NewNotificationService creates a notification service for the given rank milestones (e.g. 1, 10, 100)
+func NewNotificationService(notifier strategy.NotificationStrategy, milestones []int) *NotificationService {
+	sorted := make([]int, 0, len(milestones))
+	for _, m := range milestones {
+		if m > 0 {
+			sorted = append(sorted, m)
+		}
+	}
+	sort.Ints(sorted)
+
+	return &NotificationService{
+		strategy:   notifier,
+		milestones: sorted,
+		reached:    make(map[milestoneKey]bool),
+	}
+}
+
+// CheckRankChange sends a notification when newRank crosses a milestone the player has not reached
+// in the season before. previousRank is 0 for a player who was not ranked.
+// When several milestones are crossed at once (150 -> 5), only the best one is announced
+// and the others are marked as reached. Returns the announced milestone, or 0.
+func (n *NotificationService) CheckRankChange(ctx context.Context, userID uuid.UUID, season string, previousRank, newRank int) (int, error) {
+	if newRank <= 0 {
+		return 0, nil
+	}
+
+	n.mu.Lock()
+	announced := 0
+	var marked []milestoneKey
+	for _, m := range n.milestones {
+		crossed := newRank <= m && (previousRank == 0 || previousRank > m)
+		key := milestoneKey{userID, season, m}
+		if !crossed || n.reached[key] {
+			continue
+		}
+		n.reached[key] = true
+		marked = append(marked, key)
+		if announced == 0 {
+			announced = m
+		}
+	}
+	n.mu.Unlock()
+
+	if announced == 0 {
+		return 0, nil
+	}
+
+	notification := &strategy.Notification{
+		UserID:  userID,
+		Type:    NotificationTypeRankMilestone,
+		Title:   rankMilestoneTitle(announced),
+		Message: fmt.Sprintf("You reached rank %d in season %s", newRank, season),
+		Data: map[string]interface{}{
+			"season":        season,
+			"milestone":     announced,
+			"rank":          newRank,
+			"previous_rank": previousRank,
+		},
+	}
+	if err := n.strategy.Send(ctx, notification); err != nil {
+		// Рубежи снова считаются недостигнутыми, чтобы следующая отправка повторила уведомление
+		n.mu.Lock()
+		for _, key := range marked {
+			delete(n.reached, key)
+		}
+		n.mu.Unlock()
+		return 0, fmt.Errorf("send rank milestone notification via %s: %w", n.strategy.Name(), err)
+	}
+	return announced, nil
+}
+
+// NotifyAchievement announces a newly awarded badge
+func (n *NotificationService) NotifyAchievement(ctx context.Context, achievement *models.Achievement) error {
+	notification := &strategy.Notification{
+		UserID:  achievement.UserID,
+		Type:    NotificationTypeAchievement,
+		Title:   "New badge: " + achievement.BadgeName,
+		Message: fmt.Sprintf("You earned the %s badge in season %s", achievement.BadgeName, achievement.Season),
+		Data: map[string]interface{}{
+			"season":     achievement.Season,
+			"badge_name": achievement.BadgeName,
+		},
+	}
+	if err := n.strategy.Send(ctx, notification); err != nil {
+		return fmt.Errorf("send achievement notification via %s: %w", n.strategy.Name(), err)
+	}
+	return nil
+}
+
+func rankMilestoneTitle(milestone int) string {
+	if milestone == 1 {
+		return "You are #1!"
+	}
+	return fmt.Sprintf("Top %d!", milestone)
+}
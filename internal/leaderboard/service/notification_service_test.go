@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockNotificationStrategy is a mock implementation of strategy.NotificationStrategy
+type MockNotificationStrategy struct {
+	mock.Mock
+}
+
+func (m *MockNotificationStrategy) Send(ctx context.Context, notification *strategy.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationStrategy) Name() string {
+	return "Mock"
+}
+
+// milestoneNotification matches a rank milestone notification for the given milestone
+func milestoneNotification(userID uuid.UUID, milestone int) interface{} {
+	return mock.MatchedBy(func(n *strategy.Notification) bool {
+		return n.UserID == userID && n.Type == NotificationTypeRankMilestone && n.Data["milestone"] == milestone
+	})
+}
+
+func TestNotificationService_CheckRankChange(t *testing.T) {
+	tests := []struct {
+		name                   string
+		previousRank, newRank  int
+		wantMilestone          int
+		expectNotificationSent bool
+	}{
+		{"first rank inside top 100", 0, 57, 100, true},
+		{"climbs into top 10", 11, 10, 10, true},
+		{"crosses several milestones at once", 150, 1, 1, true},
+		{"moves within top 10", 8, 3, 0, false},
+		{"drops", 5, 20, 0, false},
+		{"stays outside every milestone", 0, 500, 0, false},
+		{"rank unknown", 20, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := new(MockNotificationStrategy)
+			userID := uuid.New()
+			if tt.expectNotificationSent {
+				notifier.On("Send", mock.Anything, milestoneNotification(userID, tt.wantMilestone)).Return(nil).Once()
+			}
+			svc := NewNotificationService(notifier, []int{100, 1, 10})
+
+			milestone, err := svc.CheckRankChange(context.Background(), userID, "global", tt.previousRank, tt.newRank)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMilestone, milestone)
+			notifier.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationService_OnlyFirstTimePerSeason(t *testing.T) {
+	notifier := new(MockNotificationStrategy)
+	userID := uuid.New()
+	notifier.On("Send", mock.Anything, milestoneNotification(userID, 10)).Return(nil).Twice()
+	svc := NewNotificationService(notifier, []int{1, 10, 100})
+	ctx := context.Background()
+
+	milestone, err := svc.CheckRankChange(ctx, userID, "global", 12, 9)
+	require.NoError(t, err)
+	assert.Equal(t, 10, milestone)
+
+	// Dropping out and climbing back in is not a first time
+	milestone, err = svc.CheckRankChange(ctx, userID, "global", 14, 7)
+	require.NoError(t, err)
+	assert.Zero(t, milestone)
+
+	// Another season is tracked separately
+	milestone, err = svc.CheckRankChange(ctx, userID, "winter", 0, 9)
+	require.NoError(t, err)
+	assert.Equal(t, 10, milestone)
+
+	notifier.AssertExpectations(t)
+}
+
+func TestNotificationService_SendFailureIsRetried(t *testing.T) {
+	notifier := new(MockNotificationStrategy)
+	userID := uuid.New()
+	notifier.On("Send", mock.Anything, milestoneNotification(userID, 1)).Return(errors.New("push gateway down")).Once()
+	notifier.On("Send", mock.Anything, milestoneNotification(userID, 1)).Return(nil).Once()
+	svc := NewNotificationService(notifier, []int{1})
+	ctx := context.Background()
+
+	_, err := svc.CheckRankChange(ctx, userID, "global", 2, 1)
+	require.Error(t, err)
+
+	milestone, err := svc.CheckRankChange(ctx, userID, "global", 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, milestone)
+	notifier.AssertExpectations(t)
+}
+
+func TestSubmitScore_SendsRankMilestoneNotification(t *testing.T) {
+	repo := &rankedScoreRepository{newMemoryScoreRepository()}
+	cfg := &config.Config{Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000}}
+	svc := NewLeaderboardService(repo, nil, nil, cfg)
+	notifier := new(MockNotificationStrategy)
+	svc.SetNotificationService(NewNotificationService(notifier, []int{1, 10}))
+	ctx := context.Background()
+
+	leader, challenger := uuid.New(), uuid.New()
+	notifier.On("Send", mock.Anything, milestoneNotification(leader, 1)).Return(nil).Once()
+	notifier.On("Send", mock.Anything, milestoneNotification(challenger, 10)).Return(nil).Once()
+	notifier.On("Send", mock.Anything, milestoneNotification(challenger, 1)).Return(nil).Once()
+
+	_, err := svc.SubmitScore(ctx, leader, &models.SubmitScoreRequest{Score: 900})
+	require.NoError(t, err)
+
+	// Enters at rank 2: top 10, not yet #1
+	_, err = svc.SubmitScore(ctx, challenger, &models.SubmitScoreRequest{Score: 500})
+	require.NoError(t, err)
+
+	// Overtakes the leader
+	_, err = svc.SubmitScore(ctx, challenger, &models.SubmitScoreRequest{Score: 1000})
+	require.NoError(t, err)
+
+	notifier.AssertExpectations(t)
+}
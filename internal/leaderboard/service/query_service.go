@@ -3,9 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	authmodels "leaderboard-service/internal/auth/models"
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/repository"
 
 	"github.com/google/uuid"
@@ -13,18 +15,25 @@ import (
 
 // QueryService demonstrates Specification Pattern usage
 type QueryService struct {
-	userRepo  repository.UserRepository
-	scoreRepo repository.ScoreRepository
+	userRepo      repository.UserRepository
+	scoreRepo     repository.ScoreRepository
+	defaultSeason string
 }
 
 // NewQueryService creates a new query service
 func NewQueryService(userRepo repository.UserRepository, scoreRepo repository.ScoreRepository) *QueryService {
 	return &QueryService{
-		userRepo:  userRepo,
-		scoreRepo: scoreRepo,
+		userRepo:      userRepo,
+		scoreRepo:     scoreRepo,
+		defaultSeason: config.FallbackSeason,
 	}
 }
 
+// SetDefaultSeason sets the season used when a query omits it (Config.GetDefaultSeason)
+func (s *QueryService) SetDefaultSeason(season string) {
+	s.defaultSeason = season
+}
+
 // SearchUsers searches for users by name or email
 func (s *QueryService) SearchUsers(ctx context.Context, query string) ([]*authmodels.User, error) {
 	// Build specification: search by name OR email
@@ -134,37 +143,65 @@ func (s *QueryService) FindScoresOutsideRange(ctx context.Context, season string
 
 // GetLeaderboardExcludingUsers gets leaderboard excluding specific users
 func (s *QueryService) GetLeaderboardExcludingUsers(ctx context.Context, season string, excludeUserIDs []string, limit int) ([]*leaderboardmodels.Score, error) {
-	// First get all scores for season
-	spec := repository.And(
-		repository.NewScoreBySeasonSpec(season),
-		repository.NewScoreOrderBySpec("score", true),
-		repository.NewScoreLimitSpec(limit*2), // Get more to filter
-	)
-
-	scores, err := s.scoreRepo.FindBySpec(ctx, spec)
-	if err != nil {
-		return nil, err
-	}
-
-	// Filter out excluded users (in-memory)
 	excludeMap := make(map[string]bool)
 	for _, id := range excludeUserIDs {
 		excludeMap[id] = true
 	}
 
+	// Read pages until enough players remain after filtering or the season runs out
 	filtered := make([]*leaderboardmodels.Score, 0, limit)
-	for _, score := range scores {
-		if !excludeMap[score.UserID.String()] {
-			filtered = append(filtered, score)
-			if len(filtered) >= limit {
-				break
+	for offset := 0; len(filtered) < limit; offset += limit {
+		scores, err := s.scoreRepo.FindAll(ctx, season, "desc", limit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, score := range scores {
+			if !excludeMap[score.UserID.String()] {
+				filtered = append(filtered, score)
+				if len(filtered) >= limit {
+					break
+				}
 			}
 		}
+
+		if len(scores) < limit {
+			break
+		}
 	}
 
 	return filtered, nil
 }
 
+// GetLeaderboardWithUserProfiles gets a leaderboard page with avatar, country and tier of every player
+func (s *QueryService) GetLeaderboardWithUserProfiles(ctx context.Context, query *leaderboardmodels.LeaderboardQuery) (*leaderboardmodels.EnrichedLeaderboardResponse, error) {
+	season := query.Season
+	if season == "" {
+		season = s.defaultSeason
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	entries, total, err := s.scoreRepo.GetLeaderboardWithProfiles(ctx, season, limit, query.Page*limit, query.SortOrder, query.ExcludeUserIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard with profiles for season %s: %w", season, err)
+	}
+	if entries == nil {
+		entries = []leaderboardmodels.EnrichedLeaderboardEntry{}
+	}
+
+	return &leaderboardmodels.EnrichedLeaderboardResponse{
+		Entries:     entries,
+		TotalCount:  total,
+		Page:        query.Page,
+		Limit:       limit,
+		HasNext:     int64((query.Page+1)*limit) < total,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
 // GetMultiSeasonLeaderboard gets combined leaderboard from multiple seasons
 func (s *QueryService) GetMultiSeasonLeaderboard(ctx context.Context, seasons []string, limitPerSeason int) (map[string][]*leaderboardmodels.Score, error) {
 	result := make(map[string][]*leaderboardmodels.Score)
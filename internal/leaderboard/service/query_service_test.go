@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedScoreRepository serves FindAll from a pre-sorted slice and records requested offsets
+type pagedScoreRepository struct {
+	repository.ScoreRepository
+	scores  []*models.Score
+	offsets []int
+}
+
+func (r *pagedScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*models.Score, error) {
+	r.offsets = append(r.offsets, offset)
+	if offset >= len(r.scores) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(r.scores) {
+		end = len(r.scores)
+	}
+	return r.scores[offset:end], nil
+}
+
+func TestGetLeaderboardExcludingUsers_ReadsMorePagesWhenManyAreExcluded(t *testing.T) {
+	scores := make([]*models.Score, 6)
+	for i := range scores {
+		scores[i] = &models.Score{UserID: uuid.New(), Score: int64(600 - i*100), Season: "global"}
+	}
+	repo := &pagedScoreRepository{scores: scores}
+	svc := NewQueryService(nil, repo)
+
+	// Three of the top four players are excluded, more than a 2x over-fetch could absorb
+	excluded := []string{scores[0].UserID.String(), scores[1].UserID.String(), scores[2].UserID.String()}
+	result, err := svc.GetLeaderboardExcludingUsers(context.Background(), "global", excluded, 2)
+	require.NoError(t, err)
+
+	require.Len(t, result, 2)
+	assert.Equal(t, scores[3].UserID, result[0].UserID)
+	assert.Equal(t, scores[4].UserID, result[1].UserID)
+	assert.Equal(t, []int{0, 2, 4}, repo.offsets)
+}
+
+func TestGetLeaderboardExcludingUsers_StopsAtEndOfSeason(t *testing.T) {
+	scores := []*models.Score{
+		{UserID: uuid.New(), Score: 300, Season: "global"},
+		{UserID: uuid.New(), Score: 200, Season: "global"},
+	}
+	repo := &pagedScoreRepository{scores: scores}
+	svc := NewQueryService(nil, repo)
+
+	result, err := svc.GetLeaderboardExcludingUsers(context.Background(), "global", []string{scores[0].UserID.String()}, 5)
+	require.NoError(t, err)
+
+	require.Len(t, result, 1)
+	assert.Equal(t, scores[1].UserID, result[0].UserID)
+	assert.Equal(t, []int{0}, repo.offsets)
+}
+
+// profileScoreRepository returns fixed enriched entries and records the query arguments
+type profileScoreRepository struct {
+	repository.ScoreRepository
+	entries        []models.EnrichedLeaderboardEntry
+	total          int64
+	season         string
+	limit, offset  int
+	excludeUserIDs []uuid.UUID
+}
+
+func (r *profileScoreRepository) GetLeaderboardWithProfiles(ctx context.Context, season string, limit, offset int, sortOrder string, excludeUserIDs []uuid.UUID) ([]models.EnrichedLeaderboardEntry, int64, error) {
+	r.season, r.limit, r.offset, r.excludeUserIDs = season, limit, offset, excludeUserIDs
+	return r.entries, r.total, nil
+}
+
+func TestGetLeaderboardWithUserProfiles(t *testing.T) {
+	entry := models.EnrichedLeaderboardEntry{
+		LeaderboardEntry: models.LeaderboardEntry{Rank: 11, UserID: uuid.New(), UserName: "alice", Score: 900, Season: "global"},
+		AvatarURL:        "https://avatars.example.com/alice.png",
+		Country:          "PL",
+		Tier:             "gold",
+	}
+	repo := &profileScoreRepository{entries: []models.EnrichedLeaderboardEntry{entry}, total: 25}
+	svc := NewQueryService(nil, repo)
+
+	excluded := []uuid.UUID{uuid.New()}
+	resp, err := svc.GetLeaderboardWithUserProfiles(context.Background(), &models.LeaderboardQuery{Limit: 10, Page: 1, ExcludeUserIDs: excluded})
+	require.NoError(t, err)
+
+	assert.Equal(t, "global", repo.season)
+	assert.Equal(t, 10, repo.limit)
+	assert.Equal(t, 10, repo.offset)
+	assert.Equal(t, excluded, repo.excludeUserIDs)
+
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, "PL", resp.Entries[0].Country)
+	assert.Equal(t, int64(25), resp.TotalCount)
+	assert.True(t, resp.HasNext)
+	assert.False(t, resp.GeneratedAt.IsZero())
+}
+
+func TestGetLeaderboardWithUserProfiles_EmptySeason(t *testing.T) {
+	repo := &profileScoreRepository{}
+	svc := NewQueryService(nil, repo)
+
+	resp, err := svc.GetLeaderboardWithUserProfiles(context.Background(), &models.LeaderboardQuery{Season: "winter"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "winter", repo.season)
+	assert.Equal(t, 50, repo.limit)
+	assert.NotNil(t, resp.Entries)
+	assert.False(t, resp.HasNext)
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// Сезон читается из PostgreSQL страницами по rankingSeedBatch строк
+const (
+	rankingSeedBatch   = 1000
+	rankingSeedTimeout = 30 * time.Second
+)
+
+// rankingCache answers rank and player count lookups from a sorted set of a season's scores.
+// The score repository keeps an existing set current; the service seeds missing sets.
+// database.RankingSet is the Redis implementation.
+type rankingCache interface {
+	// Position returns the player's place in the season's set; false when the set
+	// does not exist or does not hold the player
+	Position(ctx context.Context, season string, userID uuid.UUID) (database.RankingPosition, bool, error)
+	// Players returns the size of the season's set (ZCARD); false when the set does not exist
+	Players(ctx context.Context, season string) (int64, bool, error)
+	// Seed replaces the season's set with the scores returned by load, keeping the scores
+	// written while load runs; the set expires after ttl
+	Seed(ctx context.Context, season string, load func(ctx context.Context) ([]database.RankingMember, error), ttl time.Duration) error
+}
+
+// rankFromPosition переводит место в наборе в ранг метода ранжирования. В наборе только счета,
+// а при равном счете SQL упорядочивает по timestamp, поэтому игрок с равным счетом ранжируется
+// в PostgreSQL. Для dense равные счета выше игрока тоже сдвигают ранг, так что набор годится,
+// только пока в сезоне не встречались равные счета
+func rankFromPosition(pos database.RankingPosition, method string) (int, bool) {
+	if pos.Tied > 1 {
+		return 0, false
+	}
+	switch method {
+	case config.RankingMethodDense:
+		if pos.SeasonTies {
+			return 0, false
+		}
+	case config.RankingMethodCompetition, config.RankingMethodOrdinal:
+	default:
+		return 0, false
+	}
+	return int(pos.Better) + 1, true
+}
+
+// cachedRank looks the player's rank up in the ranking cache.
+// A missing season set is seeded in the background; until then, and for players the set
+// cannot rank (see rankFromPosition), the caller falls back to PostgreSQL.
+func (s *LeaderboardService) cachedRank(ctx context.Context, userID uuid.UUID, season string) (int, bool) {
+	if s.rankings == nil {
+		return 0, false
+	}
+	logger := utils.LoggerFromContext(ctx)
+
+	pos, found, err := s.rankings.Position(ctx, season, userID)
+	if err != nil {
+		logger.Warn().Err(err).Str("season", season).Msg("Ranking cache lookup failed")
+		return 0, false
+	}
+	if found {
+		return rankFromPosition(pos, s.config.GetRankingMethod())
+	}
+
+	// Игрока нет в наборе: либо набора нет совсем, либо игрок еще не ранжирован
+	if _, exists, err := s.rankings.Players(ctx, season); err == nil && !exists {
+		s.seedRankingCacheAsync(season)
+	}
+	return 0, false
+}
+
+// seasonPlayers returns the number of ranked players of a season, from the ranking cache when it is seeded
+func (s *LeaderboardService) seasonPlayers(ctx context.Context, season string) int64 {
+	if s.rankings != nil {
+		if players, exists, err := s.rankings.Players(ctx, season); err == nil && exists {
+			return players
+		}
+	}
+	players, err := s.scoreRepo.CountBySeason(ctx, season)
+	if err != nil {
+		utils.LoggerFromContext(ctx).Warn().Err(err).Str("season", season).Msg("Failed to count season players")
+		return 0
+	}
+	return players
+}
+
+// rankPercentile is the share of the season's players at or below rank, in percent with one decimal:
+// the leader of 200 players is at 100, the last one at 0.5. Returns 0 when the rank is unknown.
+func rankPercentile(rank int, players int64) float64 {
+	if rank <= 0 || players <= 0 {
+		return 0
+	}
+	if int64(rank) > players {
+		// Ранг из PostgreSQL и число игроков из кэша могут на мгновение разойтись
+		players = int64(rank)
+	}
+	return math.Round(float64(players-int64(rank)+1)/float64(players)*1000) / 10
+}
+
+// seedRankingCacheAsync loads a season's scores into the ranking cache once at a time per season
+func (s *LeaderboardService) seedRankingCacheAsync(season string) {
+	if _, running := s.rankingSeeds.LoadOrStore(season, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer s.rankingSeeds.Delete(season)
+		ctx, cancel := context.WithTimeout(s.ctx, rankingSeedTimeout)
+		defer cancel()
+		if err := s.seedRankingCache(ctx, season); err != nil {
+			log.Warn().Err(err).Str("season", season).Msg("Failed to seed ranking cache")
+		}
+	}()
+}
+
+// seedRankingCache копирует все результаты сезона из PostgreSQL в набор. Запись, пришедшая
+// во время чтения, попадает во временный набор и не перетирается прочитанным значением
+func (s *LeaderboardService) seedRankingCache(ctx context.Context, season string) error {
+	var players int
+	load := func(ctx context.Context) ([]database.RankingMember, error) {
+		var members []database.RankingMember
+		for offset := 0; ; offset += rankingSeedBatch {
+			page, err := s.scoreRepo.FindAll(ctx, season, "desc", rankingSeedBatch, offset)
+			if err != nil {
+				return nil, err
+			}
+			for _, score := range page {
+				members = append(members, database.RankingMember{UserID: score.UserID, Score: score.Score})
+			}
+			if len(page) < rankingSeedBatch {
+				players = len(members)
+				return members, nil
+			}
+		}
+	}
+
+	err := s.rankings.Seed(ctx, season, load, s.config.GetCacheLeaderboardTTL())
+	if errors.Is(err, database.ErrRankingSeedCancelled) {
+		// Удаление в сезоне во время загрузки: набор соберется заново при следующем промахе
+		log.Debug().Str("season", season).Msg("Ranking cache seeding cancelled by a deletion")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Info().Str("season", season).Int("players", players).Msg("✓ Ranking cache seeded")
+	return nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/database"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRankingCache keeps each season's set as a map; positions follow descending score
+type memoryRankingCache struct {
+	mu     sync.Mutex
+	sets   map[string]map[uuid.UUID]int64
+	seeded chan string
+}
+
+func newMemoryRankingCache() *memoryRankingCache {
+	return &memoryRankingCache{sets: make(map[string]map[uuid.UUID]int64), seeded: make(chan string, 1)}
+}
+
+func (c *memoryRankingCache) Position(ctx context.Context, season string, userID uuid.UUID) (database.RankingPosition, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	set := c.sets[season]
+	score, ok := set[userID]
+	if !ok {
+		return database.RankingPosition{}, false, nil
+	}
+	var pos database.RankingPosition
+	seen := make(map[int64]bool, len(set))
+	for _, other := range set {
+		if other > score {
+			pos.Better++
+		}
+		if other == score {
+			pos.Tied++
+		}
+		pos.SeasonTies = pos.SeasonTies || seen[other]
+		seen[other] = true
+	}
+	return pos, true, nil
+}
+
+func (c *memoryRankingCache) Players(ctx context.Context, season string) (int64, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	players := int64(len(c.sets[season]))
+	return players, players > 0, nil
+}
+
+func (c *memoryRankingCache) Seed(ctx context.Context, season string, load func(ctx context.Context) ([]database.RankingMember, error), ttl time.Duration) error {
+	members, err := load(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	set := make(map[uuid.UUID]int64, len(members))
+	for _, member := range members {
+		set[member.UserID] = member.Score
+	}
+	c.sets[season] = set
+	c.mu.Unlock()
+	c.seeded <- season
+	return nil
+}
+
+// seasonScoreRepository adds the paging used by ranking cache seeding to rankedScoreRepository
+type seasonScoreRepository struct {
+	*rankedScoreRepository
+}
+
+func (r *seasonScoreRepository) seasonScores(season string) []*models.Score {
+	var scores []*models.Score
+	for _, score := range r.scores {
+		if score.Season == season {
+			stored := score
+			scores = append(scores, &stored)
+		}
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].Score > scores[j].Score })
+	return scores
+}
+
+func (r *seasonScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*models.Score, error) {
+	scores := r.seasonScores(season)
+	if offset >= len(scores) {
+		return nil, nil
+	}
+	return scores[offset:min(offset+limit, len(scores))], nil
+}
+
+func newRankingCacheService(t *testing.T) (*LeaderboardService, *seasonScoreRepository, *memoryRankingCache) {
+	t.Helper()
+	repo := &seasonScoreRepository{&rankedScoreRepository{newMemoryScoreRepository()}}
+	cfg := &config.Config{
+		Validation: config.ValidationConfig{MinScore: 0, MaxScore: 1000000},
+		Scoring:    config.ScoringConfig{ReturnRankOnSubmit: true},
+	}
+	svc := NewLeaderboardService(repo, nil, nil, cfg)
+	cache := newMemoryRankingCache()
+	svc.rankings = cache
+	return svc, repo, cache
+}
+
+func TestSubmitScore_RankFromRankingCache(t *testing.T) {
+	svc, _, cache := newRankingCacheService(t)
+	userID := uuid.New()
+
+	// The cache disagrees with PostgreSQL on purpose: the response must come from the cache
+	cache.sets["global"] = map[uuid.UUID]int64{uuid.New(): 900, uuid.New(): 800, uuid.New(): 100, userID: 500}
+
+	score, err := svc.SubmitScore(context.Background(), userID, &models.SubmitScoreRequest{Score: 500})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, score.Rank)
+	assert.Equal(t, 50.0, score.Percentile)
+}
+
+func TestSubmitScore_MissingRankingSetIsSeeded(t *testing.T) {
+	svc, repo, cache := newRankingCacheService(t)
+	ctx := context.Background()
+	leader := uuid.New()
+	require.NoError(t, repo.Upsert(ctx, &models.Score{UserID: leader, Score: 900, Season: "global"}))
+	userID := uuid.New()
+
+	// No set yet: the rank comes from PostgreSQL and the season is seeded in the background
+	score, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 500})
+	require.NoError(t, err)
+	assert.Equal(t, 2, score.Rank)
+	assert.Equal(t, 50.0, score.Percentile)
+
+	select {
+	case season := <-cache.seeded:
+		assert.Equal(t, "global", season)
+	case <-time.After(time.Second):
+		t.Fatal("ranking set was not seeded")
+	}
+	pos, found, err := cache.Position(ctx, "global", userID)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int64(1), pos.Better)
+}
+
+func TestSubmitScore_TiedRankFallsBackToPostgres(t *testing.T) {
+	svc, repo, cache := newRankingCacheService(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	require.NoError(t, repo.Upsert(ctx, &models.Score{UserID: uuid.New(), Score: 600, Season: "global"}))
+
+	// The set cannot order equal scores by timestamp, so a tied player is ranked in PostgreSQL;
+	// the set disagrees with it on purpose
+	cache.sets["global"] = map[uuid.UUID]int64{uuid.New(): 900, uuid.New(): 800, uuid.New(): 500, userID: 500}
+
+	score, err := svc.SubmitScore(ctx, userID, &models.SubmitScoreRequest{Score: 500})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, score.Rank)
+}
+
+func TestRankFromPosition(t *testing.T) {
+	tests := []struct {
+		name   string
+		pos    database.RankingPosition
+		method string
+		rank   int
+		ok     bool
+	}{
+		{"dense without ties", database.RankingPosition{Better: 4, Tied: 1}, config.RankingMethodDense, 5, true},
+		{"dense with ties above", database.RankingPosition{Better: 4, Tied: 1, SeasonTies: true}, config.RankingMethodDense, 0, false},
+		{"competition with ties above", database.RankingPosition{Better: 4, Tied: 1, SeasonTies: true}, config.RankingMethodCompetition, 5, true},
+		{"ordinal with ties above", database.RankingPosition{Better: 4, Tied: 1, SeasonTies: true}, config.RankingMethodOrdinal, 5, true},
+		{"tied player", database.RankingPosition{Better: 4, Tied: 2, SeasonTies: true}, config.RankingMethodCompetition, 0, false},
+		{"unknown method", database.RankingPosition{Better: 4, Tied: 1}, "olympic", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, ok := rankFromPosition(tt.pos, tt.method)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.rank, rank)
+		})
+	}
+}
+
+func TestRankPercentile(t *testing.T) {
+	assert.Equal(t, 100.0, rankPercentile(1, 200))
+	assert.Equal(t, 0.5, rankPercentile(200, 200))
+	assert.Equal(t, 66.7, rankPercentile(2, 3))
+	assert.Equal(t, 100.0, rankPercentile(1, 1))
+	assert.Zero(t, rankPercentile(0, 10), "unranked")
+	assert.Equal(t, 20.0, rankPercentile(5, 4), "rank ahead of a stale count")
+}
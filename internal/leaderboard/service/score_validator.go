@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/repository"
+)
+
+// ScoreValidationCacheTTL is how long DynamicScoreValidator reuses a season's limits before asking the database again
+const ScoreValidationCacheTTL = 30 * time.Second
+
+// cachedValidationConfig - лимиты сезона и момент, после которого их нужно перечитать
+type cachedValidationConfig struct {
+	cfg       *models.ValidationConfig
+	expiresAt time.Time
+}
+
+// DynamicScoreValidator resolves the score limits of a season from the scoring_configs table,
+// falling back to Config.Validation for limits without a row. Limits are cached per season for ttl,
+// so a change made on another instance applies within ttl without a restart.
+type DynamicScoreValidator struct {
+	repo     repository.ScoringConfigRepository
+	fallback config.ValidationConfig
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedValidationConfig
+}
+
+// NewDynamicScoreValidator creates a validator reading per-season overrides from repo
+func NewDynamicScoreValidator(repo repository.ScoringConfigRepository, fallback config.ValidationConfig, ttl time.Duration) *DynamicScoreValidator {
+	return &DynamicScoreValidator{
+		repo:     repo,
+		fallback: fallback,
+		ttl:      ttl,
+		now:      time.Now,
+		cache:    make(map[string]cachedValidationConfig),
+	}
+}
+
+// Limits returns the allowed score range of a season. An error means the overrides could not be loaded;
+// nothing is cached then, so the next call retries.
+func (v *DynamicScoreValidator) Limits(ctx context.Context, season string) (int64, int64, error) {
+	cfg, err := v.validationConfig(ctx, season)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	minScore, maxScore := v.fallback.MinScore, v.fallback.MaxScore
+	if cfg.MinScore != nil {
+		minScore = *cfg.MinScore
+	}
+	if cfg.MaxScore != nil {
+		maxScore = *cfg.MaxScore
+	}
+	return minScore, maxScore, nil
+}
+
+// Invalidate drops the cached limits of a season; an empty season drops every season,
+// since rows without a season apply to all of them
+func (v *DynamicScoreValidator) Invalidate(season string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if season == "" {
+		v.cache = make(map[string]cachedValidationConfig)
+		return
+	}
+	delete(v.cache, season)
+}
+
+// validationConfig возвращает лимиты из кэша или читает их из базы
+func (v *DynamicScoreValidator) validationConfig(ctx context.Context, season string) (*models.ValidationConfig, error) {
+	v.mu.Lock()
+	cached, ok := v.cache[season]
+	v.mu.Unlock()
+	if ok && v.now().Before(cached.expiresAt) {
+		return cached.cfg, nil
+	}
+
+	// Запрос идет без блокировки: параллельные промахи по одному сезону просто прочитают строки дважды
+	cfg, err := v.repo.GetValidationConfig(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	v.mu.Lock()
+	v.cache[season] = cachedValidationConfig{cfg: cfg, expiresAt: v.now().Add(v.ttl)}
+	v.mu.Unlock()
+	return cfg, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicScoreValidator_SeasonOverridesGlobal(t *testing.T) {
+	repo := newMemoryScoringConfigRepository(
+		&models.ScoringConfigEntry{Key: models.ScoringConfigMaxScore, Season: "", Value: "5000"},
+		&models.ScoringConfigEntry{Key: models.ScoringConfigMaxScore, Season: "tournament", Value: "900"},
+		&models.ScoringConfigEntry{Key: models.ScoringConfigMinScore, Season: "tournament", Value: "100"},
+	)
+	validator := NewDynamicScoreValidator(repo, config.ValidationConfig{MinScore: 0, MaxScore: 1000000}, time.Minute)
+	ctx := context.Background()
+
+	minScore, maxScore, err := validator.Limits(ctx, "tournament")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), minScore)
+	assert.Equal(t, int64(900), maxScore)
+
+	minScore, maxScore, err = validator.Limits(ctx, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), minScore, "limits without a row come from the config")
+	assert.Equal(t, int64(5000), maxScore)
+}
+
+func TestDynamicScoreValidator_CachesForTTL(t *testing.T) {
+	repo := newMemoryScoringConfigRepository(&models.ScoringConfigEntry{Key: models.ScoringConfigMaxScore, Season: "tournament", Value: "900"})
+	validator := NewDynamicScoreValidator(repo, config.ValidationConfig{MaxScore: 1000000}, 30*time.Second)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	validator.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, _, err := validator.Limits(ctx, "tournament")
+	require.NoError(t, err)
+
+	// Another instance changes the limit directly in the table
+	require.NoError(t, repo.Upsert(ctx, &models.ScoringConfigEntry{Key: models.ScoringConfigMaxScore, Season: "tournament", Value: "500"}))
+
+	now = now.Add(29 * time.Second)
+	_, maxScore, err := validator.Limits(ctx, "tournament")
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), maxScore, "cached until the TTL expires")
+	assert.Equal(t, 1, repo.validationLoads)
+
+	now = now.Add(2 * time.Second)
+	_, maxScore, err = validator.Limits(ctx, "tournament")
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), maxScore)
+	assert.Equal(t, 2, repo.validationLoads)
+}
+
+func TestSubmitScore_AppliesChangedLimitWithoutReload(t *testing.T) {
+	svc := newTestLeaderboardService(newMemoryScoreRepository())
+	repo := newMemoryScoringConfigRepository()
+	svc.SetScoringConfigRepository(repo)
+	ctx := context.Background()
+
+	_, err := svc.SubmitScore(ctx, uuid.New(), &models.SubmitScoreRequest{Score: 800, Season: "tournament"})
+	require.NoError(t, err)
+
+	// UpdateScoringConfig drops the cached season, so the new limit applies at once on this instance
+	_, err = svc.UpdateScoringConfig(ctx, models.ScoringConfigMaxScore, &models.UpdateScoringConfigRequest{Value: "500", Season: "tournament"})
+	require.NoError(t, err)
+
+	_, err = svc.SubmitScore(ctx, uuid.New(), &models.SubmitScoreRequest{Score: 800, Season: "tournament"})
+	assert.Error(t, err)
+	_, err = svc.SubmitScore(ctx, uuid.New(), &models.SubmitScoreRequest{Score: 800, Season: "casual"})
+	assert.NoError(t, err, "other seasons keep the global limit")
+}
+
+func TestScoreLimits_FallsBackToSnapshotOnError(t *testing.T) {
+	svc := newTestLeaderboardService(newMemoryScoreRepository())
+	repo := newMemoryScoringConfigRepository(&models.ScoringConfigEntry{Key: models.ScoringConfigMaxScore, Season: "", Value: "5000"})
+	svc.SetScoringConfigRepository(repo)
+	require.NoError(t, svc.ReloadScoringConfig(context.Background()))
+
+	repo.validationErr = errors.New("db down")
+	minScore, maxScore := svc.scoreLimits(context.Background(), "global")
+	assert.Equal(t, int64(0), minScore)
+	assert.Equal(t, int64(5000), maxScore)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ScoringConfigRefreshInterval - как часто правила перечитываются из scoring_configs (фоновая задача)
+const ScoringConfigRefreshInterval = time.Minute
+
+// editableScoringConfigKeys - ключи, которые можно менять через admin API
+var editableScoringConfigKeys = map[string]bool{
+	models.ScoringConfigMinScore: true,
+	models.ScoringConfigMaxScore: true,
+}
+
+// scoringRules - снимок таблицы scoring_configs: season -> key -> value
+type scoringRules map[string]map[string]int64
+
+// lookup ищет правило сезона, затем общее правило (season = "")
+func (r scoringRules) lookup(season, key string) (int64, bool) {
+	if value, ok := r[season][key]; ok {
+		return value, true
+	}
+	value, ok := r[""][key]
+	return value, ok
+}
+
+// SetScoringConfigRepository enables runtime scoring rules from the scoring_configs table
+func (s *LeaderboardService) SetScoringConfigRepository(repo repository.ScoringConfigRepository) {
+	s.scoringConfigs = repo
+	s.validator = NewDynamicScoreValidator(repo, s.config.Validation, ScoreValidationCacheTTL)
+}
+
+// ReloadScoringConfig replaces the cached scoring rules with the current scoring_configs rows.
+// Registered as a background job; on error the previous rules stay in effect.
+func (s *LeaderboardService) ReloadScoringConfig(ctx context.Context) error {
+	if s.scoringConfigs == nil {
+		return utils.ServiceUnavailable("scoring config", nil)
+	}
+	return s.reloadScoringConfig(ctx)
+}
+
+// reloadScoringConfig заменяет снимок правил целиком; при ошибке остается предыдущий снимок
+func (s *LeaderboardService) reloadScoringConfig(ctx context.Context) error {
+	entries, err := s.scoringConfigs.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules := make(scoringRules)
+	for _, entry := range entries {
+		value, err := strconv.ParseInt(entry.Value, 10, 64)
+		if err != nil {
+			// Строку могли изменить в обход API - пропускаем, чтобы не сломать валидацию
+			log.Warn().Str("key", entry.Key).Str("season", entry.Season).Str("value", entry.Value).Msg("Ignoring non-numeric scoring config value")
+			continue
+		}
+		if rules[entry.Season] == nil {
+			rules[entry.Season] = make(map[string]int64)
+		}
+		rules[entry.Season][entry.Key] = value
+	}
+
+	s.rulesMu.Lock()
+	s.rules = rules
+	s.rulesMu.Unlock()
+	return nil
+}
+
+// scoreLimits returns the allowed score range for a season.
+// Runtime rules take precedence over Config.Validation: they come from the DynamicScoreValidator,
+// or from the last loaded snapshot when the validator cannot reach the database.
+func (s *LeaderboardService) scoreLimits(ctx context.Context, season string) (int64, int64) {
+	if s.validator != nil {
+		minScore, maxScore, err := s.validator.Limits(ctx, season)
+		if err == nil {
+			return minScore, maxScore
+		}
+		utils.LoggerFromContext(ctx).Warn().Err(err).Str("season", season).Msg("Failed to load score limits, using last scoring config snapshot")
+	}
+
+	minScore, maxScore := s.config.Validation.MinScore, s.config.Validation.MaxScore
+
+	s.rulesMu.RLock()
+	defer s.rulesMu.RUnlock()
+	if value, ok := s.rules.lookup(season, models.ScoringConfigMinScore); ok {
+		minScore = value
+	}
+	if value, ok := s.rules.lookup(season, models.ScoringConfigMaxScore); ok {
+		maxScore = value
+	}
+	return minScore, maxScore
+}
+
+// UpdateScoringConfig stores a scoring rule and applies it immediately on this instance.
+// Other instances pick it up on their next refresh.
+func (s *LeaderboardService) UpdateScoringConfig(ctx context.Context, key string, req *models.UpdateScoringConfigRequest) (*models.ScoringConfigEntry, error) {
+	if s.scoringConfigs == nil {
+		return nil, utils.ServiceUnavailable("scoring config", nil)
+	}
+	if !editableScoringConfigKeys[key] {
+		return nil, utils.ValidationError(fmt.Sprintf("unknown scoring config key %q", key), nil)
+	}
+	value, err := strconv.ParseInt(req.Value, 10, 64)
+	if err != nil {
+		return nil, utils.ValidationError("value must be an integer", err)
+	}
+
+	// Новое значение не должно делать диапазон пустым
+	minScore, maxScore := s.scoreLimits(ctx, req.Season)
+	if key == models.ScoringConfigMinScore {
+		minScore = value
+	} else {
+		maxScore = value
+	}
+	if minScore > maxScore {
+		return nil, utils.ValidationError(fmt.Sprintf("min_score %d would exceed max_score %d", minScore, maxScore), nil)
+	}
+
+	entry := &models.ScoringConfigEntry{Key: key, Season: req.Season, Value: strconv.FormatInt(value, 10)}
+	if err := s.scoringConfigs.Upsert(ctx, entry); err != nil {
+		return nil, utils.DatabaseError("scoring config update", err)
+	}
+
+	s.rulesMu.Lock()
+	if s.rules == nil {
+		s.rules = make(scoringRules)
+	}
+	if s.rules[req.Season] == nil {
+		s.rules[req.Season] = make(map[string]int64)
+	}
+	s.rules[req.Season][key] = value
+	s.rulesMu.Unlock()
+	s.validator.Invalidate(req.Season)
+
+	log.Info().Str("key", key).Str("season", req.Season).Int64("value", value).Msg("⚙️ Scoring config updated")
+	return entry, nil
+}
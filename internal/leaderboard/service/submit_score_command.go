@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+)
+
+// SubmitScoreCommand stores a player's score and can revert the submission.
+// The replaced score is snapshotted when Execute runs; Undo puts it back as it was and
+// drops the submission's game and score history entry.
+type SubmitScoreCommand struct {
+	UserID   uuid.UUID
+	Score    int64
+	Season   string
+	Metadata map[string]interface{}
+
+	// OnlyIfHigher keeps the stored score unless the new one is a personal best
+	OnlyIfHigher bool
+
+	repo     repository.ScoreRepository
+	previous *models.Score // nil when the player had no score in the season
+	stored   *models.Score // nil until Execute changes the row
+	// submitted is set once Execute has recorded the submission, even one that kept the stored score
+	submitted bool
+}
+
+// NewSubmitScoreCommand creates a score submission command
+func NewSubmitScoreCommand(repo repository.ScoreRepository, userID uuid.UUID, score int64, season string, metadata map[string]interface{}, onlyIfHigher bool) *SubmitScoreCommand {
+	return &SubmitScoreCommand{
+		UserID:       userID,
+		Score:        score,
+		Season:       season,
+		Metadata:     metadata,
+		OnlyIfHigher: onlyIfHigher,
+		repo:         repo,
+	}
+}
+
+// Execute upserts the score, remembering the previous one for Undo
+func (c *SubmitScoreCommand) Execute(ctx context.Context) error {
+	previous, err := c.repo.FindByUserAndSeason(ctx, c.UserID, c.Season)
+	switch {
+	case err == nil:
+		snapshot := *previous
+		c.previous = &snapshot
+	case errors.Is(err, repository.ErrRecordNotFound):
+		c.previous = nil
+	default:
+		return err
+	}
+
+	score := &models.Score{
+		UserID:   c.UserID,
+		Score:    c.Score,
+		Season:   c.Season,
+		Metadata: c.Metadata,
+	}
+
+	c.stored = nil
+	c.submitted = false
+	if c.OnlyIfHigher {
+		updated, err := c.repo.UpsertOnlyIfHigher(ctx, score)
+		if err != nil {
+			return err
+		}
+		// Не рекорд тоже засчитывается как игра, поэтому Undo все равно нужен
+		c.submitted = true
+		if !updated {
+			return nil
+		}
+	} else {
+		if err := c.repo.Upsert(ctx, score); err != nil {
+			return err
+		}
+		c.submitted = true
+	}
+
+	c.stored = score
+	return nil
+}
+
+// Undo reverts the submission: the replaced score is restored exactly (or removed if there was none)
+// and the game counted by Execute is taken back. Upsert is not used, as it would count another game.
+func (c *SubmitScoreCommand) Undo(ctx context.Context) error {
+	if !c.submitted {
+		return nil
+	}
+
+	var previous *models.Score
+	if c.previous != nil {
+		restored := *c.previous
+		previous = &restored
+	}
+	if err := c.repo.RevertSubmission(ctx, c.UserID, c.Season, previous); err != nil {
+		return err
+	}
+
+	c.stored = nil
+	c.submitted = false
+	return nil
+}
+
+// Name returns the command name for logging
+func (c *SubmitScoreCommand) Name() string {
+	return "SubmitScore"
+}
+
+// Key serializes submissions of the same player and season on the command bus
+func (c *SubmitScoreCommand) Key() string {
+	return c.UserID.String() + ":" + c.Season
+}
+
+// Stored returns the score written by the last Execute, or nil if the row was left unchanged
+func (c *SubmitScoreCommand) Stored() *models.Score {
+	return c.stored
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryScoreRepository keeps one score per user and season, with games played and
+// submissions counted the way the PostgreSQL repository counts them
+type memoryScoreRepository struct {
+	repository.ScoreRepository
+	scores      map[string]models.Score
+	games       map[string]int
+	submissions map[string]int
+}
+
+func newMemoryScoreRepository() *memoryScoreRepository {
+	return &memoryScoreRepository{
+		scores:      make(map[string]models.Score),
+		games:       make(map[string]int),
+		submissions: make(map[string]int),
+	}
+}
+
+func (r *memoryScoreRepository) key(userID uuid.UUID, season string) string {
+	return userID.String() + ":" + season
+}
+
+func (r *memoryScoreRepository) Upsert(ctx context.Context, score *models.Score) error {
+	key := r.key(score.UserID, score.Season)
+	if score.Timestamp.IsZero() {
+		score.Timestamp = time.Now()
+	}
+	r.scores[key] = *score
+	r.games[key]++
+	r.submissions[key]++
+	return nil
+}
+
+func (r *memoryScoreRepository) UpsertOnlyIfHigher(ctx context.Context, score *models.Score) (bool, error) {
+	key := r.key(score.UserID, score.Season)
+	if stored, ok := r.scores[key]; ok && stored.Score >= score.Score {
+		r.games[key]++
+		r.submissions[key]++
+		return false, nil
+	}
+	return true, r.Upsert(ctx, score)
+}
+
+func (r *memoryScoreRepository) RevertSubmission(ctx context.Context, userID uuid.UUID, season string, previous *models.Score) error {
+	key := r.key(userID, season)
+	if _, ok := r.scores[key]; !ok {
+		return repository.ErrRecordNotFound
+	}
+	r.submissions[key]--
+	if previous == nil {
+		delete(r.scores, key)
+		delete(r.games, key)
+		return nil
+	}
+	r.scores[key] = *previous
+	r.games[key]--
+	return nil
+}
+
+func (r *memoryScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*models.Score, error) {
+	score, ok := r.scores[r.key(userID, season)]
+	if !ok {
+		return nil, repository.ErrRecordNotFound
+	}
+	return &score, nil
+}
+
+func (r *memoryScoreRepository) DeleteByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) error {
+	delete(r.scores, r.key(userID, season))
+	return nil
+}
+
+func TestSubmitScoreCommand_UndoRestoresPreviousScore(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryScoreRepository()
+	userID := uuid.New()
+
+	first := NewSubmitScoreCommand(repo, userID, 100, "global", map[string]interface{}{"level": "1"}, false)
+	require.NoError(t, first.Execute(ctx))
+
+	second := NewSubmitScoreCommand(repo, userID, 250, "global", nil, false)
+	require.NoError(t, second.Execute(ctx))
+	assert.Equal(t, int64(250), second.Stored().Score)
+
+	require.NoError(t, second.Undo(ctx))
+	restored, err := repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), restored.Score)
+	assert.Equal(t, "1", restored.Metadata["level"])
+
+	require.NoError(t, first.Undo(ctx))
+	_, err = repo.FindByUserAndSeason(ctx, userID, "global")
+	assert.ErrorIs(t, err, repository.ErrRecordNotFound)
+}
+
+func TestSubmitScoreCommand_UndoRestoresRowExactly(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryScoreRepository()
+	userID := uuid.New()
+	key := repo.key(userID, "global")
+
+	require.NoError(t, NewSubmitScoreCommand(repo, userID, 100, "global", map[string]interface{}{"level": "1"}, false).Execute(ctx))
+	before := repo.scores[key]
+
+	cmd := NewSubmitScoreCommand(repo, userID, 250, "global", nil, false)
+	require.NoError(t, cmd.Execute(ctx))
+	assert.Equal(t, 2, repo.games[key])
+
+	// Undo must not count the restore as another game or submission
+	require.NoError(t, cmd.Undo(ctx))
+	assert.Equal(t, before, repo.scores[key])
+	assert.Equal(t, 1, repo.games[key])
+	assert.Equal(t, 1, repo.submissions[key])
+}
+
+func TestSubmitScoreCommand_NotPersonalBest(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryScoreRepository()
+	userID := uuid.New()
+
+	require.NoError(t, NewSubmitScoreCommand(repo, userID, 500, "global", nil, true).Execute(ctx))
+
+	lower := NewSubmitScoreCommand(repo, userID, 300, "global", nil, true)
+	require.NoError(t, lower.Execute(ctx))
+	assert.Nil(t, lower.Stored())
+
+	// The stored best is kept, but the game Execute counted is taken back
+	require.NoError(t, lower.Undo(ctx))
+	stored, err := repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), stored.Score)
+	assert.Equal(t, 1, repo.games[repo.key(userID, "global")])
+	assert.Equal(t, 1, repo.submissions[repo.key(userID, "global")])
+}
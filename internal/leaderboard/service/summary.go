@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+// GetSummary returns a player's rank and score together with the season's range and size.
+// The player's data and the season stats are loaded in parallel.
+func (s *LeaderboardService) GetSummary(ctx context.Context, userID uuid.UUID, season string) (*models.ScoreSummary, error) {
+	return s.summary(ctx, userID, season, "")
+}
+
+// summary builds the summary; personal_best_season only considers seasons starting with seasonPrefix
+// (the tenant namespace when multitenancy is enabled)
+func (s *LeaderboardService) summary(ctx context.Context, userID uuid.UUID, season, seasonPrefix string) (*models.ScoreSummary, error) {
+	if season == "" {
+		season = s.config.GetDefaultSeason()
+	}
+
+	summary := &models.ScoreSummary{UserID: userID, Season: season}
+	g, gctx := errgroup.WithContext(ctx)
+
+	// Данные игрока: место в сезоне и сезон с лучшим счетом
+	g.Go(func() error {
+		entry, err := s.GetUserRank(gctx, userID, season)
+		if err != nil {
+			return err
+		}
+		summary.UserRank = entry.Rank
+		summary.UserScore = entry.Score
+
+		best, err := s.personalBestSeason(gctx, userID, seasonPrefix)
+		if err != nil {
+			return err
+		}
+		summary.PersonalBestSeason = best
+		return nil
+	})
+
+	// Статистика сезона: число игроков, максимум и минимум
+	// FindAll и CountBySeason кэшируются декоратором репозитория
+	g.Go(func() error {
+		total, err := s.scoreRepo.CountBySeason(gctx, season)
+		if err != nil {
+			return utils.DatabaseError("season count", err)
+		}
+		highest, err := s.scoreRepo.FindAll(gctx, season, "desc", 1, 0)
+		if err != nil {
+			return utils.DatabaseError("season max score", err)
+		}
+		lowest, err := s.scoreRepo.FindAll(gctx, season, "asc", 1, 0)
+		if err != nil {
+			return utils.DatabaseError("season min score", err)
+		}
+
+		summary.TotalPlayers = total
+		if len(highest) > 0 {
+			summary.SeasonMax = highest[0].Score
+		}
+		if len(lowest) > 0 {
+			summary.SeasonMin = lowest[0].Score
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// personalBestSeason returns the season of the player's highest score among seasons with the prefix
+func (s *LeaderboardService) personalBestSeason(ctx context.Context, userID uuid.UUID, seasonPrefix string) (string, error) {
+	scores, err := s.scoreRepo.FindBySpec(ctx, repository.And(
+		repository.NewScoreByUserIDSpec(userID),
+		repository.NewScoreOrderBySpec("score", true),
+	))
+	if err != nil {
+		return "", utils.DatabaseError("personal best query", err)
+	}
+
+	for _, score := range scores {
+		if strings.HasPrefix(score.Season, seasonPrefix) {
+			return score.Season, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/middleware"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// summaryScoreRepository adds the season stats and spec queries GetSummary needs
+type summaryScoreRepository struct {
+	*rankedScoreRepository
+}
+
+func (r *summaryScoreRepository) CountBySeason(ctx context.Context, season string) (int64, error) {
+	_, total, err := r.GetLeaderboard(ctx, season, 0, 0, models.SortByScore, "desc", nil)
+	return total, err
+}
+
+func (r *summaryScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*models.Score, error) {
+	var result []*models.Score
+	for _, score := range r.scores {
+		if score.Season == season {
+			score := score
+			result = append(result, &score)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if sortOrder == "asc" {
+			return result[i].Score < result[j].Score
+		}
+		return result[i].Score > result[j].Score
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *summaryScoreRepository) FindBySpec(ctx context.Context, spec repository.Specification[models.Score]) ([]*models.Score, error) {
+	var result []*models.Score
+	for _, score := range r.scores {
+		if spec.IsSatisfiedBy(score) {
+			score := score
+			result = append(result, &score)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Score > result[j].Score })
+	return result, nil
+}
+
+func newSummaryRepository(t *testing.T, scores ...models.Score) *summaryScoreRepository {
+	t.Helper()
+	repo := &summaryScoreRepository{&rankedScoreRepository{newMemoryScoreRepository()}}
+	for _, score := range scores {
+		score := score
+		require.NoError(t, repo.Upsert(context.Background(), &score))
+	}
+	return repo
+}
+
+func TestGetSummary(t *testing.T) {
+	player, rival, rookie := uuid.New(), uuid.New(), uuid.New()
+	repo := newSummaryRepository(t,
+		models.Score{UserID: player, Score: 700, Season: "global"},
+		models.Score{UserID: rival, Score: 900, Season: "global"},
+		models.Score{UserID: rookie, Score: 100, Season: "global"},
+		models.Score{UserID: player, Score: 1200, Season: "winter"},
+	)
+	svc := newTestLeaderboardService(repo)
+
+	summary, err := svc.GetSummary(context.Background(), player, "")
+	require.NoError(t, err)
+	assert.Equal(t, &models.ScoreSummary{
+		UserID:             player,
+		Season:             "global",
+		UserRank:           2,
+		UserScore:          700,
+		SeasonMax:          900,
+		SeasonMin:          100,
+		TotalPlayers:       3,
+		PersonalBestSeason: "winter",
+	}, summary)
+
+	_, err = svc.GetSummary(context.Background(), uuid.New(), "global")
+	assert.ErrorIs(t, err, errUserNotRanked)
+}
+
+func TestMultiTenantGetSummary_PersonalBestStaysInTenant(t *testing.T) {
+	player := uuid.New()
+	repo := newSummaryRepository(t,
+		models.Score{UserID: player, Score: 300, Season: "studio_a:summer"},
+		models.Score{UserID: player, Score: 500, Season: "studio_a:winter"},
+		models.Score{UserID: player, Score: 9000, Season: "studio_b:summer"},
+	)
+	svc := NewMultiTenantLeaderboardService(newTestLeaderboardService(repo))
+
+	summary, err := svc.GetSummary(middleware.WithTenantID(context.Background(), "studio_a"), player, "summer")
+	require.NoError(t, err)
+	assert.Equal(t, "summer", summary.Season)
+	assert.Equal(t, int64(300), summary.UserScore)
+	assert.Equal(t, int64(1), summary.TotalPlayers)
+	assert.Equal(t, "winter", summary.PersonalBestSeason, "other tenants' seasons are ignored")
+}
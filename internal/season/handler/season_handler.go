@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"leaderboard-service/internal/season/models"
+	sharedhandlers "leaderboard-service/internal/shared/handlers"
+	"leaderboard-service/internal/shared/middleware"
+	sharedmodels "leaderboard-service/internal/shared/models"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// SeasonServiceInterface defines the season operations used by SeasonHandler
+type SeasonServiceInterface interface {
+	Update(ctx context.Context, name string, req *models.UpdateSeasonRequest) (*models.Season, error)
+}
+
+// SeasonHandler handles season admin endpoints
+type SeasonHandler struct {
+	seasonService SeasonServiceInterface
+}
+
+// NewSeasonHandler creates a new season handler
+func NewSeasonHandler(seasonService SeasonServiceInterface) *SeasonHandler {
+	return &SeasonHandler{
+		seasonService: seasonService,
+	}
+}
+
+// Update edits a season's display name and schedule
+// PATCH /admin/seasons/{name}
+func (h *SeasonHandler) Update(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		sharedhandlers.RespondError(w, "season name is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateSeasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhandlers.RespondError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	season, err := h.seasonService.Update(r.Context(), name, &req)
+	if err != nil {
+		log.Error().Err(err).Str("season", name).Msg("Failed to update season")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	log.Info().
+		Str("audit", "season_update").
+		Str("admin_user_id", adminID.String()).
+		Str("season", name).
+		Msg("📝 Season metadata updated")
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "season updated",
+		Data:    season,
+	}, http.StatusOK)
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Season holds display metadata and the schedule of a leaderboard season.
+// Name is the identifier used in the season field of scores.
+type Season struct {
+	Name        string     `json:"name" gorm:"type:text;primaryKey"`
+	DisplayName string     `json:"display_name" gorm:"type:text;not null"`
+	StartsAt    time.Time  `json:"starts_at" gorm:"not null"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the table name for GORM
+func (Season) TableName() string {
+	return "seasons"
+}
+
+// UpdateSeasonRequest is the payload for PATCH /admin/seasons/{name}; omitted fields are left unchanged
+type UpdateSeasonRequest struct {
+	DisplayName *string    `json:"display_name,omitempty" validate:"omitempty,min=1,max=100"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	EndsAt      *time.Time `json:"ends_at,omitempty"`
+}
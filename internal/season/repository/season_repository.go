@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"leaderboard-service/internal/season/models"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+)
+
+// PostgresSeasonRepository is a PostgreSQL implementation of SeasonRepository
+type PostgresSeasonRepository struct {
+	*repository.BaseRepository[models.Season]
+}
+
+// NewPostgresSeasonRepository creates a new PostgreSQL season repository
+func NewPostgresSeasonRepository(db *database.PostgresDB) repository.SeasonRepository {
+	return &PostgresSeasonRepository{
+		BaseRepository: repository.NewBaseRepository[models.Season](db),
+	}
+}
+
+// FindByName retrieves a season by name
+func (r *PostgresSeasonRepository) FindByName(ctx context.Context, name string) (*models.Season, error) {
+	return r.BaseRepository.FindOne(ctx, "name = ?", name)
+}
+
+// Update saves changes to an existing season
+// Save пишет все колонки, поэтому сбрасываемый ends_at тоже попадает в UPDATE
+func (r *PostgresSeasonRepository) Update(ctx context.Context, season *models.Season) error {
+	return r.BaseRepository.Update(ctx, season)
+}
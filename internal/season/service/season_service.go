@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"leaderboard-service/internal/season/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+)
+
+// maxDisplayNameLength caps the length of a season display name
+const maxDisplayNameLength = 100
+
+// SeasonService manages season metadata
+type SeasonService struct {
+	seasons repository.SeasonRepository
+}
+
+// NewSeasonService creates a new season service
+func NewSeasonService(seasons repository.SeasonRepository) *SeasonService {
+	return &SeasonService{
+		seasons: seasons,
+	}
+}
+
+// Update applies the fields set in req to a season.
+// The resulting schedule must end after it starts.
+func (s *SeasonService) Update(ctx context.Context, name string, req *models.UpdateSeasonRequest) (*models.Season, error) {
+	if req.DisplayName == nil && req.StartsAt == nil && req.EndsAt == nil {
+		return nil, utils.ValidationError("at least one of display_name, starts_at, ends_at is required", nil)
+	}
+
+	season, err := s.seasons.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, utils.NotFound("season", err)
+		}
+		return nil, utils.DatabaseError("season lookup", err)
+	}
+
+	if req.DisplayName != nil {
+		displayName := strings.TrimSpace(*req.DisplayName)
+		if displayName == "" {
+			return nil, utils.ValidationError("display_name cannot be empty", nil)
+		}
+		if len(displayName) > maxDisplayNameLength {
+			return nil, utils.ValidationError("display_name cannot be longer than 100 characters", nil)
+		}
+		season.DisplayName = displayName
+	}
+	if req.StartsAt != nil {
+		season.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		endsAt := *req.EndsAt
+		season.EndsAt = &endsAt
+	}
+
+	if season.EndsAt != nil && season.EndsAt.Before(season.StartsAt) {
+		return nil, utils.ValidationError("ends_at cannot be before starts_at", nil)
+	}
+
+	if err := s.seasons.Update(ctx, season); err != nil {
+		return nil, utils.DatabaseError("season update", err)
+	}
+
+	return season, nil
+}
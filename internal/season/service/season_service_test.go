@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/season/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySeasonRepository keeps seasons in a map
+type memorySeasonRepository struct {
+	seasons map[string]models.Season
+	updates int
+}
+
+func newMemorySeasonRepository(seasons ...models.Season) *memorySeasonRepository {
+	repo := &memorySeasonRepository{seasons: make(map[string]models.Season)}
+	for _, season := range seasons {
+		repo.seasons[season.Name] = season
+	}
+	return repo
+}
+
+func (r *memorySeasonRepository) FindByName(ctx context.Context, name string) (*models.Season, error) {
+	season, ok := r.seasons[name]
+	if !ok {
+		return nil, repository.ErrRecordNotFound
+	}
+	return &season, nil
+}
+
+func (r *memorySeasonRepository) Update(ctx context.Context, season *models.Season) error {
+	r.updates++
+	r.seasons[season.Name] = *season
+	return nil
+}
+
+func requireStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	assert.Equal(t, status, appErr.StatusCode)
+}
+
+var springStart = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestSeasonService_Update(t *testing.T) {
+	repo := newMemorySeasonRepository(models.Season{Name: "2024_01", DisplayName: "Season 1", StartsAt: springStart})
+	svc := NewSeasonService(repo)
+
+	displayName := "  Season 1 - Spring "
+	endsAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	season, err := svc.Update(context.Background(), "2024_01", &models.UpdateSeasonRequest{DisplayName: &displayName, EndsAt: &endsAt})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Season 1 - Spring", season.DisplayName)
+	require.NotNil(t, season.EndsAt)
+	assert.True(t, endsAt.Equal(*season.EndsAt))
+	assert.True(t, springStart.Equal(season.StartsAt), "starts_at was not sent and must not change")
+	assert.Equal(t, "Season 1 - Spring", repo.seasons["2024_01"].DisplayName)
+}
+
+func TestSeasonService_UpdateValidation(t *testing.T) {
+	before := springStart.Add(-time.Hour)
+	empty := " "
+	existingEnd := springStart.Add(24 * time.Hour)
+	lateStart := existingEnd.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		req  models.UpdateSeasonRequest
+	}{
+		{"empty request", models.UpdateSeasonRequest{}},
+		{"blank display name", models.UpdateSeasonRequest{DisplayName: &empty}},
+		{"ends before start", models.UpdateSeasonRequest{EndsAt: &before}},
+		{"start moved past existing end", models.UpdateSeasonRequest{StartsAt: &lateStart}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemorySeasonRepository(models.Season{Name: "2024_01", DisplayName: "Season 1", StartsAt: springStart, EndsAt: &existingEnd})
+			svc := NewSeasonService(repo)
+
+			_, err := svc.Update(context.Background(), "2024_01", &tt.req)
+			requireStatus(t, err, http.StatusBadRequest)
+			assert.Zero(t, repo.updates)
+		})
+	}
+}
+
+func TestSeasonService_UpdateUnknownSeason(t *testing.T) {
+	svc := NewSeasonService(newMemorySeasonRepository())
+
+	displayName := "Nope"
+	_, err := svc.Update(context.Background(), "missing", &models.UpdateSeasonRequest{DisplayName: &displayName})
+	requireStatus(t, err, http.StatusNotFound)
+}
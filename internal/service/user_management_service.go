@@ -2,28 +2,56 @@ package service
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	authmodels "leaderboard-service/internal/auth/models"
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// bulkInsertBatchSize is the number of users inserted per INSERT statement during bulk import
+const bulkInsertBatchSize = 500
+
+// errEmailRegistered is the per-row error of an import row whose email already has an account
+const errEmailRegistered = "email is already registered"
+
+// BulkRegisterResult describes the outcome of a single CSV row in a bulk import
+// Line is the row's line number in the CSV file, the header being line 1
+type BulkRegisterResult struct {
+	Line   int        `json:"line"`
+	Email  string     `json:"email"`
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
 // UserManagementService demonstrates Unit of Work usage
 type UserManagementService struct {
-	uow repository.UnitOfWork
+	uow           repository.UnitOfWork
+	defaultSeason string
 }
 
 // NewUserManagementService creates a new user management service
 func NewUserManagementService(uow repository.UnitOfWork) *UserManagementService {
 	return &UserManagementService{
-		uow: uow,
+		uow:           uow,
+		defaultSeason: config.FallbackSeason,
 	}
 }
 
+// SetDefaultSeason sets the season of scores written without one (Config.GetDefaultSeason)
+func (s *UserManagementService) SetDefaultSeason(season string) {
+	s.defaultSeason = season
+}
+
 // RegisterUserWithInitialScore creates a user and gives them an initial score
 // This operation must be atomic - both or neither should succeed
 func (s *UserManagementService) RegisterUserWithInitialScore(
@@ -59,7 +87,7 @@ func (s *UserManagementService) RegisterUserWithInitialScore(
 
 		// 3. Create initial score
 		if season == "" {
-			season = "global"
+			season = s.defaultSeason
 		}
 		score = &leaderboardmodels.Score{
 			UserID: user.ID,
@@ -89,7 +117,7 @@ func (s *UserManagementService) TransferScore(
 	season string,
 ) error {
 	if season == "" {
-		season = "global"
+		season = s.defaultSeason
 	}
 
 	return s.uow.Do(ctx, func(uow repository.UnitOfWork) error {
@@ -159,6 +187,34 @@ func (s *UserManagementService) DeleteUserWithScores(
 	})
 }
 
+// PromoteToAdmin grants admin rights to targetUserID. Only an existing admin may do it:
+// a requesting user without is_admin gets 403, an unknown target gets 404.
+// The new admin receives the admin role with their next login token.
+func (s *UserManagementService) PromoteToAdmin(ctx context.Context, targetUserID, requestingAdminID uuid.UUID) error {
+	return s.uow.Do(ctx, func(uow repository.UnitOfWork) error {
+		userRepo := uow.GetUserRepository()
+
+		requester, err := userRepo.FindByID(ctx, requestingAdminID)
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return utils.Forbidden("only admins can promote users", err)
+		}
+		if err != nil {
+			return utils.DatabaseError("requesting user lookup", err)
+		}
+		if !requester.IsAdmin {
+			return utils.Forbidden("only admins can promote users", nil)
+		}
+
+		if err := userRepo.SetAdmin(ctx, targetUserID, true); err != nil {
+			if errors.Is(err, repository.ErrRecordNotFound) {
+				return utils.NotFound("user", err)
+			}
+			return utils.DatabaseError("admin promotion", err)
+		}
+		return nil
+	})
+}
+
 // BatchUpdateScores updates multiple user scores atomically
 func (s *UserManagementService) BatchUpdateScores(
 	ctx context.Context,
@@ -174,7 +230,7 @@ func (s *UserManagementService) BatchUpdateScores(
 		for _, update := range updates {
 			season := update.Season
 			if season == "" {
-				season = "global"
+				season = s.defaultSeason
 			}
 
 			score := &leaderboardmodels.Score{
@@ -191,3 +247,155 @@ func (s *UserManagementService) BatchUpdateScores(
 		return nil
 	})
 }
+
+// BulkRegisterUsers imports users from a CSV with name,email,password columns.
+// Rows that cannot be parsed, fail validation or whose email is already registered are
+// reported in the results and skipped. The email check, hashing and the batch insert run in
+// a single transaction, and the insert skips emails taken in the meantime, so an import that
+// races another one reports those rows as registered; a database error rolls back the whole import.
+func (s *UserManagementService) BulkRegisterUsers(ctx context.Context, reader io.Reader) ([]BulkRegisterResult, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	// Rows with a wrong number of fields are reported per row below
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("csv is empty")
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	columns, err := bulkColumnIndexes(header)
+	if err != nil {
+		return nil, err
+	}
+
+	// bulkRow is a row that passed validation, with its index in results
+	type bulkRow struct {
+		name, email, password string
+		result                int
+	}
+
+	var results []BulkRegisterResult
+	var rows []bulkRow
+	seenEmails := make(map[string]bool)
+
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// The reader resumes on the next line, so one malformed row does not stop the import
+			results = append(results, BulkRegisterResult{Line: parseErr.StartLine, Error: fmt.Sprintf("malformed row: %v", parseErr.Err)})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		field := func(column string) string {
+			if index := columns[column]; index < len(record) {
+				return record[index]
+			}
+			return ""
+		}
+		name := strings.TrimSpace(field("name"))
+		email := strings.ToLower(strings.TrimSpace(field("email")))
+		password := field("password")
+
+		// Same name rules as AuthService.Register
+		validator := utils.NewValidator().
+			Required("name", name).
+			MinLength("name", name, 3).
+			MaxLength("name", name, 50).
+			NoSpecialChars("name", name, "").
+			Email("email", email).
+			MinLength("password", password, 6).
+			Custom("email", !seenEmails[email], "is duplicated in the file")
+		if err := validator.Error(); err != nil {
+			results = append(results, BulkRegisterResult{Line: line, Email: email, Error: err.Error()})
+			continue
+		}
+		seenEmails[email] = true
+
+		rows = append(rows, bulkRow{name: name, email: email, password: password, result: len(results)})
+		results = append(results, BulkRegisterResult{Line: line, Email: email})
+	}
+
+	if len(rows) == 0 {
+		return results, nil
+	}
+
+	err = s.uow.Do(ctx, func(uow repository.UnitOfWork) error {
+		userRepo := uow.GetUserRepository()
+
+		var users []*authmodels.User
+		var userResults []int // index into results for each user in users
+		for _, row := range rows {
+			// Registered emails are checked before hashing so they do not cost a bcrypt round each
+			exists, err := userRepo.ExistsByEmail(ctx, row.email)
+			if err != nil {
+				return fmt.Errorf("failed to check email: %w", err)
+			}
+			if exists {
+				results[row.result].Error = errEmailRegistered
+				continue
+			}
+
+			hashedPassword, err := bcrypt.GenerateFromPassword([]byte(row.password), bcrypt.DefaultCost)
+			if err != nil {
+				results[row.result].Error = "failed to hash password"
+				continue
+			}
+
+			users = append(users, &authmodels.User{
+				Name:     row.name,
+				Email:    row.email,
+				Password: string(hashedPassword),
+			})
+			userResults = append(userResults, row.result)
+		}
+
+		if len(users) == 0 {
+			return nil
+		}
+
+		// A concurrent import may register an email after the check; such rows are skipped, not a failed batch
+		if err := userRepo.CreateBatchSkippingExisting(ctx, users, bulkInsertBatchSize); err != nil {
+			return fmt.Errorf("failed to insert users: %w", err)
+		}
+
+		for i, user := range users {
+			if user.ID == uuid.Nil {
+				results[userResults[i]].Error = errEmailRegistered
+				continue
+			}
+			id := user.ID
+			results[userResults[i]].UserID = &id
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// bulkColumnIndexes maps the required CSV columns to their positions in the header
+func bulkColumnIndexes(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, required := range []string{"name", "email", "password"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header is missing required column %q", required)
+		}
+	}
+	return columns, nil
+}
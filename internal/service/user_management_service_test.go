@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	authmodels "leaderboard-service/internal/auth/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/testutil"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserManagementService_PromoteToAdmin(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewInMemoryStore()
+	svc := NewUserManagementService(store.UnitOfWork())
+
+	adminID := store.AddUser("admin")
+	require.NoError(t, store.Users.SetAdmin(ctx, adminID, true))
+	playerID := store.AddUser("player")
+	otherID := store.AddUser("other")
+
+	t.Run("non-admin is forbidden", func(t *testing.T) {
+		err := svc.PromoteToAdmin(ctx, otherID, playerID)
+		assertAppErrorStatus(t, err, http.StatusForbidden)
+
+		other, err := store.Users.FindByID(ctx, otherID)
+		require.NoError(t, err)
+		assert.False(t, other.IsAdmin)
+	})
+
+	t.Run("unknown requester is forbidden", func(t *testing.T) {
+		assertAppErrorStatus(t, svc.PromoteToAdmin(ctx, otherID, uuid.New()), http.StatusForbidden)
+	})
+
+	t.Run("unknown target is not found", func(t *testing.T) {
+		assertAppErrorStatus(t, svc.PromoteToAdmin(ctx, uuid.New(), adminID), http.StatusNotFound)
+	})
+
+	t.Run("admin promotes a player", func(t *testing.T) {
+		require.NoError(t, svc.PromoteToAdmin(ctx, playerID, adminID))
+
+		player, err := store.Users.FindByID(ctx, playerID)
+		require.NoError(t, err)
+		assert.True(t, player.IsAdmin)
+
+		// The promoted player can now promote others
+		require.NoError(t, svc.PromoteToAdmin(ctx, otherID, playerID))
+	})
+}
+
+func assertAppErrorStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	assert.Equal(t, status, appErr.StatusCode)
+}
+
+func TestUserManagementService_BulkRegisterUsersReportsRegisteredEmails(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewInMemoryStore()
+	svc := NewUserManagementService(store.UnitOfWork())
+	require.NoError(t, store.Users.Create(ctx, &authmodels.User{Name: "Taken", Email: "taken@example.com", Password: "hashed"}))
+
+	csv := "name,email,password\n" +
+		"Taken Again,taken@example.com,secret1\n" +
+		"New Player,new@example.com,secret1\n" +
+		"<b>Bold</b>,bold@example.com,secret1\n"
+	results, err := svc.BulkRegisterUsers(ctx, strings.NewReader(csv))
+	require.NoError(t, err, "a registered email must not fail the whole import")
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "email is already registered", results[0].Error)
+	assert.Nil(t, results[0].UserID)
+	assert.Equal(t, 2, results[0].Line)
+
+	assert.Empty(t, results[1].Error)
+	require.NotNil(t, results[1].UserID)
+	created, err := store.Users.FindByEmail(ctx, "new@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, *results[1].UserID, created.ID)
+
+	assert.Contains(t, results[2].Error, "name")
+	assert.Nil(t, results[2].UserID)
+}
+
+func TestUserManagementService_BulkRegisterUsersReportsMalformedRows(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewInMemoryStore()
+	svc := NewUserManagementService(store.UnitOfWork())
+
+	csv := "name,email,password\n" +
+		"Short Row,short@example.com\n" +
+		"Bad \"Quote,quote@example.com,secret1\n" +
+		"New Player,new@example.com,secret1\n"
+	results, err := svc.BulkRegisterUsers(ctx, strings.NewReader(csv))
+	require.NoError(t, err, "a malformed row must not fail the whole import")
+	require.Len(t, results, 3)
+
+	assert.Equal(t, 2, results[0].Line)
+	assert.Contains(t, results[0].Error, "password")
+	assert.Nil(t, results[0].UserID)
+
+	assert.Equal(t, 3, results[1].Line)
+	assert.Contains(t, results[1].Error, "malformed row")
+	assert.Nil(t, results[1].UserID)
+
+	assert.Equal(t, 4, results[2].Line)
+	assert.Empty(t, results[2].Error)
+	require.NotNil(t, results[2].UserID)
+}
+
+// racingUserRepository registers every checked email right after the check,
+// as a concurrent import committing between the check and the insert would
+type racingUserRepository struct {
+	*testutil.InMemoryUserRepository
+}
+
+func (r *racingUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	exists, err := r.InMemoryUserRepository.ExistsByEmail(ctx, email)
+	if err != nil || exists {
+		return exists, err
+	}
+	return false, r.InMemoryUserRepository.Create(ctx, &authmodels.User{Name: "Concurrent", Email: email, Password: "hashed"})
+}
+
+type racingUnitOfWork struct {
+	repository.UnitOfWork
+	users repository.UserRepository
+}
+
+func (u *racingUnitOfWork) GetUserRepository() repository.UserRepository { return u.users }
+
+func (u *racingUnitOfWork) Do(ctx context.Context, fn func(uow repository.UnitOfWork) error) error {
+	return fn(u)
+}
+
+func TestUserManagementService_BulkRegisterUsersReportsConcurrentlyRegisteredEmails(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewInMemoryStore()
+	uow := &racingUnitOfWork{UnitOfWork: store.UnitOfWork(), users: &racingUserRepository{store.Users}}
+	svc := NewUserManagementService(uow)
+
+	results, err := svc.BulkRegisterUsers(ctx, strings.NewReader("name,email,password\nNew Player,new@example.com,secret1\n"))
+	require.NoError(t, err, "losing the race for an email must not fail the import")
+	require.Len(t, results, 1)
+
+	assert.Equal(t, "email is already registered", results[0].Error)
+	assert.Nil(t, results[0].UserID)
+	existing, err := store.Users.FindByEmail(ctx, "new@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Concurrent", existing.Name)
+}
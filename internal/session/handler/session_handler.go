@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"leaderboard-service/internal/session/models"
+	sharedhandlers "leaderboard-service/internal/shared/handlers"
+	"leaderboard-service/internal/shared/middleware"
+	sharedmodels "leaderboard-service/internal/shared/models"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// GameSessionServiceInterface defines the game session operations used by SessionHandler
+type GameSessionServiceInterface interface {
+	Create(ctx context.Context, userID uuid.UUID, req *models.CreateSessionRequest) (*models.GameSession, error)
+	Get(ctx context.Context, sessionID, userID uuid.UUID) (*models.GameSession, error)
+	Update(ctx context.Context, sessionID, userID uuid.UUID, req *models.UpdateSessionRequest) (*models.GameSession, error)
+	Delete(ctx context.Context, sessionID, userID uuid.UUID) error
+	SubmitScore(ctx context.Context, sessionID, userID uuid.UUID, req *models.SessionScoreRequest) (*models.SessionScoreResponse, error)
+}
+
+// SessionHandler handles game session endpoints
+type SessionHandler struct {
+	sessionService GameSessionServiceInterface
+}
+
+// NewSessionHandler creates a new game session handler
+func NewSessionHandler(sessionService GameSessionServiceInterface) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+// Create opens a game session for the current user
+// POST /sessions
+func (h *SessionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req models.CreateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhandlers.RespondError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.sessionService.Create(r.Context(), userID, &req)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to create game session")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "session created",
+		Data:    session,
+	}, http.StatusCreated)
+}
+
+// Get returns a game session of the current user
+// GET /sessions/{sessionID}
+func (h *SessionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, sessionID, ok := h.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	session, err := h.sessionService.Get(r.Context(), sessionID, userID)
+	if err != nil {
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Data:    session,
+	}, http.StatusOK)
+}
+
+// Update changes the game mode, season or strategies of a session
+// PATCH /sessions/{sessionID}
+func (h *SessionHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, sessionID, ok := h.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhandlers.RespondError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.sessionService.Update(r.Context(), sessionID, userID, &req)
+	if err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to update game session")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "session updated",
+		Data:    session,
+	}, http.StatusOK)
+}
+
+// Delete ends a game session
+// DELETE /sessions/{sessionID}
+func (h *SessionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, sessionID, ok := h.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.sessionService.Delete(r.Context(), sessionID, userID); err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to delete game session")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "session deleted",
+	}, http.StatusOK)
+}
+
+// SubmitScore stores a game result calculated with the session's scoring strategy
+// POST /sessions/{sessionID}/submit-score
+func (h *SessionHandler) SubmitScore(w http.ResponseWriter, r *http.Request) {
+	userID, sessionID, ok := h.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.SessionScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sharedhandlers.RespondError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.sessionService.SubmitScore(r.Context(), sessionID, userID, &req)
+	if err != nil {
+		log.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to submit session score")
+		sharedhandlers.RespondAppError(w, err)
+		return
+	}
+
+	sharedhandlers.RespondJSON(w, sharedmodels.SuccessResponse{
+		Success: true,
+		Message: "score submitted",
+		Data:    result,
+	}, http.StatusCreated)
+}
+
+// parseRequest extracts the authenticated user and the {sessionID} URL parameter.
+// Writes the error response and returns ok=false when either is missing or invalid.
+func (h *SessionHandler) parseRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		sharedhandlers.RespondError(w, "unauthorized", http.StatusUnauthorized)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	sessionID, err := uuid.Parse(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		sharedhandlers.RespondError(w, "invalid session ID", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, sessionID, true
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+
+	"github.com/google/uuid"
+)
+
+// GameSession is a player's game session. Scores submitted through it are recalculated
+// with the session's scoring strategy before they reach the leaderboard.
+type GameSession struct {
+	ID       uuid.UUID `json:"id"`
+	UserID   uuid.UUID `json:"user_id"`
+	GameMode string    `json:"game_mode"`
+	// Season receives the session's scores; empty means the default season
+	Season          string    `json:"season,omitempty"`
+	ScoringStrategy string    `json:"scoring_strategy"`
+	RankingStrategy string    `json:"ranking_strategy"`
+	CreatedAt       time.Time `json:"created_at"`
+	// ExpiresAt moves forward on every update and score submission
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateSessionRequest opens a game session; empty fields take their defaults
+type CreateSessionRequest struct {
+	GameMode        string `json:"game_mode"`
+	Season          string `json:"season"`
+	ScoringStrategy string `json:"scoring_strategy"`
+	RankingStrategy string `json:"ranking_strategy"`
+}
+
+// UpdateSessionRequest changes the fields that are set
+type UpdateSessionRequest struct {
+	GameMode        *string `json:"game_mode,omitempty"`
+	Season          *string `json:"season,omitempty"`
+	ScoringStrategy *string `json:"scoring_strategy,omitempty"`
+	RankingStrategy *string `json:"ranking_strategy,omitempty"`
+}
+
+// SessionScoreRequest is a raw game result; the session's scoring strategy turns it into the stored score
+type SessionScoreRequest struct {
+	Score        int64                  `json:"score"`
+	Difficulty   int                    `json:"difficulty,omitempty"`
+	Multiplier   float64                `json:"multiplier,omitempty"`
+	Combo        int                    `json:"combo,omitempty"`
+	TimeBonus    int64                  `json:"time_bonus,omitempty"`
+	Achievements []string               `json:"achievements,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	// IdempotencyKey is passed on to the leaderboard submission
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// SessionScoreResponse is the score stored for a session submission
+type SessionScoreResponse struct {
+	SessionID       uuid.UUID                `json:"session_id"`
+	BaseScore       int64                    `json:"base_score"`
+	ScoringStrategy string                   `json:"scoring_strategy"`
+	Score           *leaderboardmodels.Score `json:"score"`
+}
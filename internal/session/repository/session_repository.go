@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"leaderboard-service/internal/session/models"
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisSessionPrefix - префикс ключей игровых сессий в Redis
+const redisSessionPrefix = "game_session:"
+
+// RedisGameSessionRepository хранит игровые сессии в Redis как JSON; срок жизни задает TTL ключа
+type RedisGameSessionRepository struct {
+	client *redis.Client
+}
+
+// NewRedisGameSessionRepository создает репозиторий сессий поверх общего клиента Redis
+func NewRedisGameSessionRepository(redisClient *database.RedisClient) repository.GameSessionRepository {
+	return &RedisGameSessionRepository{client: redisClient.Client}
+}
+
+// Save сохраняет сессию и продлевает ее TTL
+func (r *RedisGameSessionRepository) Save(ctx context.Context, session *models.GameSession, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game session %s: %w", session.ID, err)
+	}
+	if err := r.client.Set(ctx, sessionKey(session.ID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save game session %s: %w", session.ID, err)
+	}
+	return nil
+}
+
+// FindByID возвращает сессию; истекший ключ Redis удаляет сам, поэтому он тоже ErrRecordNotFound
+func (r *RedisGameSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.GameSession, error) {
+	data, err := r.client.Get(ctx, sessionKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, repository.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game session %s: %w", id, err)
+	}
+
+	var session models.GameSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game session %s: %w", id, err)
+	}
+	return &session, nil
+}
+
+// Delete удаляет сессию
+func (r *RedisGameSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.client.Del(ctx, sessionKey(id)).Err(); err != nil {
+		return fmt.Errorf("failed to delete game session %s: %w", id, err)
+	}
+	return nil
+}
+
+func sessionKey(id uuid.UUID) string {
+	return redisSessionPrefix + id.String()
+}
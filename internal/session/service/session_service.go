@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/session/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultGameMode is the game mode of sessions created without one
+	DefaultGameMode = "classic"
+	// DefaultScoringStrategy and DefaultRankingStrategy match strategy.NewGameSession
+	DefaultScoringStrategy = "simple"
+	DefaultRankingStrategy = "standard"
+	// maxSeasonLength matches the scores.season column
+	maxSeasonLength = 50
+)
+
+// ScoreSubmitter stores a calculated score on the leaderboard; implemented by LeaderboardService
+type ScoreSubmitter interface {
+	SubmitScore(ctx context.Context, userID uuid.UUID, req *leaderboardmodels.SubmitScoreRequest) (*leaderboardmodels.Score, error)
+}
+
+// GameSessionService manages game sessions and submits their scores through the session's scoring strategy
+type GameSessionService struct {
+	sessions repository.GameSessionRepository
+	scores   ScoreSubmitter
+	registry *strategy.StrategyRegistry
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// NewGameSessionService creates a game session service; sessions pick their strategies from registry.
+// sessions may be nil when Redis is not available; every call then fails with 503.
+func NewGameSessionService(sessions repository.GameSessionRepository, scores ScoreSubmitter, registry *strategy.StrategyRegistry, ttl time.Duration) *GameSessionService {
+	return &GameSessionService{
+		sessions: sessions,
+		scores:   scores,
+		registry: registry,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+// Create opens a session owned by userID
+func (s *GameSessionService) Create(ctx context.Context, userID uuid.UUID, req *models.CreateSessionRequest) (*models.GameSession, error) {
+	if s.sessions == nil {
+		return nil, utils.ServiceUnavailable("game session", nil)
+	}
+
+	session := s.newSession(uuid.New(), userID)
+	if req.GameMode != "" {
+		session.GameMode = req.GameMode
+	}
+	if req.ScoringStrategy != "" {
+		session.ScoringStrategy = req.ScoringStrategy
+	}
+	if req.RankingStrategy != "" {
+		session.RankingStrategy = req.RankingStrategy
+	}
+	session.Season = req.Season
+	if err := s.validateSession(session); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Get returns a session of userID
+func (s *GameSessionService) Get(ctx context.Context, sessionID, userID uuid.UUID) (*models.GameSession, error) {
+	if s.sessions == nil {
+		return nil, utils.ServiceUnavailable("game session", nil)
+	}
+	return s.findOwned(ctx, sessionID, userID)
+}
+
+// Update changes the fields set in req and extends the session's lifetime
+func (s *GameSessionService) Update(ctx context.Context, sessionID, userID uuid.UUID, req *models.UpdateSessionRequest) (*models.GameSession, error) {
+	if s.sessions == nil {
+		return nil, utils.ServiceUnavailable("game session", nil)
+	}
+
+	session, err := s.findOwned(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.GameMode != nil {
+		session.GameMode = *req.GameMode
+	}
+	if req.Season != nil {
+		session.Season = *req.Season
+	}
+	if req.ScoringStrategy != nil {
+		session.ScoringStrategy = *req.ScoringStrategy
+	}
+	if req.RankingStrategy != nil {
+		session.RankingStrategy = *req.RankingStrategy
+	}
+	if err := s.validateSession(session); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Delete ends a session of userID
+func (s *GameSessionService) Delete(ctx context.Context, sessionID, userID uuid.UUID) error {
+	if s.sessions == nil {
+		return utils.ServiceUnavailable("game session", nil)
+	}
+
+	if _, err := s.findOwned(ctx, sessionID, userID); err != nil {
+		return err
+	}
+	if err := s.sessions.Delete(ctx, sessionID); err != nil {
+		return utils.CacheError("game session delete", err)
+	}
+	return nil
+}
+
+// SubmitScore calculates the final score with the session's scoring strategy and stores it
+// on the leaderboard. An unknown session ID opens a session with default settings.
+func (s *GameSessionService) SubmitScore(ctx context.Context, sessionID, userID uuid.UUID, req *models.SessionScoreRequest) (*models.SessionScoreResponse, error) {
+	if s.sessions == nil {
+		return nil, utils.ServiceUnavailable("game session", nil)
+	}
+	if req.Score < 0 {
+		return nil, utils.ValidationError("score cannot be negative", nil)
+	}
+
+	session, err := s.sessions.FindByID(ctx, sessionID)
+	switch {
+	case errors.Is(err, repository.ErrRecordNotFound):
+		session = s.newSession(sessionID, userID)
+	case err != nil:
+		return nil, utils.CacheError("game session lookup", err)
+	case session.UserID != userID:
+		return nil, utils.Forbidden("game session belongs to another player", nil)
+	}
+
+	gameSession := strategy.NewGameSession(session.ID, session.GameMode, s.registry)
+	gameSession.SetScoringStrategy(session.ScoringStrategy)
+	gameSession.SetRankingStrategy(session.RankingStrategy)
+	finalScore, err := gameSession.CalculateScore(req.Score, &strategy.ScoringContext{
+		UserID:       userID,
+		Season:       session.Season,
+		GameMode:     session.GameMode,
+		Difficulty:   req.Difficulty,
+		Multiplier:   req.Multiplier,
+		Combo:        req.Combo,
+		TimeBonus:    req.TimeBonus,
+		Achievements: req.Achievements,
+		Metadata:     req.Metadata,
+	})
+	if err != nil {
+		return nil, utils.InternalError("failed to calculate session score", err)
+	}
+
+	// Сохраняем ID сессии в metadata, чтобы счет можно было найти через /admin/scores
+	metadata := make(map[string]interface{}, len(req.Metadata)+1)
+	for key, value := range req.Metadata {
+		metadata[key] = value
+	}
+	metadata["session_id"] = session.ID.String()
+
+	stored, err := s.scores.SubmitScore(ctx, userID, &leaderboardmodels.SubmitScoreRequest{
+		Score:          finalScore,
+		Season:         session.Season,
+		Metadata:       metadata,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Сессия живет, пока в нее играют: каждая отправка продлевает TTL
+	if err := s.save(ctx, session); err != nil {
+		log.Warn().Err(err).Str("session_id", session.ID.String()).Msg("Failed to extend game session after score submission")
+	}
+
+	return &models.SessionScoreResponse{
+		SessionID:       session.ID,
+		BaseScore:       req.Score,
+		ScoringStrategy: session.ScoringStrategy,
+		Score:           stored,
+	}, nil
+}
+
+// newSession builds a session with default settings
+func (s *GameSessionService) newSession(id, userID uuid.UUID) *models.GameSession {
+	return &models.GameSession{
+		ID:              id,
+		UserID:          userID,
+		GameMode:        DefaultGameMode,
+		ScoringStrategy: DefaultScoringStrategy,
+		RankingStrategy: DefaultRankingStrategy,
+		CreatedAt:       s.now().UTC(),
+	}
+}
+
+// findOwned loads a session and checks that it belongs to userID
+func (s *GameSessionService) findOwned(ctx context.Context, sessionID, userID uuid.UUID) (*models.GameSession, error) {
+	session, err := s.sessions.FindByID(ctx, sessionID)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return nil, utils.NotFound("game session", err)
+	}
+	if err != nil {
+		return nil, utils.CacheError("game session lookup", err)
+	}
+	if session.UserID != userID {
+		return nil, utils.Forbidden("game session belongs to another player", nil)
+	}
+	return session, nil
+}
+
+// save stores the session and moves ExpiresAt to now + ttl
+func (s *GameSessionService) save(ctx context.Context, session *models.GameSession) error {
+	session.ExpiresAt = s.now().UTC().Add(s.ttl)
+	if err := s.sessions.Save(ctx, session, s.ttl); err != nil {
+		return utils.CacheError("game session save", err)
+	}
+	return nil
+}
+
+// validateSession checks the strategy names against the registry and the season length
+func (s *GameSessionService) validateSession(session *models.GameSession) error {
+	if _, ok := s.registry.GetScoringStrategy(session.ScoringStrategy); !ok {
+		return utils.ValidationError(fmt.Sprintf("unknown scoring_strategy %q", session.ScoringStrategy), nil)
+	}
+	if _, ok := s.registry.GetRankingStrategy(session.RankingStrategy); !ok {
+		return utils.ValidationError(fmt.Sprintf("unknown ranking_strategy %q", session.RankingStrategy), nil)
+	}
+	if session.GameMode == "" {
+		return utils.ValidationError("game_mode cannot be empty", nil)
+	}
+	if len(session.Season) > maxSeasonLength {
+		return utils.ValidationError(fmt.Sprintf("season must be at most %d characters", maxSeasonLength), nil)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/session/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySessionRepository keeps sessions in a map and records the TTL of the last save
+type memorySessionRepository struct {
+	sessions map[uuid.UUID]*models.GameSession
+	lastTTL  time.Duration
+}
+
+func newMemorySessionRepository() *memorySessionRepository {
+	return &memorySessionRepository{sessions: make(map[uuid.UUID]*models.GameSession)}
+}
+
+func (r *memorySessionRepository) Save(ctx context.Context, session *models.GameSession, ttl time.Duration) error {
+	stored := *session
+	r.sessions[session.ID] = &stored
+	r.lastTTL = ttl
+	return nil
+}
+
+func (r *memorySessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.GameSession, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, repository.ErrRecordNotFound
+	}
+	found := *session
+	return &found, nil
+}
+
+func (r *memorySessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(r.sessions, id)
+	return nil
+}
+
+// recordingScoreSubmitter stores the last submission and echoes it back as a score
+type recordingScoreSubmitter struct {
+	last *leaderboardmodels.SubmitScoreRequest
+}
+
+func (s *recordingScoreSubmitter) SubmitScore(ctx context.Context, userID uuid.UUID, req *leaderboardmodels.SubmitScoreRequest) (*leaderboardmodels.Score, error) {
+	s.last = req
+	return &leaderboardmodels.Score{ID: uuid.New(), UserID: userID, Score: req.Score, Season: req.Season, Metadata: req.Metadata}, nil
+}
+
+func newTestSessionService() (*GameSessionService, *memorySessionRepository, *recordingScoreSubmitter) {
+	repo := newMemorySessionRepository()
+	scores := &recordingScoreSubmitter{}
+	svc := NewGameSessionService(repo, scores, strategy.NewDefaultStrategyRegistry(), 30*time.Minute)
+	svc.now = func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+	return svc, repo, scores
+}
+
+func assertStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *utils.AppError
+	require.True(t, errors.As(err, &appErr), "expected AppError, got %v", err)
+	assert.Equal(t, status, appErr.StatusCode)
+}
+
+func TestGameSessionService_CreateAppliesDefaultsAndTTL(t *testing.T) {
+	svc, repo, _ := newTestSessionService()
+	userID := uuid.New()
+
+	session, err := svc.Create(context.Background(), userID, &models.CreateSessionRequest{Season: "winter"})
+	require.NoError(t, err)
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, DefaultGameMode, session.GameMode)
+	assert.Equal(t, DefaultScoringStrategy, session.ScoringStrategy)
+	assert.Equal(t, DefaultRankingStrategy, session.RankingStrategy)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC), session.ExpiresAt)
+	assert.Equal(t, 30*time.Minute, repo.lastTTL)
+	assert.Contains(t, repo.sessions, session.ID)
+}
+
+func TestGameSessionService_CreateRejectsUnknownStrategy(t *testing.T) {
+	svc, repo, _ := newTestSessionService()
+
+	_, err := svc.Create(context.Background(), uuid.New(), &models.CreateSessionRequest{ScoringStrategy: "double"})
+	assertStatus(t, err, http.StatusBadRequest)
+
+	_, err = svc.Create(context.Background(), uuid.New(), &models.CreateSessionRequest{RankingStrategy: "elo"})
+	assertStatus(t, err, http.StatusBadRequest)
+	assert.Empty(t, repo.sessions)
+}
+
+func TestGameSessionService_UpdateAndDeleteCheckOwner(t *testing.T) {
+	svc, repo, _ := newTestSessionService()
+	ctx := context.Background()
+	owner, other := uuid.New(), uuid.New()
+
+	session, err := svc.Create(ctx, owner, &models.CreateSessionRequest{})
+	require.NoError(t, err)
+
+	percentage := "percentage"
+	_, err = svc.Update(ctx, session.ID, other, &models.UpdateSessionRequest{ScoringStrategy: &percentage})
+	assertStatus(t, err, http.StatusForbidden)
+
+	updated, err := svc.Update(ctx, session.ID, owner, &models.UpdateSessionRequest{ScoringStrategy: &percentage})
+	require.NoError(t, err)
+	assert.Equal(t, "percentage", updated.ScoringStrategy)
+	assert.Equal(t, DefaultGameMode, updated.GameMode, "fields that are not set stay unchanged")
+
+	assertStatus(t, svc.Delete(ctx, session.ID, other), http.StatusForbidden)
+	require.NoError(t, svc.Delete(ctx, session.ID, owner))
+	assert.Empty(t, repo.sessions)
+
+	_, err = svc.Get(ctx, session.ID, owner)
+	assertStatus(t, err, http.StatusNotFound)
+}
+
+func TestGameSessionService_SubmitScoreAppliesScoringStrategy(t *testing.T) {
+	svc, _, scores := newTestSessionService()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	session, err := svc.Create(ctx, userID, &models.CreateSessionRequest{Season: "winter", ScoringStrategy: "percentage"})
+	require.NoError(t, err)
+
+	result, err := svc.SubmitScore(ctx, session.ID, userID, &models.SessionScoreRequest{
+		Score:    1000,
+		Metadata: map[string]interface{}{"level": "3"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), result.BaseScore)
+	assert.Equal(t, int64(1500), result.Score.Score, "percentage strategy adds 50%")
+	assert.Equal(t, "percentage", result.ScoringStrategy)
+
+	require.NotNil(t, scores.last)
+	assert.Equal(t, int64(1500), scores.last.Score)
+	assert.Equal(t, "winter", scores.last.Season)
+	assert.Equal(t, "3", scores.last.Metadata["level"])
+	assert.Equal(t, session.ID.String(), scores.last.Metadata["session_id"])
+}
+
+func TestGameSessionService_SubmitScoreCreatesUnknownSession(t *testing.T) {
+	svc, repo, scores := newTestSessionService()
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	result, err := svc.SubmitScore(context.Background(), sessionID, userID, &models.SessionScoreRequest{Score: 700})
+	require.NoError(t, err)
+	assert.Equal(t, sessionID, result.SessionID)
+	assert.Equal(t, int64(700), scores.last.Score, "the default strategy keeps the score")
+
+	require.Contains(t, repo.sessions, sessionID)
+	assert.Equal(t, userID, repo.sessions[sessionID].UserID)
+}
+
+func TestGameSessionService_SubmitScoreRejectsOtherPlayersSession(t *testing.T) {
+	svc, _, scores := newTestSessionService()
+	ctx := context.Background()
+
+	session, err := svc.Create(ctx, uuid.New(), &models.CreateSessionRequest{})
+	require.NoError(t, err)
+
+	_, err = svc.SubmitScore(ctx, session.ID, uuid.New(), &models.SessionScoreRequest{Score: 100})
+	assertStatus(t, err, http.StatusForbidden)
+	assert.Nil(t, scores.last)
+}
+
+func TestGameSessionService_WithoutRepository(t *testing.T) {
+	svc := NewGameSessionService(nil, &recordingScoreSubmitter{}, strategy.NewDefaultStrategyRegistry(), time.Minute)
+
+	_, err := svc.Create(context.Background(), uuid.New(), &models.CreateSessionRequest{})
+	assertStatus(t, err, http.StatusServiceUnavailable)
+}
@@ -0,0 +1,263 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"leaderboard-service/internal/strategy"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Command - операция, которую можно выполнить и откатить
+type Command interface {
+	// Execute выполняет операцию
+	Execute(ctx context.Context) error
+
+	// Undo откатывает результат последнего Execute
+	Undo(ctx context.Context) error
+
+	// Name возвращает название команды (для логов)
+	Name() string
+
+	// Key - ключ очереди: команды с одинаковым ключом выполняются строго по очереди,
+	// с разными - параллельно
+	Key() string
+}
+
+var (
+	// ErrNothingToUndo возвращается Undo, если история выполненных команд пуста
+	ErrNothingToUndo = errors.New("nothing to undo")
+
+	// ErrNothingToRedo возвращается Redo, если нет откатанных команд
+	ErrNothingToRedo = errors.New("nothing to redo")
+
+	// ErrBusStopped возвращается, если шина остановлена до выполнения команды
+	ErrBusStopped = errors.New("command bus stopped")
+)
+
+// job - задача в очереди шины с каналом для результата
+type job struct {
+	// caller - контекст вызывающего: его отмена снимает задачу, пока та не начала выполняться
+	caller context.Context
+	name   string
+	run    func(ctx context.Context) error
+	result chan error
+}
+
+// CommandBus - очереди команд, разбитые по ключу команды
+// Команда попадает к worker по хешу Key(), поэтому две отправки счета одного игрока
+// в один сезон не гоняются друг с другом, а отправки разных игроков не ждут друг друга
+type CommandBus struct {
+	queues       []chan *job
+	done         chan struct{}
+	retry        strategy.RetryStrategy
+	historyLimit int
+
+	// mu защищает стеки undo/redo: в них пишут все worker
+	mu        sync.Mutex
+	undoStack []Command
+	redoStack []Command
+	// historyMu выстраивает вызовы Undo/Redo в очередь, чтобы два отката не взяли одну команду
+	historyMu sync.Mutex
+}
+
+// NewCommandBus создает шину команд с workers очередями по queueSize задач
+// retry может быть nil - тогда команды выполняются без повторов
+func NewCommandBus(workers, queueSize, historyLimit int, retry strategy.RetryStrategy) *CommandBus {
+	if workers < 1 {
+		workers = 1
+	}
+	queues := make([]chan *job, workers)
+	for i := range queues {
+		queues[i] = make(chan *job, queueSize)
+	}
+	return &CommandBus{
+		queues:       queues,
+		done:         make(chan struct{}),
+		retry:        retry,
+		historyLimit: historyLimit,
+	}
+}
+
+// Run запускает worker шины (должен выполняться в отдельной горутине)
+// Возвращается, когда ctx отменен и все worker завершили текущие задачи
+func (b *CommandBus) Run(ctx context.Context) {
+	defer close(b.done)
+
+	var wg sync.WaitGroup
+	for _, queue := range b.queues {
+		wg.Add(1)
+		go func(queue chan *job) {
+			defer wg.Done()
+			b.work(ctx, queue)
+		}(queue)
+	}
+	wg.Wait()
+	log.Info().Msg("Command bus stopped")
+}
+
+// work выполняет задачи одной очереди по порядку
+func (b *CommandBus) work(ctx context.Context, queue chan *job) {
+	for {
+		select {
+		case j := <-queue:
+			j.result <- b.runWithRetry(j)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Dispatch ставит команду в очередь ее ключа и ждет результата выполнения
+// Если задача уже в очереди, результат ждется и после отмены ctx: команда может успеть записать данные
+func (b *CommandBus) Dispatch(ctx context.Context, cmd Command) error {
+	return b.enqueue(ctx, cmd.Key(), cmd.Name(), func(ctx context.Context) error {
+		if err := cmd.Execute(ctx); err != nil {
+			return err
+		}
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.pushUndo(cmd)
+		b.redoStack = nil
+		return nil
+	})
+}
+
+// Undo откатывает последнюю выполненную команду в очереди ее ключа
+func (b *CommandBus) Undo(ctx context.Context) error {
+	b.historyMu.Lock()
+	defer b.historyMu.Unlock()
+
+	b.mu.Lock()
+	if len(b.undoStack) == 0 {
+		b.mu.Unlock()
+		return ErrNothingToUndo
+	}
+	cmd := b.undoStack[len(b.undoStack)-1]
+	b.mu.Unlock()
+
+	err := b.enqueue(ctx, cmd.Key(), "undo", cmd.Undo)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// Пока шел откат, поверх команды могли лечь новые - убираем именно ее
+	b.undoStack = removeCommand(b.undoStack, cmd)
+	b.redoStack = append(b.redoStack, cmd)
+	return nil
+}
+
+// Redo повторно выполняет последнюю откатанную команду
+func (b *CommandBus) Redo(ctx context.Context) error {
+	b.historyMu.Lock()
+	defer b.historyMu.Unlock()
+
+	b.mu.Lock()
+	if len(b.redoStack) == 0 {
+		b.mu.Unlock()
+		return ErrNothingToRedo
+	}
+	cmd := b.redoStack[len(b.redoStack)-1]
+	b.mu.Unlock()
+
+	err := b.enqueue(ctx, cmd.Key(), "redo", cmd.Execute)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.redoStack = removeCommand(b.redoStack, cmd)
+	b.pushUndo(cmd)
+	return nil
+}
+
+// enqueue кладет задачу в очередь ключа key и блокируется до ее выполнения
+func (b *CommandBus) enqueue(ctx context.Context, key, name string, run func(ctx context.Context) error) error {
+	j := &job{caller: ctx, name: name, run: run, result: make(chan error, 1)}
+
+	select {
+	case b.queueFor(key) <- j:
+	case <-b.done:
+		return ErrBusStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// Отмена ctx здесь не ошибка: задача уже в очереди, и ответ должен сказать, записана ли она
+	select {
+	case err := <-j.result:
+		return err
+	case <-b.done:
+		select {
+		case err := <-j.result:
+			return err
+		default:
+			return ErrBusStopped
+		}
+	}
+}
+
+// queueFor выбирает очередь по FNV-хешу ключа
+func (b *CommandBus) queueFor(key string) chan *job {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return b.queues[h.Sum32()%uint32(len(b.queues))]
+}
+
+// runWithRetry выполняет задачу, повторяя ее по retry стратегии
+// Запущенная попытка не прерывается отменой контекста вызывающего; отмена лишь
+// останавливает следующие попытки, когда предыдущая ничего не записала
+func (b *CommandBus) runWithRetry(j *job) error {
+	ctx := context.WithoutCancel(j.caller)
+	for attempt := 1; ; attempt++ {
+		// Вызывающий ушел до начала попытки - выполнять команду незачем
+		if err := j.caller.Err(); err != nil {
+			return err
+		}
+
+		err := j.run(ctx)
+		if err == nil || b.retry == nil || !b.retry.ShouldRetry(attempt, err) {
+			return err
+		}
+
+		delay := b.retry.NextDelay(attempt)
+		log.Warn().
+			Err(err).
+			Str("command", j.name).
+			Int("attempt", attempt).
+			Dur("delay", delay).
+			Msg("Command failed, retrying")
+
+		select {
+		case <-time.After(delay):
+		case <-j.caller.Done():
+			return j.caller.Err()
+		}
+	}
+}
+
+// pushUndo добавляет команду в историю, отбрасывая самые старые сверх historyLimit
+// Вызывается под b.mu
+func (b *CommandBus) pushUndo(cmd Command) {
+	b.undoStack = append(b.undoStack, cmd)
+	if b.historyLimit > 0 && len(b.undoStack) > b.historyLimit {
+		b.undoStack = b.undoStack[len(b.undoStack)-b.historyLimit:]
+	}
+}
+
+// removeCommand убирает из стека последнее вхождение cmd
+func removeCommand(stack []Command, cmd Command) []Command {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == cmd {
+			return append(stack[:i], stack[i+1:]...)
+		}
+	}
+	return stack
+}
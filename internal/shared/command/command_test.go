@@ -0,0 +1,233 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/strategy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// counterCommand увеличивает общий счетчик; failures первых вызовов Execute завершаются ошибкой
+type counterCommand struct {
+	counter  *int
+	failures int
+	err      error
+	calls    int
+	key      string
+}
+
+func (c *counterCommand) Execute(ctx context.Context) error {
+	c.calls++
+	if c.calls <= c.failures {
+		return c.err
+	}
+	*c.counter++
+	return nil
+}
+
+func (c *counterCommand) Undo(ctx context.Context) error {
+	*c.counter--
+	return nil
+}
+
+func (c *counterCommand) Name() string {
+	return "Counter"
+}
+
+func (c *counterCommand) Key() string {
+	return c.key
+}
+
+// blockingCommand ждет release внутри Execute и сообщает о старте в started
+type blockingCommand struct {
+	key      string
+	started  chan struct{}
+	release  chan struct{}
+	executed bool
+}
+
+func (c *blockingCommand) Execute(ctx context.Context) error {
+	close(c.started)
+	<-c.release
+	c.executed = true
+	return nil
+}
+
+func (c *blockingCommand) Undo(ctx context.Context) error { return nil }
+func (c *blockingCommand) Name() string                   { return "Blocking" }
+func (c *blockingCommand) Key() string                    { return c.key }
+
+func newBlockingCommand(key string) *blockingCommand {
+	return &blockingCommand{key: key, started: make(chan struct{}), release: make(chan struct{})}
+}
+
+func startBus(t *testing.T, retry strategy.RetryStrategy) *CommandBus {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	bus := NewCommandBus(4, 16, 10, retry)
+	go bus.Run(ctx)
+	return bus
+}
+
+func TestCommandBus_UndoRedo(t *testing.T) {
+	bus := startBus(t, nil)
+	ctx := context.Background()
+	counter := 0
+
+	require.NoError(t, bus.Dispatch(ctx, &counterCommand{counter: &counter}))
+	require.NoError(t, bus.Dispatch(ctx, &counterCommand{counter: &counter}))
+	assert.Equal(t, 2, counter)
+
+	require.NoError(t, bus.Undo(ctx))
+	assert.Equal(t, 1, counter)
+
+	require.NoError(t, bus.Redo(ctx))
+	assert.Equal(t, 2, counter)
+	assert.ErrorIs(t, bus.Redo(ctx), ErrNothingToRedo)
+
+	require.NoError(t, bus.Undo(ctx))
+	require.NoError(t, bus.Undo(ctx))
+	assert.Equal(t, 0, counter)
+	assert.ErrorIs(t, bus.Undo(ctx), ErrNothingToUndo)
+}
+
+func TestCommandBus_NewCommandClearsRedo(t *testing.T) {
+	bus := startBus(t, nil)
+	ctx := context.Background()
+	counter := 0
+
+	require.NoError(t, bus.Dispatch(ctx, &counterCommand{counter: &counter}))
+	require.NoError(t, bus.Undo(ctx))
+	require.NoError(t, bus.Dispatch(ctx, &counterCommand{counter: &counter}))
+
+	assert.ErrorIs(t, bus.Redo(ctx), ErrNothingToRedo)
+}
+
+func TestCommandBus_ExecutesSameKeySerially(t *testing.T) {
+	bus := startBus(t, nil)
+	ctx := context.Background()
+	counter := 0
+
+	// Счетчик не защищен мьютексом: гонка была бы видна под -race и в итоговом значении
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, bus.Dispatch(ctx, &counterCommand{counter: &counter, key: "player:global"}))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, counter)
+}
+
+func TestCommandBus_DifferentKeysDoNotWait(t *testing.T) {
+	bus := startBus(t, nil)
+	ctx := context.Background()
+
+	// Ключи подобраны так, чтобы попасть в разные очереди
+	blocked := newBlockingCommand("alice:global")
+	other := "bob:global"
+	for i := 0; bus.queueFor(other) == bus.queueFor(blocked.key); i++ {
+		other = fmt.Sprintf("bob%d:global", i)
+	}
+
+	go func() { _ = bus.Dispatch(ctx, blocked) }()
+	<-blocked.started
+	defer close(blocked.release)
+
+	counter := 0
+	done := make(chan error, 1)
+	go func() { done <- bus.Dispatch(ctx, &counterCommand{counter: &counter, key: other}) }()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+		assert.Equal(t, 1, counter)
+	case <-time.After(time.Second):
+		t.Fatal("command with another key waited for a blocked one")
+	}
+}
+
+func TestCommandBus_CancelledCallerGetsResult(t *testing.T) {
+	bus := startBus(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cmd := newBlockingCommand("alice:global")
+	done := make(chan error, 1)
+	go func() { done <- bus.Dispatch(ctx, cmd) }()
+	<-cmd.started
+
+	// Команда уже выполняется: отмена не должна превращать ее успех в ошибку
+	cancel()
+	close(cmd.release)
+
+	require.NoError(t, <-done)
+	assert.True(t, cmd.executed)
+}
+
+func TestCommandBus_CancelledBeforeStartIsSkipped(t *testing.T) {
+	bus := NewCommandBus(1, 1, 10, nil)
+	busCtx, stop := context.WithCancel(context.Background())
+	t.Cleanup(stop)
+	go bus.Run(busCtx)
+
+	first := newBlockingCommand("alice:global")
+	go func() { _ = bus.Dispatch(context.Background(), first) }()
+	<-first.started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	counter := 0
+	done := make(chan error, 1)
+	go func() { done <- bus.Dispatch(ctx, &counterCommand{counter: &counter, key: "alice:global"}) }()
+	// Вторая команда ждет в очереди за первой
+	require.Eventually(t, func() bool { return len(bus.queues[0]) == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+	close(first.release)
+
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, 0, counter)
+}
+
+func TestCommandBus_RetriesTransientErrors(t *testing.T) {
+	transient := errors.New("connection reset")
+	retry := strategy.NewExponentialBackoffRetryStrategy(3, time.Millisecond, 5*time.Millisecond, func(err error) bool {
+		return errors.Is(err, transient)
+	})
+	bus := startBus(t, retry)
+	ctx := context.Background()
+	counter := 0
+
+	cmd := &counterCommand{counter: &counter, failures: 2, err: transient}
+	require.NoError(t, bus.Dispatch(ctx, cmd))
+	assert.Equal(t, 3, cmd.calls)
+	assert.Equal(t, 1, counter)
+
+	permanent := &counterCommand{counter: &counter, failures: 1, err: errors.New("constraint violation")}
+	assert.Error(t, bus.Dispatch(ctx, permanent))
+	assert.Equal(t, 1, permanent.calls)
+}
+
+func TestCommandBus_StoppedBus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	bus := NewCommandBus(1, 0, 10, nil)
+	done := make(chan struct{})
+	go func() {
+		bus.Run(ctx)
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	counter := 0
+	assert.ErrorIs(t, bus.Dispatch(context.Background(), &counterCommand{counter: &counter}), ErrBusStopped)
+}
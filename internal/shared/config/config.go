@@ -1,37 +1,80 @@
 package config
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
+// configFileEnv names the environment variable holding the optional YAML config file path
+const configFileEnv = "LEADERBOARD_CONFIG_FILE"
+
+// fileValues holds settings read from the config file, keyed by environment variable name.
+// Set by Load; consulted by the getEnv helpers when a variable is not set.
+var fileValues map[string]string
+
 // Config holds all application configuration
 type Config struct {
-	Server     ServerConfig
-	Database   DatabaseConfig
-	Redis      RedisConfig
-	JWT        JWTConfig
-	RateLimit  RateLimitConfig
-	Supabase   SupabaseConfig
-	Log        LogConfig
-	WebSocket  WebSocketConfig
-	Cache      CacheConfig
-	Validation ValidationConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Redis       RedisConfig
+	JWT         JWTConfig
+	RateLimit   RateLimitConfig
+	Supabase    SupabaseConfig
+	Log         LogConfig
+	WebSocket   WebSocketConfig
+	Cache       CacheConfig
+	Validation  ValidationConfig
+	Leaderboard LeaderboardConfig
+	Scoring     ScoringConfig
+	// Multitenancy namespaces seasons per game client
+	Multitenancy MultitenancyConfig
+	// Simulation drives cmd/simulator; the API server ignores it
+	Simulation SimulationConfig
+	// Observability tunes diagnostics of the repository layer
+	Observability ObservabilityConfig
+	// Leagues place players in tiers by percentile rank (LEADERBOARD_LEAGUES)
+	Leagues []LeagueConfig
+	// Session controls game sessions stored in Redis
+	Session SessionConfig
+
+	// ConfigFile is the YAML file merged under environment variables (empty if none was used)
+	ConfigFile string
 }
 
 type ServerConfig struct {
 	Port string
 	Env  string
+	// DisableAuthOnTestEndpoints skips JWT on /test/* routes (honored only when Env is "development")
+	DisableAuthOnTestEndpoints bool
+	// TLSCertFile and TLSKeyFile serve HTTPS/WSS with a static certificate when both are set
+	TLSCertFile string
+	TLSKeyFile  string
+	// AutoTLS obtains Let's Encrypt certificates for TLSDomain via ACME (TLS-ALPN-01, so PORT must be 443)
+	AutoTLS   bool
+	TLSDomain string
+	// TLSCacheDir stores AutoTLS certificates between restarts
+	TLSCacheDir string
 }
 
 type DatabaseConfig struct {
 	URL      string
 	MaxConns int
 	MinConns int
+	// PrepareStatements enables GORM's prepared statement cache. Disable it when the
+	// schema changes underneath a live pool (e.g. tests that recreate tables), since
+	// cached plans then fail with "cached plan must not change result type"
+	PrepareStatements bool
+	// SoftDeleteEnabled hides scores with deleted_at set from the raw leaderboard
+	// queries (requires sql/migrations/010_scores_soft_delete.sql)
+	SoftDeleteEnabled bool
 }
 
 type RedisConfig struct {
@@ -66,6 +109,16 @@ type WebSocketConfig struct {
 	PongWaitSeconds          int
 	PingPeriodSeconds        int
 	MaxMessageSize           int64
+	// MaxClientLimit caps the number of entries a client may request with ?limit= or update_limit
+	MaxClientLimit int
+	// MaxClientsPerSeason caps the connections subscribed to one season (0 disables the cap)
+	MaxClientsPerSeason int
+	// UseDBNotify replaces the periodic broadcast polling with PostgreSQL LISTEN/NOTIFY
+	// on score changes (requires sql/migrations/011_score_notify.sql)
+	UseDBNotify bool
+	// SSEBufferSize is how many recent events per season are replayed to an SSE client
+	// reconnecting with Last-Event-ID (0 disables the replay)
+	SSEBufferSize int
 }
 
 type CacheConfig struct {
@@ -73,27 +126,156 @@ type CacheConfig struct {
 	UserCacheTTLMinutes    int
 	ScoreCacheTTLMinutes   int
 	CleanupIntervalMinutes int
+	// RedisSerializationFormat encodes values cached in Redis (SerializationFormat*)
+	RedisSerializationFormat string
 }
 
 type ValidationConfig struct {
 	MaxScore int64
 	MinScore int64
+	// MaxMetadataBytes caps the JSON size of a submission's metadata (0 disables the check)
+	MaxMetadataBytes int
+}
+
+type ScoringConfig struct {
+	// OnlyStorePersonalBest keeps a submitted score only if it beats the player's stored score
+	OnlyStorePersonalBest bool
+	// EncryptMetadata encrypts score Metadata with AES-256-GCM before it is stored
+	EncryptMetadata bool
+	// MetadataEncryptionKey is the hex-encoded 32-byte AES key used when EncryptMetadata is set
+	MetadataEncryptionKey string
+	// ReturnRankOnSubmit looks up the player's rank after a submission and returns it with the score
+	ReturnRankOnSubmit bool
+	// RankMilestones are the ranks that trigger a notification the first time a player reaches them in a season
+	RankMilestones []int
+	// AchievementThresholds are the stored scores that award a badge, once per player and season
+	AchievementThresholds []int
+	// IdempotencyWindowSeconds is how long Redis remembers a submission's idempotency key and response
+	IdempotencyWindowSeconds int
+}
+
+type MultitenancyConfig struct {
+	// Enabled stores every season as "{tenant}:{season}"; requests without a tenant are rejected
+	Enabled bool
+	// APIKeys maps X-API-Key header values to tenant IDs (MULTITENANCY_API_KEYS="key1=tenant1,key2=tenant2")
+	APIKeys map[string]string
+}
+
+// LeagueConfig is one league tier: players whose percentile rank is at least MinPercentile
+// and below MaxPercentile belong to it (the top tier also includes MaxPercentile 100)
+type LeagueConfig struct {
+	Name          string
+	MinPercentile float64
+	MaxPercentile float64
+	// Color is a display hint for clients, e.g. "#FFD700"
+	Color string
+}
+
+// DefaultLeagues is the league table used when LEADERBOARD_LEAGUES is not set
+var DefaultLeagues = []LeagueConfig{
+	{Name: "Bronze", MinPercentile: 0, MaxPercentile: 50, Color: "#CD7F32"},
+	{Name: "Silver", MinPercentile: 50, MaxPercentile: 75, Color: "#C0C0C0"},
+	{Name: "Gold", MinPercentile: 75, MaxPercentile: 95, Color: "#FFD700"},
+	{Name: "Diamond", MinPercentile: 95, MaxPercentile: 100, Color: "#B9F2FF"},
+}
+
+type SessionConfig struct {
+	// TTLMinutes is how long a game session lives after its last update or score submission
+	TTLMinutes int
+}
+
+type ObservabilityConfig struct {
+	// SlowQueryThresholdMs flags score repository calls that take longer (0 disables the check)
+	SlowQueryThresholdMs int
+}
+
+type SimulationConfig struct {
+	UpdateIntervalSec int
+	// MinScore and MaxScore bound a randomly drawn score
+	MinScore int64
+	MaxScore int64
+	// ScoreIncrement is the step of a small score gain (1-5 steps per update)
+	ScoreIncrement int64
+	// SeasonWeights is the relative chance of each season being picked (SIMULATION_SEASON_WEIGHTS="global=0.6,season1=0.1")
+	SeasonWeights map[string]float64
 }
 
+type LeaderboardConfig struct {
+	// UseMaterializedView serves GetLeaderboard from leaderboard_view instead of the window function query
+	UseMaterializedView        bool
+	ViewRefreshIntervalSeconds int
+	// RankingMethod selects how ties are ranked: RankingMethodDense, RankingMethodCompetition or RankingMethodOrdinal
+	RankingMethod string
+	// DefaultSeason is used wherever a request or tool omits the season; see GetDefaultSeason
+	DefaultSeason string
+}
+
+// FallbackSeason is the default season when Leaderboard.DefaultSeason is not set
+const FallbackSeason = "global"
+
+// Ranking methods accepted by LeaderboardConfig.RankingMethod
+const (
+	RankingMethodDense       = "dense"       // DENSE_RANK: 1, 1, 2
+	RankingMethodCompetition = "competition" // RANK: 1, 1, 3
+	RankingMethodOrdinal     = "ordinal"     // ROW_NUMBER: 1, 2, 3
+)
+
+// ErrInvalidRankingMethod is returned for a RankingMethod outside the supported values
+var ErrInvalidRankingMethod = errors.New("invalid ranking method")
+
+// ValidateRankingMethod checks that method is one of the supported ranking methods
+func ValidateRankingMethod(method string) error {
+	switch method {
+	case RankingMethodDense, RankingMethodCompetition, RankingMethodOrdinal:
+		return nil
+	}
+	return fmt.Errorf("%w %q: must be %s, %s or %s", ErrInvalidRankingMethod, method,
+		RankingMethodDense, RankingMethodCompetition, RankingMethodOrdinal)
+}
+
+// Formats of CacheConfig.RedisSerializationFormat. Only JSON is implemented; CBOR and
+// MessagePack are reserved names that Validate rejects until their codecs are added.
+const (
+	SerializationFormatJSON    = "json"
+	SerializationFormatCBOR    = "cbor"
+	SerializationFormatMsgpack = "msgpack"
+)
+
+// ErrUnsupportedSerializationFormat is returned for a RedisSerializationFormat other than json
+var ErrUnsupportedSerializationFormat = errors.New("unsupported cache serialization format")
+
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
 	// Load .env file if it exists (ignore error in production)
 	_ = godotenv.Load()
 
+	// Optional config file; environment variables still take precedence over it
+	configFile := os.Getenv(configFileEnv)
+	values, err := loadConfigFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	fileValues = values
+
+	defaultSeason := DefaultSeasonFromEnv()
 	cfg := &Config{
+		ConfigFile: configFile,
 		Server: ServerConfig{
-			Port: getEnv("PORT", "8080"),
-			Env:  getEnv("ENV", "development"),
+			Port:                       getEnv("PORT", "8080"),
+			Env:                        getEnv("ENV", "development"),
+			DisableAuthOnTestEndpoints: getEnvAsBool("DISABLE_AUTH_ON_TEST_ENDPOINTS", false),
+			TLSCertFile:                getEnv("TLS_CERT_FILE", ""),
+			TLSKeyFile:                 getEnv("TLS_KEY_FILE", ""),
+			AutoTLS:                    getEnvAsBool("TLS_AUTO", false),
+			TLSDomain:                  getEnv("TLS_DOMAIN", ""),
+			TLSCacheDir:                getEnv("TLS_CACHE_DIR", "autocert-cache"),
 		},
 		Database: DatabaseConfig{
-			URL:      getEnv("DATABASE_URL", ""),
-			MaxConns: getEnvAsInt("DB_MAX_CONNS", 25),
-			MinConns: getEnvAsInt("DB_MIN_CONNS", 5),
+			URL:               getEnv("DATABASE_URL", ""),
+			MaxConns:          getEnvAsInt("DB_MAX_CONNS", 25),
+			MinConns:          getEnvAsInt("DB_MIN_CONNS", 5),
+			PrepareStatements: getEnvAsBool("DB_PREPARE_STATEMENTS", true),
+			SoftDeleteEnabled: getEnvAsBool("DATABASE_SOFT_DELETE_ENABLED", false),
 		},
 		Redis: RedisConfig{
 			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
@@ -122,16 +304,57 @@ func Load() (*Config, error) {
 			PongWaitSeconds:          getEnvAsInt("WS_PONG_WAIT_SEC", 60),
 			PingPeriodSeconds:        getEnvAsInt("WS_PING_PERIOD_SEC", 54),
 			MaxMessageSize:           getEnvAsInt64("WS_MAX_MESSAGE_SIZE", 512*1024),
+			MaxClientLimit:           getEnvAsInt("WS_MAX_CLIENT_LIMIT", 1000),
+			MaxClientsPerSeason:      getEnvAsInt("WS_MAX_CLIENTS_PER_SEASON", 10000),
+			UseDBNotify:              getEnvAsBool("WS_USE_DB_NOTIFY", false),
+			SSEBufferSize:            getEnvAsInt("WS_SSE_BUFFER_SIZE", 100),
 		},
 		Cache: CacheConfig{
-			LeaderboardTTLMinutes:  getEnvAsInt("CACHE_LEADERBOARD_TTL_MIN", 5),
-			UserCacheTTLMinutes:    getEnvAsInt("CACHE_USER_TTL_MIN", 5),
-			ScoreCacheTTLMinutes:   getEnvAsInt("CACHE_SCORE_TTL_MIN", 2),
-			CleanupIntervalMinutes: getEnvAsInt("CACHE_CLEANUP_INTERVAL_MIN", 5),
+			LeaderboardTTLMinutes:    getEnvAsInt("CACHE_LEADERBOARD_TTL_MIN", 5),
+			UserCacheTTLMinutes:      getEnvAsInt("CACHE_USER_TTL_MIN", 5),
+			ScoreCacheTTLMinutes:     getEnvAsInt("CACHE_SCORE_TTL_MIN", 2),
+			CleanupIntervalMinutes:   getEnvAsInt("CACHE_CLEANUP_INTERVAL_MIN", 5),
+			RedisSerializationFormat: getEnv("CACHE_REDIS_SERIALIZATION_FORMAT", SerializationFormatJSON),
 		},
 		Validation: ValidationConfig{
-			MaxScore: getEnvAsInt64("VALIDATION_MAX_SCORE", 10000000),
-			MinScore: getEnvAsInt64("VALIDATION_MIN_SCORE", 0),
+			MaxScore:         getEnvAsInt64("VALIDATION_MAX_SCORE", 10000000),
+			MinScore:         getEnvAsInt64("VALIDATION_MIN_SCORE", 0),
+			MaxMetadataBytes: getEnvAsInt("VALIDATION_MAX_METADATA_BYTES", 4096),
+		},
+		Leaderboard: LeaderboardConfig{
+			UseMaterializedView:        getEnvAsBool("LEADERBOARD_USE_MATERIALIZED_VIEW", false),
+			ViewRefreshIntervalSeconds: getEnvAsInt("LEADERBOARD_VIEW_REFRESH_INTERVAL_SEC", 30),
+			RankingMethod:              getEnv("LEADERBOARD_RANKING_METHOD", RankingMethodDense),
+			DefaultSeason:              defaultSeason,
+		},
+		Scoring: ScoringConfig{
+			OnlyStorePersonalBest:    getEnvAsBool("SCORING_ONLY_PERSONAL_BEST", false),
+			EncryptMetadata:          getEnvAsBool("SCORING_ENCRYPT_METADATA", false),
+			MetadataEncryptionKey:    getEnv("SCORING_METADATA_ENCRYPTION_KEY", ""),
+			ReturnRankOnSubmit:       getEnvAsBool("SCORING_RETURN_RANK_ON_SUBMIT", false),
+			RankMilestones:           getEnvAsIntList("SCORING_RANK_MILESTONES", []int{1, 10, 100}),
+			AchievementThresholds:    getEnvAsIntList("SCORING_ACHIEVEMENT_THRESHOLDS", []int{10000, 50000, 100000}),
+			IdempotencyWindowSeconds: getEnvAsInt("SCORING_IDEMPOTENCY_WINDOW_SEC", 300),
+		},
+		Simulation: SimulationConfig{
+			UpdateIntervalSec: getEnvAsInt("SIMULATION_UPDATE_INTERVAL_SEC", 5),
+			MinScore:          getEnvAsInt64("SIMULATION_MIN_SCORE", 100),
+			MaxScore:          getEnvAsInt64("SIMULATION_MAX_SCORE", 10000),
+			ScoreIncrement:    getEnvAsInt64("SIMULATION_SCORE_INCREMENT", 50),
+			SeasonWeights: getEnvAsFloatMap("SIMULATION_SEASON_WEIGHTS", map[string]float64{
+				defaultSeason: 0.6, "season1": 0.08, "season2": 0.08, "season3": 0.08, "season4": 0.08, "season5": 0.08,
+			}),
+		},
+		Multitenancy: MultitenancyConfig{
+			Enabled: getEnvAsBool("MULTITENANCY_ENABLED", false),
+			APIKeys: getEnvAsMap("MULTITENANCY_API_KEYS"),
+		},
+		Observability: ObservabilityConfig{
+			SlowQueryThresholdMs: getEnvAsInt("OBSERVABILITY_SLOW_QUERY_THRESHOLD_MS", 100),
+		},
+		Leagues: getEnvAsLeagues("LEADERBOARD_LEAGUES", DefaultLeagues),
+		Session: SessionConfig{
+			TTLMinutes: getEnvAsInt("SESSION_TTL_MINUTES", 30),
 		},
 	}
 
@@ -150,19 +373,125 @@ func (c *Config) Validate() error {
 	if c.JWT.Secret == "" {
 		return fmt.Errorf("JWT_SECRET is required")
 	}
+	if c.Scoring.EncryptMetadata {
+		if _, err := c.GetMetadataEncryptionKey(); err != nil {
+			return err
+		}
+	}
+	if c.Scoring.IdempotencyWindowSeconds <= 0 {
+		return fmt.Errorf("SCORING_IDEMPOTENCY_WINDOW_SEC must be positive")
+	}
+	if (c.Server.TLSCertFile == "") != (c.Server.TLSKeyFile == "") {
+		return fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if c.Server.AutoTLS {
+		if c.Server.TLSDomain == "" {
+			return fmt.Errorf("TLS_DOMAIN is required when TLS_AUTO is enabled")
+		}
+		if c.Server.TLSCertFile != "" {
+			return fmt.Errorf("TLS_AUTO cannot be combined with TLS_CERT_FILE/TLS_KEY_FILE")
+		}
+	}
+	if c.WebSocket.MaxClientsPerSeason < 0 {
+		return fmt.Errorf("WS_MAX_CLIENTS_PER_SEASON cannot be negative")
+	}
+	if c.WebSocket.SSEBufferSize < 0 {
+		return fmt.Errorf("WS_SSE_BUFFER_SIZE cannot be negative")
+	}
+	if c.Validation.MaxMetadataBytes < 0 {
+		return fmt.Errorf("VALIDATION_MAX_METADATA_BYTES cannot be negative")
+	}
+	if c.Session.TTLMinutes <= 0 {
+		return fmt.Errorf("SESSION_TTL_MINUTES must be positive")
+	}
+	if c.Observability.SlowQueryThresholdMs < 0 {
+		return fmt.Errorf("OBSERVABILITY_SLOW_QUERY_THRESHOLD_MS cannot be negative")
+	}
+	if c.Cache.RedisSerializationFormat != SerializationFormatJSON {
+		return fmt.Errorf("CACHE_REDIS_SERIALIZATION_FORMAT: %w %q (only %s is available)",
+			ErrUnsupportedSerializationFormat, c.Cache.RedisSerializationFormat, SerializationFormatJSON)
+	}
+	if err := ValidateRankingMethod(c.Leaderboard.RankingMethod); err != nil {
+		return fmt.Errorf("LEADERBOARD_RANKING_METHOD: %w", err)
+	}
+	// Ранги leaderboard_view считаются при REFRESH через DENSE_RANK и от настройки не зависят
+	if c.Leaderboard.UseMaterializedView && c.Leaderboard.RankingMethod != RankingMethodDense {
+		return fmt.Errorf("LEADERBOARD_USE_MATERIALIZED_VIEW requires LEADERBOARD_RANKING_METHOD=%s, got %q",
+			RankingMethodDense, c.Leaderboard.RankingMethod)
+	}
+	// Сезон по умолчанию записывается в scores.season (VARCHAR(50)) как есть
+	if len(c.Leaderboard.DefaultSeason) > 50 {
+		return fmt.Errorf("LEADERBOARD_DEFAULT_SEASON must be at most 50 characters")
+	}
+	if err := validateLeagues(c.Leagues); err != nil {
+		return fmt.Errorf("LEADERBOARD_LEAGUES: %w", err)
+	}
+	for key, tenant := range c.Multitenancy.APIKeys {
+		if key == "" || tenant == "" || strings.Contains(tenant, ":") {
+			return fmt.Errorf("MULTITENANCY_API_KEYS entries must be key=tenant with a tenant ID without ':'")
+		}
+	}
 	return nil
 }
 
-// Helper functions
-func getEnv(key, defaultVal string) string {
+// loadConfigFile reads a YAML config file into a map keyed by environment variable name.
+// Keys may be written flat (DB_MAX_CONNS: 10) or nested (db: {max_conns: 10});
+// nested keys are joined with "_" and upper-cased. An empty path yields no values.
+func loadConfigFile(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flattenConfig("", raw, values)
+	return values, nil
+}
+
+// flattenConfig converts nested YAML sections into environment variable style keys
+func flattenConfig(prefix string, raw map[string]interface{}, values map[string]string) {
+	for key, value := range raw {
+		name := strings.ToUpper(key)
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenConfig(name, nested, values)
+			continue
+		}
+		if value != nil {
+			values[name] = fmt.Sprint(value)
+		}
+	}
+}
+
+// findOrDefaultConfig returns the environment variable, then the config file value, then defaultVal
+func findOrDefaultConfig(key, defaultVal string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
+	if value, ok := fileValues[key]; ok && value != "" {
+		return value
+	}
 	return defaultVal
 }
 
+// Helper functions
+func getEnv(key, defaultVal string) string {
+	return findOrDefaultConfig(key, defaultVal)
+}
+
 func getEnvAsInt(key string, defaultVal int) int {
-	if value := os.Getenv(key); value != "" {
+	if value := findOrDefaultConfig(key, ""); value != "" {
 		if intVal, err := strconv.Atoi(value); err == nil {
 			return intVal
 		}
@@ -171,7 +500,7 @@ func getEnvAsInt(key string, defaultVal int) int {
 }
 
 func getEnvAsInt64(key string, defaultVal int64) int64 {
-	if value := os.Getenv(key); value != "" {
+	if value := findOrDefaultConfig(key, ""); value != "" {
 		if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
 			return intVal
 		}
@@ -179,6 +508,127 @@ func getEnvAsInt64(key string, defaultVal int64) int64 {
 	return defaultVal
 }
 
+func getEnvAsBool(key string, defaultVal bool) bool {
+	if value := findOrDefaultConfig(key, ""); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultVal
+}
+
+// getEnvAsIntList parses "1,10,100"; values below 1 are dropped, so "0" yields an empty list.
+// Any entry that is not an integer falls back to defaultVal as a whole.
+func getEnvAsIntList(key string, defaultVal []int) []int {
+	value := findOrDefaultConfig(key, "")
+	if value == "" {
+		return defaultVal
+	}
+	result := make([]int, 0)
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return defaultVal
+		}
+		if n > 0 {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// getEnvAsMap parses "k1=v1,k2=v2"; an entry without "=" is kept with an empty value so Validate can reject it
+func getEnvAsMap(key string) map[string]string {
+	value := findOrDefaultConfig(key, "")
+	if value == "" {
+		return nil
+	}
+	result := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(pair, "=")
+		result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return result
+}
+
+// getEnvAsFloatMap parses "k1=0.5,k2=1"; any malformed entry falls back to defaultVal as a whole
+func getEnvAsFloatMap(key string, defaultVal map[string]float64) map[string]float64 {
+	raw := getEnvAsMap(key)
+	if raw == nil {
+		return defaultVal
+	}
+	result := make(map[string]float64, len(raw))
+	for k, v := range raw {
+		value, err := strconv.ParseFloat(v, 64)
+		if k == "" || err != nil {
+			return defaultVal
+		}
+		result[k] = value
+	}
+	return result
+}
+
+// getEnvAsLeagues parses "Bronze:0:50:#CD7F32,Silver:50:100:#C0C0C0" (name:min:max:color, color optional);
+// any malformed entry falls back to defaultVal as a whole
+func getEnvAsLeagues(key string, defaultVal []LeagueConfig) []LeagueConfig {
+	value := findOrDefaultConfig(key, "")
+	if value == "" {
+		return defaultVal
+	}
+	var result []LeagueConfig
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) < 3 || len(parts) > 4 {
+			return defaultVal
+		}
+		minPercentile, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			return defaultVal
+		}
+		maxPercentile, err := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+		if err != nil {
+			return defaultVal
+		}
+		league := LeagueConfig{
+			Name:          strings.TrimSpace(parts[0]),
+			MinPercentile: minPercentile,
+			MaxPercentile: maxPercentile,
+		}
+		if len(parts) == 4 {
+			league.Color = strings.TrimSpace(parts[3])
+		}
+		result = append(result, league)
+	}
+	return result
+}
+
+// validateLeagues checks that every league has a name and a range within 0..100 and that ranges do not overlap
+func validateLeagues(leagues []LeagueConfig) error {
+	for i, league := range leagues {
+		if league.Name == "" {
+			return fmt.Errorf("league %d has no name", i+1)
+		}
+		if league.MinPercentile < 0 || league.MaxPercentile > 100 || league.MinPercentile >= league.MaxPercentile {
+			return fmt.Errorf("league %s must satisfy 0 <= min < max <= 100", league.Name)
+		}
+		for _, other := range leagues[:i] {
+			if league.MinPercentile < other.MaxPercentile && other.MinPercentile < league.MaxPercentile {
+				return fmt.Errorf("leagues %s and %s overlap", other.Name, league.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// TestEndpointsAuthDisabled reports whether test endpoints may be called without JWT
+func (c *Config) TestEndpointsAuthDisabled() bool {
+	return c.Server.DisableAuthOnTestEndpoints && c.Server.Env == "development"
+}
+
 func (c *Config) GetJWTExpiry() time.Duration {
 	return time.Duration(c.JWT.ExpiryHours) * time.Hour
 }
@@ -199,6 +649,31 @@ func (c *Config) GetWebSocketPingPeriod() time.Duration {
 	return time.Duration(c.WebSocket.PingPeriodSeconds) * time.Second
 }
 
+func (c *Config) GetIdempotencyWindow() time.Duration {
+	return time.Duration(c.Scoring.IdempotencyWindowSeconds) * time.Second
+}
+
+// GetDefaultSeason returns Leaderboard.DefaultSeason, or FallbackSeason when it is empty
+func (c *Config) GetDefaultSeason() string {
+	if c.Leaderboard.DefaultSeason == "" {
+		return FallbackSeason
+	}
+	return c.Leaderboard.DefaultSeason
+}
+
+// GetRankingMethod returns Leaderboard.RankingMethod, or RankingMethodDense when it is empty
+func (c *Config) GetRankingMethod() string {
+	if c.Leaderboard.RankingMethod == "" {
+		return RankingMethodDense
+	}
+	return c.Leaderboard.RankingMethod
+}
+
+// DefaultSeasonFromEnv reads LEADERBOARD_DEFAULT_SEASON for tools that do not load the whole config
+func DefaultSeasonFromEnv() string {
+	return getEnv("LEADERBOARD_DEFAULT_SEASON", FallbackSeason)
+}
+
 func (c *Config) GetCacheLeaderboardTTL() time.Duration {
 	return time.Duration(c.Cache.LeaderboardTTLMinutes) * time.Minute
 }
@@ -214,3 +689,55 @@ func (c *Config) GetCacheScoreTTL() time.Duration {
 func (c *Config) GetCacheCleanupInterval() time.Duration {
 	return time.Duration(c.Cache.CleanupIntervalMinutes) * time.Minute
 }
+
+func (c *Config) GetSimulationUpdateInterval() time.Duration {
+	return time.Duration(c.Simulation.UpdateIntervalSec) * time.Second
+}
+
+// ValidateSimulation checks the simulator settings; only cmd/simulator calls it
+func (c *Config) ValidateSimulation() error {
+	sim := c.Simulation
+	if sim.UpdateIntervalSec <= 0 {
+		return fmt.Errorf("SIMULATION_UPDATE_INTERVAL_SEC must be positive")
+	}
+	if sim.MinScore < 0 || sim.MaxScore <= sim.MinScore {
+		return fmt.Errorf("SIMULATION_MIN_SCORE must be non-negative and below SIMULATION_MAX_SCORE")
+	}
+	if sim.ScoreIncrement <= 0 {
+		return fmt.Errorf("SIMULATION_SCORE_INCREMENT must be positive")
+	}
+	total := 0.0
+	for season, weight := range sim.SeasonWeights {
+		if weight < 0 {
+			return fmt.Errorf("SIMULATION_SEASON_WEIGHTS: weight of %s is negative", season)
+		}
+		total += weight
+	}
+	if total <= 0 {
+		return fmt.Errorf("SIMULATION_SEASON_WEIGHTS must give at least one season a positive weight")
+	}
+	return nil
+}
+
+func (c *Config) GetSlowQueryThreshold() time.Duration {
+	return time.Duration(c.Observability.SlowQueryThresholdMs) * time.Millisecond
+}
+
+func (c *Config) GetSessionTTL() time.Duration {
+	return time.Duration(c.Session.TTLMinutes) * time.Minute
+}
+
+func (c *Config) GetLeaderboardViewRefreshInterval() time.Duration {
+	return time.Duration(c.Leaderboard.ViewRefreshIntervalSeconds) * time.Second
+}
+
+// GetMetadataEncryptionKey decodes the hex metadata encryption key into an AES-256 key
+func (c *Config) GetMetadataEncryptionKey() ([32]byte, error) {
+	var key [32]byte
+	decoded, err := hex.DecodeString(c.Scoring.MetadataEncryptionKey)
+	if err != nil || len(decoded) != len(key) {
+		return key, fmt.Errorf("SCORING_METADATA_ENCRYPTION_KEY must be 64 hex characters (32 bytes)")
+	}
+	copy(key[:], decoded)
+	return key, nil
+}
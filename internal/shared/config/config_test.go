@@ -0,0 +1,321 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearEnv unsets variables that would otherwise override the config file
+func clearEnv(t *testing.T, keys ...string) {
+	for _, key := range keys {
+		t.Setenv(key, "")
+	}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_ConfigFile(t *testing.T) {
+	clearEnv(t, "DATABASE_URL", "JWT_SECRET", "PORT", "DB_MAX_CONNS", "SCORING_ONLY_PERSONAL_BEST", "WS_DEFAULT_LIMIT", "REDIS_ADDR")
+
+	path := writeConfigFile(t, `
+DATABASE_URL: postgres://file:secret@db:5432/leaderboard
+JWT_SECRET: from-file
+port: 9090
+db:
+  max_conns: 40
+scoring:
+  only_personal_best: true
+ws:
+  default_limit: 25
+`)
+	t.Setenv(configFileEnv, path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, path, cfg.ConfigFile)
+	assert.Equal(t, "postgres://file:secret@db:5432/leaderboard", cfg.Database.URL)
+	assert.Equal(t, "from-file", cfg.JWT.Secret)
+	assert.Equal(t, "9090", cfg.Server.Port)
+	assert.Equal(t, 40, cfg.Database.MaxConns)
+	assert.True(t, cfg.Scoring.OnlyStorePersonalBest)
+	assert.Equal(t, 25, cfg.WebSocket.DefaultLimit)
+
+	// Settings missing from the file keep their defaults
+	assert.Equal(t, "localhost:6379", cfg.Redis.Addr)
+}
+
+func TestLoad_EnvOverridesConfigFile(t *testing.T) {
+	clearEnv(t, "DATABASE_URL", "PORT")
+
+	path := writeConfigFile(t, `
+DATABASE_URL: postgres://file@db/leaderboard
+JWT_SECRET: from-file
+PORT: 9090
+`)
+	t.Setenv(configFileEnv, path)
+	t.Setenv("JWT_SECRET", "from-env")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "from-env", cfg.JWT.Secret)
+	assert.Equal(t, "9090", cfg.Server.Port)
+}
+
+func TestLoad_MissingConfigFile(t *testing.T) {
+	t.Setenv(configFileEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+
+	_, err := Load()
+	assert.Error(t, err)
+}
+
+func TestLoad_MultitenancyAPIKeys(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("MULTITENANCY_ENABLED", "true")
+	t.Setenv("MULTITENANCY_API_KEYS", "key-a=studio_a, key-b=studio_b")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.True(t, cfg.Multitenancy.Enabled)
+	assert.Equal(t, map[string]string{"key-a": "studio_a", "key-b": "studio_b"}, cfg.Multitenancy.APIKeys)
+
+	t.Setenv("MULTITENANCY_API_KEYS", "key-a")
+	_, err = Load()
+	assert.Error(t, err, "an API key without a tenant is rejected")
+}
+
+func TestLoad_SimulationSettings(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+	t.Setenv("SIMULATION_UPDATE_INTERVAL_SEC", "2")
+	t.Setenv("SIMULATION_MAX_SCORE", "500")
+	t.Setenv("SIMULATION_SEASON_WEIGHTS", "global=1,weekly=0.5")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	require.NoError(t, cfg.ValidateSimulation())
+
+	assert.Equal(t, 2*time.Second, cfg.GetSimulationUpdateInterval())
+	assert.Equal(t, int64(100), cfg.Simulation.MinScore, "unset keys keep their defaults")
+	assert.Equal(t, int64(500), cfg.Simulation.MaxScore)
+	assert.Equal(t, map[string]float64{"global": 1, "weekly": 0.5}, cfg.Simulation.SeasonWeights)
+
+	cfg.Simulation.MinScore = 500
+	assert.Error(t, cfg.ValidateSimulation(), "an empty score range is rejected")
+}
+
+func TestLoad_RankMilestones(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+
+	tests := []struct {
+		value string
+		want  []int
+	}{
+		{"", []int{1, 10, 100}},
+		{"1, 5,50", []int{1, 5, 50}},
+		{"0", []int{}},
+		{"1,ten", []int{1, 10, 100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("SCORING_RANK_MILESTONES", tt.value)
+			cfg, err := Load()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.Scoring.RankMilestones)
+		})
+	}
+}
+
+func TestLoad_IdempotencyWindow(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+	clearEnv(t, "SCORING_IDEMPOTENCY_WINDOW_SEC")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, cfg.GetIdempotencyWindow())
+
+	t.Setenv("SCORING_IDEMPOTENCY_WINDOW_SEC", "0")
+	_, err = Load()
+	assert.Error(t, err, "a zero window would let retries through immediately")
+}
+
+func TestLoad_RankingMethod(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+
+	for _, method := range []string{"", RankingMethodDense, RankingMethodCompetition, RankingMethodOrdinal} {
+		t.Run("method="+method, func(t *testing.T) {
+			t.Setenv("LEADERBOARD_RANKING_METHOD", method)
+			cfg, err := Load()
+			require.NoError(t, err)
+			if method == "" {
+				method = RankingMethodDense
+			}
+			assert.Equal(t, method, cfg.Leaderboard.RankingMethod)
+		})
+	}
+
+	t.Setenv("LEADERBOARD_RANKING_METHOD", "olympic")
+	_, err := Load()
+	assert.ErrorIs(t, err, ErrInvalidRankingMethod)
+
+	// The materialized view is always ranked densely
+	t.Setenv("LEADERBOARD_USE_MATERIALIZED_VIEW", "true")
+	t.Setenv("LEADERBOARD_RANKING_METHOD", RankingMethodCompetition)
+	_, err = Load()
+	assert.ErrorContains(t, err, "LEADERBOARD_USE_MATERIALIZED_VIEW")
+	t.Setenv("LEADERBOARD_RANKING_METHOD", RankingMethodDense)
+	_, err = Load()
+	assert.NoError(t, err)
+}
+
+func TestLoad_CacheSerializationFormat(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+	clearEnv(t, "CACHE_REDIS_SERIALIZATION_FORMAT")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, SerializationFormatJSON, cfg.Cache.RedisSerializationFormat)
+
+	for _, format := range []string{SerializationFormatCBOR, SerializationFormatMsgpack, "xml"} {
+		t.Setenv("CACHE_REDIS_SERIALIZATION_FORMAT", format)
+		_, err := Load()
+		assert.ErrorIs(t, err, ErrUnsupportedSerializationFormat, format)
+	}
+}
+
+func TestLoad_DefaultSeason(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+	clearEnv(t, "LEADERBOARD_DEFAULT_SEASON")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, FallbackSeason, cfg.GetDefaultSeason())
+
+	t.Setenv("LEADERBOARD_DEFAULT_SEASON", "main")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "main", cfg.GetDefaultSeason())
+	assert.Contains(t, cfg.Simulation.SeasonWeights, "main", "the simulator should target the default season")
+
+	assert.Equal(t, FallbackSeason, (&Config{}).GetDefaultSeason(), "a zero config keeps the historical default")
+}
+
+func TestLoad_MaxMetadataBytes(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+	clearEnv(t, "VALIDATION_MAX_METADATA_BYTES")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 4096, cfg.Validation.MaxMetadataBytes)
+
+	t.Setenv("VALIDATION_MAX_METADATA_BYTES", "-1")
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+func TestLoad_Leagues(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+	clearEnv(t, "LEADERBOARD_LEAGUES")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLeagues, cfg.Leagues)
+
+	t.Setenv("LEADERBOARD_LEAGUES", "Rookie:0:80, Pro:80:100:#FF0000")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []LeagueConfig{
+		{Name: "Rookie", MinPercentile: 0, MaxPercentile: 80},
+		{Name: "Pro", MinPercentile: 80, MaxPercentile: 100, Color: "#FF0000"},
+	}, cfg.Leagues)
+
+	t.Setenv("LEADERBOARD_LEAGUES", "Rookie:0:60,Pro:50:100")
+	_, err = Load()
+	assert.Error(t, err, "overlapping leagues are rejected")
+
+	t.Setenv("LEADERBOARD_LEAGUES", "Pro:50:120")
+	_, err = Load()
+	assert.Error(t, err, "percentiles above 100 are rejected")
+}
+
+func TestLoad_TLSSettings(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{"plain HTTP", map[string]string{}, false},
+		{"certificate files", map[string]string{"TLS_CERT_FILE": "server.crt", "TLS_KEY_FILE": "server.key"}, false},
+		{"certificate without key", map[string]string{"TLS_CERT_FILE": "server.crt"}, true},
+		{"auto TLS", map[string]string{"TLS_AUTO": "true", "TLS_DOMAIN": "leaderboard.example.com"}, false},
+		{"auto TLS without domain", map[string]string{"TLS_AUTO": "true"}, true},
+		{"auto TLS with certificate files", map[string]string{
+			"TLS_AUTO": "true", "TLS_DOMAIN": "leaderboard.example.com",
+			"TLS_CERT_FILE": "server.crt", "TLS_KEY_FILE": "server.key",
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t, "TLS_CERT_FILE", "TLS_KEY_FILE", "TLS_AUTO", "TLS_DOMAIN")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.env["TLS_CERT_FILE"], cfg.Server.TLSCertFile)
+			assert.Equal(t, tt.env["TLS_DOMAIN"], cfg.Server.TLSDomain)
+		})
+	}
+}
+
+func TestLoad_SessionTTL(t *testing.T) {
+	t.Setenv(configFileEnv, "")
+	t.Setenv("DATABASE_URL", "postgres://env@db/leaderboard")
+	t.Setenv("JWT_SECRET", "secret")
+	clearEnv(t, "SESSION_TTL_MINUTES")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, cfg.GetSessionTTL())
+
+	t.Setenv("SESSION_TTL_MINUTES", "0")
+	_, err = Load()
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// ScoreUpdatesChannel is the NOTIFY channel of the scores trigger
+// (sql/migrations/011_score_notify.sql); the payload is the season of the changed row
+const ScoreUpdatesChannel = "score_updates"
+
+// Пауза перед переподключением растет вдвое до notifyMaxReconnectDelay
+const (
+	notifyMinReconnectDelay = time.Second
+	notifyMaxReconnectDelay = 30 * time.Second
+)
+
+// DefaultNotifyDebounce is how long the listener collects notifications with the same payload
+// before calling the callback once
+const DefaultNotifyDebounce = 100 * time.Millisecond
+
+// PostgresNotifyListener delivers PostgreSQL NOTIFY payloads of one channel to a callback.
+// Notifications with the same payload are debounced: the trigger fires once per row, and a
+// callback per row would rebuild the same leaderboard for every score of a burst
+type PostgresNotifyListener struct {
+	url      string
+	channel  string
+	onNotify func(payload string)
+	debounce time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*time.Timer // payload -> таймер отложенного вызова onNotify
+}
+
+// NewPostgresNotifyListener creates a listener with DefaultNotifyDebounce; Run starts listening
+func NewPostgresNotifyListener(url, channel string, onNotify func(payload string)) *PostgresNotifyListener {
+	return &PostgresNotifyListener{
+		url:      url,
+		channel:  channel,
+		onNotify: onNotify,
+		debounce: DefaultNotifyDebounce,
+		pending:  make(map[string]*time.Timer),
+	}
+}
+
+// WithDebounce sets how long notifications with the same payload are collected; 0 calls the
+// callback for every notification. Must be called before Run
+func (l *PostgresNotifyListener) WithDebounce(d time.Duration) *PostgresNotifyListener {
+	l.debounce = d
+	return l
+}
+
+// Run listens until ctx is cancelled, reconnecting after connection errors.
+// Notifications sent while the listener is disconnected are lost.
+func (l *PostgresNotifyListener) Run(ctx context.Context) {
+	defer l.stopPending()
+
+	delay := notifyMinReconnectDelay
+	for {
+		err := l.listen(ctx, func() { delay = notifyMinReconnectDelay })
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn().Err(err).Str("channel", l.channel).Dur("retry_in", delay).Msg("PostgreSQL LISTEN connection lost")
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		delay = min(delay*2, notifyMaxReconnectDelay)
+	}
+}
+
+// listen держит отдельное соединение вне пула GORM: LISTEN действует только в своей сессии,
+// а соединения пула переиспользуются другими запросами
+func (l *PostgresNotifyListener) listen(ctx context.Context, connected func()) error {
+	conn, err := pgx.Connect(ctx, l.url)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return err
+	}
+	connected()
+	log.Info().Str("channel", l.channel).Msg("✅ Listening for PostgreSQL notifications")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		l.deliver(notification.Payload)
+	}
+}
+
+// deliver вызывает onNotify через debounce после первого уведомления с этим payload;
+// следующие уведомления до вызова с ним сливаются. Вызов идет из горутины таймера,
+// поэтому медленный onNotify не задерживает чтение уведомлений
+func (l *PostgresNotifyListener) deliver(payload string) {
+	if l.debounce <= 0 {
+		l.onNotify(payload)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.pending[payload]; ok {
+		return
+	}
+	l.pending[payload] = time.AfterFunc(l.debounce, func() {
+		l.mu.Lock()
+		delete(l.pending, payload)
+		l.mu.Unlock()
+		l.onNotify(payload)
+	})
+}
+
+// stopPending отменяет еще не сработавшие вызовы после остановки Run
+func (l *PostgresNotifyListener) stopPending() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for payload, timer := range l.pending {
+		timer.Stop()
+		delete(l.pending, payload)
+	}
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordedNotifications собирает payload'ы, с которыми вызывался onNotify
+type recordedNotifications struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recordedNotifications) record(payload string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, payload)
+}
+
+func (r *recordedNotifications) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+func TestPostgresNotifyListener_DebouncesPerPayload(t *testing.T) {
+	var got recordedNotifications
+	listener := NewPostgresNotifyListener("", ScoreUpdatesChannel, got.record).WithDebounce(20 * time.Millisecond)
+
+	// Пачка строк одного сезона дает один вызов, другой сезон - свой
+	for i := 0; i < 5; i++ {
+		listener.deliver("global")
+	}
+	listener.deliver("winter")
+
+	assert.Eventually(t, func() bool { return len(got.snapshot()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.ElementsMatch(t, []string{"global", "winter"}, got.snapshot())
+
+	// После вызова следующее уведомление снова доставляется
+	listener.deliver("global")
+	assert.Eventually(t, func() bool { return len(got.snapshot()) == 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestPostgresNotifyListener_ZeroDebounceCallsRightAway(t *testing.T) {
+	var got recordedNotifications
+	listener := NewPostgresNotifyListener("", ScoreUpdatesChannel, got.record).WithDebounce(0)
+
+	listener.deliver("global")
+	listener.deliver("global")
+
+	assert.Equal(t, []string{"global", "global"}, got.snapshot())
+}
+
+func TestPostgresNotifyListener_StopPendingDropsScheduledCalls(t *testing.T) {
+	var got recordedNotifications
+	listener := NewPostgresNotifyListener("", ScoreUpdatesChannel, got.record).WithDebounce(20 * time.Millisecond)
+
+	listener.deliver("global")
+	listener.stopPending()
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, got.snapshot())
+}
@@ -2,17 +2,25 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"leaderboard-service/internal/shared/config"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/rs/zerolog/log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// ErrPoolExhausted is returned by HealthCheck when nearly every connection of the pool is in use
+var ErrPoolExhausted = errors.New("database connection pool exhausted")
+
+// poolExhaustedRatio is the share of MaxOpenConnections above which the pool counts as exhausted
+const poolExhaustedRatio = 0.9
+
 // PostgresDB wraps the GORM database connection
 type PostgresDB struct {
 	DB *gorm.DB
@@ -32,13 +40,20 @@ func NewPostgresDB(cfg *config.Config) (*PostgresDB, error) {
 	// Open GORM connection
 	db, err := gorm.Open(postgres.Open(cfg.Database.URL), &gorm.Config{
 		Logger:                 gormLogger,
-		SkipDefaultTransaction: true, // Better performance
-		PrepareStmt:            true, // Prepare statements for reuse
+		SkipDefaultTransaction: true,                           // Better performance
+		PrepareStmt:            cfg.Database.PrepareStatements, // Prepare statements for reuse
 	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %w", err)
 	}
 
+	// Soft-deleted scores must also be hidden from raw leaderboard queries
+	if cfg.Database.SoftDeleteEnabled {
+		if err := db.Use(SoftDeleteScorePlugin{}); err != nil {
+			return nil, fmt.Errorf("unable to register soft delete plugin: %w", err)
+		}
+	}
+
 	// Get underlying SQL DB for connection pool settings
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -89,3 +104,27 @@ func (db *PostgresDB) Health(ctx context.Context) error {
 	defer cancel()
 	return sqlDB.PingContext(ctx)
 }
+
+// HealthCheck is the probe check: it returns ErrPoolExhausted when more than 90% of
+// MaxOpenConnections are in use, otherwise it pings the database. Idle connections are not
+// counted: a pool kept warm by DB_MIN_CONNS is not exhausted. The pool is checked first
+// because a ping on an exhausted pool waits for a free connection until the timeout.
+func (db *PostgresDB) HealthCheck(ctx context.Context) error {
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	stats := sqlDB.Stats()
+	if stats.MaxOpenConnections > 0 && float64(stats.InUse) > poolExhaustedRatio*float64(stats.MaxOpenConnections) {
+		return fmt.Errorf("%w: %d of %d connections in use", ErrPoolExhausted, stats.InUse, stats.MaxOpenConnections)
+	}
+
+	return db.Health(ctx)
+}
+
+// IsTransientError reports whether a database error is worth retrying:
+// the statement never reached the server or the connection timed out
+func IsTransientError(err error) bool {
+	return pgconn.SafeToRetry(err) || pgconn.Timeout(err)
+}
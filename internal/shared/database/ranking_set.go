@@ -0,0 +1,238 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// rankingSeedMarker держит временный набор загрузки существующим, пока в него не попал ни один
+// игрок: по нему запись из декоратора понимает, что идет загрузка. Не может совпасть с UUID
+const rankingSeedMarker = "~seeding"
+
+// rankingSeedTTL ограничивает жизнь временного набора, если загрузка оборвалась
+const rankingSeedTTL = 5 * time.Minute
+
+// rankingSeedBatch - сколько игроков загрузки добавляется одним вызовом скрипта
+const rankingSeedBatch = 1000
+
+// markTiesLua помечает сезон как имеющий равные счета. Метка живет не меньше набора key,
+// иначе набор с равными счетами пережил бы ее и dense-ранги читались бы из него
+const markTiesLua = `
+local function mark_ties(key, ties)
+	local ttl = redis.call('PTTL', key)
+	local flagTTL = redis.call('PTTL', ties)
+	if flagTTL == -2 then
+		redis.call('SET', ties, 1)
+		if ttl > 0 then redis.call('PEXPIRE', ties, ttl) end
+	elseif ttl == -1 then
+		redis.call('PERSIST', ties)
+	elseif flagTTL > 0 and ttl > flagTTL then
+		redis.call('PEXPIRE', ties, ttl)
+	end
+end
+
+local function add(key, ties, score, member, flag)
+	local tied = redis.call('ZCOUNT', key, score, score)
+	local own = redis.call('ZSCORE', key, member)
+	if own and tonumber(own) == tonumber(score) then tied = tied - 1 end
+	if tied > 0 then mark_ties(key, ties) end
+	redis.call('ZADD', key, flag, score, member)
+end
+`
+
+// addToRankingSetScript обновляет игрока в наборе сезона и во временном наборе идущей загрузки.
+// Набор не создается с нуля: один ZADD дал бы набор из одного игрока с рангом 1 для всех.
+// KEYS: набор, набор загрузки, метка равных счетов; ARGV: счет, user_id
+var addToRankingSetScript = redis.NewScript(markTiesLua + `
+if redis.call('EXISTS', KEYS[1]) == 1 then add(KEYS[1], KEYS[3], ARGV[1], ARGV[2], 'CH') end
+if redis.call('EXISTS', KEYS[2]) == 1 then add(KEYS[2], KEYS[3], ARGV[1], ARGV[2], 'CH') end
+return 0
+`)
+
+// addSeededScript добавляет прочитанных из базы игроков во временный набор с NX: значение,
+// записанное декоратором во время загрузки, новее прочитанного. Возвращает 0, если загрузку
+// отменило удаление (временного набора больше нет).
+// KEYS: набор загрузки, метка; ARGV: пары счет, user_id
+var addSeededScript = redis.NewScript(markTiesLua + `
+if redis.call('EXISTS', KEYS[1]) == 0 then return 0 end
+for i = 1, #ARGV, 2 do
+	add(KEYS[1], KEYS[2], ARGV[i], ARGV[i + 1], 'NX')
+end
+return 1
+`)
+
+// finishSeedScript подменяет набор сезона загруженным и продлевает метку вместе с ним.
+// Возвращает 1 при подмене, 0 при отмененной загрузке и 2 для пустого сезона.
+// KEYS: набор загрузки, набор, метка; ARGV: маркер, TTL в миллисекундах (0 - без срока)
+var finishSeedScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then return 0 end
+redis.call('ZREM', KEYS[1], ARGV[1])
+if redis.call('EXISTS', KEYS[1]) == 0 then return 2 end
+redis.call('RENAME', KEYS[1], KEYS[2])
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then redis.call('PEXPIRE', KEYS[2], ttl) end
+if redis.call('EXISTS', KEYS[3]) == 1 then
+	if ttl > 0 then
+		if redis.call('PTTL', KEYS[3]) > 0 and redis.call('PTTL', KEYS[3]) < ttl then redis.call('PEXPIRE', KEYS[3], ttl) end
+	else
+		redis.call('PERSIST', KEYS[3])
+	end
+end
+return 1
+`)
+
+// rankingPositionScript читает положение игрока одним вызовом, чтобы счетчики не разошлись
+// с его счетом. KEYS: набор, метка; ARGV: user_id
+var rankingPositionScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score then return false end
+return {
+	redis.call('ZCOUNT', KEYS[1], '(' .. score, '+inf'),
+	redis.call('ZCOUNT', KEYS[1], score, score),
+	redis.call('EXISTS', KEYS[2]),
+}
+`)
+
+// ErrRankingSeedCancelled is returned by RankingSet.Seed when a deletion in the season
+// dropped the set being loaded; the season has to be seeded again
+var ErrRankingSeedCancelled = errors.New("ranking set seeding cancelled")
+
+// RankingPosition is a player's place in a season's ranking set
+type RankingPosition struct {
+	Better int64 // players with a higher score
+	Tied   int64 // players with the same score, the player included
+	// SeasonTies is set once two players of the season may have had equal scores in the set;
+	// it stays set until the set expires
+	SeasonTies bool
+}
+
+// RankingMember is a player's score as loaded into a ranking set
+type RankingMember struct {
+	UserID uuid.UUID
+	Score  int64
+}
+
+// RankingSet keeps the sorted set leaderboard:{season} (member user_id, score the player's score).
+// A set only ever holds scores: equal scores are not ordered by timestamp, so callers have to
+// fall back to PostgreSQL for tied players.
+type RankingSet struct {
+	client *redis.Client
+}
+
+// NewRankingSet creates a RankingSet on the given Redis client
+func NewRankingSet(redisClient *RedisClient) *RankingSet {
+	return &RankingSet{client: redisClient.Client}
+}
+
+// RankingSetKey returns the key of a season's ranking set
+func RankingSetKey(season string) string {
+	return "leaderboard:" + season
+}
+
+func rankingSeedKey(season string) string {
+	return "ranking_seed:" + season
+}
+
+func rankingTiesKey(season string) string {
+	return "ranking_ties:" + season
+}
+
+// Add mirrors a stored score into the season's set and into a seeding in progress.
+// Nothing is written for a season that is not seeded.
+func (s *RankingSet) Add(ctx context.Context, season string, score int64, userID uuid.UUID) error {
+	keys := []string{RankingSetKey(season), rankingSeedKey(season), rankingTiesKey(season)}
+	return addToRankingSetScript.Run(ctx, s.client, keys, score, userID.String()).Err()
+}
+
+// Remove drops a player from the season's set and cancels a seeding in progress,
+// which could otherwise add the player back from its earlier read
+func (s *RankingSet) Remove(ctx context.Context, season string, userID uuid.UUID) error {
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, RankingSetKey(season), userID.String())
+		pipe.Del(ctx, rankingSeedKey(season))
+		return nil
+	})
+	return err
+}
+
+// Drop deletes the season's set, its tie mark and a seeding in progress
+func (s *RankingSet) Drop(ctx context.Context, season string) error {
+	return s.client.Del(ctx, RankingSetKey(season), rankingSeedKey(season), rankingTiesKey(season)).Err()
+}
+
+// Position returns the player's place in the season's set; false when the set does not hold the player
+func (s *RankingSet) Position(ctx context.Context, season string, userID uuid.UUID) (RankingPosition, bool, error) {
+	keys := []string{RankingSetKey(season), rankingTiesKey(season)}
+	counts, err := rankingPositionScript.Run(ctx, s.client, keys, userID.String()).Int64Slice()
+	if errors.Is(err, redis.Nil) {
+		return RankingPosition{}, false, nil
+	}
+	if err != nil {
+		return RankingPosition{}, false, err
+	}
+	return RankingPosition{Better: counts[0], Tied: counts[1], SeasonTies: counts[2] == 1}, true, nil
+}
+
+// Players returns the size of the season's set; false when the set does not exist
+func (s *RankingSet) Players(ctx context.Context, season string) (int64, bool, error) {
+	players, err := s.client.ZCard(ctx, RankingSetKey(season)).Result()
+	if err != nil {
+		return 0, false, err
+	}
+	// Redis не хранит пустые sorted set, поэтому 0 означает, что набора нет
+	return players, players > 0, nil
+}
+
+// Seed replaces the season's set with the scores returned by load; the set expires after ttl.
+// The temporary set exists before load reads the database, so scores written meanwhile are
+// merged into it and win over the read ones. A deletion during the load cancels the seeding
+// with ErrRankingSeedCancelled.
+func (s *RankingSet) Seed(ctx context.Context, season string, load func(ctx context.Context) ([]RankingMember, error), ttl time.Duration) error {
+	seedKey := rankingSeedKey(season)
+	// Временный набор не очищается: если загрузку уже ведет другой экземпляр, его набор
+	// актуален (декоратор пишет в него), и повторная вставка с NX ничего не испортит
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAddNX(ctx, seedKey, redis.Z{Score: -1, Member: rankingSeedMarker})
+		pipe.Expire(ctx, seedKey, rankingSeedTTL)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	members, err := load(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{seedKey, rankingTiesKey(season)}
+	for start := 0; start < len(members); start += rankingSeedBatch {
+		end := min(start+rankingSeedBatch, len(members))
+		args := make([]interface{}, 0, 2*(end-start))
+		for _, member := range members[start:end] {
+			args = append(args, member.Score, member.UserID.String())
+		}
+		added, err := addSeededScript.Run(ctx, s.client, keys, args...).Int()
+		if err != nil {
+			return err
+		}
+		if added == 0 {
+			return ErrRankingSeedCancelled
+		}
+	}
+
+	keys = []string{seedKey, RankingSetKey(season), rankingTiesKey(season)}
+	finished, err := finishSeedScript.Run(ctx, s.client, keys, rankingSeedMarker, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if finished == 0 {
+		return ErrRankingSeedCancelled
+	}
+	// 2 - в сезоне никого нет: набор не создается, как и пустой sorted set в Redis
+	return nil
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SoftDeleteScoresTag marks a raw query over scores aliased as s. SoftDeleteScorePlugin
+// replaces it with "AND s.deleted_at IS NULL", so it must follow a WHERE condition.
+// Without the plugin the tag stays a plain SQL comment.
+const SoftDeleteScoresTag = "/* soft_delete:scores */"
+
+// SoftDeleteScoresTagAs is SoftDeleteScoresTag for scores aliased as alias,
+// e.g. a correlated subquery that has to tell its rows from the outer s
+func SoftDeleteScoresTagAs(alias string) string {
+	return "/* soft_delete:scores:" + alias + " */"
+}
+
+// softDeleteScoresTagPattern находит метки; алиас без суффикса - s
+var softDeleteScoresTagPattern = regexp.MustCompile(`/\* soft_delete:scores(?::([A-Za-z_][A-Za-z0-9_]*))? \*/`)
+
+// SoftDeleteScorePlugin hides soft-deleted scores from raw queries.
+// GORM adds "deleted_at IS NULL" only to queries it builds from a model; db.Raw bypasses it.
+type SoftDeleteScorePlugin struct{}
+
+// Name implements gorm.Plugin
+func (SoftDeleteScorePlugin) Name() string {
+	return "soft_delete_scores"
+}
+
+// Initialize implements gorm.Plugin
+// Raw(...).Scan идет через цепочку Row, Raw(...).Find - через Query, Exec - через Raw
+func (p SoftDeleteScorePlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register(p.Name()+":query", applySoftDeleteScores); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register(p.Name()+":row", applySoftDeleteScores); err != nil {
+		return err
+	}
+	return db.Callback().Raw().Before("gorm:raw").Register(p.Name()+":raw", applySoftDeleteScores)
+}
+
+// applySoftDeleteScores заменяет метки на условие "AND <алиас>.deleted_at IS NULL" в уже собранном SQL.
+// Запросы без метки не меняются; построенные GORM из ScoreEntity фильтрует сам GORM по DeletedAt
+func applySoftDeleteScores(db *gorm.DB) {
+	sql := db.Statement.SQL.String()
+	if !strings.Contains(sql, "/* soft_delete:scores") {
+		return
+	}
+	sql = softDeleteScoresTagPattern.ReplaceAllStringFunc(sql, func(tag string) string {
+		alias := softDeleteScoresTagPattern.FindStringSubmatch(tag)[1]
+		if alias == "" {
+			alias = "s"
+		}
+		return "AND " + alias + ".deleted_at IS NULL"
+	})
+	db.Statement.SQL.Reset()
+	db.Statement.SQL.WriteString(sql)
+}
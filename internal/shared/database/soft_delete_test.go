@@ -0,0 +1,89 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDryRunDB открывает GORM в режиме DryRun: SQL строится, но не выполняется
+func newDryRunDB(t *testing.T, plugins ...gorm.Plugin) *gorm.DB {
+	t.Helper()
+
+	sqlDB, err := sql.Open("pgx", "postgres://localhost:5432/dryrun")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	for _, p := range plugins {
+		require.NoError(t, db.Use(p))
+	}
+	return db
+}
+
+const taggedScoresQuery = `SELECT s.user_id FROM scores s WHERE s.season = ? ` + SoftDeleteScoresTag + ` ORDER BY s.score DESC`
+
+func TestSoftDeleteScorePlugin_RewritesTaggedRawQueries(t *testing.T) {
+	db := newDryRunDB(t, SoftDeleteScorePlugin{})
+	var rows []struct{ UserID string }
+
+	tests := []struct {
+		name  string
+		query func(tx *gorm.DB) *gorm.DB
+	}{
+		{"scan", func(tx *gorm.DB) *gorm.DB { return tx.Raw(taggedScoresQuery, "global").Scan(&rows) }},
+		{"find", func(tx *gorm.DB) *gorm.DB { return tx.Raw(taggedScoresQuery, "global").Find(&rows) }},
+		{"exec", func(tx *gorm.DB) *gorm.DB {
+			return tx.Exec(`UPDATE scores s SET score = 0 WHERE s.season = ? `+SoftDeleteScoresTag, "global")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := db.ToSQL(tt.query)
+
+			assert.Contains(t, query, "s.season = 'global' AND s.deleted_at IS NULL")
+			assert.NotContains(t, query, SoftDeleteScoresTag)
+		})
+	}
+}
+
+func TestSoftDeleteScorePlugin_RewritesAliasedTag(t *testing.T) {
+	db := newDryRunDB(t, SoftDeleteScorePlugin{})
+	var count int64
+
+	query := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Raw(`SELECT COUNT(*) FROM scores o WHERE o.season = ? `+SoftDeleteScoresTagAs("o"), "global").Scan(&count)
+	})
+
+	assert.Contains(t, query, "o.season = 'global' AND o.deleted_at IS NULL")
+}
+
+func TestSoftDeleteScorePlugin_LeavesUntaggedQueries(t *testing.T) {
+	db := newDryRunDB(t, SoftDeleteScorePlugin{})
+	var rows []struct{ UserID string }
+
+	query := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Raw(`SELECT user_id FROM scores WHERE season = ?`, "global").Scan(&rows)
+	})
+
+	assert.Equal(t, `SELECT user_id FROM scores WHERE season = 'global'`, query)
+}
+
+func TestSoftDeleteScoresTag_IsCommentWithoutPlugin(t *testing.T) {
+	db := newDryRunDB(t)
+	var rows []struct{ UserID string }
+
+	query := db.ToSQL(func(tx *gorm.DB) *gorm.DB { return tx.Raw(taggedScoresQuery, "global").Scan(&rows) })
+
+	assert.Contains(t, query, "s.season = 'global' "+SoftDeleteScoresTag)
+	assert.NotContains(t, query, "deleted_at")
+}
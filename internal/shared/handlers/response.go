@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	sharedmodels "leaderboard-service/internal/shared/models"
+	"leaderboard-service/internal/shared/utils"
 )
 
 // RespondJSON sends a JSON response
@@ -18,11 +20,48 @@ func RespondJSON(w http.ResponseWriter, data interface{}, statusCode int) {
 
 // RespondError sends a JSON error response
 func RespondError(w http.ResponseWriter, message string, statusCode int) {
+	RespondErrorWithCode(w, message, "", statusCode)
+}
+
+// RespondErrorWithCode sends a JSON error response with a machine-readable error code
+func RespondErrorWithCode(w http.ResponseWriter, message, errorCode string, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	_ = json.NewEncoder(w).Encode(sharedmodels.ErrorResponse{
-		Error:   http.StatusText(statusCode),
-		Message: message,
-		Code:    statusCode,
+		Error:     http.StatusText(statusCode),
+		Message:   message,
+		Code:      statusCode,
+		ErrorCode: errorCode,
 	})
 }
+
+// RespondAppError maps a service error to an HTTP response.
+// *utils.AppError anywhere in the chain supplies status, code and message;
+// any other error becomes a generic 500 so internal details are not leaked.
+func RespondAppError(w http.ResponseWriter, err error) {
+	var appErr *utils.AppError
+	if errors.As(err, &appErr) {
+		RespondErrorWithCode(w, appErr.Message, appErr.Code, appErr.StatusCode)
+		return
+	}
+	RespondErrorWithCode(w, "internal server error", utils.ErrCodeInternalError, http.StatusInternalServerError)
+}
+
+// validationErrorResponse is an error response listing every invalid field
+type validationErrorResponse struct {
+	sharedmodels.ErrorResponse
+	Fields utils.FieldErrors `json:"fields"`
+}
+
+// RespondValidationErrors sends 422 Unprocessable Entity with all field errors of a request
+func RespondValidationErrors(w http.ResponseWriter, errs utils.FieldErrors) {
+	RespondJSON(w, validationErrorResponse{
+		ErrorResponse: sharedmodels.ErrorResponse{
+			Error:     http.StatusText(http.StatusUnprocessableEntity),
+			Message:   errs.Error(),
+			Code:      http.StatusUnprocessableEntity,
+			ErrorCode: utils.ErrCodeValidation,
+		},
+		Fields: errs,
+	}, http.StatusUnprocessableEntity)
+}
@@ -0,0 +1,266 @@
+// Package metrics отдает метрики сервиса в текстовом формате Prometheus (GET /metrics).
+// Клиентская библиотека Prometheus не подключена, поэтому GaugeVec и CounterVec -
+// минимальные аналоги prometheus.GaugeVec и prometheus.CounterVec с тем же форматом вывода.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Метрики WebSocket хаба
+var (
+	// WSConnections - число подключенных WebSocket клиентов по сезонам
+	WSConnections = NewGaugeVec("leaderboard_ws_connections", "Connected WebSocket clients per season.", "season")
+	// WSBroadcasts - число рассылок лидерборда клиентам сезона
+	WSBroadcasts = NewCounterVec("leaderboard_ws_broadcasts_total", "Broadcasts sent to the WebSocket clients of a season.", "season")
+)
+
+// DefaultRegistry - реестр, который отдает Handler
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.MustRegister(WSConnections, WSBroadcasts)
+}
+
+// Collector пишет свои серии в текстовом формате Prometheus
+type Collector interface {
+	Name() string
+	WriteText(w io.Writer) error
+}
+
+// Registry - набор метрик с уникальными именами
+type Registry struct {
+	mu         sync.RWMutex
+	collectors map[string]Collector
+}
+
+// NewRegistry создает пустой реестр
+func NewRegistry() *Registry {
+	return &Registry{collectors: make(map[string]Collector)}
+}
+
+// Register добавляет метрики; имя, которое уже занято, - ошибка
+func (r *Registry) Register(collectors ...Collector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range collectors {
+		if _, exists := r.collectors[c.Name()]; exists {
+			return fmt.Errorf("metric %q is already registered", c.Name())
+		}
+	}
+	for _, c := range collectors {
+		r.collectors[c.Name()] = c
+	}
+	return nil
+}
+
+// MustRegister - Register, который паникует при ошибке (для init)
+func (r *Registry) MustRegister(collectors ...Collector) {
+	if err := r.Register(collectors...); err != nil {
+		panic(err)
+	}
+}
+
+// WriteText пишет все метрики, отсортированные по имени
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	collectors := make([]Collector, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		collectors = append(collectors, r.collectors[name])
+	}
+	r.mu.RUnlock()
+
+	for _, c := range collectors {
+		if err := c.WriteText(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler отдает метрики реестра
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.WriteText(w)
+	})
+}
+
+// Handler отдает метрики DefaultRegistry
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+// vec хранит значения метрики по наборам значений меток
+type vec struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+
+	mu     sync.Mutex
+	values map[string]float64
+	series map[string][]string
+}
+
+func newVec(name, help, kind string, labels []string) *vec {
+	return &vec{
+		name:   name,
+		help:   help,
+		kind:   kind,
+		labels: labels,
+		values: make(map[string]float64),
+		series: make(map[string][]string),
+	}
+}
+
+// key склеивает значения меток; \xff не встречается в UTF-8 тексте
+func (v *vec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metric %s: expected %d label values, got %d", v.name, len(v.labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+func (v *vec) add(delta float64, labelValues []string) {
+	key := v.key(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, ok := v.series[key]; !ok {
+		v.series[key] = append([]string(nil), labelValues...)
+	}
+	v.values[key] += delta
+}
+
+func (v *vec) value(labelValues []string) float64 {
+	key := v.key(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.values[key]
+}
+
+// Name возвращает имя метрики
+func (v *vec) Name() string {
+	return v.name
+}
+
+// WriteText пишет HELP, TYPE и серии, отсортированные по значениям меток
+func (v *vec) WriteText(w io.Writer) error {
+	v.mu.Lock()
+	keys := make([]string, 0, len(v.values))
+	for key := range v.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, v.kind)
+	for _, key := range keys {
+		b.WriteString(v.name)
+		if len(v.labels) > 0 {
+			b.WriteByte('{')
+			for i, label := range v.labels {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				fmt.Fprintf(&b, "%s=\"%s\"", label, escapeLabelValue(v.series[key][i]))
+			}
+			b.WriteByte('}')
+		}
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatFloat(v.values[key], 'g', -1, 64))
+		b.WriteByte('\n')
+	}
+	v.mu.Unlock()
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeLabelValue экранирует \, " и перевод строки, как требует формат Prometheus
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// GaugeVec - значение, которое растет и убывает, по наборам меток (аналог prometheus.GaugeVec)
+type GaugeVec struct {
+	*vec
+}
+
+// NewGaugeVec создает gauge с метками labels
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{newVec(name, help, "gauge", labels)}
+}
+
+// Set устанавливает значение серии
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	key := g.key(labelValues)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.series[key] = append([]string(nil), labelValues...)
+	g.values[key] = value
+}
+
+// Inc увеличивает серию на 1
+func (g *GaugeVec) Inc(labelValues ...string) {
+	g.add(1, labelValues)
+}
+
+// Dec уменьшает серию на 1
+func (g *GaugeVec) Dec(labelValues ...string) {
+	g.add(-1, labelValues)
+}
+
+// Delete убирает серию из вывода, например сезон без клиентов
+func (g *GaugeVec) Delete(labelValues ...string) {
+	key := g.key(labelValues)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.values, key)
+	delete(g.series, key)
+}
+
+// Value возвращает значение серии (0, если ее нет)
+func (g *GaugeVec) Value(labelValues ...string) float64 {
+	return g.value(labelValues)
+}
+
+// CounterVec - монотонно растущий счетчик по наборам меток (аналог prometheus.CounterVec)
+type CounterVec struct {
+	*vec
+}
+
+// NewCounterVec создает счетчик с метками labels
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{newVec(name, help, "counter", labels)}
+}
+
+// Inc увеличивает серию на 1
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.add(1, labelValues)
+}
+
+// Add увеличивает серию на delta; отрицательный delta - ошибка программиста
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("counter %s cannot decrease", c.name))
+	}
+	c.add(delta, labelValues)
+}
+
+// Value возвращает значение серии (0, если ее нет)
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	return c.value(labelValues)
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WriteTextFormat(t *testing.T) {
+	registry := NewRegistry()
+	gauge := NewGaugeVec("test_connections", "Connections.", "season")
+	counter := NewCounterVec("test_broadcasts_total", "Broadcasts.", "season")
+	require.NoError(t, registry.Register(gauge, counter))
+
+	gauge.Inc("winter")
+	gauge.Inc("winter")
+	gauge.Set(3, `say "hi"`)
+	counter.Add(2.5, "winter")
+
+	var out bytes.Buffer
+	require.NoError(t, registry.WriteText(&out))
+	assert.Equal(t, `# HELP test_broadcasts_total Broadcasts.
+# TYPE test_broadcasts_total counter
+test_broadcasts_total{season="winter"} 2.5
+# HELP test_connections Connections.
+# TYPE test_connections gauge
+test_connections{season="say \"hi\""} 3
+test_connections{season="winter"} 2
+`, out.String())
+
+	gauge.Delete("winter")
+	assert.Zero(t, gauge.Value("winter"))
+	assert.Error(t, registry.Register(NewGaugeVec("test_connections", "Duplicate.")), "names are unique")
+	assert.Panics(t, func() { counter.Add(-1, "winter") })
+	assert.Panics(t, func() { gauge.Inc("winter", "extra") }, "label values must match the labels")
+}
+
+func TestHandler_ServesDefaultRegistry(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, rr.Body.String(), "# TYPE leaderboard_ws_connections gauge")
+	assert.Contains(t, rr.Body.String(), "# TYPE leaderboard_ws_broadcasts_total counter")
+}
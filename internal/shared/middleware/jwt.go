@@ -21,6 +21,14 @@ const (
 	UserIDKey contextKey = "user_id"
 	EmailKey  contextKey = "email"
 	RoleKey   contextKey = "role"
+	// TenantIDKey holds the tenant namespace of the request (set from the tenant_id claim or an API key)
+	TenantIDKey contextKey = "tenant_id"
+)
+
+// Roles carried in the "role" claim of tokens issued by AuthService.Login
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
 )
 
 // JWTMiddleware validates JWT tokens
@@ -62,6 +70,9 @@ func (m *JWTMiddleware) Authenticate(next http.Handler) http.Handler {
 		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
 		ctx = context.WithValue(ctx, EmailKey, claims.Email)
 		ctx = context.WithValue(ctx, RoleKey, claims.Role)
+		if claims.TenantID != "" {
+			ctx = WithTenantID(ctx, claims.TenantID)
+		}
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -95,10 +106,14 @@ func (m *JWTMiddleware) validateToken(tokenString string) (*authmodels.AuthClaim
 		return nil, err
 	}
 
+	// tenant_id is optional: tokens issued by this service do not carry it
+	tenantID, _ := claims["tenant_id"].(string)
+
 	return &authmodels.AuthClaims{
-		UserID: userID,
-		Email:  claims["email"].(string),
-		Role:   claims["role"].(string),
+		UserID:   userID,
+		Email:    claims["email"].(string),
+		Role:     claims["role"].(string),
+		TenantID: tenantID,
 	}, nil
 }
 
@@ -118,6 +133,9 @@ func (m *JWTMiddleware) GenerateToken(userID uuid.UUID, email, role string, expi
 		"exp":     expiresAt.Unix(),
 		"iat":     time.Now().Unix(),
 	}
+	if role == RoleAdmin {
+		claims["admin"] = true
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(m.secret))
@@ -134,6 +152,26 @@ func GetUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	return userID, ok
 }
 
+// GetRoleFromContext extracts the role claim from request context
+func GetRoleFromContext(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(RoleKey).(string)
+	return role, ok
+}
+
+// RequireRole rejects requests whose JWT role claim differs from role.
+// Must be chained after Authenticate.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if claimed, ok := GetRoleFromContext(r.Context()); !ok || claimed != role {
+				respondError(w, "insufficient permissions", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // respondError sends a JSON error response
 func respondError(w http.ResponseWriter, message string, code int) {
 	w.Header().Set("Content-Type", "application/json")
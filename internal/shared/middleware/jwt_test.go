@@ -120,3 +120,43 @@ func TestJWTMiddleware_Authenticate_InvalidToken(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, rr.Code)
 }
+
+func TestRequireRole(t *testing.T) {
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:      "test-secret-key",
+			ExpiryHours: 24,
+		},
+	}
+
+	jwtMiddleware := NewJWTMiddleware(cfg)
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := jwtMiddleware.Authenticate(RequireRole("admin")(nextHandler))
+
+	tests := []struct {
+		name     string
+		role     string
+		expected int
+	}{
+		{"admin allowed", "admin", http.StatusOK},
+		{"user forbidden", "user", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, _, _ := jwtMiddleware.GenerateToken(uuid.New(), "test@example.com", tt.role, time.Hour)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"leaderboard-service/internal/shared/config"
+)
+
+// APIKeyHeader carries a game client's API key, which identifies its tenant
+const APIKeyHeader = "X-API-Key"
+
+// TenantMiddleware resolves the tenant of a request when multitenancy is enabled
+type TenantMiddleware struct {
+	enabled bool
+	apiKeys map[string]string
+}
+
+// NewTenantMiddleware creates a tenant middleware from the multitenancy config
+func NewTenantMiddleware(cfg *config.Config) *TenantMiddleware {
+	return &TenantMiddleware{
+		enabled: cfg.Multitenancy.Enabled,
+		apiKeys: cfg.Multitenancy.APIKeys,
+	}
+}
+
+// Resolve puts the tenant from the X-API-Key header into the request context, falling back to
+// the tenant_id JWT claim set by Authenticate. Requests without a tenant are rejected.
+// Does nothing when multitenancy is disabled.
+func (m *TenantMiddleware) Resolve(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claimed, hasClaim := GetTenantIDFromContext(r.Context())
+
+		if key := r.Header.Get(APIKeyHeader); key != "" {
+			tenantID, ok := m.apiKeys[key]
+			if !ok {
+				respondError(w, "invalid API key", http.StatusUnauthorized)
+				return
+			}
+			if hasClaim && claimed != tenantID {
+				respondError(w, "API key and token belong to different tenants", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithTenantID(r.Context(), tenantID)))
+			return
+		}
+
+		if !hasClaim {
+			respondError(w, "tenant is required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithTenantID returns a context carrying the tenant ID
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, TenantIDKey, tenantID)
+}
+
+// GetTenantIDFromContext extracts the tenant ID from request context
+func GetTenantIDFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(TenantIDKey).(string)
+	return tenantID, ok && tenantID != ""
+}
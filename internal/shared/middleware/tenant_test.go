@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/shared/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantMiddleware_Resolve(t *testing.T) {
+	cfg := &config.Config{
+		JWT: config.JWTConfig{Secret: "test-secret-key"},
+		Multitenancy: config.MultitenancyConfig{
+			Enabled: true,
+			APIKeys: map[string]string{"key-a": "studio_a"},
+		},
+	}
+	jwtMiddleware := NewJWTMiddleware(cfg)
+
+	var resolved string
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resolved, _ = GetTenantIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	tenants := NewTenantMiddleware(cfg)
+
+	tenantToken := func(tenantID string) string {
+		claims := jwt.MapClaims{
+			"user_id":   uuid.New().String(),
+			"email":     "game@example.com",
+			"role":      "user",
+			"tenant_id": tenantID,
+			"exp":       time.Now().Add(time.Hour).Unix(),
+		}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWT.Secret))
+		require.NoError(t, err)
+		return token
+	}
+	plainToken, _, err := jwtMiddleware.GenerateToken(uuid.New(), "test@example.com", "user", time.Hour)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		token    string
+		apiKey   string
+		expected int
+		tenant   string
+	}{
+		{"tenant claim", tenantToken("studio_b"), "", http.StatusOK, "studio_b"},
+		{"API key", plainToken, "key-a", http.StatusOK, "studio_a"},
+		{"no tenant", plainToken, "", http.StatusForbidden, ""},
+		{"unknown API key", plainToken, "key-x", http.StatusUnauthorized, ""},
+		{"claim and key disagree", tenantToken("studio_b"), "key-a", http.StatusForbidden, ""},
+	}
+
+	handler := jwtMiddleware.Authenticate(tenants.Resolve(nextHandler))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved = ""
+			req := httptest.NewRequest(http.MethodGet, "/leaderboard", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expected, rr.Code)
+			assert.Equal(t, tt.tenant, resolved)
+		})
+	}
+}
+
+func TestTenantMiddleware_DisabledPassesThrough(t *testing.T) {
+	tenants := NewTenantMiddleware(&config.Config{})
+	called := false
+	handler := tenants.Resolve(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/leaderboard/top", nil))
+
+	assert.True(t, called)
+}
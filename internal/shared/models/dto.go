@@ -28,9 +28,10 @@ type ScoreDTO struct {
 
 // ErrorResponse represents an error API response
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Error     string `json:"error"`
+	Message   string `json:"message"`
+	Code      int    `json:"code"`
+	ErrorCode string `json:"error_code,omitempty"` // Machine-readable code, e.g. "NOT_FOUND"
 }
 
 // SuccessResponse represents a generic success API response
@@ -10,6 +10,31 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrRecordNotFound возвращается, когда запись не найдена (проверяется через errors.Is)
+var ErrRecordNotFound = errors.New("record not found")
+
+// ErrMetadataNotSearchable возвращается FindByMetadata, когда metadata хранится в зашифрованном виде
+var ErrMetadataNotSearchable = errors.New("score metadata is encrypted and cannot be searched")
+
+// ErrScoreLocked возвращается Upsert, когда счет пользователя за сезон одновременно сохраняет другой запрос
+var ErrScoreLocked = errors.New("score is locked by a concurrent submission")
+
+// EntityRepository - общие операции над сущностью T
+// Реализуется BaseRepository и CachedBaseRepository, поэтому доменные репозитории
+// могут встраивать любой из них, не меняя остальной код
+type EntityRepository[T any] interface {
+	FindBySpec(ctx context.Context, spec Specification[T]) ([]*T, error)
+	FindOneBySpec(ctx context.Context, spec Specification[T]) (*T, error)
+	CountBySpec(ctx context.Context, spec Specification[T]) (int64, error)
+	Create(ctx context.Context, entity *T) error
+	CreateBatch(ctx context.Context, entities []*T, batchSize int) error
+	Update(ctx context.Context, entity *T) error
+	Delete(ctx context.Context, condition string, args ...interface{}) error
+	FindOne(ctx context.Context, condition string, args ...interface{}) (*T, error)
+	FindAll(ctx context.Context, condition string, args ...interface{}) ([]*T, error)
+	Count(ctx context.Context, condition string, args ...interface{}) (int64, error)
+}
+
 // BaseRepository - переиспользуемый базовый репозиторий с общими методами
 // Реализует общие паттерны работы с БД для всех доменных репозиториев
 type BaseRepository[T any] struct {
@@ -53,7 +78,7 @@ func (r *BaseRepository[T]) FindOneBySpec(ctx context.Context, spec Specificatio
 	err := query.First(&result).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("record not found")
+			return nil, ErrRecordNotFound
 		}
 		return nil, fmt.Errorf("failed to find one by spec: %w", err)
 	}
@@ -85,6 +110,17 @@ func (r *BaseRepository[T]) Create(ctx context.Context, entity *T) error {
 	return nil
 }
 
+// CreateBatch вставляет записи пачками по batchSize - переиспользуемый метод
+func (r *BaseRepository[T]) CreateBatch(ctx context.Context, entities []*T, batchSize int) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if err := r.db.DB.WithContext(ctx).CreateInBatches(entities, batchSize).Error; err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+	return nil
+}
+
 // Update обновляет существующую запись - переиспользуемый метод
 func (r *BaseRepository[T]) Update(ctx context.Context, entity *T) error {
 	result := r.db.DB.WithContext(ctx).Save(entity)
@@ -116,7 +152,7 @@ func (r *BaseRepository[T]) FindOne(ctx context.Context, condition string, args
 	err := r.db.DB.WithContext(ctx).Where(condition, args...).First(&result).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("record not found")
+			return nil, ErrRecordNotFound
 		}
 		return nil, fmt.Errorf("failed to find one: %w", err)
 	}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// SpecCache - хранилище результатов FindBySpec (реализуется decorators.SimpleCache)
+type SpecCache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration)
+	DeleteByPrefix(prefix string)
+}
+
+// CachedBaseRepository - BaseRepository с кэшем результатов FindBySpec
+// Ключ - описание спецификации (Describe), поэтому одинаковые запросы разделяют запись кэша.
+// Любая запись (Create, CreateBatch, Update, Delete) сбрасывает весь кэш типа T:
+// по условию Delete или по измененной сущности нельзя понять, какие спецификации она затронула
+type CachedBaseRepository[T any] struct {
+	*BaseRepository[T]
+	cache  SpecCache
+	ttl    time.Duration
+	prefix string
+}
+
+// NewCachedBaseRepository оборачивает базовый репозиторий кэшем спецификаций
+func NewCachedBaseRepository[T any](base *BaseRepository[T], cache SpecCache, ttl time.Duration) *CachedBaseRepository[T] {
+	return &CachedBaseRepository[T]{
+		BaseRepository: base,
+		cache:          cache,
+		ttl:            ttl,
+		prefix:         SpecCachePrefix[T](),
+	}
+}
+
+// SpecCachePrefix - префикс ключей кэша спецификаций типа T; по нему кэш сбрасывают
+// после записей в обход CachedBaseRepository (транзакции Unit of Work, сырой SQL)
+func SpecCachePrefix[T any]() string {
+	return "spec:" + reflect.TypeOf((*T)(nil)).Elem().String() + ":"
+}
+
+// FindBySpec находит записи по спецификации, повторные запросы отдаются из кэша
+func (r *CachedBaseRepository[T]) FindBySpec(ctx context.Context, spec Specification[T]) ([]*T, error) {
+	key := r.prefix + DescribeSpec(spec)
+
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.([]*T), nil
+	}
+
+	results, err := r.BaseRepository.FindBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, results, r.ttl)
+	return results, nil
+}
+
+// Create создает запись и сбрасывает кэш
+func (r *CachedBaseRepository[T]) Create(ctx context.Context, entity *T) error {
+	defer r.Invalidate()
+	return r.BaseRepository.Create(ctx, entity)
+}
+
+// CreateBatch вставляет записи пачками и сбрасывает кэш
+func (r *CachedBaseRepository[T]) CreateBatch(ctx context.Context, entities []*T, batchSize int) error {
+	defer r.Invalidate()
+	return r.BaseRepository.CreateBatch(ctx, entities, batchSize)
+}
+
+// Update обновляет запись и сбрасывает кэш
+func (r *CachedBaseRepository[T]) Update(ctx context.Context, entity *T) error {
+	defer r.Invalidate()
+	return r.BaseRepository.Update(ctx, entity)
+}
+
+// Delete удаляет записи и сбрасывает кэш
+func (r *CachedBaseRepository[T]) Delete(ctx context.Context, condition string, args ...interface{}) error {
+	defer r.Invalidate()
+	return r.BaseRepository.Delete(ctx, condition, args...)
+}
+
+// Invalidate сбрасывает все закэшированные результаты типа T
+// Вызывается и после неудачной записи: ошибка (например, таймаут) не гарантирует, что строки не изменились
+func (r *CachedBaseRepository[T]) Invalidate() {
+	r.cache.DeleteByPrefix(r.prefix)
+}
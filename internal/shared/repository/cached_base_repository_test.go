@@ -0,0 +1,101 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/shared/database"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/repository/decorators"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   int
+	Name string
+}
+
+type widgetByNameSpec struct {
+	repository.BaseSpecification[widget]
+	name string
+}
+
+func (s widgetByNameSpec) Apply(db *gorm.DB) *gorm.DB { return db.Where("name = ?", s.name) }
+func (s widgetByNameSpec) Describe() string           { return "widget_by_name(" + s.name + ")" }
+
+// newCachedWidgetRepository создает кэширующий репозиторий поверх GORM в режиме DryRun
+// и возвращает счетчик SELECT-запросов, дошедших до базы
+func newCachedWidgetRepository(t *testing.T) (*repository.CachedBaseRepository[widget], *int) {
+	t.Helper()
+
+	sqlDB, err := sql.Open("pgx", "postgres://localhost:5432/dryrun")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	queries := 0
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:count_query", func(*gorm.DB) { queries++ }))
+
+	base := repository.NewBaseRepository[widget](&database.PostgresDB{DB: db})
+	return repository.NewCachedBaseRepository(base, decorators.NewSimpleCache(), time.Minute), &queries
+}
+
+func TestCachedBaseRepository_FindBySpecHitAndMiss(t *testing.T) {
+	repo, queries := newCachedWidgetRepository(t)
+	ctx := context.Background()
+
+	_, err := repo.FindBySpec(ctx, widgetByNameSpec{name: "a"})
+	require.NoError(t, err)
+	_, err = repo.FindBySpec(ctx, widgetByNameSpec{name: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, *queries, "same description is served from cache")
+
+	_, err = repo.FindBySpec(ctx, widgetByNameSpec{name: "b"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, *queries, "different description misses")
+}
+
+func TestCachedBaseRepository_WritesInvalidate(t *testing.T) {
+	writes := map[string]func(repo *repository.CachedBaseRepository[widget]) error{
+		"create": func(repo *repository.CachedBaseRepository[widget]) error {
+			return repo.Create(context.Background(), &widget{Name: "a"})
+		},
+		"create batch": func(repo *repository.CachedBaseRepository[widget]) error {
+			return repo.CreateBatch(context.Background(), []*widget{{Name: "a"}}, 10)
+		},
+		"update": func(repo *repository.CachedBaseRepository[widget]) error {
+			return repo.Update(context.Background(), &widget{ID: 1, Name: "a"})
+		},
+		"delete": func(repo *repository.CachedBaseRepository[widget]) error {
+			return repo.Delete(context.Background(), "id = ?", 1)
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			repo, queries := newCachedWidgetRepository(t)
+			ctx := context.Background()
+
+			_, err := repo.FindBySpec(ctx, widgetByNameSpec{name: "a"})
+			require.NoError(t, err)
+
+			// В DryRun Update и Delete не затрагивают строк и возвращают ошибку - кэш сбрасывается и тогда
+			_ = write(repo)
+
+			_, err = repo.FindBySpec(ctx, widgetByNameSpec{name: "a"})
+			require.NoError(t, err)
+			assert.Equal(t, 2, *queries)
+		})
+	}
+}
@@ -7,10 +7,17 @@ import (
 
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
 
 	"github.com/google/uuid"
 )
 
+// scoreSpecPrefix prefixes cached specification results
+const scoreSpecPrefix = "score_spec:"
+
+// totalCountKey caches Count, the number of scores across all seasons
+const totalCountKey = "total_count"
+
 // CachedScoreRepository decorates ScoreRepository with caching
 type CachedScoreRepository struct {
 	inner repository.ScoreRepository
@@ -36,12 +43,40 @@ func (r *CachedScoreRepository) Upsert(ctx context.Context, score *leaderboardmo
 
 	// Invalidate ALL leaderboard caches for this season (including all pagination/sort combinations)
 	r.cache.Delete(r.scoreKey(score.UserID, score.Season))
+	r.cache.Delete(r.streakKey(score.UserID, score.Season))
 	r.cache.DeleteByPrefix(fmt.Sprintf("leaderboard:%s:", score.Season))
 	r.cache.Delete(r.countKey(score.Season))
+	r.cache.Delete(r.medianKey(score.Season))
+	r.cache.DeleteByPrefix(r.allPrefix(score.Season))
+	r.cache.Delete(totalCountKey)
+	r.cache.DeleteByPrefix(scoreSpecPrefix)
 
 	return nil
 }
 
+// UpsertOnlyIfHigher upserts a personal best and invalidates cache only when the row changed
+func (r *CachedScoreRepository) UpsertOnlyIfHigher(ctx context.Context, score *leaderboardmodels.Score) (bool, error) {
+	updated, err := r.inner.UpsertOnlyIfHigher(ctx, score)
+	if err != nil {
+		return false, err
+	}
+	// Every submission extends the streak, even one that keeps the old score
+	r.cache.Delete(r.streakKey(score.UserID, score.Season))
+	if !updated {
+		return false, nil
+	}
+
+	r.cache.Delete(r.scoreKey(score.UserID, score.Season))
+	r.cache.DeleteByPrefix(fmt.Sprintf("leaderboard:%s:", score.Season))
+	r.cache.Delete(r.countKey(score.Season))
+	r.cache.Delete(r.medianKey(score.Season))
+	r.cache.DeleteByPrefix(r.allPrefix(score.Season))
+	r.cache.Delete(totalCountKey)
+	r.cache.DeleteByPrefix(scoreSpecPrefix)
+
+	return true, nil
+}
+
 // FindByUserAndSeason retrieves a score with caching
 func (r *CachedScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error) {
 	key := r.scoreKey(userID, season)
@@ -64,10 +99,15 @@ func (r *CachedScoreRepository) FindByUserAndSeason(ctx context.Context, userID
 }
 
 // GetLeaderboard retrieves leaderboard WITHOUT caching (dynamic data)
-func (r *CachedScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+func (r *CachedScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
 	// Leaderboard changes frequently - always fetch fresh data from DB
 	// Caching leaderboard causes stale data issues with real-time updates
-	return r.inner.GetLeaderboard(ctx, season, limit, offset, sortOrder)
+	return r.inner.GetLeaderboard(ctx, season, limit, offset, sortBy, sortOrder, excludeUserIDs)
+}
+
+// GetLeaderboardPage retrieves a keyset page WITHOUT caching, like GetLeaderboard
+func (r *CachedScoreRepository) GetLeaderboardPage(ctx context.Context, season, sortOrder string, after utils.CursorPosition, limit int) ([]leaderboardmodels.LeaderboardEntry, error) {
+	return r.inner.GetLeaderboardPage(ctx, season, sortOrder, after, limit)
 }
 
 // CountBySeason retrieves count with caching
@@ -102,10 +142,192 @@ func (r *CachedScoreRepository) DeleteByUserAndSeason(ctx context.Context, userI
 	r.cache.Delete(r.scoreKey(userID, season))
 	r.cache.DeleteByPrefix(fmt.Sprintf("leaderboard:%s:", season))
 	r.cache.Delete(r.countKey(season))
+	r.cache.Delete(r.medianKey(season))
+	r.cache.DeleteByPrefix(r.allPrefix(season))
+	r.cache.Delete(totalCountKey)
+	r.cache.DeleteByPrefix(scoreSpecPrefix)
 
 	return nil
 }
 
+// RevertSubmission undoes the latest submission of a player and invalidates the season's cache
+func (r *CachedScoreRepository) RevertSubmission(ctx context.Context, userID uuid.UUID, season string, previous *leaderboardmodels.Score) error {
+	err := r.inner.RevertSubmission(ctx, userID, season, previous)
+	if err != nil {
+		return err
+	}
+
+	// Откат убирает и запись истории, поэтому серия тоже устаревает
+	r.cache.Delete(r.scoreKey(userID, season))
+	r.cache.Delete(r.streakKey(userID, season))
+	r.cache.DeleteByPrefix(fmt.Sprintf("leaderboard:%s:", season))
+	r.cache.Delete(r.countKey(season))
+	r.cache.Delete(r.medianKey(season))
+	r.cache.DeleteByPrefix(r.allPrefix(season))
+	r.cache.Delete(totalCountKey)
+	r.cache.DeleteByPrefix(scoreSpecPrefix)
+
+	return nil
+}
+
+// DeleteBySeason deletes all scores of a season and drops every cached entry for it
+func (r *CachedScoreRepository) DeleteBySeason(ctx context.Context, season string) (int64, error) {
+	deleted, err := r.inner.DeleteBySeason(ctx, season)
+	if err != nil {
+		return 0, err
+	}
+
+	r.Invalidate(ctx, season)
+
+	return deleted, nil
+}
+
+// CopySeasonScores copies scores into toSeason and drops every cached entry for it
+func (r *CachedScoreRepository) CopySeasonScores(ctx context.Context, fromSeason, toSeason string, limit int) (int64, error) {
+	copied, err := r.inner.CopySeasonScores(ctx, fromSeason, toSeason, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	r.Invalidate(ctx, toSeason)
+
+	return copied, nil
+}
+
+// Invalidate drops every cached entry of a season: per-user scores, leaderboard pages, FindAll pages,
+// count and median. Spec results and the total count span seasons, so they are dropped as well.
+func (r *CachedScoreRepository) Invalidate(ctx context.Context, season string) {
+	r.cache.DeleteBySuffix(":" + season)
+	r.cache.DeleteByPrefix(fmt.Sprintf("leaderboard:%s:", season))
+	r.cache.Delete(r.countKey(season))
+	r.cache.Delete(r.medianKey(season))
+	r.cache.DeleteByPrefix(r.allPrefix(season))
+	r.cache.Delete(totalCountKey)
+	r.cache.DeleteByPrefix(scoreSpecPrefix)
+}
+
+// GetMedianScore retrieves the season median with caching
+func (r *CachedScoreRepository) GetMedianScore(ctx context.Context, season string) (int64, error) {
+	key := r.medianKey(season)
+
+	// Check cache
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.(int64), nil
+	}
+
+	// Cache miss
+	median, err := r.inner.GetMedianScore(ctx, season)
+	if err != nil {
+		return 0, err
+	}
+
+	// Store in cache
+	r.cache.Set(key, median, r.ttl)
+
+	return median, nil
+}
+
+// GetScoreDistribution retrieves the season histogram WITHOUT caching (dashboards poll it rarely)
+func (r *CachedScoreRepository) GetScoreDistribution(ctx context.Context, season string, buckets int) ([]leaderboardmodels.ScoreBucket, error) {
+	return r.inner.GetScoreDistribution(ctx, season, buckets)
+}
+
+// GetLeaderboardFromView retrieves leaderboard from the materialized view WITHOUT caching (the view is already a snapshot)
+func (r *CachedScoreRepository) GetLeaderboardFromView(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	return r.inner.GetLeaderboardFromView(ctx, season, limit, offset, sortOrder)
+}
+
+// RefreshLeaderboardView refreshes the materialized view
+func (r *CachedScoreRepository) RefreshLeaderboardView(ctx context.Context, season string) error {
+	return r.inner.RefreshLeaderboardView(ctx, season)
+}
+
+// GetGlobalStandings retrieves cross-season standings WITHOUT caching (changes on every submission)
+func (r *CachedScoreRepository) GetGlobalStandings(ctx context.Context, limit int) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	return r.inner.GetGlobalStandings(ctx, limit)
+}
+
+// GetCrossSeasonRankings retrieves cross-season rankings WITHOUT caching (changes on every submission)
+func (r *CachedScoreRepository) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]leaderboardmodels.CrossSeasonEntry, error) {
+	return r.inner.GetCrossSeasonRankings(ctx, seasons, normalizeByMax, limit)
+}
+
+// FindByMetadata finds scores by metadata WITHOUT caching (operator lookups, rarely repeated)
+func (r *CachedScoreRepository) FindByMetadata(ctx context.Context, season, key, value string, limit int) ([]*leaderboardmodels.Score, error) {
+	return r.inner.FindByMetadata(ctx, season, key, value, limit)
+}
+
+// GetLeaderboardByCountry retrieves a country's leaderboard (no caching, a player's country changes independently of scores)
+func (r *CachedScoreRepository) GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	return r.inner.GetLeaderboardByCountry(ctx, country, season, limit, offset)
+}
+
+// GetLeaderboardWithProfiles retrieves leaderboard entries with player profiles (no caching, profiles change independently of scores)
+func (r *CachedScoreRepository) GetLeaderboardWithProfiles(ctx context.Context, season string, limit, offset int, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.EnrichedLeaderboardEntry, int64, error) {
+	return r.inner.GetLeaderboardWithProfiles(ctx, season, limit, offset, sortOrder, excludeUserIDs)
+}
+
+// FindAll retrieves a page of scores with caching
+func (r *CachedScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*leaderboardmodels.Score, error) {
+	key := r.allKey(season, limit, offset, sortOrder)
+
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.([]*leaderboardmodels.Score), nil
+	}
+
+	scores, err := r.inner.FindAll(ctx, season, sortOrder, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, scores, r.ttl)
+
+	return scores, nil
+}
+
+// Count returns the total number of scores across all seasons with caching
+func (r *CachedScoreRepository) Count(ctx context.Context) (int64, error) {
+	if cached, ok := r.cache.Get(totalCountKey); ok {
+		return cached.(int64), nil
+	}
+
+	count, err := r.inner.Count(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	r.cache.Set(totalCountKey, count, r.ttl)
+
+	return count, nil
+}
+
+// GetUserRank computes a player's rank WITHOUT caching (any submission in the season can move it)
+func (r *CachedScoreRepository) GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.LeaderboardEntry, error) {
+	return r.inner.GetUserRank(ctx, userID, season)
+}
+
+// GetStreak retrieves a player's daily submission streak with caching
+func (r *CachedScoreRepository) GetStreak(ctx context.Context, userID uuid.UUID, season string) (int, int, error) {
+	key := r.streakKey(userID, season)
+
+	// Check cache
+	if cached, ok := r.cache.Get(key); ok {
+		streak := cached.([2]int)
+		return streak[0], streak[1], nil
+	}
+
+	// Cache miss
+	current, longest, err := r.inner.GetStreak(ctx, userID, season)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Store in cache
+	r.cache.Set(key, [2]int{current, longest}, r.ttl)
+
+	return current, longest, nil
+}
+
 // Helper types and methods
 
 type leaderboardCacheEntry struct {
@@ -117,6 +339,11 @@ func (r *CachedScoreRepository) scoreKey(userID uuid.UUID, season string) string
 	return fmt.Sprintf("score:%s:%s", userID.String(), season)
 }
 
+// streakKey ends with ":season", so Invalidate drops it together with the per-user scores
+func (r *CachedScoreRepository) streakKey(userID uuid.UUID, season string) string {
+	return fmt.Sprintf("streak:%s:%s", userID.String(), season)
+}
+
 func (r *CachedScoreRepository) leaderboardKey(season string) string {
 	return fmt.Sprintf("leaderboard:%s", season)
 }
@@ -129,20 +356,75 @@ func (r *CachedScoreRepository) countKey(season string) string {
 	return fmt.Sprintf("count:%s", season)
 }
 
-// FindBySpec finds scores matching a specification (no caching for complex queries)
+func (r *CachedScoreRepository) medianKey(season string) string {
+	return fmt.Sprintf("median:%s", season)
+}
+
+func (r *CachedScoreRepository) allKey(season string, limit, offset int, sortOrder string) string {
+	return fmt.Sprintf("%s%d:%d:%s", r.allPrefix(season), limit, offset, sortOrder)
+}
+
+// allPrefix matches every cached FindAll page of a season
+func (r *CachedScoreRepository) allPrefix(season string) string {
+	return fmt.Sprintf("all:%s:", season)
+}
+
+// specKey builds a cache key from the spec description.
+// A spec can match rows of any season, so every write drops all of them (see scoreSpecPrefix).
+func (r *CachedScoreRepository) specKey(op string, spec repository.Specification[leaderboardmodels.Score]) string {
+	return fmt.Sprintf("%s%s:%s", scoreSpecPrefix, op, repository.DescribeSpec(spec))
+}
+
+// FindBySpec finds scores matching a specification, cached by the spec description
 func (r *CachedScoreRepository) FindBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) ([]*leaderboardmodels.Score, error) {
-	// Specifications are too complex to cache efficiently, delegate to inner repository
-	return r.inner.FindBySpec(ctx, spec)
+	key := r.specKey("find", spec)
+
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.([]*leaderboardmodels.Score), nil
+	}
+
+	scores, err := r.inner.FindBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, scores, r.ttl)
+
+	return scores, nil
 }
 
-// FindOneBySpec finds first score matching a specification (no caching for complex queries)
+// FindOneBySpec finds first score matching a specification, cached by the spec description
 func (r *CachedScoreRepository) FindOneBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) (*leaderboardmodels.Score, error) {
-	// Specifications are too complex to cache efficiently, delegate to inner repository
-	return r.inner.FindOneBySpec(ctx, spec)
+	key := r.specKey("one", spec)
+
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.(*leaderboardmodels.Score), nil
+	}
+
+	score, err := r.inner.FindOneBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, score, r.ttl)
+
+	return score, nil
 }
 
-// CountBySpec counts scores matching a specification (no caching for counts)
+// CountBySpec counts scores matching a specification, cached by the spec description
 func (r *CachedScoreRepository) CountBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) (int64, error) {
-	// Counts are typically fast and don't benefit much from caching
-	return r.inner.CountBySpec(ctx, spec)
+	key := r.specKey("count", spec)
+
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.(int64), nil
+	}
+
+	count, err := r.inner.CountBySpec(ctx, spec)
+	if err != nil {
+		return 0, err
+	}
+
+	r.cache.Set(key, count, r.ttl)
+
+	return count, nil
 }
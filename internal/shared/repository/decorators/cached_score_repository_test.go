@@ -0,0 +1,268 @@
+package decorators
+
+import (
+	"context"
+	"testing"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSpecRepository counts FindBySpec calls that reach the database layer
+type countingSpecRepository struct {
+	*memoryScoreRepository
+	findBySpecCalls int
+}
+
+func (r *countingSpecRepository) FindBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) ([]*leaderboardmodels.Score, error) {
+	r.findBySpecCalls++
+	var result []*leaderboardmodels.Score
+	for _, score := range r.scores {
+		if spec.IsSatisfiedBy(*score) {
+			result = append(result, score)
+		}
+	}
+	return result, nil
+}
+
+func (r *countingSpecRepository) UpsertOnlyIfHigher(ctx context.Context, score *leaderboardmodels.Score) (bool, error) {
+	return true, r.Upsert(ctx, score)
+}
+
+func TestCachedScoreRepository_FindBySpecCachedByDescription(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingSpecRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	repo := NewCachedScoreRepository(inner, NewSimpleCache())
+
+	require.NoError(t, repo.Upsert(ctx, &leaderboardmodels.Score{UserID: uuid.New(), Score: 1500, Season: "global"}))
+
+	// Two separately built but equal specs share one cache entry
+	first, err := repo.FindBySpec(ctx, repository.HighScoresSpec("global", 1000, 10))
+	require.NoError(t, err)
+	second, err := repo.FindBySpec(ctx, repository.HighScoresSpec("global", 1000, 10))
+	require.NoError(t, err)
+	assert.Len(t, second, len(first))
+	assert.Equal(t, 1, inner.findBySpecCalls)
+
+	// A different spec is a different key
+	_, err = repo.FindBySpec(ctx, repository.HighScoresSpec("global", 2000, 10))
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.findBySpecCalls)
+
+	// Any write drops cached spec results
+	require.NoError(t, repo.Upsert(ctx, &leaderboardmodels.Score{UserID: uuid.New(), Score: 1800, Season: "other"}))
+	third, err := repo.FindBySpec(ctx, repository.HighScoresSpec("global", 1000, 10))
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.findBySpecCalls)
+	assert.Len(t, third, 1)
+}
+
+// countingFindRepository counts FindByUserAndSeason calls that reach the database layer
+type countingFindRepository struct {
+	*memoryScoreRepository
+	finds int
+}
+
+func (r *countingFindRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error) {
+	r.finds++
+	return r.memoryScoreRepository.FindByUserAndSeason(ctx, userID, season)
+}
+
+func TestCachedScoreRepository_InvalidateDropsOnlyThatSeason(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingFindRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	repo := NewCachedScoreRepository(inner, NewSimpleCache())
+
+	userID := uuid.New()
+	require.NoError(t, repo.Upsert(ctx, &leaderboardmodels.Score{UserID: userID, Score: 100, Season: "global"}))
+	require.NoError(t, repo.Upsert(ctx, &leaderboardmodels.Score{UserID: userID, Score: 200, Season: "winter"}))
+
+	// Warm both seasons
+	for _, season := range []string{"global", "winter"} {
+		_, err := repo.FindByUserAndSeason(ctx, userID, season)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, inner.finds)
+
+	// A write that bypasses the decorator leaves the cache stale until Invalidate
+	require.NoError(t, inner.Upsert(ctx, &leaderboardmodels.Score{UserID: userID, Score: 150, Season: "global"}))
+	stale, err := repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), stale.Score)
+
+	repo.(repository.SeasonCacheInvalidator).Invalidate(ctx, "global")
+
+	fresh, err := repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(150), fresh.Score)
+	_, err = repo.FindByUserAndSeason(ctx, userID, "winter")
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.finds, "winter must still be served from cache")
+}
+
+func TestLoggedScoreRepository_InvalidateReachesCache(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingFindRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	repo := NewLoggedScoreRepository(NewCachedScoreRepository(inner, NewSimpleCache()))
+
+	userID := uuid.New()
+	require.NoError(t, repo.Upsert(ctx, &leaderboardmodels.Score{UserID: userID, Score: 100, Season: "global"}))
+	_, err := repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+
+	invalidator, ok := repo.(repository.SeasonCacheInvalidator)
+	require.True(t, ok)
+	invalidator.Invalidate(ctx, "global")
+
+	_, err = repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.finds)
+}
+
+// countingAllRepository counts FindAll and Count calls that reach the database layer
+type countingAllRepository struct {
+	*memoryScoreRepository
+	findAllCalls int
+	countCalls   int
+}
+
+func (r *countingAllRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*leaderboardmodels.Score, error) {
+	r.findAllCalls++
+	var result []*leaderboardmodels.Score
+	for _, score := range r.scores {
+		if score.Season == season {
+			result = append(result, score)
+		}
+	}
+	return result, nil
+}
+
+func (r *countingAllRepository) Count(ctx context.Context) (int64, error) {
+	r.countCalls++
+	return int64(len(r.scores)), nil
+}
+
+func (r *countingAllRepository) DeleteByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) error {
+	delete(r.scores, userID.String()+":"+season)
+	return nil
+}
+
+func (r *countingAllRepository) DeleteBySeason(ctx context.Context, season string) (int64, error) {
+	var deleted int64
+	for key, score := range r.scores {
+		if score.Season == season {
+			delete(r.scores, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestCachedScoreRepository_FindAllAndCountCached(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingAllRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	repo := NewCachedScoreRepository(inner, NewSimpleCache())
+
+	require.NoError(t, repo.Upsert(ctx, &leaderboardmodels.Score{UserID: uuid.New(), Score: 100, Season: "global"}))
+
+	for i := 0; i < 2; i++ {
+		page, err := repo.FindAll(ctx, "global", "desc", 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, page, 1)
+
+		total, err := repo.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+	}
+	assert.Equal(t, 1, inner.findAllCalls)
+	assert.Equal(t, 1, inner.countCalls)
+
+	// Another page is a different key
+	_, err := repo.FindAll(ctx, "global", "desc", 10, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.findAllCalls)
+}
+
+func TestCachedScoreRepository_FindAllAndCountInvalidatedByWrites(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingAllRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	repo := NewCachedScoreRepository(inner, NewSimpleCache())
+
+	warm := func() ([]*leaderboardmodels.Score, int64) {
+		page, err := repo.FindAll(ctx, "global", "desc", 10, 0)
+		require.NoError(t, err)
+		total, err := repo.Count(ctx)
+		require.NoError(t, err)
+		return page, total
+	}
+
+	userID := uuid.New()
+	warm()
+
+	require.NoError(t, repo.Upsert(ctx, &leaderboardmodels.Score{UserID: userID, Score: 100, Season: "global"}))
+	page, total := warm()
+	assert.Len(t, page, 1)
+	assert.Equal(t, int64(1), total)
+
+	require.NoError(t, repo.DeleteByUserAndSeason(ctx, userID, "global"))
+	page, total = warm()
+	assert.Empty(t, page)
+	assert.Equal(t, int64(0), total)
+
+	assert.Equal(t, 3, inner.findAllCalls)
+	assert.Equal(t, 3, inner.countCalls)
+
+	require.NoError(t, repo.Upsert(ctx, &leaderboardmodels.Score{UserID: userID, Score: 200, Season: "global"}))
+	warm()
+	_, err := repo.DeleteBySeason(ctx, "global")
+	require.NoError(t, err)
+	page, total = warm()
+	assert.Empty(t, page, "deleting a season drops its FindAll pages")
+	assert.Equal(t, int64(0), total, "deleting a season drops the total count")
+}
+
+// streakRepository counts GetStreak calls; every UpsertOnlyIfHigher keeps the stored score
+type streakRepository struct {
+	*memoryScoreRepository
+	streakCalls int
+}
+
+func (r *streakRepository) GetStreak(ctx context.Context, userID uuid.UUID, season string) (int, int, error) {
+	r.streakCalls++
+	return r.streakCalls, r.streakCalls, nil
+}
+
+func (r *streakRepository) UpsertOnlyIfHigher(ctx context.Context, score *leaderboardmodels.Score) (bool, error) {
+	return false, nil
+}
+
+func TestCachedScoreRepository_GetStreakCachedUntilSubmission(t *testing.T) {
+	ctx := context.Background()
+	inner := &streakRepository{memoryScoreRepository: newMemoryScoreRepository()}
+	repo := NewCachedScoreRepository(inner, NewSimpleCache())
+	userID := uuid.New()
+
+	for i := 0; i < 2; i++ {
+		current, longest, err := repo.GetStreak(ctx, userID, "global")
+		require.NoError(t, err)
+		assert.Equal(t, 1, current)
+		assert.Equal(t, 1, longest)
+	}
+	assert.Equal(t, 1, inner.streakCalls)
+
+	// A submission that keeps the old score still counts for the streak
+	_, err := repo.UpsertOnlyIfHigher(ctx, &leaderboardmodels.Score{UserID: userID, Score: 10, Season: "global"})
+	require.NoError(t, err)
+	current, _, err := repo.GetStreak(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, 2, current)
+
+	repo.(repository.SeasonCacheInvalidator).Invalidate(ctx, "global")
+	_, _, err = repo.GetStreak(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.streakCalls)
+}
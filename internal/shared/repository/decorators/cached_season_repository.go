@@ -0,0 +1,58 @@
+package decorators
+
+import (
+	"context"
+	"time"
+
+	seasonmodels "leaderboard-service/internal/season/models"
+	"leaderboard-service/internal/shared/repository"
+)
+
+// CachedSeasonRepository decorates SeasonRepository with caching
+// Сезоны читаются часто и меняются только через админский API
+type CachedSeasonRepository struct {
+	inner repository.SeasonRepository
+	cache *SimpleCache
+	ttl   time.Duration
+}
+
+// NewCachedSeasonRepository creates a cached season repository
+func NewCachedSeasonRepository(inner repository.SeasonRepository, cache *SimpleCache) repository.SeasonRepository {
+	return &CachedSeasonRepository{
+		inner: inner,
+		cache: cache,
+		ttl:   10 * time.Minute,
+	}
+}
+
+// FindByName retrieves a season with caching; misses are not cached
+func (r *CachedSeasonRepository) FindByName(ctx context.Context, name string) (*seasonmodels.Season, error) {
+	key := r.seasonKey(name)
+
+	if cached, ok := r.cache.Get(key); ok {
+		season := *cached.(*seasonmodels.Season)
+		return &season, nil
+	}
+
+	season, err := r.inner.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Храним копию, чтобы изменения вызывающего кода не попадали в кеш
+	stored := *season
+	r.cache.Set(key, &stored, r.ttl)
+
+	return season, nil
+}
+
+// Update saves a season and invalidates its cache entry
+func (r *CachedSeasonRepository) Update(ctx context.Context, season *seasonmodels.Season) error {
+	// Инвалидируем даже при ошибке: строка могла измениться до сбоя
+	defer r.cache.Delete(r.seasonKey(season.Name))
+	return r.inner.Update(ctx, season)
+}
+
+func (r *CachedSeasonRepository) seasonKey(name string) string {
+	return "season:" + name
+}
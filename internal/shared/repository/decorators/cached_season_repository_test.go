@@ -0,0 +1,70 @@
+package decorators
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	seasonmodels "leaderboard-service/internal/season/models"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSeasonRepository keeps seasons in a map and counts reads
+type countingSeasonRepository struct {
+	seasons map[string]seasonmodels.Season
+	finds   int
+}
+
+func (r *countingSeasonRepository) FindByName(ctx context.Context, name string) (*seasonmodels.Season, error) {
+	r.finds++
+	season, ok := r.seasons[name]
+	if !ok {
+		return nil, repository.ErrRecordNotFound
+	}
+	return &season, nil
+}
+
+func (r *countingSeasonRepository) Update(ctx context.Context, season *seasonmodels.Season) error {
+	r.seasons[season.Name] = *season
+	return nil
+}
+
+func TestCachedSeasonRepository_UpdateInvalidates(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingSeasonRepository{seasons: map[string]seasonmodels.Season{
+		"2024_01": {Name: "2024_01", DisplayName: "Season 1", StartsAt: time.Now()},
+	}}
+	repo := NewCachedSeasonRepository(inner, NewSimpleCache())
+
+	first, err := repo.FindByName(ctx, "2024_01")
+	require.NoError(t, err)
+	first.DisplayName = "changed by caller"
+
+	second, err := repo.FindByName(ctx, "2024_01")
+	require.NoError(t, err)
+	assert.Equal(t, "Season 1", second.DisplayName, "cached value must not share memory with callers")
+	assert.Equal(t, 1, inner.finds)
+
+	second.DisplayName = "Season 1 - Spring"
+	require.NoError(t, repo.Update(ctx, second))
+
+	third, err := repo.FindByName(ctx, "2024_01")
+	require.NoError(t, err)
+	assert.Equal(t, "Season 1 - Spring", third.DisplayName)
+	assert.Equal(t, 2, inner.finds)
+}
+
+func TestCachedSeasonRepository_MissNotCached(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingSeasonRepository{seasons: map[string]seasonmodels.Season{}}
+	repo := NewCachedSeasonRepository(inner, NewSimpleCache())
+
+	_, err := repo.FindByName(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrRecordNotFound)
+	_, err = repo.FindByName(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrRecordNotFound)
+	assert.Equal(t, 2, inner.finds)
+}
@@ -85,6 +85,18 @@ func (c *SimpleCache) DeleteByPrefix(prefix string) {
 	}
 }
 
+// DeleteBySuffix removes all keys ending with suffix
+func (c *SimpleCache) DeleteBySuffix(suffix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.data {
+		if len(key) >= len(suffix) && key[len(key)-len(suffix):] == suffix {
+			delete(c.data, key)
+		}
+	}
+}
+
 // Clear removes all entries from cache
 func (c *SimpleCache) Clear() {
 	c.mu.Lock()
@@ -110,6 +122,22 @@ func (c *SimpleCache) cleanupExpired() {
 	}
 }
 
+// userSpecPrefix prefixes cached specification results
+const userSpecPrefix = "user_spec:"
+
+// InvalidateUserSpecs drops the specification results cached by every CachedUserRepository
+// sharing cache, for writes that do not go through one (e.g. inside a unit of work)
+func InvalidateUserSpecs(cache *SimpleCache) {
+	cache.DeleteByPrefix(userSpecPrefix)
+}
+
+// InvalidateUsers drops every user cached by ID or email, e.g. after a transaction
+// changed the admin flag of users the decorators did not see
+func InvalidateUsers(cache *SimpleCache) {
+	cache.DeleteByPrefix("user:id:")
+	cache.DeleteByPrefix("user:email:")
+}
+
 // CachedUserRepository decorates UserRepository with caching
 type CachedUserRepository struct {
 	inner repository.UserRepository
@@ -135,6 +163,38 @@ func (r *CachedUserRepository) Create(ctx context.Context, user *authmodels.User
 
 	// Cache the created user
 	r.cacheUser(user)
+	r.cache.DeleteByPrefix(userSpecPrefix)
+
+	return nil
+}
+
+// CreateBatch creates users in batches and caches them
+func (r *CachedUserRepository) CreateBatch(ctx context.Context, users []*authmodels.User, batchSize int) error {
+	if err := r.inner.CreateBatch(ctx, users, batchSize); err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		r.cacheUser(user)
+	}
+	r.cache.DeleteByPrefix(userSpecPrefix)
+
+	return nil
+}
+
+// CreateBatchSkippingExisting creates users in batches, skipping taken emails, and caches the inserted ones
+func (r *CachedUserRepository) CreateBatchSkippingExisting(ctx context.Context, users []*authmodels.User, batchSize int) error {
+	err := r.inner.CreateBatchSkippingExisting(ctx, users, batchSize)
+	r.cache.DeleteByPrefix(userSpecPrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if user.ID != uuid.Nil {
+			r.cacheUser(user)
+		}
+	}
 
 	return nil
 }
@@ -160,6 +220,42 @@ func (r *CachedUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*aut
 	return user, nil
 }
 
+// FindByIDs retrieves users by IDs, querying the inner repository only for cache misses
+func (r *CachedUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*authmodels.User, error) {
+	users := make(map[uuid.UUID]*authmodels.User, len(ids))
+	var misses []uuid.UUID
+
+	for _, id := range ids {
+		if cached, ok := r.cache.Get(r.userIDKey(id)); ok {
+			users[id] = cached.(*authmodels.User)
+			continue
+		}
+		misses = append(misses, id)
+	}
+
+	if len(misses) == 0 {
+		return users, nil
+	}
+
+	// All misses are fetched in one batch query
+	fetched, err := r.inner.FindByIDs(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, user := range fetched {
+		r.cacheUser(user)
+		users[id] = user
+	}
+
+	return users, nil
+}
+
+// FindAll is not cached: pages shift with every registration, so entries would go stale at once
+func (r *CachedUserRepository) FindAll(ctx context.Context, limit, offset int) ([]*authmodels.User, int64, error) {
+	return r.inner.FindAll(ctx, limit, offset)
+}
+
 // FindByEmail retrieves a user by email with caching
 func (r *CachedUserRepository) FindByEmail(ctx context.Context, email string) (*authmodels.User, error) {
 	key := r.userEmailKey(email)
@@ -181,6 +277,15 @@ func (r *CachedUserRepository) FindByEmail(ctx context.Context, email string) (*
 	return user, nil
 }
 
+// ExistsByEmail answers from the cached user when there is one. A miss is not cached,
+// because the next registration would make it stale.
+func (r *CachedUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	if _, ok := r.cache.Get(r.userEmailKey(email)); ok {
+		return true, nil
+	}
+	return r.inner.ExistsByEmail(ctx, email)
+}
+
 // Update updates a user and invalidates cache
 func (r *CachedUserRepository) Update(ctx context.Context, user *authmodels.User) error {
 	err := r.inner.Update(ctx, user)
@@ -193,6 +298,22 @@ func (r *CachedUserRepository) Update(ctx context.Context, user *authmodels.User
 
 	// Cache the updated user
 	r.cacheUser(user)
+	r.cache.DeleteByPrefix(userSpecPrefix)
+
+	return nil
+}
+
+// SetAdmin changes the admin flag and drops the cached copies of the user
+func (r *CachedUserRepository) SetAdmin(ctx context.Context, userID uuid.UUID, isAdmin bool) error {
+	if err := r.inner.SetAdmin(ctx, userID, isAdmin); err != nil {
+		return err
+	}
+
+	if cached, ok := r.cache.Get(r.userIDKey(userID)); ok {
+		r.cache.Delete(r.userEmailKey(cached.(*authmodels.User).Email))
+	}
+	r.cache.Delete(r.userIDKey(userID))
+	r.cache.DeleteByPrefix(userSpecPrefix)
 
 	return nil
 }
@@ -213,6 +334,7 @@ func (r *CachedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 	// Invalidate cache
 	r.cache.Delete(r.userIDKey(id))
+	r.cache.DeleteByPrefix(userSpecPrefix)
 
 	return nil
 }
@@ -235,20 +357,61 @@ func (r *CachedUserRepository) userEmailKey(email string) string {
 	return fmt.Sprintf("user:email:%s", email)
 }
 
-// FindBySpec finds users matching a specification (no caching for complex queries)
+// specKey builds a cache key from the spec description; any user write drops all of them
+func (r *CachedUserRepository) specKey(op string, spec repository.Specification[authmodels.User]) string {
+	return fmt.Sprintf("%s%s:%s", userSpecPrefix, op, repository.DescribeSpec(spec))
+}
+
+// FindBySpec finds users matching a specification, cached by the spec description
 func (r *CachedUserRepository) FindBySpec(ctx context.Context, spec repository.Specification[authmodels.User]) ([]*authmodels.User, error) {
-	// Specifications are too complex to cache efficiently, delegate to inner repository
-	return r.inner.FindBySpec(ctx, spec)
+	key := r.specKey("find", spec)
+
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.([]*authmodels.User), nil
+	}
+
+	users, err := r.inner.FindBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, users, r.ttl)
+
+	return users, nil
 }
 
-// FindOneBySpec finds first user matching a specification (no caching for complex queries)
+// FindOneBySpec finds first user matching a specification, cached by the spec description
 func (r *CachedUserRepository) FindOneBySpec(ctx context.Context, spec repository.Specification[authmodels.User]) (*authmodels.User, error) {
-	// Specifications are too complex to cache efficiently, delegate to inner repository
-	return r.inner.FindOneBySpec(ctx, spec)
+	key := r.specKey("one", spec)
+
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.(*authmodels.User), nil
+	}
+
+	user, err := r.inner.FindOneBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cache.Set(key, user, r.ttl)
+
+	return user, nil
 }
 
-// CountBySpec counts users matching a specification (no caching for counts)
+// CountBySpec counts users matching a specification, cached by the spec description
 func (r *CachedUserRepository) CountBySpec(ctx context.Context, spec repository.Specification[authmodels.User]) (int64, error) {
-	// Counts are typically fast and don't benefit much from caching
-	return r.inner.CountBySpec(ctx, spec)
+	key := r.specKey("count", spec)
+
+	if cached, ok := r.cache.Get(key); ok {
+		return cached.(int64), nil
+	}
+
+	count, err := r.inner.CountBySpec(ctx, spec)
+	if err != nil {
+		return 0, err
+	}
+
+	r.cache.Set(key, count, r.ttl)
+
+	return count, nil
 }
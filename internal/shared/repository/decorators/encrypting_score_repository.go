@@ -0,0 +1,182 @@
+package decorators
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/google/uuid"
+)
+
+// encryptedMetadataKey is the only key of an encrypted Metadata map; its value is base64 ciphertext
+const encryptedMetadataKey = "_encrypted"
+
+// EncryptingScoreRepository decorates ScoreRepository with Metadata encryption at rest.
+// Metadata is encrypted before it reaches the inner repository and decrypted on the way out;
+// methods that never touch Metadata are delegated through the embedded interface.
+type EncryptingScoreRepository struct {
+	repository.ScoreRepository
+	encryption strategy.EncryptionStrategy
+}
+
+// NewEncryptingScoreRepository creates a score repository that encrypts Metadata
+func NewEncryptingScoreRepository(inner repository.ScoreRepository, encryption strategy.EncryptionStrategy) repository.ScoreRepository {
+	return &EncryptingScoreRepository{
+		ScoreRepository: inner,
+		encryption:      encryption,
+	}
+}
+
+// Upsert encrypts Metadata and inserts/updates the score
+func (r *EncryptingScoreRepository) Upsert(ctx context.Context, score *leaderboardmodels.Score) error {
+	encrypted, err := r.encryptedCopy(score)
+	if err != nil {
+		return err
+	}
+	if err := r.ScoreRepository.Upsert(ctx, encrypted); err != nil {
+		return err
+	}
+	score.ID = encrypted.ID
+	return nil
+}
+
+// UpsertOnlyIfHigher encrypts Metadata and stores the score if it is a personal best
+func (r *EncryptingScoreRepository) UpsertOnlyIfHigher(ctx context.Context, score *leaderboardmodels.Score) (bool, error) {
+	encrypted, err := r.encryptedCopy(score)
+	if err != nil {
+		return false, err
+	}
+	updated, err := r.ScoreRepository.UpsertOnlyIfHigher(ctx, encrypted)
+	if err != nil {
+		return false, err
+	}
+	if updated {
+		score.ID = encrypted.ID
+	}
+	return updated, nil
+}
+
+// RevertSubmission encrypts the Metadata of the restored score and undoes the latest submission
+func (r *EncryptingScoreRepository) RevertSubmission(ctx context.Context, userID uuid.UUID, season string, previous *leaderboardmodels.Score) error {
+	if previous == nil {
+		return r.ScoreRepository.RevertSubmission(ctx, userID, season, nil)
+	}
+	encrypted, err := r.encryptedCopy(previous)
+	if err != nil {
+		return err
+	}
+	return r.ScoreRepository.RevertSubmission(ctx, userID, season, encrypted)
+}
+
+// FindByUserAndSeason retrieves a score and decrypts its Metadata
+func (r *EncryptingScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error) {
+	score, err := r.ScoreRepository.FindByUserAndSeason(ctx, userID, season)
+	if err != nil || score == nil {
+		return score, err
+	}
+	return r.decryptedCopy(score)
+}
+
+// FindBySpec finds scores matching a specification and decrypts their Metadata
+func (r *EncryptingScoreRepository) FindBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) ([]*leaderboardmodels.Score, error) {
+	scores, err := r.ScoreRepository.FindBySpec(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	decrypted := make([]*leaderboardmodels.Score, len(scores))
+	for i, score := range scores {
+		if decrypted[i], err = r.decryptedCopy(score); err != nil {
+			return nil, err
+		}
+	}
+	return decrypted, nil
+}
+
+// FindByMetadata is not supported: the database only holds ciphertext, so metadata values cannot be matched in SQL
+func (r *EncryptingScoreRepository) FindByMetadata(ctx context.Context, season, key, value string, limit int) ([]*leaderboardmodels.Score, error) {
+	return nil, repository.ErrMetadataNotSearchable
+}
+
+// FindAll retrieves a page of scores and decrypts their Metadata
+func (r *EncryptingScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*leaderboardmodels.Score, error) {
+	scores, err := r.ScoreRepository.FindAll(ctx, season, sortOrder, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	decrypted := make([]*leaderboardmodels.Score, len(scores))
+	for i, score := range scores {
+		if decrypted[i], err = r.decryptedCopy(score); err != nil {
+			return nil, err
+		}
+	}
+	return decrypted, nil
+}
+
+// FindOneBySpec finds the first score matching a specification and decrypts its Metadata
+func (r *EncryptingScoreRepository) FindOneBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) (*leaderboardmodels.Score, error) {
+	score, err := r.ScoreRepository.FindOneBySpec(ctx, spec)
+	if err != nil || score == nil {
+		return score, err
+	}
+	return r.decryptedCopy(score)
+}
+
+// Invalidate forwards cache invalidation to the inner repository when it caches
+func (r *EncryptingScoreRepository) Invalidate(ctx context.Context, season string) {
+	if invalidator, ok := r.ScoreRepository.(repository.SeasonCacheInvalidator); ok {
+		invalidator.Invalidate(ctx, season)
+	}
+}
+
+// encryptedCopy returns a copy of score with Metadata replaced by its ciphertext.
+// The caller's score keeps plaintext Metadata.
+func (r *EncryptingScoreRepository) encryptedCopy(score *leaderboardmodels.Score) (*leaderboardmodels.Score, error) {
+	encrypted := *score
+	if len(score.Metadata) == 0 {
+		return &encrypted, nil
+	}
+
+	plaintext, err := json.Marshal(score.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	ciphertext, err := r.encryption.Encrypt(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt metadata: %w", err)
+	}
+
+	encrypted.Metadata = map[string]interface{}{
+		encryptedMetadataKey: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+	return &encrypted, nil
+}
+
+// decryptedCopy returns a copy of score with plaintext Metadata.
+// Rows written before encryption was enabled are returned unchanged.
+func (r *EncryptingScoreRepository) decryptedCopy(score *leaderboardmodels.Score) (*leaderboardmodels.Score, error) {
+	encoded, ok := score.Metadata[encryptedMetadataKey].(string)
+	if !ok || len(score.Metadata) != 1 {
+		return score, nil
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	plaintext, err := r.encryption.Decrypt(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt metadata: %w", err)
+	}
+
+	decrypted := *score
+	decrypted.Metadata = nil
+	if err := json.Unmarshal(plaintext, &decrypted.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+	return &decrypted, nil
+}
@@ -0,0 +1,96 @@
+package decorators
+
+import (
+	"context"
+	"testing"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/strategy"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryScoreRepository stores scores in a map keyed by user and season
+type memoryScoreRepository struct {
+	repository.ScoreRepository
+	scores map[string]*leaderboardmodels.Score
+}
+
+func newMemoryScoreRepository() *memoryScoreRepository {
+	return &memoryScoreRepository{scores: make(map[string]*leaderboardmodels.Score)}
+}
+
+func (r *memoryScoreRepository) Upsert(ctx context.Context, score *leaderboardmodels.Score) error {
+	stored := *score
+	stored.ID = uuid.New()
+	r.scores[score.UserID.String()+":"+score.Season] = &stored
+	score.ID = stored.ID
+	return nil
+}
+
+func (r *memoryScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error) {
+	return r.scores[userID.String()+":"+season], nil
+}
+
+func TestEncryptingScoreRepository_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	inner := newMemoryScoreRepository()
+	var key [32]byte
+	copy(key[:], "metadata-encryption-test-key-32b")
+	repo := NewEncryptingScoreRepository(inner, strategy.NewAESGCMEncryptionStrategy(key))
+
+	userID := uuid.New()
+	score := &leaderboardmodels.Score{
+		UserID: userID,
+		Score:  1200,
+		Season: "global",
+		Metadata: map[string]interface{}{
+			"session_id": "sess-42",
+			"ip":         "198.51.100.23",
+			"level":      float64(7),
+		},
+	}
+
+	require.NoError(t, repo.Upsert(ctx, score))
+	assert.NotEqual(t, uuid.Nil, score.ID)
+	assert.Equal(t, "sess-42", score.Metadata["session_id"], "caller keeps plaintext metadata")
+
+	stored := inner.scores[userID.String()+":global"]
+	require.Len(t, stored.Metadata, 1)
+	assert.Contains(t, stored.Metadata, encryptedMetadataKey)
+	assert.NotContains(t, stored.Metadata[encryptedMetadataKey], "198.51.100.23")
+
+	found, err := repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, score.Metadata, found.Metadata)
+	assert.Equal(t, int64(1200), found.Score)
+}
+
+func TestEncryptingScoreRepository_PlaintextRowsPassThrough(t *testing.T) {
+	ctx := context.Background()
+	inner := newMemoryScoreRepository()
+	repo := NewEncryptingScoreRepository(inner, strategy.NewAESGCMEncryptionStrategy([32]byte{}))
+
+	userID := uuid.New()
+	require.NoError(t, inner.Upsert(ctx, &leaderboardmodels.Score{
+		UserID:   userID,
+		Season:   "global",
+		Metadata: map[string]interface{}{"device": "legacy"},
+	}))
+
+	found, err := repo.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", found.Metadata["device"])
+}
+
+func TestEncryptingScoreRepository_FindByMetadataUnsupported(t *testing.T) {
+	var key [32]byte
+	repo := NewEncryptingScoreRepository(newMemoryScoreRepository(), strategy.NewAESGCMEncryptionStrategy(key))
+
+	scores, err := repo.FindByMetadata(context.Background(), "global", "session_id", "sess-42", 10)
+	assert.ErrorIs(t, err, repository.ErrMetadataNotSearchable)
+	assert.Nil(t, scores)
+}
@@ -6,32 +6,75 @@ import (
 
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
-// LoggedScoreRepository decorates ScoreRepository with logging
+// DefaultSlowQueryThreshold is the slow query threshold of NewLoggedScoreRepository
+const DefaultSlowQueryThreshold = 100 * time.Millisecond
+
+// LoggedScoreRepository decorates ScoreRepository with logging.
+// Calls slower than the threshold are also logged as warnings and counted per season and method.
 type LoggedScoreRepository struct {
-	inner repository.ScoreRepository
+	inner              repository.ScoreRepository
+	slowQueryThreshold time.Duration
+	slowQueries        *SlowQueryCounter
 }
 
 // NewLoggedScoreRepository creates a logged score repository
 func NewLoggedScoreRepository(inner repository.ScoreRepository) repository.ScoreRepository {
+	return NewLoggedScoreRepositoryWithThreshold(inner, DefaultSlowQueryThreshold)
+}
+
+// NewLoggedScoreRepositoryWithThreshold creates a logged score repository that flags calls
+// slower than threshold; 0 disables the check
+func NewLoggedScoreRepositoryWithThreshold(inner repository.ScoreRepository, threshold time.Duration) *LoggedScoreRepository {
 	return &LoggedScoreRepository{
-		inner: inner,
+		inner:              inner,
+		slowQueryThreshold: threshold,
+		slowQueries:        NewSlowQueryCounter(),
 	}
 }
 
+// SlowQueries returns the counter of slow calls, e.g. for a stats endpoint or alerting
+func (r *LoggedScoreRepository) SlowQueries() *SlowQueryCounter {
+	return r.slowQueries
+}
+
+// isSlow сообщает, превысил ли вызов порог; параметры для лога собираются только в этом случае
+func (r *LoggedScoreRepository) isSlow(duration time.Duration) bool {
+	return r.slowQueryThreshold > 0 && duration > r.slowQueryThreshold
+}
+
+// logSlowQuery пишет предупреждение со всеми параметрами вызова и увеличивает счетчик
+func (r *LoggedScoreRepository) logSlowQuery(ctx context.Context, method, season string, duration time.Duration, params map[string]interface{}) {
+	r.slowQueries.Inc(season, method)
+
+	utils.LoggerFromContext(ctx).Warn().
+		Bool("slow_query", true).
+		Str("method", "ScoreRepository."+method).
+		Str("season", season).
+		Dur("duration", duration).
+		Dur("threshold", r.slowQueryThreshold).
+		Fields(params).
+		Msg("Slow score repository call")
+}
+
 // Upsert inserts/updates a score with logging
 func (r *LoggedScoreRepository) Upsert(ctx context.Context, score *leaderboardmodels.Score) error {
 	start := time.Now()
 	err := r.inner.Upsert(ctx, score)
 	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "Upsert", score.Season, duration, map[string]interface{}{"user_id": score.UserID, "score": score.Score})
+	}
 
-	logEvent := log.Info()
+	logger := utils.LoggerFromContext(ctx)
+	logEvent := logger.Info()
 	if err != nil {
-		logEvent = log.Error().Err(err)
+		logEvent = logger.Error().Err(err)
 	}
 
 	logEvent.
@@ -45,11 +88,41 @@ func (r *LoggedScoreRepository) Upsert(ctx context.Context, score *leaderboardmo
 	return err
 }
 
+// UpsertOnlyIfHigher upserts a personal best with logging
+func (r *LoggedScoreRepository) UpsertOnlyIfHigher(ctx context.Context, score *leaderboardmodels.Score) (bool, error) {
+	start := time.Now()
+	updated, err := r.inner.UpsertOnlyIfHigher(ctx, score)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "UpsertOnlyIfHigher", score.Season, duration, map[string]interface{}{"user_id": score.UserID, "score": score.Score})
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logEvent := logger.Info()
+	if err != nil {
+		logEvent = logger.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.UpsertOnlyIfHigher").
+		Str("user_id", score.UserID.String()).
+		Int64("score", score.Score).
+		Str("season", score.Season).
+		Bool("updated", updated).
+		Dur("duration", duration).
+		Msg("Personal best upsert")
+
+	return updated, err
+}
+
 // FindByUserAndSeason retrieves a score with logging
 func (r *LoggedScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error) {
 	start := time.Now()
 	score, err := r.inner.FindByUserAndSeason(ctx, userID, season)
 	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "FindByUserAndSeason", season, duration, map[string]interface{}{"user_id": userID})
+	}
 
 	logEvent := log.Debug()
 	if err != nil {
@@ -68,10 +141,13 @@ func (r *LoggedScoreRepository) FindByUserAndSeason(ctx context.Context, userID
 }
 
 // GetLeaderboard retrieves leaderboard with logging
-func (r *LoggedScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+func (r *LoggedScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
 	start := time.Now()
-	entries, totalCount, err := r.inner.GetLeaderboard(ctx, season, limit, offset, sortOrder)
+	entries, totalCount, err := r.inner.GetLeaderboard(ctx, season, limit, offset, sortBy, sortOrder, excludeUserIDs)
 	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetLeaderboard", season, duration, map[string]interface{}{"limit": limit, "offset": offset, "sort_by": sortBy, "sort_order": sortOrder, "excluded_user_ids": excludeUserIDs})
+	}
 
 	logEvent := log.Debug()
 	if err != nil {
@@ -83,7 +159,9 @@ func (r *LoggedScoreRepository) GetLeaderboard(ctx context.Context, season strin
 		Str("season", season).
 		Int("limit", limit).
 		Int("offset", offset).
+		Str("sort_by", sortBy).
 		Str("sort_order", sortOrder).
+		Int("excluded_users", len(excludeUserIDs)).
 		Int("entries_count", len(entries)).
 		Int64("total_count", totalCount).
 		Dur("duration", duration).
@@ -92,11 +170,97 @@ func (r *LoggedScoreRepository) GetLeaderboard(ctx context.Context, season strin
 	return entries, totalCount, err
 }
 
+// GetLeaderboardWithProfiles retrieves leaderboard entries with player profiles and logging
+func (r *LoggedScoreRepository) GetLeaderboardWithProfiles(ctx context.Context, season string, limit, offset int, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.EnrichedLeaderboardEntry, int64, error) {
+	start := time.Now()
+	entries, total, err := r.inner.GetLeaderboardWithProfiles(ctx, season, limit, offset, sortOrder, excludeUserIDs)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetLeaderboardWithProfiles", season, duration, map[string]interface{}{"limit": limit, "offset": offset, "sort_order": sortOrder, "excluded_user_ids": excludeUserIDs})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetLeaderboardWithProfiles").
+		Str("season", season).
+		Int("limit", limit).
+		Int("offset", offset).
+		Int("excluded", len(excludeUserIDs)).
+		Int("results", len(entries)).
+		Int64("total", total).
+		Dur("duration", duration).
+		Msg("Leaderboard with profiles query")
+
+	return entries, total, err
+}
+
+// FindByMetadata finds scores by a metadata key/value pair with logging
+func (r *LoggedScoreRepository) FindByMetadata(ctx context.Context, season, key, value string, limit int) ([]*leaderboardmodels.Score, error) {
+	start := time.Now()
+	scores, err := r.inner.FindByMetadata(ctx, season, key, value, limit)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "FindByMetadata", season, duration, map[string]interface{}{"metadata_key": key, "metadata_value": value, "limit": limit})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.FindByMetadata").
+		Str("season", season).
+		Str("metadata_key", key).
+		Str("metadata_value", value).
+		Int("limit", limit).
+		Int("results", len(scores)).
+		Dur("duration", duration).
+		Msg("Find scores by metadata")
+
+	return scores, err
+}
+
+// GetLeaderboardByCountry retrieves a country's leaderboard with logging
+func (r *LoggedScoreRepository) GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	start := time.Now()
+	entries, total, err := r.inner.GetLeaderboardByCountry(ctx, country, season, limit, offset)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetLeaderboardByCountry", season, duration, map[string]interface{}{"country": country, "limit": limit, "offset": offset})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetLeaderboardByCountry").
+		Str("country", country).
+		Str("season", season).
+		Int("limit", limit).
+		Int("offset", offset).
+		Int("results", len(entries)).
+		Int64("total", total).
+		Dur("duration", duration).
+		Msg("Country leaderboard query")
+
+	return entries, total, err
+}
+
 // CountBySeason retrieves count with logging
 func (r *LoggedScoreRepository) CountBySeason(ctx context.Context, season string) (int64, error) {
 	start := time.Now()
 	count, err := r.inner.CountBySeason(ctx, season)
 	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "CountBySeason", season, duration, map[string]interface{}{})
+	}
 
 	logEvent := log.Debug()
 	if err != nil {
@@ -113,11 +277,64 @@ func (r *LoggedScoreRepository) CountBySeason(ctx context.Context, season string
 	return count, err
 }
 
+// FindAll retrieves a page of scores with logging
+func (r *LoggedScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*leaderboardmodels.Score, error) {
+	start := time.Now()
+	scores, err := r.inner.FindAll(ctx, season, sortOrder, limit, offset)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "FindAll", season, duration, map[string]interface{}{"sort_order": sortOrder, "limit": limit, "offset": offset})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.FindAll").
+		Str("season", season).
+		Str("sort_order", sortOrder).
+		Int("limit", limit).
+		Int("offset", offset).
+		Int("results", len(scores)).
+		Dur("duration", duration).
+		Msg("Score page query")
+
+	return scores, err
+}
+
+// Count retrieves the total number of scores with logging
+func (r *LoggedScoreRepository) Count(ctx context.Context) (int64, error) {
+	start := time.Now()
+	count, err := r.inner.Count(ctx)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "Count", "", duration, map[string]interface{}{})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.Count").
+		Int64("count", count).
+		Dur("duration", duration).
+		Msg("Total score count")
+
+	return count, err
+}
+
 // DeleteByUserAndSeason deletes a score with logging
 func (r *LoggedScoreRepository) DeleteByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) error {
 	start := time.Now()
 	err := r.inner.DeleteByUserAndSeason(ctx, userID, season)
 	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "DeleteByUserAndSeason", season, duration, map[string]interface{}{"user_id": userID})
+	}
 
 	logEvent := log.Info()
 	if err != nil {
@@ -134,11 +351,337 @@ func (r *LoggedScoreRepository) DeleteByUserAndSeason(ctx context.Context, userI
 	return err
 }
 
+// RevertSubmission undoes the latest submission of a player with logging
+func (r *LoggedScoreRepository) RevertSubmission(ctx context.Context, userID uuid.UUID, season string, previous *leaderboardmodels.Score) error {
+	start := time.Now()
+	err := r.inner.RevertSubmission(ctx, userID, season, previous)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "RevertSubmission", season, duration, map[string]interface{}{"user_id": userID})
+	}
+
+	logEvent := log.Info()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.RevertSubmission").
+		Str("user_id", userID.String()).
+		Str("season", season).
+		Bool("restored", previous != nil).
+		Dur("duration", duration).
+		Msg("Score submission reverted")
+
+	return err
+}
+
+// DeleteBySeason deletes all scores of a season with logging
+func (r *LoggedScoreRepository) DeleteBySeason(ctx context.Context, season string) (int64, error) {
+	start := time.Now()
+	deleted, err := r.inner.DeleteBySeason(ctx, season)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "DeleteBySeason", season, duration, map[string]interface{}{})
+	}
+
+	logEvent := log.Info()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.DeleteBySeason").
+		Str("season", season).
+		Int64("deleted", deleted).
+		Dur("duration", duration).
+		Msg("Season scores deletion")
+
+	return deleted, err
+}
+
+// CopySeasonScores copies the top scores of a season into another with logging
+func (r *LoggedScoreRepository) CopySeasonScores(ctx context.Context, fromSeason, toSeason string, limit int) (int64, error) {
+	start := time.Now()
+	copied, err := r.inner.CopySeasonScores(ctx, fromSeason, toSeason, limit)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "CopySeasonScores", toSeason, duration, map[string]interface{}{
+			"from_season": fromSeason,
+			"limit":       limit,
+		})
+	}
+
+	logEvent := log.Info()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.CopySeasonScores").
+		Str("from_season", fromSeason).
+		Str("to_season", toSeason).
+		Int("limit", limit).
+		Int64("copied", copied).
+		Dur("duration", duration).
+		Msg("Season scores copy")
+
+	return copied, err
+}
+
+// GetMedianScore retrieves the season median with logging
+func (r *LoggedScoreRepository) GetMedianScore(ctx context.Context, season string) (int64, error) {
+	start := time.Now()
+	median, err := r.inner.GetMedianScore(ctx, season)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetMedianScore", season, duration, map[string]interface{}{})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetMedianScore").
+		Str("season", season).
+		Int64("median", median).
+		Dur("duration", duration).
+		Msg("Median score query")
+
+	return median, err
+}
+
+// GetScoreDistribution retrieves the season histogram with logging
+func (r *LoggedScoreRepository) GetScoreDistribution(ctx context.Context, season string, buckets int) ([]leaderboardmodels.ScoreBucket, error) {
+	start := time.Now()
+	distribution, err := r.inner.GetScoreDistribution(ctx, season, buckets)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetScoreDistribution", season, duration, map[string]interface{}{"buckets": buckets})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetScoreDistribution").
+		Str("season", season).
+		Int("buckets", len(distribution)).
+		Dur("duration", duration).
+		Msg("Score distribution query")
+
+	return distribution, err
+}
+
+// GetLeaderboardFromView retrieves leaderboard from the materialized view with logging
+func (r *LoggedScoreRepository) GetLeaderboardFromView(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	start := time.Now()
+	entries, totalCount, err := r.inner.GetLeaderboardFromView(ctx, season, limit, offset, sortOrder)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetLeaderboardFromView", season, duration, map[string]interface{}{"limit": limit, "offset": offset, "sort_order": sortOrder})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetLeaderboardFromView").
+		Str("season", season).
+		Int("limit", limit).
+		Int("offset", offset).
+		Str("sort_order", sortOrder).
+		Int("entries_count", len(entries)).
+		Int64("total_count", totalCount).
+		Dur("duration", duration).
+		Msg("Leaderboard view query")
+
+	return entries, totalCount, err
+}
+
+// RefreshLeaderboardView refreshes the materialized view with logging
+func (r *LoggedScoreRepository) RefreshLeaderboardView(ctx context.Context, season string) error {
+	start := time.Now()
+	err := r.inner.RefreshLeaderboardView(ctx, season)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "RefreshLeaderboardView", season, duration, map[string]interface{}{})
+	}
+
+	logEvent := log.Info()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.RefreshLeaderboardView").
+		Str("season", season).
+		Dur("duration", duration).
+		Msg("Leaderboard view refresh")
+
+	return err
+}
+
+// GetGlobalStandings retrieves cross-season standings with logging
+func (r *LoggedScoreRepository) GetGlobalStandings(ctx context.Context, limit int) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	start := time.Now()
+	entries, totalCount, err := r.inner.GetGlobalStandings(ctx, limit)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetGlobalStandings", "", duration, map[string]interface{}{"limit": limit})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetGlobalStandings").
+		Int("limit", limit).
+		Int("entries_count", len(entries)).
+		Int64("total_count", totalCount).
+		Dur("duration", duration).
+		Msg("Global standings query")
+
+	return entries, totalCount, err
+}
+
+// GetLeaderboardPage retrieves a keyset leaderboard page with logging
+func (r *LoggedScoreRepository) GetLeaderboardPage(ctx context.Context, season, sortOrder string, after utils.CursorPosition, limit int) ([]leaderboardmodels.LeaderboardEntry, error) {
+	start := time.Now()
+	entries, err := r.inner.GetLeaderboardPage(ctx, season, sortOrder, after, limit)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetLeaderboardPage", season, duration, map[string]interface{}{
+			"sort_order":      sortOrder,
+			"after_score":     after.Score,
+			"after_timestamp": after.Timestamp,
+			"after_rank":      after.Rank,
+			"after_offset":    after.Offset,
+			"limit":           limit,
+		})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetLeaderboardPage").
+		Str("season", season).
+		Str("sort_order", sortOrder).
+		Int64("after_score", after.Score).
+		Int("after_rank", after.Rank).
+		Int("after_offset", after.Offset).
+		Int("limit", limit).
+		Int("entries_count", len(entries)).
+		Dur("duration", duration).
+		Msg("Leaderboard keyset page query")
+
+	return entries, err
+}
+
+// GetCrossSeasonRankings retrieves normalized cross-season rankings with logging
+func (r *LoggedScoreRepository) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]leaderboardmodels.CrossSeasonEntry, error) {
+	start := time.Now()
+	entries, err := r.inner.GetCrossSeasonRankings(ctx, seasons, normalizeByMax, limit)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetCrossSeasonRankings", "", duration, map[string]interface{}{
+			"seasons":          seasons,
+			"normalize_by_max": normalizeByMax,
+			"limit":            limit,
+		})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetCrossSeasonRankings").
+		Strs("seasons", seasons).
+		Bool("normalize_by_max", normalizeByMax).
+		Int("limit", limit).
+		Int("entries_count", len(entries)).
+		Dur("duration", duration).
+		Msg("Cross-season rankings query")
+
+	return entries, err
+}
+
+// GetUserRank computes a single player's rank with logging
+func (r *LoggedScoreRepository) GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.LeaderboardEntry, error) {
+	start := time.Now()
+	entry, err := r.inner.GetUserRank(ctx, userID, season)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetUserRank", season, duration, map[string]interface{}{"user_id": userID})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	rank := 0
+	if entry != nil {
+		rank = entry.Rank
+	}
+	logEvent.
+		Str("method", "ScoreRepository.GetUserRank").
+		Str("user_id", userID.String()).
+		Str("season", season).
+		Int("rank", rank).
+		Dur("duration", duration).
+		Msg("User rank query")
+
+	return entry, err
+}
+
+// GetStreak computes a player's daily submission streak with logging
+func (r *LoggedScoreRepository) GetStreak(ctx context.Context, userID uuid.UUID, season string) (int, int, error) {
+	start := time.Now()
+	current, longest, err := r.inner.GetStreak(ctx, userID, season)
+	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "GetStreak", season, duration, map[string]interface{}{"user_id": userID})
+	}
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "ScoreRepository.GetStreak").
+		Str("user_id", userID.String()).
+		Str("season", season).
+		Int("streak_current", current).
+		Int("streak_longest", longest).
+		Dur("duration", duration).
+		Msg("Streak query")
+
+	return current, longest, err
+}
+
 // FindBySpec finds scores by specification with logging
 func (r *LoggedScoreRepository) FindBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) ([]*leaderboardmodels.Score, error) {
 	start := time.Now()
 	scores, err := r.inner.FindBySpec(ctx, spec)
 	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "FindBySpec", "", duration, map[string]interface{}{"spec": repository.DescribeSpec(spec)})
+	}
 
 	logEvent := log.Debug()
 	if err != nil {
@@ -159,6 +702,9 @@ func (r *LoggedScoreRepository) FindOneBySpec(ctx context.Context, spec reposito
 	start := time.Now()
 	score, err := r.inner.FindOneBySpec(ctx, spec)
 	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "FindOneBySpec", "", duration, map[string]interface{}{"spec": repository.DescribeSpec(spec)})
+	}
 
 	logEvent := log.Debug()
 	if err != nil {
@@ -179,6 +725,9 @@ func (r *LoggedScoreRepository) CountBySpec(ctx context.Context, spec repository
 	start := time.Now()
 	count, err := r.inner.CountBySpec(ctx, spec)
 	duration := time.Since(start)
+	if r.isSlow(duration) {
+		r.logSlowQuery(ctx, "CountBySpec", "", duration, map[string]interface{}{"spec": repository.DescribeSpec(spec)})
+	}
 
 	logEvent := log.Debug()
 	if err != nil {
@@ -193,3 +742,17 @@ func (r *LoggedScoreRepository) CountBySpec(ctx context.Context, spec repository
 
 	return count, err
 }
+
+// Invalidate forwards cache invalidation to the inner repository when it caches, with logging
+func (r *LoggedScoreRepository) Invalidate(ctx context.Context, season string) {
+	invalidator, ok := r.inner.(repository.SeasonCacheInvalidator)
+	if ok {
+		invalidator.Invalidate(ctx, season)
+	}
+
+	log.Info().
+		Str("method", "ScoreRepository.Invalidate").
+		Str("season", season).
+		Bool("cached", ok).
+		Msg("Season cache invalidated")
+}
@@ -0,0 +1,82 @@
+package decorators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowScoreRepository delays lookups to exceed the slow query threshold
+type slowScoreRepository struct {
+	*memoryScoreRepository
+	delay time.Duration
+}
+
+func (r *slowScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error) {
+	time.Sleep(r.delay)
+	return r.memoryScoreRepository.FindByUserAndSeason(ctx, userID, season)
+}
+
+// captureLog redirects the global logger, which LoggedScoreRepository writes to, into a buffer
+func captureLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = previous })
+	return &buf
+}
+
+// slowQueryLines returns the log lines flagged with slow_query
+func slowQueryLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		if entry["slow_query"] == true {
+			lines = append(lines, entry)
+		}
+	}
+	return lines
+}
+
+func TestLoggedScoreRepository_WarnsOnSlowQuery(t *testing.T) {
+	buf := captureLog(t)
+	inner := &slowScoreRepository{memoryScoreRepository: newMemoryScoreRepository(), delay: 20 * time.Millisecond}
+	repo := NewLoggedScoreRepositoryWithThreshold(inner, 5*time.Millisecond)
+	userID := uuid.New()
+
+	_, err := repo.FindByUserAndSeason(context.Background(), userID, "winter")
+	require.NoError(t, err)
+
+	lines := slowQueryLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "warn", lines[0]["level"])
+	assert.Equal(t, "ScoreRepository.FindByUserAndSeason", lines[0]["method"])
+	assert.Equal(t, "winter", lines[0]["season"])
+	assert.Equal(t, userID.String(), lines[0]["user_id"])
+	assert.Equal(t, int64(1), repo.SlowQueries().Get("winter", "FindByUserAndSeason"))
+}
+
+func TestLoggedScoreRepository_FastQueryNotFlagged(t *testing.T) {
+	buf := captureLog(t)
+	repo := NewLoggedScoreRepositoryWithThreshold(newMemoryScoreRepository(), time.Second)
+
+	require.NoError(t, repo.Upsert(context.Background(), &leaderboardmodels.Score{UserID: uuid.New(), Score: 10, Season: "winter"}))
+
+	assert.Empty(t, slowQueryLines(t, buf))
+	assert.Empty(t, repo.SlowQueries().Snapshot())
+}
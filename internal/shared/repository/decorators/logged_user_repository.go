@@ -44,6 +44,56 @@ func (r *LoggedUserRepository) Create(ctx context.Context, user *authmodels.User
 	return err
 }
 
+// CreateBatch inserts users in batches with logging
+func (r *LoggedUserRepository) CreateBatch(ctx context.Context, users []*authmodels.User, batchSize int) error {
+	start := time.Now()
+	err := r.inner.CreateBatch(ctx, users, batchSize)
+	duration := time.Since(start)
+
+	logEvent := log.Info()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "UserRepository.CreateBatch").
+		Int("count", len(users)).
+		Int("batch_size", batchSize).
+		Dur("duration", duration).
+		Msg("Batch user creation")
+
+	return err
+}
+
+// CreateBatchSkippingExisting inserts users in batches, skipping taken emails, with logging
+func (r *LoggedUserRepository) CreateBatchSkippingExisting(ctx context.Context, users []*authmodels.User, batchSize int) error {
+	start := time.Now()
+	err := r.inner.CreateBatchSkippingExisting(ctx, users, batchSize)
+	duration := time.Since(start)
+
+	logEvent := log.Info()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	inserted := 0
+	for _, user := range users {
+		if user.ID != uuid.Nil {
+			inserted++
+		}
+	}
+
+	logEvent.
+		Str("method", "UserRepository.CreateBatchSkippingExisting").
+		Int("count", len(users)).
+		Int("inserted", inserted).
+		Int("batch_size", batchSize).
+		Dur("duration", duration).
+		Msg("Batch user creation")
+
+	return err
+}
+
 // FindByID retrieves a user by ID with logging
 func (r *LoggedUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*authmodels.User, error) {
 	start := time.Now()
@@ -65,6 +115,50 @@ func (r *LoggedUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*aut
 	return user, err
 }
 
+// FindByIDs retrieves users by IDs with logging
+func (r *LoggedUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*authmodels.User, error) {
+	start := time.Now()
+	users, err := r.inner.FindByIDs(ctx, ids)
+	duration := time.Since(start)
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "UserRepository.FindByIDs").
+		Int("requested", len(ids)).
+		Int("found", len(users)).
+		Dur("duration", duration).
+		Msg("Batch user lookup by IDs")
+
+	return users, err
+}
+
+// FindAll retrieves a page of users with logging
+func (r *LoggedUserRepository) FindAll(ctx context.Context, limit, offset int) ([]*authmodels.User, int64, error) {
+	start := time.Now()
+	users, total, err := r.inner.FindAll(ctx, limit, offset)
+	duration := time.Since(start)
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "UserRepository.FindAll").
+		Int("limit", limit).
+		Int("offset", offset).
+		Int("results", len(users)).
+		Int64("total", total).
+		Dur("duration", duration).
+		Msg("User list query")
+
+	return users, total, err
+}
+
 // FindByEmail retrieves a user by email with logging
 func (r *LoggedUserRepository) FindByEmail(ctx context.Context, email string) (*authmodels.User, error) {
 	start := time.Now()
@@ -86,6 +180,27 @@ func (r *LoggedUserRepository) FindByEmail(ctx context.Context, email string) (*
 	return user, err
 }
 
+// ExistsByEmail checks whether an email is registered with logging
+func (r *LoggedUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	start := time.Now()
+	exists, err := r.inner.ExistsByEmail(ctx, email)
+	duration := time.Since(start)
+
+	logEvent := log.Debug()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "UserRepository.ExistsByEmail").
+		Str("email", email).
+		Dur("duration", duration).
+		Bool("exists", exists).
+		Msg("User email check")
+
+	return exists, err
+}
+
 // Update updates a user with logging
 func (r *LoggedUserRepository) Update(ctx context.Context, user *authmodels.User) error {
 	start := time.Now()
@@ -106,6 +221,27 @@ func (r *LoggedUserRepository) Update(ctx context.Context, user *authmodels.User
 	return err
 }
 
+// SetAdmin changes the admin flag of a user with logging
+func (r *LoggedUserRepository) SetAdmin(ctx context.Context, userID uuid.UUID, isAdmin bool) error {
+	start := time.Now()
+	err := r.inner.SetAdmin(ctx, userID, isAdmin)
+	duration := time.Since(start)
+
+	logEvent := log.Info()
+	if err != nil {
+		logEvent = log.Error().Err(err)
+	}
+
+	logEvent.
+		Str("method", "UserRepository.SetAdmin").
+		Str("user_id", userID.String()).
+		Bool("is_admin", isAdmin).
+		Dur("duration", duration).
+		Msg("User admin flag update")
+
+	return err
+}
+
 // Delete deletes a user with logging
 func (r *LoggedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	start := time.Now()
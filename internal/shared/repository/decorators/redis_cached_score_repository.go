@@ -2,13 +2,16 @@ package decorators
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
 	"leaderboard-service/internal/shared/database"
 	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
@@ -16,22 +19,30 @@ import (
 
 // RedisCachedScoreRepository decorates ScoreRepository with Redis caching
 type RedisCachedScoreRepository struct {
-	inner repository.ScoreRepository
-	redis *database.RedisClient
-	ttl   time.Duration
+	inner      repository.ScoreRepository
+	redis      *database.RedisClient
+	serializer Serializer
+	rankings   *database.RankingSet
+	ttl        time.Duration
 }
 
-// NewRedisCachedScoreRepository creates a Redis-cached score repository
-func NewRedisCachedScoreRepository(inner repository.ScoreRepository, redis *database.RedisClient) repository.ScoreRepository {
+// NewRedisCachedScoreRepository creates a Redis-cached score repository that encodes
+// cached values with serializer (JSON when nil)
+func NewRedisCachedScoreRepository(inner repository.ScoreRepository, redis *database.RedisClient, serializer Serializer) repository.ScoreRepository {
 	if redis == nil {
 		log.Warn().Msg("Redis is nil, returning uncached repository")
 		return inner
 	}
+	if serializer == nil {
+		serializer = JSONSerializer{}
+	}
 
 	return &RedisCachedScoreRepository{
-		inner: inner,
-		redis: redis,
-		ttl:   30 * time.Second, // Short TTL for frequently changing data
+		inner:      inner,
+		redis:      redis,
+		serializer: serializer,
+		rankings:   database.NewRankingSet(redis),
+		ttl:        30 * time.Second, // Short TTL for frequently changing data
 	}
 }
 
@@ -44,12 +55,33 @@ func (r *RedisCachedScoreRepository) Upsert(ctx context.Context, score *leaderbo
 
 	// Invalidate ALL leaderboard caches for this season using Redis SCAN
 	r.invalidateLeaderboardCache(ctx, score.Season)
-	r.redis.Client.Del(ctx, r.scoreKey(score.UserID, score.Season))
+	r.redis.Client.Del(ctx, r.scoreKey(score.UserID, score.Season), r.streakKey(score.UserID, score.Season))
 	r.redis.Client.Del(ctx, r.countKey(score.Season))
+	r.updateRankingSet(ctx, score)
 
 	return nil
 }
 
+// UpsertOnlyIfHigher upserts a personal best and invalidates Redis cache only when the row changed
+func (r *RedisCachedScoreRepository) UpsertOnlyIfHigher(ctx context.Context, score *leaderboardmodels.Score) (bool, error) {
+	updated, err := r.inner.UpsertOnlyIfHigher(ctx, score)
+	if err != nil {
+		return false, err
+	}
+	// The submission is in score_history whether or not it beat the stored score
+	r.redis.Client.Del(ctx, r.streakKey(score.UserID, score.Season))
+	if !updated {
+		return false, nil
+	}
+
+	r.invalidateLeaderboardCache(ctx, score.Season)
+	r.redis.Client.Del(ctx, r.scoreKey(score.UserID, score.Season))
+	r.redis.Client.Del(ctx, r.countKey(score.Season))
+	r.updateRankingSet(ctx, score)
+
+	return true, nil
+}
+
 // FindByUserAndSeason retrieves a score with Redis caching
 func (r *RedisCachedScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error) {
 	key := r.scoreKey(userID, season)
@@ -58,7 +90,7 @@ func (r *RedisCachedScoreRepository) FindByUserAndSeason(ctx context.Context, us
 	cached, err := r.redis.Client.Get(ctx, key).Result()
 	if err == nil {
 		var score leaderboardmodels.Score
-		if err := json.Unmarshal([]byte(cached), &score); err == nil {
+		if err := r.serializer.Unmarshal([]byte(cached), &score); err == nil {
 			return &score, nil
 		}
 	}
@@ -70,7 +102,7 @@ func (r *RedisCachedScoreRepository) FindByUserAndSeason(ctx context.Context, us
 	}
 
 	// Store in Redis
-	if data, err := json.Marshal(score); err == nil {
+	if data, err := r.serializer.Marshal(score); err == nil {
 		r.redis.Client.Set(ctx, key, data, r.ttl)
 	}
 
@@ -78,8 +110,8 @@ func (r *RedisCachedScoreRepository) FindByUserAndSeason(ctx context.Context, us
 }
 
 // GetLeaderboard retrieves leaderboard with Redis caching
-func (r *RedisCachedScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
-	key := r.leaderboardKeyWithParams(season, limit, offset, sortOrder)
+func (r *RedisCachedScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	key := r.leaderboardKeyWithParams(season, limit, offset, sortBy, sortOrder, excludeUserIDs)
 
 	// Try cache first
 	cached, err := r.redis.Client.Get(ctx, key).Result()
@@ -88,7 +120,7 @@ func (r *RedisCachedScoreRepository) GetLeaderboard(ctx context.Context, season
 			Entries    []leaderboardmodels.LeaderboardEntry `json:"entries"`
 			TotalCount int64                                `json:"total_count"`
 		}
-		if err := json.Unmarshal([]byte(cached), &result); err == nil {
+		if err := r.serializer.Unmarshal([]byte(cached), &result); err == nil {
 			log.Info().
 				Str("key", key).
 				Int("entries", len(result.Entries)).
@@ -98,7 +130,7 @@ func (r *RedisCachedScoreRepository) GetLeaderboard(ctx context.Context, season
 	}
 
 	// Cache miss - fetch from DB
-	entries, totalCount, err := r.inner.GetLeaderboard(ctx, season, limit, offset, sortOrder)
+	entries, totalCount, err := r.inner.GetLeaderboard(ctx, season, limit, offset, sortBy, sortOrder, excludeUserIDs)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -112,7 +144,7 @@ func (r *RedisCachedScoreRepository) GetLeaderboard(ctx context.Context, season
 		TotalCount: totalCount,
 	}
 
-	if data, err := json.Marshal(result); err == nil {
+	if data, err := r.serializer.Marshal(result); err == nil {
 		r.redis.Client.Set(ctx, key, data, r.ttl)
 		log.Info().
 			Str("key", key).
@@ -157,13 +189,150 @@ func (r *RedisCachedScoreRepository) DeleteByUserAndSeason(ctx context.Context,
 	r.invalidateLeaderboardCache(ctx, season)
 	r.redis.Client.Del(ctx, r.scoreKey(userID, season))
 	r.redis.Client.Del(ctx, r.countKey(season))
+	if err := r.rankings.Remove(ctx, season, userID); err != nil {
+		log.Warn().Err(err).Str("season", season).Msg("Failed to remove player from ranking set")
+	}
+
+	return nil
+}
+
+// RevertSubmission undoes the latest submission of a player and brings the Redis keys in line
+func (r *RedisCachedScoreRepository) RevertSubmission(ctx context.Context, userID uuid.UUID, season string, previous *leaderboardmodels.Score) error {
+	err := r.inner.RevertSubmission(ctx, userID, season, previous)
+	if err != nil {
+		return err
+	}
+
+	r.invalidateLeaderboardCache(ctx, season)
+	r.redis.Client.Del(ctx, r.scoreKey(userID, season), r.streakKey(userID, season))
+	r.redis.Client.Del(ctx, r.countKey(season))
+	if previous == nil {
+		if err := r.rankings.Remove(ctx, season, userID); err != nil {
+			log.Warn().Err(err).Str("season", season).Msg("Failed to remove player from ranking set")
+		}
+	} else {
+		// Add перезаписывает счет в наборе, в том числе более низким
+		r.updateRankingSet(ctx, previous)
+	}
 
 	return nil
 }
 
+// DeleteBySeason deletes all scores of a season and clears its Redis keys
+func (r *RedisCachedScoreRepository) DeleteBySeason(ctx context.Context, season string) (int64, error) {
+	deleted, err := r.inner.DeleteBySeason(ctx, season)
+	if err != nil {
+		return 0, err
+	}
+
+	r.Invalidate(ctx, season)
+
+	return deleted, nil
+}
+
+// CopySeasonScores copies scores into toSeason and clears its Redis keys
+func (r *RedisCachedScoreRepository) CopySeasonScores(ctx context.Context, fromSeason, toSeason string, limit int) (int64, error) {
+	copied, err := r.inner.CopySeasonScores(ctx, fromSeason, toSeason, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	r.Invalidate(ctx, toSeason)
+
+	return copied, nil
+}
+
+// Invalidate drops leaderboard pages, per-user scores and the count of a season from Redis
+func (r *RedisCachedScoreRepository) Invalidate(ctx context.Context, season string) {
+	r.invalidateLeaderboardCache(ctx, season)
+	r.invalidateByPattern(ctx, fmt.Sprintf("score:*:%s", season))
+	r.invalidateByPattern(ctx, fmt.Sprintf("streak:*:%s", season))
+	r.redis.Client.Del(ctx, r.countKey(season))
+	if err := r.rankings.Drop(ctx, season); err != nil {
+		log.Warn().Err(err).Str("season", season).Msg("Failed to drop ranking set")
+	}
+}
+
+// updateRankingSet mirrors a stored score into the season's ranking set (ZADD leaderboard:{season} score userID).
+// Failures are logged: the set expires and is seeded again, PostgreSQL stays the source of truth.
+func (r *RedisCachedScoreRepository) updateRankingSet(ctx context.Context, score *leaderboardmodels.Score) {
+	if err := r.rankings.Add(ctx, score.Season, score.Score, score.UserID); err != nil {
+		log.Warn().Err(err).Str("key", database.RankingSetKey(score.Season)).Msg("Failed to update ranking set")
+	}
+}
+
+// GetMedianScore retrieves the season median (no caching, aggregate is cheap on the season index)
+func (r *RedisCachedScoreRepository) GetMedianScore(ctx context.Context, season string) (int64, error) {
+	return r.inner.GetMedianScore(ctx, season)
+}
+
+// GetScoreDistribution retrieves the season histogram (no caching, same as the median)
+func (r *RedisCachedScoreRepository) GetScoreDistribution(ctx context.Context, season string, buckets int) ([]leaderboardmodels.ScoreBucket, error) {
+	return r.inner.GetScoreDistribution(ctx, season, buckets)
+}
+
+// GetLeaderboardFromView retrieves leaderboard from the materialized view (no caching, the view is already a snapshot)
+func (r *RedisCachedScoreRepository) GetLeaderboardFromView(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	return r.inner.GetLeaderboardFromView(ctx, season, limit, offset, sortOrder)
+}
+
+// RefreshLeaderboardView refreshes the materialized view
+func (r *RedisCachedScoreRepository) RefreshLeaderboardView(ctx context.Context, season string) error {
+	return r.inner.RefreshLeaderboardView(ctx, season)
+}
+
+// GetGlobalStandings retrieves cross-season standings (no caching, changes on every submission)
+func (r *RedisCachedScoreRepository) GetGlobalStandings(ctx context.Context, limit int) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	return r.inner.GetGlobalStandings(ctx, limit)
+}
+
+// GetLeaderboardPage retrieves a keyset page (no caching: every cursor is a different key)
+func (r *RedisCachedScoreRepository) GetLeaderboardPage(ctx context.Context, season, sortOrder string, after utils.CursorPosition, limit int) ([]leaderboardmodels.LeaderboardEntry, error) {
+	return r.inner.GetLeaderboardPage(ctx, season, sortOrder, after, limit)
+}
+
+// GetCrossSeasonRankings retrieves normalized cross-season rankings (no caching, changes on every submission)
+func (r *RedisCachedScoreRepository) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]leaderboardmodels.CrossSeasonEntry, error) {
+	return r.inner.GetCrossSeasonRankings(ctx, seasons, normalizeByMax, limit)
+}
+
+// FindByMetadata finds scores by metadata WITHOUT caching (operator lookups, rarely repeated)
+func (r *RedisCachedScoreRepository) FindByMetadata(ctx context.Context, season, key, value string, limit int) ([]*leaderboardmodels.Score, error) {
+	return r.inner.FindByMetadata(ctx, season, key, value, limit)
+}
+
+// GetLeaderboardByCountry retrieves a country's leaderboard (no caching, a player's country changes independently of scores)
+func (r *RedisCachedScoreRepository) GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	return r.inner.GetLeaderboardByCountry(ctx, country, season, limit, offset)
+}
+
+// GetLeaderboardWithProfiles retrieves leaderboard entries with player profiles (no caching, profiles change independently of scores)
+func (r *RedisCachedScoreRepository) GetLeaderboardWithProfiles(ctx context.Context, season string, limit, offset int, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.EnrichedLeaderboardEntry, int64, error) {
+	return r.inner.GetLeaderboardWithProfiles(ctx, season, limit, offset, sortOrder, excludeUserIDs)
+}
+
+// FindAll retrieves a page of scores (no caching)
+func (r *RedisCachedScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*leaderboardmodels.Score, error) {
+	return r.inner.FindAll(ctx, season, sortOrder, limit, offset)
+}
+
+// Count returns the total number of scores (no caching)
+func (r *RedisCachedScoreRepository) Count(ctx context.Context) (int64, error) {
+	return r.inner.Count(ctx)
+}
+
+// GetUserRank computes a player's rank (no caching, any submission in the season can move it)
+func (r *RedisCachedScoreRepository) GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.LeaderboardEntry, error) {
+	return r.inner.GetUserRank(ctx, userID, season)
+}
+
 // invalidateLeaderboardCache removes all leaderboard keys for a season using SCAN
 func (r *RedisCachedScoreRepository) invalidateLeaderboardCache(ctx context.Context, season string) {
-	pattern := fmt.Sprintf("leaderboard:%s:*", season)
+	r.invalidateByPattern(ctx, fmt.Sprintf("leaderboard:%s:*", season))
+}
+
+// invalidateByPattern removes all keys matching a pattern using SCAN
+func (r *RedisCachedScoreRepository) invalidateByPattern(ctx context.Context, pattern string) {
 
 	// Use SCAN to find all matching keys (non-blocking)
 	iter := r.redis.Client.Scan(ctx, 0, pattern, 100).Iterator()
@@ -192,14 +361,57 @@ func (r *RedisCachedScoreRepository) invalidateLeaderboardCache(ctx context.Cont
 	}
 }
 
+// GetStreak retrieves a player's daily submission streak with Redis caching
+func (r *RedisCachedScoreRepository) GetStreak(ctx context.Context, userID uuid.UUID, season string) (int, int, error) {
+	key := r.streakKey(userID, season)
+
+	// Try cache first
+	cached, err := r.redis.Client.Get(ctx, key).Result()
+	if err == nil {
+		var streak [2]int
+		if err := r.serializer.Unmarshal([]byte(cached), &streak); err == nil {
+			return streak[0], streak[1], nil
+		}
+	}
+
+	// Cache miss - fetch from DB
+	current, longest, err := r.inner.GetStreak(ctx, userID, season)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Store in Redis
+	if data, err := r.serializer.Marshal([2]int{current, longest}); err == nil {
+		r.redis.Client.Set(ctx, key, data, r.ttl)
+	}
+
+	return current, longest, nil
+}
+
 // Helper methods for cache keys
 
 func (r *RedisCachedScoreRepository) scoreKey(userID uuid.UUID, season string) string {
 	return fmt.Sprintf("score:%s:%s", userID.String(), season)
 }
 
-func (r *RedisCachedScoreRepository) leaderboardKeyWithParams(season string, limit, offset int, sortOrder string) string {
-	return fmt.Sprintf("leaderboard:%s:%d:%d:%s", season, limit, offset, sortOrder)
+func (r *RedisCachedScoreRepository) leaderboardKeyWithParams(season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) string {
+	key := fmt.Sprintf("leaderboard:%s:%d:%d:%s:%s", season, limit, offset, sortBy, sortOrder)
+	if len(excludeUserIDs) == 0 {
+		return key
+	}
+
+	// Sort IDs so the same blocklist maps to the same key regardless of order
+	ids := make([]string, len(excludeUserIDs))
+	for i, id := range excludeUserIDs {
+		ids[i] = id.String()
+	}
+	sort.Strings(ids)
+	sum := sha256.Sum256([]byte(strings.Join(ids, ",")))
+	return fmt.Sprintf("%s:exclude:%x", key, sum[:8])
+}
+
+func (r *RedisCachedScoreRepository) streakKey(userID uuid.UUID, season string) string {
+	return fmt.Sprintf("streak:%s:%s", userID.String(), season)
 }
 
 func (r *RedisCachedScoreRepository) countKey(season string) string {
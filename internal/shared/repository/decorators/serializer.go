@@ -0,0 +1,37 @@
+package decorators
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"leaderboard-service/internal/shared/config"
+)
+
+// Serializer encodes values stored in Redis by RedisCachedScoreRepository
+type Serializer interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONSerializer stores cache values as JSON
+type JSONSerializer struct{}
+
+// Marshal encodes v as JSON
+func (JSONSerializer) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON data into v
+func (JSONSerializer) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// NewSerializer returns the serializer for a config.SerializationFormat* value; empty means JSON.
+// CBOR and MessagePack need codecs that are not part of the module yet, so they are rejected.
+func NewSerializer(format string) (Serializer, error) {
+	switch format {
+	case "", config.SerializationFormatJSON:
+		return JSONSerializer{}, nil
+	}
+	return nil, fmt.Errorf("%w %q", config.ErrUnsupportedSerializationFormat, format)
+}
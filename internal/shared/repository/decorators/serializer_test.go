@@ -0,0 +1,85 @@
+package decorators
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/config"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cachedLeaderboard matches the value GetLeaderboard stores in Redis
+type cachedLeaderboard struct {
+	Entries    []leaderboardmodels.LeaderboardEntry
+	TotalCount int64
+}
+
+func newCachedLeaderboard(size int) cachedLeaderboard {
+	entries := make([]leaderboardmodels.LeaderboardEntry, size)
+	for i := range entries {
+		entries[i] = leaderboardmodels.LeaderboardEntry{
+			Rank:        i + 1,
+			UserID:      uuid.New(),
+			UserName:    fmt.Sprintf("player_%03d", i),
+			Score:       int64(100000 - i*37),
+			Season:      "global",
+			Timestamp:   time.Date(2024, 1, 1, 12, 0, i, 0, time.UTC),
+			GamesPlayed: i%7 + 1,
+		}
+	}
+	return cachedLeaderboard{Entries: entries, TotalCount: int64(size) * 10}
+}
+
+func TestNewSerializer(t *testing.T) {
+	for _, format := range []string{"", config.SerializationFormatJSON} {
+		serializer, err := NewSerializer(format)
+		require.NoError(t, err)
+		assert.IsType(t, JSONSerializer{}, serializer)
+	}
+
+	for _, format := range []string{config.SerializationFormatCBOR, config.SerializationFormatMsgpack} {
+		_, err := NewSerializer(format)
+		assert.ErrorIs(t, err, config.ErrUnsupportedSerializationFormat)
+	}
+}
+
+func TestJSONSerializer_RoundTrip(t *testing.T) {
+	original := newCachedLeaderboard(3)
+
+	data, err := JSONSerializer{}.Marshal(original)
+	require.NoError(t, err)
+
+	var decoded cachedLeaderboard
+	require.NoError(t, JSONSerializer{}.Unmarshal(data, &decoded))
+	assert.Equal(t, original, decoded)
+}
+
+// BenchmarkSerializers_Leaderboard100 encodes and decodes a 100-entry leaderboard page.
+// New formats should be added here so their size and speed can be compared with JSON.
+func BenchmarkSerializers_Leaderboard100(b *testing.B) {
+	payload := newCachedLeaderboard(100)
+	serializers := map[string]Serializer{
+		config.SerializationFormatJSON: JSONSerializer{},
+	}
+
+	for name, serializer := range serializers {
+		b.Run(name, func(b *testing.B) {
+			data, err := serializer.Marshal(payload)
+			require.NoError(b, err)
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				data, _ := serializer.Marshal(payload)
+				var decoded cachedLeaderboard
+				_ = serializer.Unmarshal(data, &decoded)
+			}
+			b.ReportMetric(float64(len(data)), "bytes/payload")
+		})
+	}
+}
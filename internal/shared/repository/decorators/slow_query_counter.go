@@ -0,0 +1,46 @@
+package decorators
+
+import "sync"
+
+// SlowQueryKey identifies a slow query series: the season (empty for calls without one) and the method
+type SlowQueryKey struct {
+	Season string
+	Method string
+}
+
+// SlowQueryCounter counts slow repository calls per season and method.
+// Safe for concurrent use.
+type SlowQueryCounter struct {
+	mu     sync.Mutex
+	counts map[SlowQueryKey]int64
+}
+
+// NewSlowQueryCounter creates an empty counter
+func NewSlowQueryCounter() *SlowQueryCounter {
+	return &SlowQueryCounter{counts: make(map[SlowQueryKey]int64)}
+}
+
+// Inc records one slow call
+func (c *SlowQueryCounter) Inc(season, method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[SlowQueryKey{Season: season, Method: method}]++
+}
+
+// Get returns the number of slow calls of a method in a season
+func (c *SlowQueryCounter) Get(season, method string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[SlowQueryKey{Season: season, Method: method}]
+}
+
+// Snapshot returns a copy of all counts
+func (c *SlowQueryCounter) Snapshot() map[SlowQueryKey]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot := make(map[SlowQueryKey]int64, len(c.counts))
+	for key, count := range c.counts {
+		snapshot[key] = count
+	}
+	return snapshot
+}
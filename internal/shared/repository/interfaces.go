@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	authmodels "leaderboard-service/internal/auth/models"
+	challengemodels "leaderboard-service/internal/challenge/models"
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	seasonmodels "leaderboard-service/internal/season/models"
+	sessionmodels "leaderboard-service/internal/session/models"
+	"leaderboard-service/internal/shared/utils"
 
 	"github.com/google/uuid"
 )
@@ -15,15 +20,35 @@ type UserRepository interface {
 	// Create creates a new user in the database
 	Create(ctx context.Context, user *authmodels.User) error
 
+	// CreateBatch inserts users in chunks of batchSize using multi-row INSERTs
+	CreateBatch(ctx context.Context, users []*authmodels.User, batchSize int) error
+
+	// CreateBatchSkippingExisting inserts users in chunks of batchSize like CreateBatch, but a user whose
+	// email is already registered (also by a concurrent insert) is skipped instead of failing the batch.
+	// Inserted users get their ID; skipped users are left with uuid.Nil.
+	CreateBatchSkippingExisting(ctx context.Context, users []*authmodels.User, batchSize int) error
+
 	// FindByID retrieves a user by their UUID
 	FindByID(ctx context.Context, id uuid.UUID) (*authmodels.User, error)
 
+	// FindByIDs retrieves users by their UUIDs; IDs that do not exist are absent from the map
+	FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*authmodels.User, error)
+
 	// FindByEmail retrieves a user by their email address
 	FindByEmail(ctx context.Context, email string) (*authmodels.User, error)
 
+	// ExistsByEmail reports whether a user with the email address is registered
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+
+	// FindAll retrieves a page of users, newest first, together with the total number of users
+	FindAll(ctx context.Context, limit, offset int) ([]*authmodels.User, int64, error)
+
 	// Update updates an existing user's information
 	Update(ctx context.Context, user *authmodels.User) error
 
+	// SetAdmin grants or revokes admin rights; returns ErrRecordNotFound for an unknown user
+	SetAdmin(ctx context.Context, userID uuid.UUID, isAdmin bool) error
+
 	// Delete removes a user from the database
 	Delete(ctx context.Context, id uuid.UUID) error
 
@@ -43,19 +68,90 @@ type ScoreRepository interface {
 	// Upsert inserts a new score or updates if the user already has a score for the season
 	Upsert(ctx context.Context, score *leaderboardmodels.Score) error
 
+	// UpsertOnlyIfHigher inserts a score or replaces it only when the new value beats the stored one
+	// Returns whether a row was written
+	UpsertOnlyIfHigher(ctx context.Context, score *leaderboardmodels.Score) (bool, error)
+
 	// FindByUserAndSeason retrieves a user's score for a specific season
 	FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error)
 
 	// GetLeaderboard retrieves paginated leaderboard entries for a season with user details
-	// Returns entries and total count for pagination
-	GetLeaderboard(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error)
+	// Returns entries and total count for pagination; excludeUserIDs are left out of both.
+	// sortBy is one of models.SortBy* (empty means score) and also decides the rank.
+	GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.LeaderboardEntry, int64, error)
+
+	// GetLeaderboardPage returns the page of a season's leaderboard that follows the entry at after
+	// in (score DESC, timestamp ASC, user_id ASC) order, using keyset pagination instead of OFFSET.
+	// Only sortOrder "desc" (or empty) is supported; ranks continue from after.Rank and after.Offset
+	// with the configured ranking method, so ties across the page boundary keep their rank.
+	GetLeaderboardPage(ctx context.Context, season, sortOrder string, after utils.CursorPosition, limit int) ([]leaderboardmodels.LeaderboardEntry, error)
+
+	// GetLeaderboardWithProfiles works like GetLeaderboard but also returns avatar_url, country and tier of each player
+	GetLeaderboardWithProfiles(ctx context.Context, season string, limit, offset int, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.EnrichedLeaderboardEntry, int64, error)
+
+	// GetLeaderboardByCountry retrieves a season's leaderboard of the players registered in a country
+	// (ISO 3166-1 alpha-2); ranks are positions within the country. Returns entries and the country's total.
+	GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) ([]leaderboardmodels.LeaderboardEntry, int64, error)
+
+	// FindByMetadata returns up to limit scores of a season whose metadata has key with the given value,
+	// most recent first. Values are compared as text, so {"simulated": true} matches value "true".
+	FindByMetadata(ctx context.Context, season, key, value string, limit int) ([]*leaderboardmodels.Score, error)
 
 	// CountBySeason returns the total number of scores for a given season
 	CountBySeason(ctx context.Context, season string) (int64, error)
 
+	// FindAll retrieves a page of raw scores of a season ordered by score ("asc" or "desc"), earlier submissions first on ties
+	FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*leaderboardmodels.Score, error)
+
+	// Count returns the number of scores across all seasons
+	Count(ctx context.Context) (int64, error)
+
 	// DeleteByUserAndSeason removes a user's score for a specific season
 	DeleteByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) error
 
+	// RevertSubmission undoes the player's latest submission in a season: the row is set back to
+	// previous exactly (removed when previous is nil), the submission's game and score history
+	// entry are dropped, and nothing new is recorded. Returns ErrRecordNotFound if the row is gone.
+	RevertSubmission(ctx context.Context, userID uuid.UUID, season string, previous *leaderboardmodels.Score) error
+
+	// DeleteBySeason removes every score of a season and returns the number of deleted rows
+	DeleteBySeason(ctx context.Context, season string) (int64, error)
+
+	// CopySeasonScores inserts the top limit scores of fromSeason into toSeason as they are:
+	// metadata, timestamp and games_played are kept and no score history is written.
+	// Fails if a copied player already has a score in toSeason. Returns the number of copied rows.
+	CopySeasonScores(ctx context.Context, fromSeason, toSeason string, limit int) (int64, error)
+
+	// GetMedianScore returns the median score of a season (0 for an empty season)
+	GetMedianScore(ctx context.Context, season string) (int64, error)
+
+	// GetScoreDistribution splits the season's score range into up to buckets equal ranges
+	// and counts the players in each (see leaderboardmodels.NewScoreBuckets); empty for an empty season
+	GetScoreDistribution(ctx context.Context, season string, buckets int) ([]leaderboardmodels.ScoreBucket, error)
+
+	// GetLeaderboardFromView reads paginated entries with pre-computed ranks from the leaderboard_view materialized view
+	GetLeaderboardFromView(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error)
+
+	// RefreshLeaderboardView recomputes the leaderboard_view materialized view
+	// season names the season that changed; an empty season means all seasons
+	RefreshLeaderboardView(ctx context.Context, season string) error
+
+	// GetGlobalStandings ranks players across all seasons by their single best score
+	// Returns the top limit entries (Season is the season of the best score) and the number of ranked players
+	GetGlobalStandings(ctx context.Context, limit int) ([]leaderboardmodels.LeaderboardEntry, int64, error)
+
+	// GetCrossSeasonRankings ranks players over the given seasons by normalized score (0..100 per season).
+	// normalizeByMax divides by the season's best score; otherwise the season's min..max range maps to 0..100
+	GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]leaderboardmodels.CrossSeasonEntry, error)
+
+	// GetUserRank computes a single player's rank in a season without loading the whole leaderboard
+	// Returns ErrRecordNotFound if the user has no score in the season
+	GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.LeaderboardEntry, error)
+
+	// GetStreak returns the player's current and longest run of consecutive days (UTC) with a submission in the season
+	// The current streak is 0 unless the player submitted today or yesterday; a player without submissions has 0, 0
+	GetStreak(ctx context.Context, userID uuid.UUID, season string) (currentStreak, longestStreak int, err error)
+
 	// FindBySpec finds scores matching a specification
 	FindBySpec(ctx context.Context, spec Specification[leaderboardmodels.Score]) ([]*leaderboardmodels.Score, error)
 
@@ -65,3 +161,81 @@ type ScoreRepository interface {
 	// CountBySpec counts scores matching a specification
 	CountBySpec(ctx context.Context, spec Specification[leaderboardmodels.Score]) (int64, error)
 }
+
+// SeasonCacheInvalidator is implemented by ScoreRepository decorators that cache season data.
+// Invalidate drops every cached entry of the season without a write through the repository.
+type SeasonCacheInvalidator interface {
+	Invalidate(ctx context.Context, season string)
+}
+
+// ChallengeRepository defines the interface for challenge data access operations
+type ChallengeRepository interface {
+	// Create stores a new challenge
+	Create(ctx context.Context, challenge *challengemodels.Challenge) error
+
+	// FindByID retrieves a challenge by its UUID
+	FindByID(ctx context.Context, id uuid.UUID) (*challengemodels.Challenge, error)
+
+	// FindByUser retrieves challenges where the user is the challenger or the challenged player, newest first
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]*challengemodels.Challenge, error)
+
+	// Update saves changes to an existing challenge
+	Update(ctx context.Context, challenge *challengemodels.Challenge) error
+
+	// Delete removes a challenge
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// MarkWon marks accepted, unexpired pending challenges of the challenged player in a season
+	// whose target is at most score as won; returns the number of challenges won
+	MarkWon(ctx context.Context, challengedID uuid.UUID, season string, score int64, now time.Time) (int64, error)
+
+	// ExpireOverdue closes pending challenges past their deadline: accepted ones become lost,
+	// unaccepted ones expired; returns the number of closed challenges
+	ExpireOverdue(ctx context.Context, now time.Time) (int64, error)
+}
+
+// ScoringConfigRepository defines the interface for runtime scoring rules
+type ScoringConfigRepository interface {
+	// Get retrieves a rule for a season; returns ErrRecordNotFound if the season has no such row
+	Get(ctx context.Context, key, season string) (*leaderboardmodels.ScoringConfigEntry, error)
+
+	// FindAll retrieves every rule of every season
+	FindAll(ctx context.Context) ([]*leaderboardmodels.ScoringConfigEntry, error)
+
+	// GetValidationConfig retrieves the score limits of a season; the season's own rows take precedence
+	// over rows for every season. Fields without a row are nil.
+	GetValidationConfig(ctx context.Context, season string) (*leaderboardmodels.ValidationConfig, error)
+
+	// Upsert creates or replaces a rule
+	Upsert(ctx context.Context, entry *leaderboardmodels.ScoringConfigEntry) error
+}
+
+// AchievementRepository defines the interface for score milestone badges
+type AchievementRepository interface {
+	// Award stores the badge unless the player already has it in the season; returns true if it is new
+	Award(ctx context.Context, achievement *leaderboardmodels.Achievement) (bool, error)
+
+	// FindByUserAndSeason retrieves a player's badges in a season, oldest first
+	FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) ([]*leaderboardmodels.Achievement, error)
+}
+
+// SeasonRepository defines the interface for season metadata
+type SeasonRepository interface {
+	// FindByName retrieves a season by name; returns ErrRecordNotFound if it does not exist
+	FindByName(ctx context.Context, name string) (*seasonmodels.Season, error)
+
+	// Update saves changes to an existing season
+	Update(ctx context.Context, season *seasonmodels.Season) error
+}
+
+// GameSessionRepository defines the interface for game sessions; stored sessions expire on their own
+type GameSessionRepository interface {
+	// Save creates or replaces a session that expires after ttl
+	Save(ctx context.Context, session *sessionmodels.GameSession, ttl time.Duration) error
+
+	// FindByID retrieves a session; returns ErrRecordNotFound if it does not exist or has expired
+	FindByID(ctx context.Context, id uuid.UUID) (*sessionmodels.GameSession, error)
+
+	// Delete removes a session; deleting a missing session is not an error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"fmt"
+
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
 
 	"github.com/google/uuid"
@@ -27,6 +29,10 @@ func (s *ScoreByUserIDSpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return score.UserID == s.UserID
 }
 
+func (s *ScoreByUserIDSpec) Describe() string {
+	return fmt.Sprintf("score_by_user(%s)", s.UserID)
+}
+
 // ScoreBySeasonSpec filters scores by season
 type ScoreBySeasonSpec struct {
 	BaseSpecification[leaderboardmodels.Score]
@@ -45,6 +51,10 @@ func (s *ScoreBySeasonSpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return score.Season == s.Season
 }
 
+func (s *ScoreBySeasonSpec) Describe() string {
+	return fmt.Sprintf("score_by_season(%s)", s.Season)
+}
+
 // ScoreMinValueSpec filters scores with minimum value
 type ScoreMinValueSpec struct {
 	BaseSpecification[leaderboardmodels.Score]
@@ -63,6 +73,10 @@ func (s *ScoreMinValueSpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return score.Score >= s.MinScore
 }
 
+func (s *ScoreMinValueSpec) Describe() string {
+	return fmt.Sprintf("score_min(%d)", s.MinScore)
+}
+
 // ScoreMaxValueSpec filters scores with maximum value
 type ScoreMaxValueSpec struct {
 	BaseSpecification[leaderboardmodels.Score]
@@ -81,6 +95,10 @@ func (s *ScoreMaxValueSpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return score.Score <= s.MaxScore
 }
 
+func (s *ScoreMaxValueSpec) Describe() string {
+	return fmt.Sprintf("score_max(%d)", s.MaxScore)
+}
+
 // ScoreRangeSpec filters scores within a range
 type ScoreRangeSpec struct {
 	BaseSpecification[leaderboardmodels.Score]
@@ -100,6 +118,10 @@ func (s *ScoreRangeSpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return score.Score >= s.MinScore && score.Score <= s.MaxScore
 }
 
+func (s *ScoreRangeSpec) Describe() string {
+	return fmt.Sprintf("score_range(%d, %d)", s.MinScore, s.MaxScore)
+}
+
 // ScoreTopNSpec gets top N scores
 type ScoreTopNSpec struct {
 	BaseSpecification[leaderboardmodels.Score]
@@ -118,6 +140,10 @@ func (s *ScoreTopNSpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return true // TopN doesn't affect individual entities
 }
 
+func (s *ScoreTopNSpec) Describe() string {
+	return fmt.Sprintf("score_top(%d)", s.N)
+}
+
 // ScoreOrderBySpec orders scores
 type ScoreOrderBySpec struct {
 	BaseSpecification[leaderboardmodels.Score]
@@ -141,6 +167,10 @@ func (s *ScoreOrderBySpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return true // Order doesn't affect individual entities
 }
 
+func (s *ScoreOrderBySpec) Describe() string {
+	return describeOrder("score_order", s.Field, s.Desc)
+}
+
 // ScoreLimitSpec limits results
 type ScoreLimitSpec struct {
 	BaseSpecification[leaderboardmodels.Score]
@@ -159,6 +189,10 @@ func (s *ScoreLimitSpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return true
 }
 
+func (s *ScoreLimitSpec) Describe() string {
+	return fmt.Sprintf("score_limit(%d)", s.Limit)
+}
+
 // ScoreOffsetSpec adds offset
 type ScoreOffsetSpec struct {
 	BaseSpecification[leaderboardmodels.Score]
@@ -177,6 +211,10 @@ func (s *ScoreOffsetSpec) IsSatisfiedBy(score leaderboardmodels.Score) bool {
 	return true
 }
 
+func (s *ScoreOffsetSpec) Describe() string {
+	return fmt.Sprintf("score_offset(%d)", s.Offset)
+}
+
 // Convenience builders for common queries
 
 // LeaderboardSpec - top scores for a season
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"strings"
+
 	"gorm.io/gorm"
 )
 
@@ -12,6 +14,32 @@ type Specification[T any] interface {
 
 	// IsSatisfiedBy checks if an entity satisfies this specification (optional, for in-memory filtering)
 	IsSatisfiedBy(entity T) bool
+
+	// Describe returns a human-readable description such as "score_by_season(global)"
+	// Equal descriptions mean equal queries, so it is also used as a cache key
+	Describe() string
+}
+
+// DescribeSpec описывает спецификацию, допуская nil (запрос без условий)
+func DescribeSpec[T any](spec Specification[T]) string {
+	if spec == nil {
+		return "all"
+	}
+	return spec.Describe()
+}
+
+// describeOrder описывает сортировку, например "score_order(score desc)"
+func describeOrder(name, field string, desc bool) string {
+	direction := "asc"
+	if desc {
+		direction = "desc"
+	}
+	return name + "(" + field + " " + direction + ")"
+}
+
+// describeComposite собирает описание вида "op(a, b, c)"
+func describeComposite(op string, parts []string) string {
+	return op + "(" + strings.Join(parts, ", ") + ")"
 }
 
 // BaseSpecification provides default implementation
@@ -51,6 +79,23 @@ func (s *AndSpecification[T]) IsSatisfiedBy(entity T) bool {
 	return s.left.IsSatisfiedBy(entity) && s.right.IsSatisfiedBy(entity)
 }
 
+// Describe flattens nested ANDs: And(a, b, c) is "and(a, b, c)", not "and(and(a, b), c)"
+func (s *AndSpecification[T]) Describe() string {
+	return describeComposite("and", s.operands())
+}
+
+func (s *AndSpecification[T]) operands() []string {
+	var parts []string
+	for _, spec := range []Specification[T]{s.left, s.right} {
+		if nested, ok := spec.(*AndSpecification[T]); ok {
+			parts = append(parts, nested.operands()...)
+		} else {
+			parts = append(parts, DescribeSpec(spec))
+		}
+	}
+	return parts
+}
+
 // Or combines two specifications with OR logic
 type OrSpecification[T any] struct {
 	CompositeSpecification[T]
@@ -66,19 +111,35 @@ func NewOrSpecification[T any](left, right Specification[T]) Specification[T] {
 }
 
 func (s *OrSpecification[T]) Apply(db *gorm.DB) *gorm.DB {
-	// OR requires subquery or raw SQL
-	var leftQuery, rightQuery *gorm.DB
-	leftQuery = s.left.Apply(db.Session(&gorm.Session{}))
-	rightQuery = s.right.Apply(db.Session(&gorm.Session{}))
+	// Операнды собираются в чистых сессиях (NewDB), иначе они унаследуют условия, уже наложенные на db
+	leftQuery := s.left.Apply(db.Session(&gorm.Session{NewDB: true}))
+	rightQuery := s.right.Apply(db.Session(&gorm.Session{NewDB: true}))
 
-	// Combine with OR
-	return db.Where(leftQuery).Or(rightQuery)
+	// Скобки вокруг OR сохраняют приоритет рядом с соседними AND-условиями
+	return db.Where(leftQuery.Or(rightQuery))
 }
 
 func (s *OrSpecification[T]) IsSatisfiedBy(entity T) bool {
 	return s.left.IsSatisfiedBy(entity) || s.right.IsSatisfiedBy(entity)
 }
 
+// Describe flattens nested ORs the same way as AndSpecification
+func (s *OrSpecification[T]) Describe() string {
+	return describeComposite("or", s.operands())
+}
+
+func (s *OrSpecification[T]) operands() []string {
+	var parts []string
+	for _, spec := range []Specification[T]{s.left, s.right} {
+		if nested, ok := spec.(*OrSpecification[T]); ok {
+			parts = append(parts, nested.operands()...)
+		} else {
+			parts = append(parts, DescribeSpec(spec))
+		}
+	}
+	return parts
+}
+
 // Not negates a specification
 type NotSpecification[T any] struct {
 	CompositeSpecification[T]
@@ -93,7 +154,7 @@ func NewNotSpecification[T any](spec Specification[T]) Specification[T] {
 
 func (s *NotSpecification[T]) Apply(db *gorm.DB) *gorm.DB {
 	// NOT requires wrapping in NOT()
-	subQuery := s.spec.Apply(db.Session(&gorm.Session{}))
+	subQuery := s.spec.Apply(db.Session(&gorm.Session{NewDB: true}))
 	return db.Not(subQuery)
 }
 
@@ -101,6 +162,10 @@ func (s *NotSpecification[T]) IsSatisfiedBy(entity T) bool {
 	return !s.spec.IsSatisfiedBy(entity)
 }
 
+func (s *NotSpecification[T]) Describe() string {
+	return "not(" + DescribeSpec(s.spec) + ")"
+}
+
 // Helper functions for fluent API
 func And[T any](specs ...Specification[T]) Specification[T] {
 	if len(specs) == 0 {
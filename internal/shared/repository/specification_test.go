@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	authmodels "leaderboard-service/internal/auth/models"
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newDryRunDB открывает GORM в режиме DryRun: запросы строятся, но не отправляются в базу
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	sqlDB, err := sql.Open("pgx", "postgres://localhost:5432/dryrun")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+// scoreQuerySQL returns the SELECT built for the specification and its bind variables
+func scoreQuerySQL(t *testing.T, spec Specification[leaderboardmodels.Score]) (string, []interface{}) {
+	t.Helper()
+	var scores []leaderboardmodels.Score
+	stmt := spec.Apply(newDryRunDB(t).Model(&leaderboardmodels.Score{})).Find(&scores).Statement
+	return stmt.SQL.String(), stmt.Vars
+}
+
+func TestDescribe_ScoreSpecifications(t *testing.T) {
+	userID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	tests := []struct {
+		spec     Specification[leaderboardmodels.Score]
+		expected string
+	}{
+		{NewScoreBySeasonSpec("global"), "score_by_season(global)"},
+		{NewScoreByUserIDSpec(userID), "score_by_user(550e8400-e29b-41d4-a716-446655440000)"},
+		{NewScoreRangeSpec(10, 20), "score_range(10, 20)"},
+		{NewScoreOrderBySpec("score", true), "score_order(score desc)"},
+		{And(NewScoreMinValueSpec(1000), NewScoreLimitSpec(50)), "and(score_min(1000), score_limit(50))"},
+		{
+			LeaderboardSpec("global", 10),
+			"and(score_by_season(global), score_order(score desc), score_limit(10))",
+		},
+		{
+			Or(NewScoreBySeasonSpec("a"), NewScoreBySeasonSpec("b"), Not(NewScoreMaxValueSpec(5))),
+			"or(score_by_season(a), score_by_season(b), not(score_max(5)))",
+		},
+		{
+			And(Or(NewScoreBySeasonSpec("a"), NewScoreBySeasonSpec("b")), NewScoreTopNSpec(3)),
+			"and(or(score_by_season(a), score_by_season(b)), score_top(3))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.spec.Describe())
+		})
+	}
+}
+
+func TestDescribe_UserSpecifications(t *testing.T) {
+	assert.Equal(t, "or(user_full_text(bob), user_by_email(bob))", SearchUsersSpec("bob").Describe())
+	assert.Equal(t, "or(user_by_name(bo), user_by_email(bo))", SearchUsersSpec("bo").Describe(), "short queries fall back to LIKE")
+	assert.Equal(t,
+		"and(user_by_email_domain(example.com), user_order(created_at desc))",
+		ActiveUsersSpec("example.com").Describe())
+}
+
+func TestDescribeSpec_Nil(t *testing.T) {
+	assert.Equal(t, "all", DescribeSpec[authmodels.User](nil))
+}
+
+func TestSpecification_ApplySQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  Specification[leaderboardmodels.Score]
+		where string
+		vars  []interface{}
+	}{
+		{
+			"and",
+			And(NewScoreMinValueSpec(1000), NewScoreBySeasonSpec("global")),
+			"WHERE score >= $1 AND season = $2",
+			[]interface{}{int64(1000), "global"},
+		},
+		{
+			"or",
+			Or(NewScoreBySeasonSpec("a"), NewScoreBySeasonSpec("b"), NewScoreBySeasonSpec("c")),
+			"WHERE (season = $1 OR season = $2) OR season = $3",
+			[]interface{}{"a", "b", "c"},
+		},
+		{
+			"not",
+			Not(NewScoreBySeasonSpec("a")),
+			"WHERE NOT season = $1",
+			[]interface{}{"a"},
+		},
+		{
+			"not of and",
+			Not(And(NewScoreMinValueSpec(5), NewScoreBySeasonSpec("a"))),
+			"WHERE NOT (score >= $1 AND season = $2)",
+			[]interface{}{int64(5), "a"},
+		},
+		{
+			"or after and keeps its parentheses",
+			And(NewScoreMinValueSpec(5), Or(NewScoreBySeasonSpec("a"), NewScoreBySeasonSpec("b"))),
+			"WHERE score >= $1 AND (season = $2 OR season = $3)",
+			[]interface{}{int64(5), "a", "b"},
+		},
+		{
+			"or before and keeps its parentheses",
+			And(Or(NewScoreBySeasonSpec("a"), NewScoreBySeasonSpec("b")), NewScoreMinValueSpec(5)),
+			"WHERE (season = $1 OR season = $2) AND score >= $3",
+			[]interface{}{"a", "b", int64(5)},
+		},
+		{
+			"not after and",
+			And(NewScoreMinValueSpec(5), Not(NewScoreBySeasonSpec("a"))),
+			"WHERE score >= $1 AND NOT season = $2",
+			[]interface{}{int64(5), "a"},
+		},
+		{
+			// AND binds tighter than OR, so GORM needs no parentheses here
+			"or of ands",
+			Or(And(NewScoreBySeasonSpec("a"), NewScoreMinValueSpec(5)), NewScoreBySeasonSpec("b")),
+			"WHERE season = $1 AND score >= $2 OR season = $3",
+			[]interface{}{"a", int64(5), "b"},
+		},
+		{
+			"leaderboard",
+			LeaderboardSpec("global", 10),
+			"WHERE season = $1 ORDER BY score DESC LIMIT $2",
+			[]interface{}{"global", 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, vars := scoreQuerySQL(t, tt.spec)
+
+			assert.Equal(t, `SELECT * FROM "scores" `+tt.where, query)
+			assert.Equal(t, tt.vars, vars)
+		})
+	}
+}
+
+func TestSpecification_IsSatisfiedBy(t *testing.T) {
+	userID := uuid.New()
+	score := leaderboardmodels.Score{UserID: userID, Score: 1500, Season: "global"}
+
+	tests := []struct {
+		spec Specification[leaderboardmodels.Score]
+		want bool
+	}{
+		{And(NewScoreMinValueSpec(1000), NewScoreBySeasonSpec("global")), true},
+		{And(NewScoreMinValueSpec(1000), NewScoreBySeasonSpec("winter")), false},
+		{Or(NewScoreBySeasonSpec("winter"), NewScoreByUserIDSpec(userID)), true},
+		{Or(NewScoreBySeasonSpec("winter"), NewScoreMaxValueSpec(100)), false},
+		{Not(NewScoreBySeasonSpec("winter")), true},
+		{Not(NewScoreRangeSpec(1000, 2000)), false},
+		{And(Or(NewScoreBySeasonSpec("a"), NewScoreBySeasonSpec("global")), Not(NewScoreMaxValueSpec(1000))), true},
+		// Ordering and paging do not filter single entities
+		{LeaderboardSpec("global", 1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec.Describe(), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.spec.IsSatisfiedBy(score))
+		})
+	}
+}
+
+func TestSpecification_IsSatisfiedBy_FiltersUsers(t *testing.T) {
+	users := []authmodels.User{
+		{Name: "Bobby", Email: "bobby@example.com"},
+		{Name: "Alice", Email: "bob"},
+		{Name: "Carol", Email: "carol@example.com"},
+	}
+
+	var matched []string
+	spec := SearchUsersSpec("bob")
+	for _, user := range users {
+		if spec.IsSatisfiedBy(user) {
+			matched = append(matched, user.Name)
+		}
+	}
+	assert.Equal(t, []string{"Bobby", "Alice"}, matched)
+}
+
+func TestUserFullTextSearchSpec_ApplySQL(t *testing.T) {
+	var users []authmodels.User
+	stmt := NewUserFullTextSearchSpec("Bob  O'Neil!").Apply(newDryRunDB(t).Model(&authmodels.User{})).Find(&users).Statement
+
+	assert.Equal(t, `SELECT * FROM "users" WHERE search_vector @@ to_tsquery('english', $1)`, stmt.SQL.String())
+	assert.Equal(t, []interface{}{"bob:* & o:* & neil:*"}, stmt.Vars, "tsquery operators in the input are dropped")
+}
+
+func TestUserFullTextSearchSpec_IsSatisfiedBy(t *testing.T) {
+	user := authmodels.User{Name: "Bobby Tables", Email: "bobby@school.example"}
+
+	assert.True(t, NewUserFullTextSearchSpec("bob").IsSatisfiedBy(user))
+	assert.True(t, NewUserFullTextSearchSpec("tab bob").IsSatisfiedBy(user))
+	assert.True(t, NewUserFullTextSearchSpec("school").IsSatisfiedBy(user))
+	assert.False(t, NewUserFullTextSearchSpec("obby").IsSatisfiedBy(user), "words match by prefix, not substring")
+	assert.False(t, NewUserFullTextSearchSpec("bob smith").IsSatisfiedBy(user))
+	assert.False(t, NewUserFullTextSearchSpec("!!").IsSatisfiedBy(user))
+}
+
+func TestDescribe_NotOfComposite(t *testing.T) {
+	assert.Equal(t,
+		"not(and(score_by_season(a), score_min(5)))",
+		Not(And(NewScoreBySeasonSpec("a"), NewScoreMinValueSpec(5))).Describe())
+	assert.Equal(t,
+		"or(and(score_by_season(a), score_min(5)), score_by_season(b))",
+		Or(And(NewScoreBySeasonSpec("a"), NewScoreMinValueSpec(5)), NewScoreBySeasonSpec("b")).Describe())
+}
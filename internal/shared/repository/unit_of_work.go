@@ -38,20 +38,37 @@ type GormUnitOfWork struct {
 	scoreRepo     ScoreRepository
 	userFactory   func(*database.PostgresDB) UserRepository
 	scoreFactory  func(*database.PostgresDB) ScoreRepository
+	afterCommit   []func()
 	inTransaction bool
 }
 
+// UnitOfWorkOption configures a GormUnitOfWork
+type UnitOfWorkOption func(*GormUnitOfWork)
+
+// WithAfterCommit runs fn after every successful commit. Repositories inside the
+// transaction are undecorated, so this is where caches over the same tables are dropped.
+func WithAfterCommit(fn func()) UnitOfWorkOption {
+	return func(uow *GormUnitOfWork) {
+		uow.afterCommit = append(uow.afterCommit, fn)
+	}
+}
+
 // NewUnitOfWork creates a new unit of work
 func NewUnitOfWork(
 	db *database.PostgresDB,
 	userFactory func(*database.PostgresDB) UserRepository,
 	scoreFactory func(*database.PostgresDB) ScoreRepository,
+	opts ...UnitOfWorkOption,
 ) UnitOfWork {
-	return &GormUnitOfWork{
+	uow := &GormUnitOfWork{
 		db:           db,
 		userFactory:  userFactory,
 		scoreFactory: scoreFactory,
 	}
+	for _, opt := range opts {
+		opt(uow)
+	}
+	return uow
 }
 
 // Begin starts a new transaction
@@ -91,6 +108,9 @@ func (uow *GormUnitOfWork) Commit() error {
 	if err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	for _, fn := range uow.afterCommit {
+		fn()
+	}
 	return nil
 }
 
@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	authmodels "leaderboard-service/internal/auth/models"
 
@@ -29,6 +32,10 @@ func (s *UserByIDSpec) IsSatisfiedBy(user authmodels.User) bool {
 	return user.ID == s.ID
 }
 
+func (s *UserByIDSpec) Describe() string {
+	return fmt.Sprintf("user_by_id(%s)", s.ID)
+}
+
 // UserByEmailSpec filters users by email
 type UserByEmailSpec struct {
 	BaseSpecification[authmodels.User]
@@ -47,6 +54,10 @@ func (s *UserByEmailSpec) IsSatisfiedBy(user authmodels.User) bool {
 	return user.Email == s.Email
 }
 
+func (s *UserByEmailSpec) Describe() string {
+	return fmt.Sprintf("user_by_email(%s)", s.Email)
+}
+
 // UserByNameSpec filters users by name (case-insensitive partial match)
 type UserByNameSpec struct {
 	BaseSpecification[authmodels.User]
@@ -65,6 +76,68 @@ func (s *UserByNameSpec) IsSatisfiedBy(user authmodels.User) bool {
 	return strings.Contains(strings.ToLower(user.Name), strings.ToLower(s.Name))
 }
 
+func (s *UserByNameSpec) Describe() string {
+	return fmt.Sprintf("user_by_name(%s)", s.Name)
+}
+
+// UserFullTextSearchSpec matches users whose name or email contains words starting with every query word.
+// Uses the users.search_vector GIN index (sql/migrations/013_users_search_vector.sql).
+type UserFullTextSearchSpec struct {
+	BaseSpecification[authmodels.User]
+	Query string
+}
+
+func NewUserFullTextSearchSpec(query string) Specification[authmodels.User] {
+	return &UserFullTextSearchSpec{Query: query}
+}
+
+func (s *UserFullTextSearchSpec) Apply(db *gorm.DB) *gorm.DB {
+	terms := searchTerms(s.Query)
+	if len(terms) == 0 {
+		// Запрос без букв и цифр ничего не находит, как и пустой tsquery
+		return db.Where("1 = 0")
+	}
+	// Слова берутся как префиксы (bob:*), чтобы поиск вел себя ближе к прежнему LIKE
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return db.Where("search_vector @@ to_tsquery('english', ?)", strings.Join(terms, " & "))
+}
+
+// IsSatisfiedBy approximates the tsquery without stemming: every query word must start a word of the name or email
+func (s *UserFullTextSearchSpec) IsSatisfiedBy(user authmodels.User) bool {
+	terms := searchTerms(s.Query)
+	if len(terms) == 0 {
+		return false
+	}
+	words := searchTerms(user.Name + " " + user.Email)
+	for _, term := range terms {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *UserFullTextSearchSpec) Describe() string {
+	return fmt.Sprintf("user_full_text(%s)", s.Query)
+}
+
+// searchTerms разбивает строку на слова из букв и цифр в нижнем регистре.
+// Остальные символы отбрасываются, поэтому операторы tsquery (&, |, !, :) не попадают в запрос
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
 // UserByEmailDomainSpec filters users by email domain
 type UserByEmailDomainSpec struct {
 	BaseSpecification[authmodels.User]
@@ -83,6 +156,10 @@ func (s *UserByEmailDomainSpec) IsSatisfiedBy(user authmodels.User) bool {
 	return strings.HasSuffix(user.Email, "@"+s.Domain)
 }
 
+func (s *UserByEmailDomainSpec) Describe() string {
+	return fmt.Sprintf("user_by_email_domain(%s)", s.Domain)
+}
+
 // UserCreatedAfterSpec filters users created after a specific time
 type UserCreatedAfterSpec struct {
 	BaseSpecification[authmodels.User]
@@ -101,6 +178,10 @@ func (s *UserCreatedAfterSpec) IsSatisfiedBy(user authmodels.User) bool {
 	return user.CreatedAt.String() > s.After
 }
 
+func (s *UserCreatedAfterSpec) Describe() string {
+	return fmt.Sprintf("user_created_after(%s)", s.After)
+}
+
 // UserLimitSpec limits the number of results
 type UserLimitSpec struct {
 	BaseSpecification[authmodels.User]
@@ -119,6 +200,10 @@ func (s *UserLimitSpec) IsSatisfiedBy(user authmodels.User) bool {
 	return true // Limit doesn't affect individual entities
 }
 
+func (s *UserLimitSpec) Describe() string {
+	return fmt.Sprintf("user_limit(%d)", s.Limit)
+}
+
 // UserOrderBySpec orders results
 type UserOrderBySpec struct {
 	BaseSpecification[authmodels.User]
@@ -142,6 +227,10 @@ func (s *UserOrderBySpec) IsSatisfiedBy(user authmodels.User) bool {
 	return true // Order doesn't affect individual entities
 }
 
+func (s *UserOrderBySpec) Describe() string {
+	return describeOrder("user_order", s.Field, s.Desc)
+}
+
 // Convenience builders for common queries
 
 // ActiveUsersSpec - example of composed specification
@@ -162,10 +251,21 @@ func RecentUsersSpec(after string, limit int) Specification[authmodels.User] {
 	)
 }
 
-// SearchUsersSpec - search by name or email
+// minFullTextQueryLength is the shortest query SearchUsersSpec sends to the full-text index.
+// Shorter queries match too many prefixes to benefit from it and use LIKE instead.
+const minFullTextQueryLength = 3
+
+// SearchUsersSpec - search by name or email.
+// Queries of minFullTextQueryLength or more characters use the full-text index; an exact email always matches.
 func SearchUsersSpec(query string) Specification[authmodels.User] {
+	if utf8.RuneCountInString(strings.TrimSpace(query)) < minFullTextQueryLength {
+		return Or(
+			NewUserByNameSpec(query),
+			NewUserByEmailSpec(query),
+		)
+	}
 	return Or(
-		NewUserByNameSpec(query),
+		NewUserFullTextSearchSpec(query),
 		NewUserByEmailSpec(query),
 	)
 }
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type correlationIDKey struct{}
+
+// WithCorrelationID сохраняет ID корреляции в контексте, чтобы слои ниже могли добавить его в логи
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext возвращает ID корреляции или пустую строку, если его нет
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
+
+// LoggerFromContext возвращает глобальный логгер с полем correlation_id, если ID есть в контексте
+func LoggerFromContext(ctx context.Context) *zerolog.Logger {
+	correlationID := CorrelationIDFromContext(ctx)
+	if correlationID == "" {
+		return &log.Logger
+	}
+	logger := log.With().Str("correlation_id", correlationID).Logger()
+	return &logger
+}
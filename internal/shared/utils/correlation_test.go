@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationIDFromContext(t *testing.T) {
+	assert.Empty(t, CorrelationIDFromContext(context.Background()))
+
+	ctx := WithCorrelationID(context.Background(), "abc-123")
+	assert.Equal(t, "abc-123", CorrelationIDFromContext(ctx))
+}
+
+func TestLoggerFromContext_AddsCorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = previous })
+
+	LoggerFromContext(WithCorrelationID(context.Background(), "abc-123")).Info().Msg("with id")
+	assert.Contains(t, buf.String(), `"correlation_id":"abc-123"`)
+
+	buf.Reset()
+	LoggerFromContext(context.Background()).Info().Msg("without id")
+	assert.NotContains(t, buf.String(), "correlation_id")
+}
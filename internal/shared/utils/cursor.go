@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CursorPosition - последняя запись страницы, после которой продолжается keyset-выдача.
+// Offset - число строк лидерборда до этой записи включительно: при равных счетах ранг
+// отстает от позиции, и без него ни номер страницы, ни RANK/ROW_NUMBER следующей
+// страницы не восстановить. UserID разрешает полные совпадения (score, timestamp)
+type CursorPosition struct {
+	Rank      int       `json:"rank"`
+	Offset    int       `json:"offset"`
+	Score     int64     `json:"score"`
+	Timestamp time.Time `json:"ts"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// CursorPaginationHelper кодирует и декодирует непрозрачные курсоры лидерборда.
+// Курсор - base64 (URL-safe) от JSON {"rank":5,"score":1200,"ts":"2024-01-01T00:00:00Z"};
+// курсор keyset-выдачи (EncodePosition) дополнительно несет "offset" и "user_id"
+type CursorPaginationHelper struct{}
+
+// NewCursorPaginationHelper создает helper для курсоров
+func NewCursorPaginationHelper() *CursorPaginationHelper {
+	return &CursorPaginationHelper{}
+}
+
+// cursorPayload - JSON курсора; offset и user_id есть только у курсоров позиции
+type cursorPayload struct {
+	Rank      int        `json:"rank"`
+	Offset    int        `json:"offset,omitempty"`
+	Score     int64      `json:"score"`
+	Timestamp time.Time  `json:"ts"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+}
+
+// EncodeCursor кодирует ранг, счет и время записи в курсор
+func (h *CursorPaginationHelper) EncodeCursor(rank int, score int64, ts time.Time) string {
+	return encodeCursor(cursorPayload{Rank: rank, Score: score, Timestamp: ts})
+}
+
+// DecodeCursor декодирует курсор EncodeCursor (или EncodePosition) и проверяет его содержимое.
+// Ранг должен быть >= 1, счет не может быть отрицательным (0 - допустимый счет)
+func (h *CursorPaginationHelper) DecodeCursor(s string) (rank, score int64, ts time.Time, err error) {
+	payload, err := decodeCursor(s)
+	if err != nil {
+		return 0, 0, time.Time{}, err
+	}
+	return int64(payload.Rank), payload.Score, payload.Timestamp, nil
+}
+
+// EncodePosition кодирует позицию последней записи страницы в курсор keyset-выдачи
+func (h *CursorPaginationHelper) EncodePosition(pos CursorPosition) string {
+	userID := pos.UserID
+	return encodeCursor(cursorPayload{
+		Rank:      pos.Rank,
+		Offset:    pos.Offset,
+		Score:     pos.Score,
+		Timestamp: pos.Timestamp,
+		UserID:    &userID,
+	})
+}
+
+// DecodePosition декодирует курсор EncodePosition. Кроме проверок DecodeCursor, offset
+// не может быть меньше ранга, а user_id обязателен: курсоры EncodeCursor здесь отклоняются
+func (h *CursorPaginationHelper) DecodePosition(s string) (CursorPosition, error) {
+	payload, err := decodeCursor(s)
+	if err != nil {
+		return CursorPosition{}, err
+	}
+	if payload.Offset < payload.Rank {
+		return CursorPosition{}, fmt.Errorf("invalid cursor offset: %d", payload.Offset)
+	}
+	if payload.UserID == nil || *payload.UserID == uuid.Nil {
+		return CursorPosition{}, fmt.Errorf("invalid cursor user")
+	}
+	return CursorPosition{
+		Rank:      payload.Rank,
+		Offset:    payload.Offset,
+		Score:     payload.Score,
+		Timestamp: payload.Timestamp,
+		UserID:    *payload.UserID,
+	}, nil
+}
+
+func encodeCursor(payload cursorPayload) string {
+	payload.Timestamp = payload.Timestamp.UTC()
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor разбирает курсор и проверяет общие для обоих видов поля
+func decodeCursor(s string) (cursorPayload, error) {
+	if s == "" {
+		return cursorPayload{}, fmt.Errorf("cursor is empty")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursorPayload{}, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return cursorPayload{}, fmt.Errorf("invalid cursor payload: %w", err)
+	}
+
+	if payload.Rank < 1 {
+		return cursorPayload{}, fmt.Errorf("invalid cursor rank: %d", payload.Rank)
+	}
+	if payload.Score < 0 {
+		return cursorPayload{}, fmt.Errorf("invalid cursor score: %d", payload.Score)
+	}
+	if payload.Timestamp.IsZero() {
+		return cursorPayload{}, fmt.Errorf("invalid cursor timestamp")
+	}
+	return payload, nil
+}
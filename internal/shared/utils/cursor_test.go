@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cursorUserID = uuid.MustParse("8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00")
+
+func TestCursorPaginationHelper_RoundTrip(t *testing.T) {
+	helper := NewCursorPaginationHelper()
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	cursor := helper.EncodeCursor(5, 1200, ts)
+	require.NotEmpty(t, cursor)
+
+	rank, score, decodedTS, err := helper.DecodeCursor(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), rank)
+	assert.Equal(t, int64(1200), score)
+	assert.True(t, ts.Equal(decodedTS))
+}
+
+func TestCursorPaginationHelper_EncodeFormat(t *testing.T) {
+	helper := NewCursorPaginationHelper()
+
+	raw, err := base64.RawURLEncoding.DecodeString(helper.EncodeCursor(5, 1200, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rank":5,"score":1200,"ts":"2024-01-01T00:00:00Z"}`, string(raw))
+}
+
+func TestCursorPaginationHelper_PositionRoundTrip(t *testing.T) {
+	helper := NewCursorPaginationHelper()
+	pos := CursorPosition{
+		Rank:      5,
+		Offset:    7,
+		Score:     1200,
+		Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UserID:    cursorUserID,
+	}
+
+	cursor := helper.EncodePosition(pos)
+	require.NotEmpty(t, cursor)
+
+	decoded, err := helper.DecodePosition(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, 5, decoded.Rank)
+	assert.Equal(t, 7, decoded.Offset)
+	assert.Equal(t, int64(1200), decoded.Score)
+	assert.True(t, pos.Timestamp.Equal(decoded.Timestamp))
+	assert.Equal(t, cursorUserID, decoded.UserID)
+}
+
+func TestCursorPaginationHelper_PositionEncodeFormat(t *testing.T) {
+	helper := NewCursorPaginationHelper()
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	raw, err := base64.RawURLEncoding.DecodeString(helper.EncodePosition(CursorPosition{
+		Rank: 5, Offset: 7, Score: 1200, Timestamp: ts, UserID: cursorUserID,
+	}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rank":5,"offset":7,"score":1200,"ts":"2024-01-01T00:00:00Z","user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`, string(raw))
+}
+
+func TestCursorPaginationHelper_ZeroScore(t *testing.T) {
+	helper := NewCursorPaginationHelper()
+
+	_, score, _, err := helper.DecodeCursor(helper.EncodeCursor(100, 0, time.Now()))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), score)
+
+	pos, err := helper.DecodePosition(helper.EncodePosition(CursorPosition{
+		Rank: 100, Offset: 100, Score: 0, Timestamp: time.Now(), UserID: cursorUserID,
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), pos.Score)
+}
+
+func TestCursorPaginationHelper_PositionNeedsOffsetAndUser(t *testing.T) {
+	helper := NewCursorPaginationHelper()
+
+	// A rank/score cursor decodes as such, but cannot resume keyset pagination
+	cursor := helper.EncodeCursor(5, 1200, time.Now())
+	_, _, _, err := helper.DecodeCursor(cursor)
+	require.NoError(t, err)
+	_, err = helper.DecodePosition(cursor)
+	assert.Error(t, err)
+
+	// Every position cursor is also a valid rank/score cursor
+	rank, score, _, err := helper.DecodeCursor(helper.EncodePosition(CursorPosition{
+		Rank: 5, Offset: 7, Score: 1200, Timestamp: time.Now(), UserID: cursorUserID,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), rank)
+	assert.Equal(t, int64(1200), score)
+}
+
+func TestCursorPaginationHelper_DecodeMalformed(t *testing.T) {
+	helper := NewCursorPaginationHelper()
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	// Malformed for both decoders
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"empty string", ""},
+		{"legacy plain cursor", "5:1200"},
+		{"not base64", "!!!@@@"},
+		{"base64 of non-JSON", encode("hello")},
+		{"JSON array", encode(`[1,2,3]`)},
+		{"wrong field types", encode(`{"rank":"5","offset":5,"score":1200,"ts":"2024-01-01T00:00:00Z","user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`)},
+		{"fractional rank", encode(`{"rank":1.5,"offset":5,"score":1200,"ts":"2024-01-01T00:00:00Z","user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`)},
+		{"zero rank", encode(`{"rank":0,"offset":5,"score":1200,"ts":"2024-01-01T00:00:00Z","user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`)},
+		{"negative rank", encode(`{"rank":-3,"offset":5,"score":1200,"ts":"2024-01-01T00:00:00Z","user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`)},
+		{"negative score", encode(`{"rank":5,"offset":5,"score":-1,"ts":"2024-01-01T00:00:00Z","user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`)},
+		{"missing timestamp", encode(`{"rank":5,"offset":5,"score":1200,"user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`)},
+		{"bad timestamp", encode(`{"rank":5,"offset":5,"score":1200,"ts":"yesterday","user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`)},
+		{"empty object", encode(`{}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := helper.DecodeCursor(tt.cursor)
+			assert.Error(t, err)
+			_, err = helper.DecodePosition(tt.cursor)
+			assert.Error(t, err)
+		})
+	}
+
+	// Malformed only as keyset positions
+	for name, cursor := range map[string]string{
+		"offset before rank":    encode(`{"rank":5,"offset":4,"score":1200,"ts":"2024-01-01T00:00:00Z","user_id":"8f14e45f-ceea-467f-a0e6-9c0b3c2d1a00"}`),
+		"missing user":          encode(`{"rank":5,"offset":5,"score":1200,"ts":"2024-01-01T00:00:00Z"}`),
+		"nil user":              encode(`{"rank":5,"offset":5,"score":1200,"ts":"2024-01-01T00:00:00Z","user_id":"00000000-0000-0000-0000-000000000000"}`),
+		"cursor without offset": encode(`{"rank":5,"score":1200,"ts":"2024-01-01T00:00:00Z"}`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := helper.DecodePosition(cursor)
+			assert.Error(t, err)
+		})
+	}
+}
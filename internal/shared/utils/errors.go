@@ -32,6 +32,7 @@ const (
 	ErrCodeUnauthorized       = "UNAUTHORIZED"
 	ErrCodeForbidden          = "FORBIDDEN"
 	ErrCodeConflict           = "CONFLICT"
+	ErrCodeUnprocessable      = "UNPROCESSABLE_ENTITY"
 	ErrCodeInternalError      = "INTERNAL_ERROR"
 	ErrCodeValidation         = "VALIDATION_ERROR"
 	ErrCodeDatabaseError      = "DATABASE_ERROR"
@@ -102,6 +103,16 @@ func Conflict(message string, err error) *AppError {
 	}
 }
 
+// Unprocessable создает ошибку "запрос понятен, но не может быть выполнен"
+func Unprocessable(message string, err error) *AppError {
+	return &AppError{
+		Code:       ErrCodeUnprocessable,
+		Message:    message,
+		StatusCode: http.StatusUnprocessableEntity,
+		Err:        err,
+	}
+}
+
 // InternalError создает ошибку "внутренняя ошибка сервера"
 func InternalError(message string, err error) *AppError {
 	return &AppError{
@@ -67,9 +67,12 @@ func ParsePaginationParams(pageStr, pageSizeStr string) *PaginationParams {
 	return NewPaginationParams(page, pageSize)
 }
 
-// NewPaginationMeta создает метаданные пагинации
+// NewPaginationMeta создает метаданные пагинации; при pageSize < 1 страниц нет
 func NewPaginationMeta(page, pageSize int, totalCount int64) PaginationMeta {
-	totalPages := int(math.Ceil(float64(totalCount) / float64(pageSize)))
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = int(math.Ceil(float64(totalCount) / float64(pageSize)))
+	}
 
 	return PaginationMeta{
 		Page:       page,
@@ -180,3 +180,9 @@ func TestPaginationEdgeCases(t *testing.T) {
 		assert.False(t, meta.HasNext)
 	})
 }
+
+func TestNewPaginationMeta_ZeroPageSize(t *testing.T) {
+	meta := NewPaginationMeta(1, 0, 10)
+	assert.Equal(t, 0, meta.TotalPages)
+	assert.False(t, meta.HasNext)
+}
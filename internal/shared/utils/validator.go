@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
@@ -9,8 +10,8 @@ import (
 
 // FieldError представляет ошибку валидации поля
 type FieldError struct {
-	Field   string
-	Message string
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func (e *FieldError) Error() string {
@@ -154,6 +155,24 @@ func (v *Validator) Username(field, value string) *Validator {
 	return v
 }
 
+// NoSpecialChars проверяет, что строка состоит только из латинских букв, цифр, пробела, '_', '-', '.'
+// и символов из allowedExtra. Отсекает разметку, кавычки и управляющие символы в отображаемых именах
+func (v *Validator) NoSpecialChars(field, value string, allowedExtra string) *Validator {
+	for _, char := range value {
+		switch {
+		case char < unicode.MaxASCII && (unicode.IsLetter(char) || unicode.IsDigit(char)):
+		case strings.ContainsRune(" _-.", char), strings.ContainsRune(allowedExtra, char):
+		default:
+			v.errors = append(v.errors, FieldError{
+				Field:   field,
+				Message: "must contain only letters, numbers, spaces, underscores, hyphens and dots",
+			})
+			return v
+		}
+	}
+	return v
+}
+
 // Password проверяет сложность пароля
 func (v *Validator) Password(field, value string, minLength int) *Validator {
 	if len(value) < minLength {
@@ -210,6 +229,38 @@ func (v *Validator) Season(field, value string) *Validator {
 	return v
 }
 
+// CountryCode проверяет двухбуквенный код страны ISO 3166-1 alpha-2 (регистр не важен)
+func (v *Validator) CountryCode(field, value string) *Validator {
+	countryRegex := regexp.MustCompile(`^[a-zA-Z]{2}$`)
+	if !countryRegex.MatchString(value) {
+		v.errors = append(v.errors, FieldError{
+			Field:   field,
+			Message: "must be a two-letter ISO 3166-1 country code",
+		})
+	}
+	return v
+}
+
+// JSONSize проверяет, что value в JSON занимает не больше maxBytes байт.
+// Значение, которое не сериализуется в JSON, тоже считается ошибкой
+func (v *Validator) JSONSize(field string, value interface{}, maxBytes int) *Validator {
+	data, err := json.Marshal(value)
+	if err != nil {
+		v.errors = append(v.errors, FieldError{
+			Field:   field,
+			Message: "must be serializable to JSON",
+		})
+		return v
+	}
+	if len(data) > maxBytes {
+		v.errors = append(v.errors, FieldError{
+			Field:   field,
+			Message: fmt.Sprintf("must not exceed %d bytes as JSON (got %d)", maxBytes, len(data)),
+		})
+	}
+	return v
+}
+
 // OneOf проверяет, что значение есть в списке допустимых
 func (v *Validator) OneOf(field, value string, allowed []string) *Validator {
 	for _, a := range allowed {
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -116,6 +117,64 @@ func TestValidator_Email(t *testing.T) {
 	}
 }
 
+func TestValidator_NoSpecialChars(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		allowedExtra string
+		wantError    bool
+	}{
+		{"Letters and spaces", "John Doe", "", false},
+		{"Allowed punctuation", "j.doe_99-x", "", false},
+		{"Empty", "", "", false},
+		{"Script tag", "<script>", "", true},
+		{"SQL quote", "'; DROP TABLE users; --", "", true},
+		{"Encoded null byte", "%00", "", true},
+		{"Non-ASCII letter", "Jürgen", "", true},
+		{"Apostrophe allowed extra", "O'Brien", "'", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator()
+			v.NoSpecialChars("field", tt.value, tt.allowedExtra)
+
+			assert.Equal(t, tt.wantError, v.errors.HasErrors())
+		})
+	}
+}
+
+func TestValidator_JSONSize(t *testing.T) {
+	// {"k":"<n chars>"} encodes to 8+n bytes
+	metadata := func(n int) map[string]interface{} {
+		return map[string]interface{}{"k": strings.Repeat("x", n)}
+	}
+
+	tests := []struct {
+		name      string
+		value     interface{}
+		maxBytes  int
+		wantError bool
+	}{
+		{"Below limit", metadata(10), 32, false},
+		{"Exactly at limit", metadata(24), 32, false},
+		{"One byte over limit", metadata(25), 32, true},
+		{"Nil encodes as null", nil, 4, false},
+		{"Nil over tiny limit", nil, 3, true},
+		{"Empty map", map[string]interface{}{}, 2, false},
+		{"Not serializable", map[string]interface{}{"ch": make(chan int)}, 4096, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator()
+			v.JSONSize("metadata", tt.value, tt.maxBytes)
+
+			assert.Equal(t, tt.wantError, v.errors.HasErrors())
+		})
+	}
+}
+
 func TestValidator_Chaining(t *testing.T) {
 	v := NewValidator()
 	v.Required("name", "John").
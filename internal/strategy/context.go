@@ -2,6 +2,8 @@ package strategy
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
@@ -220,6 +222,22 @@ func (m *LeaderboardManager) GetLeaderboard(scores []*leaderboardmodels.Score) [
 	return ranked
 }
 
+// SortScores применяет только стратегию сортировки (без фильтрации и ранжирования)
+func (m *LeaderboardManager) SortScores(scores []*leaderboardmodels.Score) []*leaderboardmodels.Score {
+	if m.sortStrategy == nil {
+		return scores
+	}
+	return m.sortStrategy.Sort(scores)
+}
+
+// GetSortStrategyName возвращает название текущей стратегии сортировки
+func (m *LeaderboardManager) GetSortStrategyName() string {
+	if m.sortStrategy == nil {
+		return "None"
+	}
+	return m.sortStrategy.Name()
+}
+
 // SetRankingStrategy устанавливает стратегию ранжирования
 func (m *LeaderboardManager) SetRankingStrategy(strategy RankingStrategy) {
 	m.rankingStrategy = strategy
@@ -262,7 +280,12 @@ func (f *StrategyFactory) CreateScoringStrategy(name string) ScoringStrategy {
 }
 
 // CreateRankingStrategy создает стратегию ранжирования по имени
+// Имя вида "dense+ordinal" создает CompositeRankingStrategy: ничьи первой стратегии разбивает вторая
 func (f *StrategyFactory) CreateRankingStrategy(name string) RankingStrategy {
+	if primary, tiebreaker, ok := strings.Cut(name, "+"); ok {
+		return NewCompositeRankingStrategy(f.CreateRankingStrategy(primary), f.CreateRankingStrategy(tiebreaker))
+	}
+
 	switch name {
 	case "standard":
 		return NewStandardRankingStrategy()
@@ -283,6 +306,29 @@ func (f *StrategyFactory) CreateRankingStrategy(name string) RankingStrategy {
 	}
 }
 
+// CreateSortStrategy создает стратегию сортировки по имени. "score_desc_name_asc" (и стратегия
+// по умолчанию) не знает имен игроков и упорядочивает ничьи по UserID; см. CreateSortStrategyWithNames
+func (f *StrategyFactory) CreateSortStrategy(name string) SortStrategy {
+	return f.CreateSortStrategyWithNames(name, nil)
+}
+
+// CreateSortStrategyWithNames - CreateSortStrategy с именами игроков по UserID,
+// по которым "score_desc_name_asc" разрешает ничьи
+func (f *StrategyFactory) CreateSortStrategyWithNames(name string, names map[uuid.UUID]string) SortStrategy {
+	switch name {
+	case "score_desc_name_asc":
+		return NewScoreDescNameAscSortStrategy(names)
+	case "score_asc":
+		return NewScoreAscSortStrategy()
+	case "timestamp_asc":
+		return NewTimestampSortStrategy(true)
+	case "timestamp_desc":
+		return NewTimestampSortStrategy(false)
+	default:
+		return NewScoreDescNameAscSortStrategy(names)
+	}
+}
+
 // StrategyRegistry - реестр стратегий (для динамического выбора)
 type StrategyRegistry struct {
 	scoringStrategies map[string]ScoringStrategy
@@ -313,12 +359,50 @@ func (r *StrategyRegistry) GetScoringStrategy(name string) (ScoringStrategy, boo
 	return strategy, ok
 }
 
+// ListScoringStrategies возвращает имена зарегистрированных стратегий подсчета по алфавиту
+func (r *StrategyRegistry) ListScoringStrategies() []string {
+	return sortedKeys(r.scoringStrategies)
+}
+
+// ListRankingStrategies возвращает имена зарегистрированных стратегий ранжирования по алфавиту
+func (r *StrategyRegistry) ListRankingStrategies() []string {
+	return sortedKeys(r.rankingStrategies)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // GetRankingStrategy получает стратегию ранжирования по имени
 func (r *StrategyRegistry) GetRankingStrategy(name string) (RankingStrategy, bool) {
 	strategy, ok := r.rankingStrategies[name]
 	return strategy, ok
 }
 
+// ScoringStrategyNames - имена стратегий подсчета, которые создает StrategyFactory
+var ScoringStrategyNames = []string{"simple", "weighted", "bonus", "multiplayer", "percentage"}
+
+// RankingStrategyNames - имена стратегий ранжирования StrategyFactory (без составных "a+b")
+var RankingStrategyNames = []string{"standard", "dense", "competition", "modified", "ordinal", "percentile", "fractional"}
+
+// NewDefaultStrategyRegistry создает реестр со всеми стратегиями фабрики под их именами
+func NewDefaultStrategyRegistry() *StrategyRegistry {
+	factory := NewStrategyFactory()
+	registry := NewStrategyRegistry()
+	for _, name := range ScoringStrategyNames {
+		registry.RegisterScoringStrategy(name, factory.CreateScoringStrategy(name))
+	}
+	for _, name := range RankingStrategyNames {
+		registry.RegisterRankingStrategy(name, factory.CreateRankingStrategy(name))
+	}
+	return registry
+}
+
 // GameSession - игровая сессия с динамическим выбором стратегий
 type GameSession struct {
 	ID                  uuid.UUID
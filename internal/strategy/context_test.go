@@ -82,3 +82,35 @@ func TestLeaderboardRanker(t *testing.T) {
 		assert.Empty(t, result)
 	})
 }
+
+func TestNewDefaultStrategyRegistry(t *testing.T) {
+	registry := NewDefaultStrategyRegistry()
+
+	for _, name := range ScoringStrategyNames {
+		_, ok := registry.GetScoringStrategy(name)
+		assert.True(t, ok, name)
+	}
+	for _, name := range RankingStrategyNames {
+		_, ok := registry.GetRankingStrategy(name)
+		assert.True(t, ok, name)
+	}
+
+	assert.Equal(t, []string{"bonus", "multiplayer", "percentage", "simple", "weighted"}, registry.ListScoringStrategies())
+	assert.Len(t, registry.ListRankingStrategies(), len(RankingStrategyNames))
+	assert.Equal(t, "competition", registry.ListRankingStrategies()[0])
+
+	session := NewGameSession(uuid.New(), "arcade", registry)
+	session.SetScoringStrategy("percentage")
+	score, err := session.CalculateScore(1000, &ScoringContext{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), score)
+}
+
+func TestStrategyRegistry_ListEmpty(t *testing.T) {
+	registry := NewStrategyRegistry()
+	assert.Empty(t, registry.ListScoringStrategies())
+	assert.Empty(t, registry.ListRankingStrategies())
+
+	registry.RegisterRankingStrategy("dense", NewDenseRankingStrategy())
+	assert.Equal(t, []string{"dense"}, registry.ListRankingStrategies())
+}
@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encryption Strategies - стратегии шифрования данных
+
+// ErrCiphertextTooShort возвращается, если шифротекст короче nonce
+var ErrCiphertextTooShort = errors.New("ciphertext too short")
+
+// AESGCMEncryptionStrategy - шифрование AES-256-GCM
+// Результат Encrypt: nonce (12 байт) + шифротекст с тегом аутентификации
+type AESGCMEncryptionStrategy struct {
+	Key [32]byte
+}
+
+func NewAESGCMEncryptionStrategy(key [32]byte) *AESGCMEncryptionStrategy {
+	return &AESGCMEncryptionStrategy{
+		Key: key,
+	}
+}
+
+func (s *AESGCMEncryptionStrategy) Encrypt(data []byte) ([]byte, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	// Новый случайный nonce на каждое шифрование: повтор nonce с тем же ключом ломает GCM
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+func (s *AESGCMEncryptionStrategy) Decrypt(data []byte) ([]byte, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return nil, ErrCiphertextTooShort
+	}
+
+	plaintext, err := gcm.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (s *AESGCMEncryptionStrategy) Name() string {
+	return "AES-256-GCM"
+}
+
+// gcm создает AEAD на основе ключа стратегии
+func (s *AESGCMEncryptionStrategy) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.Key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,58 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESGCMEncryptionStrategy(t *testing.T) {
+	var key [32]byte
+	copy(key[:], "0123456789abcdef0123456789abcdef")
+	strategy := NewAESGCMEncryptionStrategy(key)
+	assert.Equal(t, "AES-256-GCM", strategy.Name())
+
+	plaintext := []byte(`{"session_id":"abc-123","ip":"203.0.113.7"}`)
+
+	t.Run("round trip", func(t *testing.T) {
+		ciphertext, err := strategy.Encrypt(plaintext)
+		require.NoError(t, err)
+		assert.NotContains(t, string(ciphertext), "203.0.113.7")
+
+		decrypted, err := strategy.Decrypt(ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("random nonce", func(t *testing.T) {
+		first, err := strategy.Encrypt(plaintext)
+		require.NoError(t, err)
+		second, err := strategy.Encrypt(plaintext)
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		ciphertext, err := strategy.Encrypt(plaintext)
+		require.NoError(t, err)
+
+		var otherKey [32]byte
+		_, err = NewAESGCMEncryptionStrategy(otherKey).Decrypt(ciphertext)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		ciphertext, err := strategy.Encrypt(plaintext)
+		require.NoError(t, err)
+		ciphertext[len(ciphertext)-1] ^= 0xff
+
+		_, err = strategy.Decrypt(ciphertext)
+		assert.Error(t, err)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		_, err := strategy.Decrypt([]byte("short"))
+		assert.ErrorIs(t, err, ErrCiphertextTooShort)
+	})
+}
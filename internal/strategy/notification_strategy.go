@@ -0,0 +1,31 @@
+package strategy
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Notification Strategies - стратегии доставки уведомлений
+
+// LogNotificationStrategy - пишет уведомление в лог вместо доставки игроку
+// Используется, пока не подключен push/email канал
+type LogNotificationStrategy struct{}
+
+func NewLogNotificationStrategy() *LogNotificationStrategy {
+	return &LogNotificationStrategy{}
+}
+
+func (s *LogNotificationStrategy) Send(ctx context.Context, notification *Notification) error {
+	log.Info().
+		Str("user_id", notification.UserID.String()).
+		Str("type", notification.Type).
+		Str("title", notification.Title).
+		Interface("data", notification.Data).
+		Msg(notification.Message)
+	return nil
+}
+
+func (s *LogNotificationStrategy) Name() string {
+	return "Log"
+}
@@ -7,6 +7,7 @@ import (
 )
 
 // Ranking Strategies - различные стратегии ранжирования
+// Сортировка стабильная: при равных счетах сохраняется порядок, заданный SortStrategy
 
 // StandardRankingStrategy - стандартная стратегия ранжирования (1, 2, 3, 4, ...)
 // Каждый игрок получает уникальный ранг
@@ -24,7 +25,7 @@ func (s *StandardRankingStrategy) CalculateRanks(scores []*leaderboardmodels.Sco
 	// Сортируем по убыванию счета
 	sorted := make([]*leaderboardmodels.Score, len(scores))
 	copy(sorted, scores)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].Score > sorted[j].Score
 	})
 
@@ -62,7 +63,7 @@ func (s *DenseRankingStrategy) CalculateRanks(scores []*leaderboardmodels.Score)
 	// Сортируем по убыванию счета
 	sorted := make([]*leaderboardmodels.Score, len(scores))
 	copy(sorted, scores)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].Score > sorted[j].Score
 	})
 
@@ -106,7 +107,7 @@ func (s *CompetitionRankingStrategy) CalculateRanks(scores []*leaderboardmodels.
 	// Сортируем по убыванию счета
 	sorted := make([]*leaderboardmodels.Score, len(scores))
 	copy(sorted, scores)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].Score > sorted[j].Score
 	})
 
@@ -150,7 +151,7 @@ func (s *ModifiedCompetitionRankingStrategy) CalculateRanks(scores []*leaderboar
 	// Сортируем по убыванию счета
 	sorted := make([]*leaderboardmodels.Score, len(scores))
 	copy(sorted, scores)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].Score > sorted[j].Score
 	})
 
@@ -204,7 +205,7 @@ func (s *OrdinalRankingStrategy) CalculateRanks(scores []*leaderboardmodels.Scor
 	// Сортируем по счету (убывание), затем по времени (возрастание)
 	sorted := make([]*leaderboardmodels.Score, len(scores))
 	copy(sorted, scores)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		if sorted[i].Score != sorted[j].Score {
 			return sorted[i].Score > sorted[j].Score
 		}
@@ -244,7 +245,7 @@ func (s *PercentileRankingStrategy) CalculateRanks(scores []*leaderboardmodels.S
 	// Сортируем по убыванию счета
 	sorted := make([]*leaderboardmodels.Score, len(scores))
 	copy(sorted, scores)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].Score > sorted[j].Score
 	})
 
@@ -286,7 +287,7 @@ func (s *FractionalRankingStrategy) CalculateRanks(scores []*leaderboardmodels.S
 	// Сортируем по убыванию счета
 	sorted := make([]*leaderboardmodels.Score, len(scores))
 	copy(sorted, scores)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].Score > sorted[j].Score
 	})
 
@@ -327,3 +328,84 @@ func (s *FractionalRankingStrategy) CalculateRanks(scores []*leaderboardmodels.S
 func (s *FractionalRankingStrategy) Name() string {
 	return "Fractional"
 }
+
+// CompositeRankingStrategy - составная стратегия: Primary задает ранги, а группы игроков
+// с одинаковым рангом заново ранжируются стратегией Tiebreaker
+// Например, Dense + Ordinal: 1, 2, 2, 3 превращается в 1, 2, 3, 4, где равные счета
+// упорядочены по времени, а при равном времени - порядком SortStrategy (например, по имени)
+// Рассчитана на возрастающие ранги (Percentile в качестве Primary не подходит)
+type CompositeRankingStrategy struct {
+	Primary    RankingStrategy
+	Tiebreaker RankingStrategy
+}
+
+func NewCompositeRankingStrategy(primary, tiebreaker RankingStrategy) *CompositeRankingStrategy {
+	return &CompositeRankingStrategy{
+		Primary:    primary,
+		Tiebreaker: tiebreaker,
+	}
+}
+
+func (s *CompositeRankingStrategy) CalculateRanks(scores []*leaderboardmodels.Score) []*RankedScore {
+	primary := s.Primary.CalculateRanks(scores)
+	if s.Tiebreaker == nil || len(primary) == 0 {
+		return primary
+	}
+
+	ranked := make([]*RankedScore, 0, len(primary))
+	// offset сдвигает последующие ранги, если Primary не оставил места под разбитую ничью (Dense)
+	offset := 0
+	lastRank := 0
+
+	i := 0
+	for i < len(primary) {
+		// Находим группу с одинаковым рангом Primary
+		tieStart := i
+		tieEnd := i
+		for tieEnd < len(primary) && primary[tieEnd].Rank == primary[tieStart].Rank {
+			tieEnd++
+		}
+
+		base := primary[tieStart].Rank + offset
+		if base <= lastRank {
+			offset += lastRank + 1 - base
+			base = lastRank + 1
+		}
+
+		if tieEnd-tieStart == 1 {
+			ranked = append(ranked, &RankedScore{Score: primary[tieStart].Score, Rank: base})
+			lastRank = base
+			i = tieEnd
+			continue
+		}
+
+		group := make([]*leaderboardmodels.Score, 0, tieEnd-tieStart)
+		for _, entry := range primary[tieStart:tieEnd] {
+			group = append(group, entry.Score)
+		}
+
+		// Ранги Tiebreaker внутри группы начинаются с 1; ничьи, которые он не разбил, сохраняются
+		for j, entry := range s.Tiebreaker.CalculateRanks(group) {
+			rank := base + entry.Rank - 1
+			ranked = append(ranked, &RankedScore{
+				Score:        entry.Score,
+				Rank:         rank,
+				TiedWithPrev: j > 0 && entry.TiedWithPrev,
+			})
+			if rank > lastRank {
+				lastRank = rank
+			}
+		}
+
+		i = tieEnd
+	}
+
+	return ranked
+}
+
+func (s *CompositeRankingStrategy) Name() string {
+	if s.Tiebreaker == nil {
+		return s.Primary.Name()
+	}
+	return s.Primary.Name() + "+" + s.Tiebreaker.Name()
+}
@@ -2,6 +2,7 @@ package strategy
 
 import (
 	"testing"
+	"time"
 
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
 
@@ -146,3 +147,160 @@ func TestCompetitionRankingStrategy_AllSameScores(t *testing.T) {
 	assert.True(t, ranked[1].TiedWithPrev)
 	assert.True(t, ranked[2].TiedWithPrev)
 }
+
+func TestFractionalRankingStrategy_ThreeWayTieAveragesRanks(t *testing.T) {
+	strategy := NewFractionalRankingStrategy()
+
+	scores := []*leaderboardmodels.Score{
+		{ID: uuid.New(), UserID: uuid.New(), Score: 1000},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 900}, // Ranks 2, 3, 4 tied
+		{ID: uuid.New(), UserID: uuid.New(), Score: 900},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 900},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 800},
+	}
+
+	ranked := strategy.CalculateRanks(scores)
+
+	assert.Equal(t, 5, len(ranked))
+	assert.Equal(t, 1, ranked[0].Rank)
+
+	// (2 + 3 + 4) / 3 = 3
+	for _, r := range ranked[1:4] {
+		assert.Equal(t, 3, r.Rank)
+		assert.Equal(t, int64(900), r.Score.Score)
+	}
+	assert.False(t, ranked[1].TiedWithPrev)
+	assert.True(t, ranked[2].TiedWithPrev)
+	assert.True(t, ranked[3].TiedWithPrev)
+
+	assert.Equal(t, 5, ranked[4].Rank)
+	assert.False(t, ranked[4].TiedWithPrev)
+}
+
+func TestFractionalRankingStrategy_TwoWayTieRoundsDown(t *testing.T) {
+	strategy := NewFractionalRankingStrategy()
+
+	scores := []*leaderboardmodels.Score{
+		{ID: uuid.New(), UserID: uuid.New(), Score: 1000},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 900}, // Ranks 2, 3 tied
+		{ID: uuid.New(), UserID: uuid.New(), Score: 900},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 800},
+	}
+
+	ranked := strategy.CalculateRanks(scores)
+
+	assert.Equal(t, 4, len(ranked))
+	assert.Equal(t, 1, ranked[0].Rank)
+	// (2 + 3) / 2 = 2.5, truncated by integer division
+	assert.Equal(t, 2, ranked[1].Rank)
+	assert.Equal(t, 2, ranked[2].Rank)
+	assert.True(t, ranked[2].TiedWithPrev)
+	assert.Equal(t, 4, ranked[3].Rank)
+}
+
+func TestFractionalRankingStrategy_EmptyList(t *testing.T) {
+	strategy := NewFractionalRankingStrategy()
+	ranked := strategy.CalculateRanks([]*leaderboardmodels.Score{})
+
+	assert.Equal(t, 0, len(ranked))
+}
+
+func TestFractionalRankingStrategy_Name(t *testing.T) {
+	strategy := NewFractionalRankingStrategy()
+	assert.Equal(t, "Fractional", strategy.Name())
+}
+
+// tieScores builds a leaderboard with a three-way and a two-way tie; within a tie, earlier timestamps come later in the input
+func tieScores() []*leaderboardmodels.Score {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	return []*leaderboardmodels.Score{
+		{ID: uuid.New(), UserID: uuid.New(), Score: 1000, Timestamp: base},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 900, Timestamp: base.Add(3 * time.Minute)},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 900, Timestamp: base.Add(1 * time.Minute)},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 900, Timestamp: base.Add(2 * time.Minute)},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 800, Timestamp: base.Add(5 * time.Minute)},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 800, Timestamp: base.Add(4 * time.Minute)},
+		{ID: uuid.New(), UserID: uuid.New(), Score: 700, Timestamp: base},
+	}
+}
+
+func TestCompositeRankingStrategy_BreaksMultiWayTies(t *testing.T) {
+	scores := tieScores()
+
+	for _, primary := range []RankingStrategy{NewDenseRankingStrategy(), NewCompetitionRankingStrategy()} {
+		t.Run(primary.Name(), func(t *testing.T) {
+			ranked := NewCompositeRankingStrategy(primary, NewOrdinalRankingStrategy()).CalculateRanks(scores)
+
+			// Ties are ordered by timestamp; the ranks after a broken tie follow on without gaps
+			want := []*leaderboardmodels.Score{scores[0], scores[2], scores[3], scores[1], scores[5], scores[4], scores[6]}
+			assert.Equal(t, len(want), len(ranked))
+			for i, entry := range ranked {
+				assert.Equal(t, i+1, entry.Rank)
+				assert.Same(t, want[i], entry.Score)
+				assert.False(t, entry.TiedWithPrev)
+			}
+		})
+	}
+}
+
+func TestCompositeRankingStrategy_KeepsTiesTheTiebreakerCannotBreak(t *testing.T) {
+	scores := tieScores()
+
+	ranked := NewCompositeRankingStrategy(NewDenseRankingStrategy(), NewCompetitionRankingStrategy()).CalculateRanks(scores)
+
+	ranks := make([]int, len(ranked))
+	for i, entry := range ranked {
+		ranks[i] = entry.Rank
+	}
+	assert.Equal(t, []int{1, 2, 2, 2, 3, 3, 4}, ranks)
+	assert.False(t, ranked[1].TiedWithPrev)
+	assert.True(t, ranked[2].TiedWithPrev)
+	assert.True(t, ranked[3].TiedWithPrev)
+	assert.False(t, ranked[4].TiedWithPrev)
+	assert.True(t, ranked[5].TiedWithPrev)
+}
+
+func TestCompositeRankingStrategy_BreaksTiesByNameFromSortStrategy(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	carol, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	names := map[uuid.UUID]string{carol: "carol", alice: "alice", bob: "bob"}
+	scores := []*leaderboardmodels.Score{
+		{UserID: carol, Score: 500, Timestamp: at},
+		{UserID: alice, Score: 500, Timestamp: at},
+		{UserID: bob, Score: 500, Timestamp: at},
+	}
+	manager := NewLeaderboardManager(
+		NewCompositeRankingStrategy(NewDenseRankingStrategy(), NewOrdinalRankingStrategy()),
+		NewScoreDescNameAscSortStrategy(names),
+		nil,
+	)
+
+	ranked := manager.GetLeaderboard(scores)
+
+	assert.Equal(t, 3, len(ranked))
+	for i, userID := range []uuid.UUID{alice, bob, carol} {
+		assert.Equal(t, i+1, ranked[i].Rank)
+		assert.Equal(t, userID, ranked[i].UserID)
+	}
+}
+
+func TestCompositeRankingStrategy_EmptyList(t *testing.T) {
+	strategy := NewCompositeRankingStrategy(NewDenseRankingStrategy(), NewOrdinalRankingStrategy())
+	ranked := strategy.CalculateRanks([]*leaderboardmodels.Score{})
+
+	assert.Equal(t, 0, len(ranked))
+}
+
+func TestCompositeRankingStrategy_Name(t *testing.T) {
+	strategy := NewCompositeRankingStrategy(NewDenseRankingStrategy(), NewOrdinalRankingStrategy())
+	assert.Equal(t, "Dense+Ordinal", strategy.Name())
+}
+
+func TestStrategyFactory_CreateCompositeRankingStrategy(t *testing.T) {
+	strategy := NewStrategyFactory().CreateRankingStrategy("dense+ordinal")
+
+	composite, ok := strategy.(*CompositeRankingStrategy)
+	assert.True(t, ok)
+	assert.Equal(t, "Dense", composite.Primary.Name())
+	assert.Equal(t, "Ordinal", composite.Tiebreaker.Name())
+}
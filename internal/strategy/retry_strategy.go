@@ -0,0 +1,51 @@
+package strategy
+
+import (
+	"time"
+)
+
+// Retry Strategies - стратегии повторных попыток
+
+// ExponentialBackoffRetryStrategy - повтор с экспоненциально растущей задержкой
+// Повторяются только ошибки, для которых Retryable возвращает true (nil - любые ошибки)
+type ExponentialBackoffRetryStrategy struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Retryable    func(err error) bool
+}
+
+func NewExponentialBackoffRetryStrategy(maxAttempts int, initialDelay, maxDelay time.Duration, retryable func(err error) bool) *ExponentialBackoffRetryStrategy {
+	return &ExponentialBackoffRetryStrategy{
+		MaxAttempts:  maxAttempts,
+		InitialDelay: initialDelay,
+		MaxDelay:     maxDelay,
+		Retryable:    retryable,
+	}
+}
+
+// ShouldRetry - attempt начинается с 1 (первая неудачная попытка)
+func (s *ExponentialBackoffRetryStrategy) ShouldRetry(attempt int, err error) bool {
+	if err == nil || attempt >= s.MaxAttempts {
+		return false
+	}
+	if s.Retryable != nil {
+		return s.Retryable(err)
+	}
+	return true
+}
+
+func (s *ExponentialBackoffRetryStrategy) NextDelay(attempt int) time.Duration {
+	delay := s.InitialDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if s.MaxDelay > 0 && delay >= s.MaxDelay {
+			return s.MaxDelay
+		}
+	}
+	return delay
+}
+
+func (s *ExponentialBackoffRetryStrategy) Name() string {
+	return "ExponentialBackoff"
+}
@@ -0,0 +1,35 @@
+package strategy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExponentialBackoffRetryStrategy(t *testing.T) {
+	transient := errors.New("connection reset")
+	permanent := errors.New("constraint violation")
+	strategy := NewExponentialBackoffRetryStrategy(3, 10*time.Millisecond, 25*time.Millisecond, func(err error) bool {
+		return errors.Is(err, transient)
+	})
+	assert.Equal(t, "ExponentialBackoff", strategy.Name())
+
+	t.Run("retries transient errors up to max attempts", func(t *testing.T) {
+		assert.True(t, strategy.ShouldRetry(1, transient))
+		assert.True(t, strategy.ShouldRetry(2, transient))
+		assert.False(t, strategy.ShouldRetry(3, transient))
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		assert.False(t, strategy.ShouldRetry(1, permanent))
+		assert.False(t, strategy.ShouldRetry(1, nil))
+	})
+
+	t.Run("delay doubles up to max", func(t *testing.T) {
+		assert.Equal(t, 10*time.Millisecond, strategy.NextDelay(1))
+		assert.Equal(t, 20*time.Millisecond, strategy.NextDelay(2))
+		assert.Equal(t, 25*time.Millisecond, strategy.NextDelay(3))
+	})
+}
@@ -1,7 +1,10 @@
 package strategy
 
 import (
+	"context"
 	"math"
+	"sync"
+	"time"
 )
 
 // Scoring Strategies - различные стратегии подсчета очков
@@ -60,6 +63,87 @@ func (s *WeightedScoringStrategy) Name() string {
 	return "Weighted"
 }
 
+// ScoringMultipliers - коэффициенты для взвешенного подсчета очков
+type ScoringMultipliers struct {
+	DifficultyMultiplier float64
+	ComboBonus           float64
+}
+
+// ScoreConfigRepository читает коэффициенты подсчета из хранилища
+// Возвращает nil без ошибки, если для season/gameMode конфигурация не задана
+type ScoreConfigRepository interface {
+	GetMultipliers(ctx context.Context, season, gameMode string) (*ScoringMultipliers, error)
+}
+
+// multipliersLookupTimeout ограничивает чтение коэффициентов из БД внутри Calculate
+const multipliersLookupTimeout = 2 * time.Second
+
+// cachedMultipliers - запись кэша коэффициентов (nil означает "конфигурации нет")
+type cachedMultipliers struct {
+	multipliers *ScoringMultipliers
+	expiresAt   time.Time
+}
+
+// DBWeightedScoringStrategy - взвешенная стратегия с коэффициентами из таблицы scoring_config
+// Коэффициенты кэшируются на cacheTTL; при отсутствии конфигурации или ошибке БД
+// используются статические значения по умолчанию
+type DBWeightedScoringStrategy struct {
+	repo     ScoreConfigRepository
+	defaults ScoringMultipliers
+	cacheTTL time.Duration
+
+	mu    sync.RWMutex
+	cache map[string]cachedMultipliers
+}
+
+func NewDBWeightedScoringStrategy(repo ScoreConfigRepository, defaults ScoringMultipliers, cacheTTL time.Duration) *DBWeightedScoringStrategy {
+	return &DBWeightedScoringStrategy{
+		repo:     repo,
+		defaults: defaults,
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]cachedMultipliers),
+	}
+}
+
+func (s *DBWeightedScoringStrategy) Calculate(baseScore int64, context *ScoringContext) int64 {
+	multipliers := s.multipliers(context.Season, context.GameMode)
+	weighted := NewWeightedScoringStrategy(multipliers.DifficultyMultiplier, multipliers.ComboBonus)
+	return weighted.Calculate(baseScore, context)
+}
+
+// multipliers возвращает коэффициенты для season/gameMode из кэша или БД
+func (s *DBWeightedScoringStrategy) multipliers(season, gameMode string) ScoringMultipliers {
+	key := season + ":" + gameMode
+
+	s.mu.RLock()
+	entry, ok := s.cache[key]
+	s.mu.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		ctx, cancel := context.WithTimeout(context.Background(), multipliersLookupTimeout)
+		found, err := s.repo.GetMultipliers(ctx, season, gameMode)
+		cancel()
+		if err != nil {
+			// Ошибку не кэшируем, чтобы следующий вызов снова обратился к БД
+			return s.defaults
+		}
+
+		entry = cachedMultipliers{multipliers: found, expiresAt: time.Now().Add(s.cacheTTL)}
+		s.mu.Lock()
+		s.cache[key] = entry
+		s.mu.Unlock()
+	}
+
+	if entry.multipliers == nil {
+		return s.defaults
+	}
+	return *entry.multipliers
+}
+
+func (s *DBWeightedScoringStrategy) Name() string {
+	return "DBWeighted"
+}
+
 // BonusScoringStrategy - стратегия с бонусами за достижения
 type BonusScoringStrategy struct {
 	TimeBonusEnabled       bool
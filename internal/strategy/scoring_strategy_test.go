@@ -1,7 +1,10 @@
 package strategy
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -67,3 +70,244 @@ func TestCompositeScoringStrategy(t *testing.T) {
 	result := composite.Calculate(1000, context)
 	assert.Equal(t, int64(3000), result)
 }
+
+func TestScoringStrategyNames(t *testing.T) {
+	tests := []struct {
+		strategy ScoringStrategy
+		expected string
+	}{
+		{NewWeightedScoringStrategy(1.5, 0.1), "Weighted"},
+		{NewBonusScoringStrategy(true, 100, 500), "Bonus"},
+		{NewMultiplayerScoringStrategy(0.2, 10, 5, 2), "Multiplayer"},
+		{NewPercentageScoringStrategy(0.5), "Percentage"},
+		{NewThresholdScoringStrategy(nil), "Threshold"},
+		{NewSeasonalScoringStrategy(nil, 1.0), "Seasonal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.strategy.Name())
+		})
+	}
+}
+
+func TestWeightedScoringStrategy_Boundaries(t *testing.T) {
+	strategy := NewWeightedScoringStrategy(1.5, 0.1)
+
+	tests := []struct {
+		name      string
+		baseScore int64
+		context   *ScoringContext
+		expected  int64
+	}{
+		{"zero base score", 0, &ScoringContext{Difficulty: 5, Combo: 10, Multiplier: 2}, 0},
+		{"multiplier only", 1000, &ScoringContext{Multiplier: 2}, 2000},
+		{"negative multiplier is ignored", 1000, &ScoringContext{Multiplier: -2}, 1000},
+		{"negative difficulty and combo are ignored", 1000, &ScoringContext{Difficulty: -3, Combo: -4}, 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, strategy.Calculate(tt.baseScore, tt.context))
+		})
+	}
+}
+
+func TestPercentageScoringStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage float64
+		baseScore  int64
+		expected   int64
+	}{
+		{"zero base score", 0.5, 0, 0},
+		{"fifty percent bonus", 0.5, 1000, 1500},
+		{"half a point rounds up", 0.5, 1, 2},
+		{"zero percentage", 0, 1000, 1000},
+		{"negative percentage is a penalty", -0.25, 1000, 750},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewPercentageScoringStrategy(tt.percentage)
+			assert.Equal(t, tt.expected, strategy.Calculate(tt.baseScore, &ScoringContext{}))
+		})
+	}
+}
+
+func TestBonusScoringStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  *BonusScoringStrategy
+		baseScore int64
+		context   *ScoringContext
+		expected  int64
+	}{
+		{"zero base score without bonuses", NewBonusScoringStrategy(true, 100, 500), 0, &ScoringContext{}, 0},
+		{"time bonus", NewBonusScoringStrategy(true, 100, 500), 1000, &ScoringContext{TimeBonus: 300}, 1300},
+		{"time bonus is capped", NewBonusScoringStrategy(true, 100, 500), 1000, &ScoringContext{TimeBonus: 900}, 1500},
+		{"negative time bonus is ignored", NewBonusScoringStrategy(true, 100, 500), 1000, &ScoringContext{TimeBonus: -50}, 1000},
+		{"time bonus disabled", NewBonusScoringStrategy(false, 100, 500), 1000, &ScoringContext{TimeBonus: 300}, 1000},
+		{"achievements", NewBonusScoringStrategy(true, 100, 500), 1000, &ScoringContext{Achievements: []string{"first_blood", "flawless"}}, 1200},
+		{"zero base score with bonuses", NewBonusScoringStrategy(true, 100, 500), 0, &ScoringContext{TimeBonus: 200, Achievements: []string{"flawless"}}, 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.strategy.Calculate(tt.baseScore, tt.context))
+		})
+	}
+}
+
+func TestMultiplayerScoringStrategy(t *testing.T) {
+	strategy := NewMultiplayerScoringStrategy(0.2, 10, 5, 2)
+
+	tests := []struct {
+		name      string
+		baseScore int64
+		metadata  map[string]interface{}
+		expected  int64
+	}{
+		{"nil metadata", 1000, nil, 1000},
+		{"zero base score with nil metadata", 0, nil, 0},
+		{"kills, deaths and assists", 1000, map[string]interface{}{"kills": 10, "deaths": 2, "assists": 5}, 1100},
+		{"team win bonus", 1000, map[string]interface{}{"kills": 10, "deaths": 2, "assists": 5, "team_win": true}, 1320},
+		{"numbers decoded from JSON", 1000, map[string]interface{}{"kills": 10.0, "deaths": 2.0, "assists": 5.0}, 1100},
+		{"values of the wrong type are ignored", 1000, map[string]interface{}{"kills": "10", "team_win": "yes"}, 1000},
+		{"never below zero", 0, map[string]interface{}{"deaths": 300}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			context := &ScoringContext{Metadata: tt.metadata, Multiplier: -1}
+			assert.Equal(t, tt.expected, strategy.Calculate(tt.baseScore, context))
+		})
+	}
+}
+
+func TestThresholdScoringStrategy(t *testing.T) {
+	strategy := NewThresholdScoringStrategy([]ThresholdBonus{
+		{MinScore: 1000, Bonus: 100},
+		{MinScore: 5000, Bonus: 500},
+	})
+
+	tests := []struct {
+		name      string
+		baseScore int64
+		expected  int64
+	}{
+		{"zero base score", 0, 0},
+		{"just below the first threshold", 999, 999},
+		{"first threshold", 1000, 1100},
+		{"both thresholds", 5000, 5600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, strategy.Calculate(tt.baseScore, &ScoringContext{}))
+		})
+	}
+
+	assert.Equal(t, int64(1000), NewThresholdScoringStrategy(nil).Calculate(1000, &ScoringContext{}), "no thresholds keep the score")
+}
+
+func TestSeasonalScoringStrategy(t *testing.T) {
+	strategy := NewSeasonalScoringStrategy(map[string]float64{"winter": 2.0, "summer": 0.5}, 1.0)
+
+	tests := []struct {
+		name      string
+		season    string
+		baseScore int64
+		expected  int64
+	}{
+		{"zero base score", "winter", 0, 0},
+		{"season multiplier", "winter", 1000, 2000},
+		{"fraction rounds half up", "summer", 1001, 501},
+		{"unknown season uses default", "spring", 1000, 1000},
+		{"empty season uses default", "", 1000, 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, strategy.Calculate(tt.baseScore, &ScoringContext{Season: tt.season}))
+		})
+	}
+
+	assert.Equal(t, int64(0), NewSeasonalScoringStrategy(nil, 0).Calculate(1000, &ScoringContext{}), "zero default multiplier")
+}
+
+func TestCompositeScoringStrategy_ChainsInOrder(t *testing.T) {
+	percentage := NewPercentageScoringStrategy(0.5)
+	threshold := NewThresholdScoringStrategy([]ThresholdBonus{{MinScore: 1500, Bonus: 100}})
+	context := &ScoringContext{}
+
+	// Порог проверяется по результату предыдущей стратегии, поэтому порядок важен
+	assert.Equal(t, int64(1600), NewCompositeScoringStrategy(percentage, threshold).Calculate(1000, context))
+	assert.Equal(t, int64(1500), NewCompositeScoringStrategy(threshold, percentage).Calculate(1000, context))
+	assert.Equal(t, int64(1000), NewCompositeScoringStrategy().Calculate(1000, context), "no strategies keep the score")
+}
+
+// fakeScoreConfigRepository возвращает коэффициенты из map и считает обращения
+type fakeScoreConfigRepository struct {
+	configs map[string]*ScoringMultipliers
+	err     error
+	calls   int
+}
+
+func (r *fakeScoreConfigRepository) GetMultipliers(ctx context.Context, season, gameMode string) (*ScoringMultipliers, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.configs[season+":"+gameMode], nil
+}
+
+func TestDBWeightedScoringStrategy(t *testing.T) {
+	defaults := ScoringMultipliers{DifficultyMultiplier: 1.5, ComboBonus: 0.1}
+	scoringContext := &ScoringContext{Season: "winter", GameMode: "ranked", Difficulty: 2}
+
+	t.Run("uses multipliers from repository", func(t *testing.T) {
+		repo := &fakeScoreConfigRepository{configs: map[string]*ScoringMultipliers{
+			"winter:ranked": {DifficultyMultiplier: 3.0, ComboBonus: 0.2},
+		}}
+		strategy := NewDBWeightedScoringStrategy(repo, defaults, time.Minute)
+		assert.Equal(t, "DBWeighted", strategy.Name())
+
+		assert.Equal(t, int64(6000), strategy.Calculate(1000, scoringContext))
+	})
+
+	t.Run("falls back to defaults when config is missing", func(t *testing.T) {
+		repo := &fakeScoreConfigRepository{}
+		strategy := NewDBWeightedScoringStrategy(repo, defaults, time.Minute)
+
+		assert.Equal(t, int64(3000), strategy.Calculate(1000, scoringContext))
+	})
+
+	t.Run("falls back to defaults on repository error", func(t *testing.T) {
+		repo := &fakeScoreConfigRepository{err: errors.New("connection refused")}
+		strategy := NewDBWeightedScoringStrategy(repo, defaults, time.Minute)
+
+		assert.Equal(t, int64(3000), strategy.Calculate(1000, scoringContext))
+		strategy.Calculate(1000, scoringContext)
+		assert.Equal(t, 2, repo.calls)
+	})
+
+	t.Run("caches lookups", func(t *testing.T) {
+		repo := &fakeScoreConfigRepository{}
+		strategy := NewDBWeightedScoringStrategy(repo, defaults, time.Minute)
+
+		strategy.Calculate(1000, scoringContext)
+		strategy.Calculate(500, scoringContext)
+		assert.Equal(t, 1, repo.calls)
+	})
+
+	t.Run("reloads after ttl", func(t *testing.T) {
+		repo := &fakeScoreConfigRepository{}
+		strategy := NewDBWeightedScoringStrategy(repo, defaults, time.Millisecond)
+
+		strategy.Calculate(1000, scoringContext)
+		time.Sleep(5 * time.Millisecond)
+		strategy.Calculate(1000, scoringContext)
+		assert.Equal(t, 2, repo.calls)
+	})
+}
@@ -0,0 +1,103 @@
+package strategy
+
+import (
+	"sort"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+
+	"github.com/google/uuid"
+)
+
+// Sort Strategies - различные стратегии сортировки
+// Все стратегии возвращают новый срез и не изменяют исходный
+
+// ScoreDescNameAscSortStrategy - сортировка по счету (убывание),
+// при равенстве - по имени игрока в алфавитном порядке
+type ScoreDescNameAscSortStrategy struct {
+	// Names - имена игроков по UserID; если имени нет, сравнивается UserID
+	Names map[uuid.UUID]string
+}
+
+func NewScoreDescNameAscSortStrategy(names map[uuid.UUID]string) *ScoreDescNameAscSortStrategy {
+	return &ScoreDescNameAscSortStrategy{Names: names}
+}
+
+func (s *ScoreDescNameAscSortStrategy) Sort(scores []*leaderboardmodels.Score) []*leaderboardmodels.Score {
+	sorted := copyScores(scores)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Score != sorted[j].Score {
+			return sorted[i].Score > sorted[j].Score
+		}
+		return s.nameOf(sorted[i].UserID) < s.nameOf(sorted[j].UserID)
+	})
+	return sorted
+}
+
+func (s *ScoreDescNameAscSortStrategy) nameOf(userID uuid.UUID) string {
+	if name, ok := s.Names[userID]; ok {
+		return name
+	}
+	return userID.String()
+}
+
+func (s *ScoreDescNameAscSortStrategy) Name() string {
+	return "ScoreDescNameAsc"
+}
+
+// ScoreAscSortStrategy - сортировка по счету (возрастание),
+// при равенстве сохраняется исходный порядок
+type ScoreAscSortStrategy struct{}
+
+func NewScoreAscSortStrategy() *ScoreAscSortStrategy {
+	return &ScoreAscSortStrategy{}
+}
+
+func (s *ScoreAscSortStrategy) Sort(scores []*leaderboardmodels.Score) []*leaderboardmodels.Score {
+	sorted := copyScores(scores)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Score < sorted[j].Score
+	})
+	return sorted
+}
+
+func (s *ScoreAscSortStrategy) Name() string {
+	return "ScoreAsc"
+}
+
+// TimestampSortStrategy - сортировка по времени отправки счета
+// При одинаковом времени более высокий счет идет первым
+type TimestampSortStrategy struct {
+	Ascending bool
+}
+
+func NewTimestampSortStrategy(ascending bool) *TimestampSortStrategy {
+	return &TimestampSortStrategy{Ascending: ascending}
+}
+
+func (s *TimestampSortStrategy) Sort(scores []*leaderboardmodels.Score) []*leaderboardmodels.Score {
+	sorted := copyScores(scores)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Timestamp.Equal(sorted[j].Timestamp) {
+			if s.Ascending {
+				return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+			}
+			return sorted[i].Timestamp.After(sorted[j].Timestamp)
+		}
+		return sorted[i].Score > sorted[j].Score
+	})
+	return sorted
+}
+
+func (s *TimestampSortStrategy) Name() string {
+	if s.Ascending {
+		return "TimestampAsc"
+	}
+	return "TimestampDesc"
+}
+
+// copyScores копирует срез, чтобы не менять порядок у вызывающего кода
+func copyScores(scores []*leaderboardmodels.Score) []*leaderboardmodels.Score {
+	sorted := make([]*leaderboardmodels.Score, len(scores))
+	copy(sorted, scores)
+	return sorted
+}
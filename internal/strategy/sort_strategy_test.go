@@ -0,0 +1,186 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreDescNameAscSortStrategy(t *testing.T) {
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	names := map[uuid.UUID]string{alice: "Alice", bob: "Bob", carol: "Carol"}
+
+	scores := []*leaderboardmodels.Score{
+		{UserID: carol, Score: 500},
+		{UserID: bob, Score: 1000},
+		{UserID: alice, Score: 500},
+		{UserID: uuid.New(), Score: 200},
+	}
+
+	strategy := NewScoreDescNameAscSortStrategy(names)
+	sorted := strategy.Sort(scores)
+
+	require.Len(t, sorted, 4)
+	assert.Equal(t, bob, sorted[0].UserID)
+	assert.Equal(t, alice, sorted[1].UserID, "tie on 500 broken alphabetically")
+	assert.Equal(t, carol, sorted[2].UserID)
+	assert.Equal(t, int64(200), sorted[3].Score)
+	assert.Equal(t, "ScoreDescNameAsc", strategy.Name())
+
+	// Исходный срез не изменяется
+	assert.Equal(t, carol, scores[0].UserID)
+}
+
+func TestScoreDescNameAscSortStrategy_MissingNames(t *testing.T) {
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	scores := []*leaderboardmodels.Score{
+		{UserID: second, Score: 300},
+		{UserID: first, Score: 300},
+	}
+
+	sorted := NewScoreDescNameAscSortStrategy(nil).Sort(scores)
+
+	require.Len(t, sorted, 2)
+	assert.Equal(t, first, sorted[0].UserID, "falls back to UserID ordering")
+	assert.Equal(t, second, sorted[1].UserID)
+}
+
+func TestScoreAscSortStrategy(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	scores := []*leaderboardmodels.Score{
+		{UserID: uuid.New(), Score: 900},
+		{UserID: first, Score: 100},
+		{UserID: second, Score: 100},
+		{UserID: uuid.New(), Score: 400},
+	}
+
+	strategy := NewScoreAscSortStrategy()
+	sorted := strategy.Sort(scores)
+
+	require.Len(t, sorted, 4)
+	assert.Equal(t, first, sorted[0].UserID, "ties keep input order")
+	assert.Equal(t, second, sorted[1].UserID)
+	assert.Equal(t, int64(400), sorted[2].Score)
+	assert.Equal(t, int64(900), sorted[3].Score)
+	assert.Equal(t, "ScoreAsc", strategy.Name())
+}
+
+func TestTimestampSortStrategy(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	scores := []*leaderboardmodels.Score{
+		{Score: 100, Timestamp: base.Add(2 * time.Minute)},
+		{Score: 300, Timestamp: base},
+		{Score: 700, Timestamp: base},
+		{Score: 500, Timestamp: base.Add(time.Minute)},
+	}
+
+	t.Run("ascending", func(t *testing.T) {
+		strategy := NewTimestampSortStrategy(true)
+		sorted := strategy.Sort(scores)
+
+		require.Len(t, sorted, 4)
+		assert.Equal(t, int64(700), sorted[0].Score, "same timestamp - higher score first")
+		assert.Equal(t, int64(300), sorted[1].Score)
+		assert.Equal(t, int64(500), sorted[2].Score)
+		assert.Equal(t, int64(100), sorted[3].Score)
+		assert.Equal(t, "TimestampAsc", strategy.Name())
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		strategy := NewTimestampSortStrategy(false)
+		sorted := strategy.Sort(scores)
+
+		require.Len(t, sorted, 4)
+		assert.Equal(t, int64(100), sorted[0].Score)
+		assert.Equal(t, int64(500), sorted[1].Score)
+		assert.Equal(t, int64(700), sorted[2].Score, "same timestamp - higher score first")
+		assert.Equal(t, int64(300), sorted[3].Score)
+		assert.Equal(t, "TimestampDesc", strategy.Name())
+	})
+}
+
+func TestStrategyFactory_CreateSortStrategy(t *testing.T) {
+	factory := NewStrategyFactory()
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"score_desc_name_asc", "ScoreDescNameAsc"},
+		{"score_asc", "ScoreAsc"},
+		{"timestamp_asc", "TimestampAsc"},
+		{"timestamp_desc", "TimestampDesc"},
+		{"unknown", "ScoreDescNameAsc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, factory.CreateSortStrategy(tt.name).Name())
+		})
+	}
+}
+
+func TestStrategyFactory_CreateSortStrategyWithNames(t *testing.T) {
+	// UUID у Zed меньше, поэтому без имен он оказался бы первым
+	zed := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	amy := uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	scores := []*leaderboardmodels.Score{{UserID: zed, Score: 500}, {UserID: amy, Score: 500}}
+
+	strategy := NewStrategyFactory().CreateSortStrategyWithNames("score_desc_name_asc", map[uuid.UUID]string{zed: "Zed", amy: "Amy"})
+	sorted := strategy.Sort(scores)
+
+	require.Len(t, sorted, 2)
+	assert.Equal(t, amy, sorted[0].UserID, "ties are ordered by name")
+	assert.Equal(t, zed, sorted[1].UserID)
+}
+
+func TestLeaderboardManager_SortStrategies(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	names := map[uuid.UUID]string{alice: "Alice", bob: "Bob"}
+
+	scores := []*leaderboardmodels.Score{
+		{UserID: bob, Score: 800},
+		{UserID: alice, Score: 800},
+		{UserID: uuid.New(), Score: 1000},
+	}
+
+	manager := NewLeaderboardManager(NewCompetitionRankingStrategy(), NewScoreDescNameAscSortStrategy(names), nil)
+	assert.Equal(t, "ScoreDescNameAsc", manager.GetSortStrategyName())
+
+	t.Run("sorted order survives ranking", func(t *testing.T) {
+		ranked := manager.GetLeaderboard(scores)
+
+		require.Len(t, ranked, 3)
+		assert.Equal(t, 1, ranked[0].Rank)
+		assert.Equal(t, alice, ranked[1].Score.UserID)
+		assert.Equal(t, bob, ranked[2].Score.UserID)
+		assert.Equal(t, 2, ranked[1].Rank)
+		assert.Equal(t, 2, ranked[2].Rank)
+		assert.True(t, ranked[2].TiedWithPrev)
+	})
+
+	t.Run("switch strategy", func(t *testing.T) {
+		manager.SetSortStrategy(NewStrategyFactory().CreateSortStrategy("score_asc"))
+		sorted := manager.SortScores(scores)
+
+		require.Len(t, sorted, 3)
+		assert.Equal(t, bob, sorted[0].UserID)
+		assert.Equal(t, alice, sorted[1].UserID)
+		assert.Equal(t, int64(1000), sorted[2].Score)
+	})
+
+	t.Run("nil strategy keeps order", func(t *testing.T) {
+		manager.SetSortStrategy(nil)
+		assert.Equal(t, scores, manager.SortScores(scores))
+		assert.Equal(t, "None", manager.GetSortStrategyName())
+	})
+}
@@ -0,0 +1,321 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/database"
+)
+
+// TestDatabaseURLEnv points integration tests at an existing PostgreSQL instead of a Docker container
+const TestDatabaseURLEnv = "TEST_DATABASE_URL"
+
+const (
+	// postgresImage - образ контейнера, та же версия, что в docker-compose
+	postgresImage = "postgres:15-alpine"
+	// containerStartTimeout - сколько ждать, пока PostgreSQL в контейнере начнет принимать подключения
+	containerStartTimeout = time.Minute
+	// migrationLockID - ключ advisory lock, под которым тестовые бинарники по очереди применяют миграции
+	migrationLockID = 7345001
+)
+
+// sharedTestDB - одно подключение на тестовый бинарник
+type sharedTestDB struct {
+	once sync.Once
+	db   *database.PostgresDB
+	err  error
+}
+
+var (
+	externalDB  sharedTestDB
+	containerDB sharedTestDB
+
+	containerOnce sync.Once
+	containerID   string
+	containerURL  string
+	containerErr  error
+)
+
+// TestDatabaseURL returns the PostgreSQL URL for integration tests: TEST_DATABASE_URL, then DATABASE_URL,
+// then the shared Docker container, which is started and migrated on first use.
+// Returns "" when the container could not be started; OpenPostgresTestDB reports why.
+func TestDatabaseURL() string {
+	if url := externalDatabaseURL(); url != "" {
+		return url
+	}
+	if _, err := openContainerDB(); err != nil {
+		return ""
+	}
+	return containerURL
+}
+
+// externalDatabaseURL - адрес базы, заданный через окружение, или ""
+func externalDatabaseURL() string {
+	for _, key := range []string{TestDatabaseURLEnv, "DATABASE_URL"} {
+		if url := os.Getenv(key); url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// OpenPostgresTestDB connects to the integration test database once per test binary and brings it up
+// to date with sql/schema.sql and sql/migrations. Without TEST_DATABASE_URL or DATABASE_URL it starts
+// a PostgreSQL container in Docker. Later calls return the same connection.
+func OpenPostgresTestDB() (*database.PostgresDB, error) {
+	if url := externalDatabaseURL(); url != "" {
+		return externalDB.open(url)
+	}
+	return openContainerDB()
+}
+
+// openContainerDB запускает общий контейнер и подключается к нему
+func openContainerDB() (*database.PostgresDB, error) {
+	containerOnce.Do(func() {
+		containerID, containerURL, containerErr = startPostgresContainer()
+	})
+	if containerErr != nil {
+		return nil, containerErr
+	}
+	return containerDB.open(containerURL)
+}
+
+// open подключается к url и применяет схему и миграции; выполняется один раз
+func (s *sharedTestDB) open(url string) (*database.PostgresDB, error) {
+	s.once.Do(func() {
+		s.db, s.err = database.NewPostgresDB(testDatabaseConfig(url))
+		if s.err != nil {
+			return
+		}
+		s.err = migrate(s.db)
+	})
+	return s.db, s.err
+}
+
+func testDatabaseConfig(url string) *config.Config {
+	return &config.Config{
+		Database: config.DatabaseConfig{
+			URL:      url,
+			MaxConns: 25,
+			MinConns: 5,
+			// Tests truncate and reseed tables, so avoid stale cached plans
+			PrepareStatements: false,
+		},
+	}
+}
+
+// ClosePostgresTestDB closes the shared test database and removes the Docker container if one was
+// started; call it from TestMain
+func ClosePostgresTestDB() error {
+	var errs []string
+	for _, shared := range []*sharedTestDB{&externalDB, &containerDB} {
+		if shared.db == nil {
+			continue
+		}
+		if err := shared.db.Close(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if containerID != "" {
+		if out, err := exec.Command("docker", "rm", "-f", containerID).CombinedOutput(); err != nil {
+			errs = append(errs, fmt.Sprintf("remove container: %v: %s", err, strings.TrimSpace(string(out))))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("close test database: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// NewPostgresContainer returns a connection to the shared Docker PostgreSQL of this test binary,
+// scoped to a transaction, and a cleanup function that rolls it back. The container is started and
+// migrated on first use and removed by ClosePostgresTestDB.
+// Skips in -short mode and fails the test when Docker cannot start the container.
+func NewPostgresContainer(t *testing.T) (*database.PostgresDB, func()) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	shared, err := openContainerDB()
+	if err != nil {
+		t.Fatalf("Failed to start PostgreSQL container: %v", err)
+	}
+	return beginTestTx(t, shared)
+}
+
+// NewPostgresTestDB is NewPostgresContainer for tests and benchmarks that may also run against
+// TEST_DATABASE_URL or DATABASE_URL: the container is only started when neither is set.
+func NewPostgresTestDB(t testing.TB) (*database.PostgresDB, func()) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	shared, err := OpenPostgresTestDB()
+	if err != nil {
+		t.Fatalf("Failed to open the integration test database: %v", err)
+	}
+	return beginTestTx(t, shared)
+}
+
+// beginTestTx открывает транзакцию, которую cleanup откатывает: тест не оставляет строк
+func beginTestTx(t testing.TB, shared *database.PostgresDB) (*database.PostgresDB, func()) {
+	t.Helper()
+	tx := shared.DB.Begin()
+	if tx.Error != nil {
+		t.Fatalf("Failed to begin test transaction: %v", tx.Error)
+	}
+	return &database.PostgresDB{DB: tx}, func() { tx.Rollback() }
+}
+
+// startPostgresContainer запускает PostgreSQL в Docker на случайном порту 127.0.0.1
+// и ждет, пока сервер начнет принимать подключения
+func startPostgresContainer() (id, url string, err error) {
+	out, err := exec.Command("docker", "run", "-d", "--rm",
+		"--label", "leaderboard-service.testutil=postgres",
+		"-e", "POSTGRES_USER=postgres",
+		"-e", "POSTGRES_PASSWORD=postgres",
+		"-e", "POSTGRES_DB=leaderboard",
+		"-p", "127.0.0.1::5432",
+		postgresImage,
+	).Output()
+	if err != nil {
+		return "", "", fmt.Errorf("docker run %s: %w", postgresImage, commandError(err))
+	}
+	id = strings.TrimSpace(string(out))
+
+	// При ошибке контейнер не нужен: ClosePostgresTestDB о нем не узнает
+	defer func() {
+		if err != nil {
+			_ = exec.Command("docker", "rm", "-f", id).Run()
+		}
+	}()
+
+	out, err = exec.Command("docker", "port", id, "5432/tcp").Output()
+	if err != nil {
+		return "", "", fmt.Errorf("docker port: %w", commandError(err))
+	}
+	// docker port печатает по строке на каждый адрес, нужен первый
+	hostPort := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	url = fmt.Sprintf("postgres://postgres:postgres@%s/leaderboard?sslmode=disable", hostPort)
+
+	if err := waitForPostgres(url, containerStartTimeout); err != nil {
+		return "", "", err
+	}
+	return id, url, nil
+}
+
+// commandError добавляет к ошибке docker его stderr
+func commandError(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
+
+// waitForPostgres ждет успешного ping. Во время initdb образ слушает только unix-сокет,
+// поэтому TCP-подключение проходит уже к основному серверу
+func waitForPostgres(url string, timeout time.Duration) error {
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err = db.PingContext(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("postgres container not ready after %s: %w", timeout, err)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// migrate применяет sql/schema.sql к пустой базе, затем все sql/migrations по порядку.
+// Схема не идемпотентна (CREATE TRIGGER), а миграции написаны для повторного применения,
+// поэтому они выполняются всегда. Advisory lock не дает двум тестовым бинарникам мигрировать одну базу параллельно
+func migrate(db *database.PostgresDB) error {
+	ctx := context.Background()
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("lock migrations: %w", err)
+	}
+	defer func() { _, _ = conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID) }()
+
+	sqlDir, err := findSQLDir()
+	if err != nil {
+		return err
+	}
+
+	var exists bool
+	if err := conn.QueryRowContext(ctx, "SELECT to_regclass('public.users') IS NOT NULL").Scan(&exists); err != nil {
+		return fmt.Errorf("check schema: %w", err)
+	}
+	files := []string{}
+	if !exists {
+		files = append(files, filepath.Join(sqlDir, "schema.sql"))
+	}
+	migrations, err := filepath.Glob(filepath.Join(sqlDir, "migrations", "*.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(migrations)
+	files = append(files, migrations...)
+
+	for _, path := range files {
+		script, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", filepath.Base(path), err)
+		}
+		// Без аргументов pgx выполняет запрос простым протоколом, который допускает несколько команд
+		if _, err := conn.ExecContext(ctx, string(script)); err != nil {
+			return fmt.Errorf("apply %s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+// findSQLDir ищет каталог sql со schema.sql вверх от рабочего каталога теста (каталога пакета)
+func findSQLDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, "sql")
+		if _, err := os.Stat(filepath.Join(path, "schema.sql")); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("sql/schema.sql not found above the test directory")
+		}
+		dir = parent
+	}
+}
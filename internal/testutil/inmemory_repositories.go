@@ -0,0 +1,997 @@
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	authmodels "leaderboard-service/internal/auth/models"
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryUserRepository is a map-backed UserRepository for unit tests.
+// Stored users are copied on the way in and out, so callers cannot mutate repository state.
+type InMemoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]authmodels.User
+}
+
+// NewInMemoryUserRepository creates an empty in-memory user repository
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{users: make(map[uuid.UUID]authmodels.User)}
+}
+
+// Create stores a new user, assigning an ID and timestamps like the database defaults do
+func (r *InMemoryUserRepository) Create(ctx context.Context, user *authmodels.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.createLocked(user)
+}
+
+// CreateBatch stores users atomically: nothing is written if any user is a duplicate
+func (r *InMemoryUserRepository) CreateBatch(ctx context.Context, users []*authmodels.User, batchSize int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	emails := make(map[string]bool, len(users))
+	for _, user := range users {
+		if emails[user.Email] || r.emailTakenLocked(user.Email) {
+			return fmt.Errorf("failed to create batch: duplicate email %s", user.Email)
+		}
+		emails[user.Email] = true
+	}
+	for _, user := range users {
+		if err := r.createLocked(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateBatchSkippingExisting stores users whose email is free; the others keep uuid.Nil as their ID
+func (r *InMemoryUserRepository) CreateBatchSkippingExisting(ctx context.Context, users []*authmodels.User, batchSize int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range users {
+		if r.emailTakenLocked(user.Email) {
+			user.ID = uuid.Nil
+			continue
+		}
+		if err := r.createLocked(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *InMemoryUserRepository) createLocked(user *authmodels.User) error {
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	if _, exists := r.users[user.ID]; exists {
+		return fmt.Errorf("failed to create: duplicate id %s", user.ID)
+	}
+	if r.emailTakenLocked(user.Email) {
+		return fmt.Errorf("failed to create: duplicate email %s", user.Email)
+	}
+
+	now := time.Now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	user.UpdatedAt = now
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *InMemoryUserRepository) emailTakenLocked(email string) bool {
+	for _, existing := range r.users {
+		if existing.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+// FindByID retrieves a user by ID; returns repository.ErrRecordNotFound if missing
+func (r *InMemoryUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*authmodels.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+// FindByIDs retrieves the users that exist among ids
+func (r *InMemoryUserRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*authmodels.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[uuid.UUID]*authmodels.User, len(ids))
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			result[id] = &user
+		}
+	}
+	return result, nil
+}
+
+// FindAll returns a page of users ordered by CreatedAt DESC and the total number of users
+func (r *InMemoryUserRepository) FindAll(ctx context.Context, limit, offset int) ([]*authmodels.User, int64, error) {
+	r.mu.RLock()
+	users := make([]*authmodels.User, 0, len(r.users))
+	for _, user := range r.users {
+		user := user
+		users = append(users, &user)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.After(users[j].CreatedAt)
+		}
+		return users[i].ID.String() < users[j].ID.String()
+	})
+	return paginate(users, limit, offset), int64(len(users)), nil
+}
+
+// FindByEmail retrieves a user by email; returns repository.ErrRecordNotFound if missing
+func (r *InMemoryUserRepository) FindByEmail(ctx context.Context, email string) (*authmodels.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, repository.ErrRecordNotFound
+}
+
+// ExistsByEmail reports whether a stored user has the email
+func (r *InMemoryUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Update replaces a stored user
+func (r *InMemoryUserRepository) Update(ctx context.Context, user *authmodels.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.ID]; !ok {
+		return repository.ErrRecordNotFound
+	}
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = *user
+	return nil
+}
+
+// SetAdmin changes the admin flag of a stored user
+func (r *InMemoryUserRepository) SetAdmin(ctx context.Context, userID uuid.UUID, isAdmin bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok {
+		return repository.ErrRecordNotFound
+	}
+	user.IsAdmin = isAdmin
+	user.UpdatedAt = time.Now()
+	r.users[userID] = user
+	return nil
+}
+
+// Delete removes a user
+func (r *InMemoryUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return repository.ErrRecordNotFound
+	}
+	delete(r.users, id)
+	return nil
+}
+
+// FindBySpec returns users for which spec.IsSatisfiedBy holds, oldest first.
+// Ordering and limit specs only affect SQL, so they are not applied here.
+func (r *InMemoryUserRepository) FindBySpec(ctx context.Context, spec repository.Specification[authmodels.User]) ([]*authmodels.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*authmodels.User
+	for _, user := range r.users {
+		if spec == nil || spec.IsSatisfiedBy(user) {
+			user := user
+			result = append(result, &user)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result, nil
+}
+
+// FindOneBySpec returns the first user matching spec; returns repository.ErrRecordNotFound if none
+func (r *InMemoryUserRepository) FindOneBySpec(ctx context.Context, spec repository.Specification[authmodels.User]) (*authmodels.User, error) {
+	users, _ := r.FindBySpec(ctx, spec)
+	if len(users) == 0 {
+		return nil, repository.ErrRecordNotFound
+	}
+	return users[0], nil
+}
+
+// CountBySpec counts users matching spec
+func (r *InMemoryUserRepository) CountBySpec(ctx context.Context, spec repository.Specification[authmodels.User]) (int64, error) {
+	users, _ := r.FindBySpec(ctx, spec)
+	return int64(len(users)), nil
+}
+
+// userNames returns the name of every known user; scores of unknown users are hidden
+// from leaderboards the same way the SQL JOIN on users hides them
+func (r *InMemoryUserRepository) userNames() map[uuid.UUID]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make(map[uuid.UUID]string, len(r.users))
+	for id, user := range r.users {
+		names[id] = user.Name
+	}
+	return names
+}
+
+// usersOutsideCountry returns the IDs of users registered in any other country or in none
+func (r *InMemoryUserRepository) usersOutsideCountry(country string) map[uuid.UUID]bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	outside := make(map[uuid.UUID]bool)
+	for id, user := range r.users {
+		if user.Country != country {
+			outside[id] = true
+		}
+	}
+	return outside
+}
+
+// InMemoryScoreRepository is a map-backed ScoreRepository for unit tests.
+// Leaderboard queries read user names from the paired InMemoryUserRepository.
+type InMemoryScoreRepository struct {
+	mu      sync.RWMutex
+	scores  map[scoreKey]leaderboardmodels.Score
+	games   map[scoreKey]int         // games_played: every upsert counts, even one that keeps the old score
+	history map[scoreKey][]time.Time // submission times, like score_history
+	users   *InMemoryUserRepository
+	now     func() time.Time
+}
+
+type scoreKey struct {
+	userID uuid.UUID
+	season string
+}
+
+// NewInMemoryScoreRepository creates an empty in-memory score repository joined to users
+func NewInMemoryScoreRepository(users *InMemoryUserRepository) *InMemoryScoreRepository {
+	return &InMemoryScoreRepository{
+		scores:  make(map[scoreKey]leaderboardmodels.Score),
+		games:   make(map[scoreKey]int),
+		history: make(map[scoreKey][]time.Time),
+		users:   users,
+		now:     time.Now,
+	}
+}
+
+// Upsert inserts or replaces the user's score for the season
+func (r *InMemoryScoreRepository) Upsert(ctx context.Context, score *leaderboardmodels.Score) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.upsertLocked(score)
+	return nil
+}
+
+// UpsertOnlyIfHigher writes the score only when it beats the stored one
+func (r *InMemoryScoreRepository) UpsertOnlyIfHigher(ctx context.Context, score *leaderboardmodels.Score) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := scoreKey{score.UserID, score.Season}
+	if existing, ok := r.scores[key]; ok && score.Score <= existing.Score {
+		r.games[key]++
+		r.recordSubmissionLocked(key)
+		return false, nil
+	}
+	r.upsertLocked(score)
+	return true, nil
+}
+
+func (r *InMemoryScoreRepository) upsertLocked(score *leaderboardmodels.Score) {
+	key := scoreKey{score.UserID, score.Season}
+	if existing, ok := r.scores[key]; ok {
+		score.ID = existing.ID
+	} else if score.ID == uuid.Nil {
+		score.ID = uuid.New()
+	}
+	if score.Timestamp.IsZero() {
+		score.Timestamp = r.now()
+	}
+	r.scores[key] = *score
+	r.games[key]++
+	r.recordSubmissionLocked(key)
+}
+
+func (r *InMemoryScoreRepository) recordSubmissionLocked(key scoreKey) {
+	r.history[key] = append(r.history[key], r.now())
+}
+
+func utcDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// streakLocked counts runs of consecutive days the same way as the score_history query
+func (r *InMemoryScoreRepository) streakLocked(key scoreKey) (current, longest int) {
+	seen := make(map[time.Time]bool, len(r.history[key]))
+	days := make([]time.Time, 0, len(r.history[key]))
+	for _, submitted := range r.history[key] {
+		if day := utcDay(submitted); !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	if len(days) == 0 {
+		return 0, 0
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && day.Sub(days[i-1]) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	if !days[len(days)-1].Before(utcDay(r.now()).Add(-24 * time.Hour)) {
+		current = run
+	}
+	return current, longest
+}
+
+// FindByUserAndSeason retrieves a score; returns repository.ErrRecordNotFound if missing
+func (r *InMemoryScoreRepository) FindByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	score, ok := r.scores[scoreKey{userID, season}]
+	if !ok {
+		return nil, repository.ErrRecordNotFound
+	}
+	return &score, nil
+}
+
+// GetLeaderboard returns a page of ranked entries using the same ordering and DENSE_RANK semantics as the SQL query
+func (r *InMemoryScoreRepository) GetLeaderboard(ctx context.Context, season string, limit, offset int, sortBy, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	excluded := make(map[uuid.UUID]bool, len(excludeUserIDs))
+	for _, id := range excludeUserIDs {
+		excluded[id] = true
+	}
+
+	entries := r.rankedSeason(season, excluded)
+	r.mu.RLock()
+	for i := range entries {
+		key := scoreKey{entries[i].UserID, season}
+		entries[i].GamesPlayed = r.games[key]
+		entries[i].StreakCurrent, entries[i].StreakLongest = r.streakLocked(key)
+	}
+	r.mu.RUnlock()
+
+	if sortBy == leaderboardmodels.SortByTimestamp || sortBy == leaderboardmodels.SortByGamesPlayed {
+		rankBy(entries, sortBy)
+	}
+	total := int64(len(entries))
+	if sortOrder == "asc" {
+		// Only the sort column flips; ties keep their rank order like the SQL ORDER BY
+		sort.SliceStable(entries, func(i, j int) bool {
+			return sortKeys(entries[i], sortBy)[0] < sortKeys(entries[j], sortBy)[0]
+		})
+	}
+	return paginate(entries, limit, offset), total, nil
+}
+
+// GetLeaderboardPage returns the entries that follow after in (score DESC, timestamp ASC, user_id ASC)
+// order like the keyset query; ranks come from the whole season, so ties across the cursor keep theirs
+func (r *InMemoryScoreRepository) GetLeaderboardPage(ctx context.Context, season, sortOrder string, after utils.CursorPosition, limit int) ([]leaderboardmodels.LeaderboardEntry, error) {
+	if sortOrder == "asc" {
+		return nil, fmt.Errorf("keyset pagination supports only descending order")
+	}
+
+	entries, _, err := r.GetLeaderboard(ctx, season, 0, 0, leaderboardmodels.SortByScore, "desc", nil)
+	if err != nil {
+		return nil, err
+	}
+	page := make([]leaderboardmodels.LeaderboardEntry, 0, limit)
+	for _, entry := range entries {
+		if !followsCursor(entry, after) {
+			continue
+		}
+		page = append(page, entry)
+		if len(page) == limit {
+			break
+		}
+	}
+	return page, nil
+}
+
+// followsCursor reports whether entry comes after the cursor in the keyset order
+func followsCursor(entry leaderboardmodels.LeaderboardEntry, after utils.CursorPosition) bool {
+	if entry.Score != after.Score {
+		return entry.Score < after.Score
+	}
+	if !entry.Timestamp.Equal(after.Timestamp) {
+		return entry.Timestamp.After(after.Timestamp)
+	}
+	return entry.UserID.String() > after.UserID.String()
+}
+
+// GetLeaderboardWithProfiles is GetLeaderboard with the profile fields of the paired users
+func (r *InMemoryScoreRepository) GetLeaderboardWithProfiles(ctx context.Context, season string, limit, offset int, sortOrder string, excludeUserIDs []uuid.UUID) ([]leaderboardmodels.EnrichedLeaderboardEntry, int64, error) {
+	entries, total, err := r.GetLeaderboard(ctx, season, limit, offset, leaderboardmodels.SortByScore, sortOrder, excludeUserIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	enriched := make([]leaderboardmodels.EnrichedLeaderboardEntry, len(entries))
+	for i, entry := range entries {
+		enriched[i].LeaderboardEntry = entry
+		if user, err := r.users.FindByID(ctx, entry.UserID); err == nil {
+			enriched[i].AvatarURL = user.AvatarURL
+			enriched[i].Country = user.Country
+			enriched[i].Tier = user.Tier
+		}
+	}
+	return enriched, total, nil
+}
+
+// GetLeaderboardByCountry ranks the season's players of one country among themselves
+func (r *InMemoryScoreRepository) GetLeaderboardByCountry(ctx context.Context, country, season string, limit, offset int) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	entries := r.rankedSeason(season, r.users.usersOutsideCountry(country))
+	r.mu.RLock()
+	for i := range entries {
+		entries[i].GamesPlayed = r.games[scoreKey{entries[i].UserID, season}]
+	}
+	r.mu.RUnlock()
+	return paginate(entries, limit, offset), int64(len(entries)), nil
+}
+
+// FindByMetadata returns a season's scores whose metadata value under key, as text, equals value
+func (r *InMemoryScoreRepository) FindByMetadata(ctx context.Context, season, key, value string, limit int) ([]*leaderboardmodels.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*leaderboardmodels.Score
+	for k, score := range r.scores {
+		if k.season != season {
+			continue
+		}
+		if text, ok := metadataText(score.Metadata, key); ok && text == value {
+			score := score
+			result = append(result, &score)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp.After(result[j].Timestamp)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// metadataText renders a metadata value the way PostgreSQL's ->> does: strings as is, anything else as JSON
+func metadataText(metadata map[string]interface{}, key string) (string, bool) {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	if text, ok := value.(string); ok {
+		return text, true
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// CountBySeason counts scores of a season
+func (r *InMemoryScoreRepository) CountBySeason(ctx context.Context, season string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for key := range r.scores {
+		if key.season == season {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindAll returns a page of a season's scores ordered like the SQL query
+func (r *InMemoryScoreRepository) FindAll(ctx context.Context, season, sortOrder string, limit, offset int) ([]*leaderboardmodels.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*leaderboardmodels.Score
+	for key, score := range r.scores {
+		if key.season == season {
+			score := score
+			result = append(result, &score)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			if sortOrder == "asc" {
+				return result[i].Score < result[j].Score
+			}
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	return paginate(result, limit, offset), nil
+}
+
+// Count counts scores of all seasons
+func (r *InMemoryScoreRepository) Count(ctx context.Context) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int64(len(r.scores)), nil
+}
+
+// DeleteByUserAndSeason removes one score; returns repository.ErrRecordNotFound if missing
+func (r *InMemoryScoreRepository) DeleteByUserAndSeason(ctx context.Context, userID uuid.UUID, season string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := scoreKey{userID, season}
+	if _, ok := r.scores[key]; !ok {
+		return repository.ErrRecordNotFound
+	}
+	delete(r.scores, key)
+	delete(r.games, key)
+	delete(r.history, key)
+	return nil
+}
+
+// RevertSubmission undoes the latest submission: the score goes back to previous (or is removed),
+// and the submission's game and history entry are dropped
+func (r *InMemoryScoreRepository) RevertSubmission(ctx context.Context, userID uuid.UUID, season string, previous *leaderboardmodels.Score) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := scoreKey{userID, season}
+	if _, ok := r.scores[key]; !ok {
+		return repository.ErrRecordNotFound
+	}
+	if history := r.history[key]; len(history) > 0 {
+		r.history[key] = history[:len(history)-1]
+	}
+	if previous == nil {
+		delete(r.scores, key)
+		delete(r.games, key)
+		return nil
+	}
+	r.scores[key] = *previous
+	if r.games[key] > 1 {
+		r.games[key]--
+	}
+	return nil
+}
+
+// DeleteBySeason removes every score of a season
+func (r *InMemoryScoreRepository) DeleteBySeason(ctx context.Context, season string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for key := range r.scores {
+		if key.season == season {
+			delete(r.scores, key)
+			delete(r.games, key)
+			deleted++
+		}
+	}
+	for key := range r.history {
+		if key.season == season {
+			delete(r.history, key)
+		}
+	}
+	return deleted, nil
+}
+
+// CopySeasonScores copies the top limit scores of fromSeason into toSeason with their metadata,
+// timestamp and games played; a player who already has a toSeason score fails the whole copy
+func (r *InMemoryScoreRepository) CopySeasonScores(ctx context.Context, fromSeason, toSeason string, limit int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var source []leaderboardmodels.Score
+	for key, score := range r.scores {
+		if key.season == fromSeason {
+			source = append(source, score)
+		}
+	}
+	sort.Slice(source, func(i, j int) bool {
+		if source[i].Score != source[j].Score {
+			return source[i].Score > source[j].Score
+		}
+		if !source[i].Timestamp.Equal(source[j].Timestamp) {
+			return source[i].Timestamp.Before(source[j].Timestamp)
+		}
+		return source[i].UserID.String() < source[j].UserID.String()
+	})
+	if len(source) > limit {
+		source = source[:limit]
+	}
+
+	for _, score := range source {
+		if _, ok := r.scores[scoreKey{score.UserID, toSeason}]; ok {
+			return 0, fmt.Errorf("duplicate score for user %s in season %s", score.UserID, toSeason)
+		}
+	}
+	for _, score := range source {
+		games := r.games[scoreKey{score.UserID, fromSeason}]
+		score.ID = uuid.New()
+		score.Season = toSeason
+		r.scores[scoreKey{score.UserID, toSeason}] = score
+		r.games[scoreKey{score.UserID, toSeason}] = games
+	}
+	return int64(len(source)), nil
+}
+
+// GetMedianScore interpolates the median like PERCENTILE_CONT(0.5), rounded to the nearest integer
+func (r *InMemoryScoreRepository) GetMedianScore(ctx context.Context, season string) (int64, error) {
+	r.mu.RLock()
+	var values []int64
+	for key, score := range r.scores {
+		if key.season == season {
+			values = append(values, score.Score)
+		}
+	}
+	r.mu.RUnlock()
+
+	if len(values) == 0 {
+		return 0, nil
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	mid := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[mid], nil
+	}
+	sum := values[mid-1] + values[mid]
+	// Round half away from zero like math.Round on the float median
+	return (sum + sum%2) / 2, nil
+}
+
+// GetScoreDistribution counts scores per bucket with the same integer formula as the SQL query
+func (r *InMemoryScoreRepository) GetScoreDistribution(ctx context.Context, season string, buckets int) ([]leaderboardmodels.ScoreBucket, error) {
+	r.mu.RLock()
+	var values []int64
+	for key, score := range r.scores {
+		if key.season == season {
+			values = append(values, score.Score)
+		}
+	}
+	r.mu.RUnlock()
+
+	if len(values) == 0 {
+		return []leaderboardmodels.ScoreBucket{}, nil
+	}
+	lowest, highest := values[0], values[0]
+	for _, v := range values {
+		if v < lowest {
+			lowest = v
+		}
+		if v > highest {
+			highest = v
+		}
+	}
+
+	result := leaderboardmodels.NewScoreBuckets(lowest, highest, buckets)
+	width := highest - lowest + 1
+	for _, v := range values {
+		result[(v-lowest)*int64(len(result))/width].Count++
+	}
+	return result, nil
+}
+
+// GetLeaderboardFromView has no snapshot to read, so it serves the live leaderboard
+func (r *InMemoryScoreRepository) GetLeaderboardFromView(ctx context.Context, season string, limit, offset int, sortOrder string) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	return r.GetLeaderboard(ctx, season, limit, offset, leaderboardmodels.SortByScore, sortOrder, nil)
+}
+
+// RefreshLeaderboardView is a no-op: the in-memory "view" is always current
+func (r *InMemoryScoreRepository) RefreshLeaderboardView(ctx context.Context, season string) error {
+	return nil
+}
+
+// GetGlobalStandings ranks each player's best score across all seasons
+func (r *InMemoryScoreRepository) GetGlobalStandings(ctx context.Context, limit int) ([]leaderboardmodels.LeaderboardEntry, int64, error) {
+	names := r.users.userNames()
+
+	r.mu.RLock()
+	best := make(map[uuid.UUID]leaderboardmodels.Score)
+	for key, score := range r.scores {
+		current, ok := best[key.userID]
+		if !ok || score.Score > current.Score || (score.Score == current.Score && score.Timestamp.Before(current.Timestamp)) {
+			best[key.userID] = score
+		}
+	}
+	r.mu.RUnlock()
+
+	total := int64(len(best))
+	entries := make([]leaderboardmodels.LeaderboardEntry, 0, len(best))
+	for userID, score := range best {
+		name, ok := names[userID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, toEntry(score, name))
+	}
+	rank(entries)
+	return paginate(entries, limit, 0), total, nil
+}
+
+// GetCrossSeasonRankings averages each player's normalized season scores (0..100) like the SQL query
+// and ranks the averages densely, rounded to two decimals
+func (r *InMemoryScoreRepository) GetCrossSeasonRankings(ctx context.Context, seasons []string, normalizeByMax bool, limit int) ([]leaderboardmodels.CrossSeasonEntry, error) {
+	names := r.users.userNames()
+	requested := make(map[string]bool, len(seasons))
+	for _, season := range seasons {
+		requested[season] = true
+	}
+
+	r.mu.RLock()
+	maxScore := make(map[string]int64)
+	minScore := make(map[string]int64)
+	seasonScores := make(map[uuid.UUID]map[string]int64)
+	for key, score := range r.scores {
+		if !requested[key.season] {
+			continue
+		}
+		if current, ok := maxScore[key.season]; !ok || score.Score > current {
+			maxScore[key.season] = score.Score
+		}
+		if current, ok := minScore[key.season]; !ok || score.Score < current {
+			minScore[key.season] = score.Score
+		}
+		if seasonScores[key.userID] == nil {
+			seasonScores[key.userID] = make(map[string]int64)
+		}
+		seasonScores[key.userID][key.season] = score.Score
+	}
+	r.mu.RUnlock()
+
+	entries := make([]leaderboardmodels.CrossSeasonEntry, 0, len(seasonScores))
+	for userID, scores := range seasonScores {
+		name, ok := names[userID]
+		if !ok {
+			continue
+		}
+		total := 0.0
+		for season, score := range scores {
+			high, low := maxScore[season], minScore[season]
+			switch {
+			case normalizeByMax && high > 0:
+				total += float64(score) * 100 / float64(high)
+			case !normalizeByMax && high > low:
+				total += float64(score-low) * 100 / float64(high-low)
+			case !normalizeByMax:
+				total += 100
+			}
+		}
+		entries = append(entries, leaderboardmodels.CrossSeasonEntry{
+			UserID:          userID,
+			UserName:        name,
+			NormalizedScore: math.Round(total/float64(len(seasons))*100) / 100,
+			SeasonScores:    scores,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].NormalizedScore != entries[j].NormalizedScore {
+			return entries[i].NormalizedScore > entries[j].NormalizedScore
+		}
+		return entries[i].UserID.String() < entries[j].UserID.String()
+	})
+	current := 0
+	for i := range entries {
+		if i == 0 || entries[i].NormalizedScore != entries[i-1].NormalizedScore {
+			current++
+		}
+		entries[i].Rank = current
+	}
+	return paginate(entries, limit, 0), nil
+}
+
+// GetUserRank returns the player's entry from the ranked season
+func (r *InMemoryScoreRepository) GetUserRank(ctx context.Context, userID uuid.UUID, season string) (*leaderboardmodels.LeaderboardEntry, error) {
+	for _, entry := range r.rankedSeason(season, nil) {
+		if entry.UserID == userID {
+			return &entry, nil
+		}
+	}
+	return nil, repository.ErrRecordNotFound
+}
+
+// GetStreak returns the player's daily submission streaks from the recorded submission history
+func (r *InMemoryScoreRepository) GetStreak(ctx context.Context, userID uuid.UUID, season string) (int, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	current, longest := r.streakLocked(scoreKey{userID, season})
+	return current, longest, nil
+}
+
+// FindBySpec returns scores for which spec.IsSatisfiedBy holds, highest first.
+// Ordering and limit specs only affect SQL, so they are not applied here.
+func (r *InMemoryScoreRepository) FindBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) ([]*leaderboardmodels.Score, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*leaderboardmodels.Score
+	for _, score := range r.scores {
+		if spec == nil || spec.IsSatisfiedBy(score) {
+			score := score
+			result = append(result, &score)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Score > result[j].Score })
+	return result, nil
+}
+
+// FindOneBySpec returns the first score matching spec; returns repository.ErrRecordNotFound if none
+func (r *InMemoryScoreRepository) FindOneBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) (*leaderboardmodels.Score, error) {
+	scores, _ := r.FindBySpec(ctx, spec)
+	if len(scores) == 0 {
+		return nil, repository.ErrRecordNotFound
+	}
+	return scores[0], nil
+}
+
+// CountBySpec counts scores matching spec
+func (r *InMemoryScoreRepository) CountBySpec(ctx context.Context, spec repository.Specification[leaderboardmodels.Score]) (int64, error) {
+	scores, _ := r.FindBySpec(ctx, spec)
+	return int64(len(scores)), nil
+}
+
+// rankedSeason returns every entry of a season in leaderboard order with ranks assigned
+func (r *InMemoryScoreRepository) rankedSeason(season string, excluded map[uuid.UUID]bool) []leaderboardmodels.LeaderboardEntry {
+	names := r.users.userNames()
+
+	r.mu.RLock()
+	entries := make([]leaderboardmodels.LeaderboardEntry, 0)
+	for key, score := range r.scores {
+		if key.season != season || excluded[key.userID] {
+			continue
+		}
+		name, ok := names[key.userID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, toEntry(score, name))
+	}
+	r.mu.RUnlock()
+
+	rank(entries)
+	return entries
+}
+
+// rank sorts by score DESC, timestamp ASC and assigns DENSE_RANK over that pair
+func rank(entries []leaderboardmodels.LeaderboardEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		if !entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return entries[i].Timestamp.Before(entries[j].Timestamp)
+		}
+		return entries[i].UserID.String() < entries[j].UserID.String()
+	})
+
+	current := 0
+	for i := range entries {
+		if i == 0 || entries[i].Score != entries[i-1].Score || !entries[i].Timestamp.Equal(entries[i-1].Timestamp) {
+			current++
+		}
+		entries[i].Rank = current
+	}
+}
+
+// sortKeys lists the ranking keys of an entry for sortBy, most significant first; higher ranks first
+func sortKeys(entry leaderboardmodels.LeaderboardEntry, sortBy string) []int64 {
+	earlier := -entry.Timestamp.UnixNano()
+	switch sortBy {
+	case leaderboardmodels.SortByTimestamp:
+		return []int64{entry.Timestamp.UnixNano(), entry.Score}
+	case leaderboardmodels.SortByGamesPlayed:
+		return []int64{int64(entry.GamesPlayed), entry.Score, earlier}
+	default:
+		return []int64{entry.Score, earlier}
+	}
+}
+
+// rankBy is rank for any sort dimension: sorts by sortKeys DESC and assigns DENSE_RANK over them
+func rankBy(entries []leaderboardmodels.LeaderboardEntry, sortBy string) {
+	compare := func(a, b leaderboardmodels.LeaderboardEntry) int {
+		ka, kb := sortKeys(a, sortBy), sortKeys(b, sortBy)
+		for i := range ka {
+			if ka[i] != kb[i] {
+				if ka[i] > kb[i] {
+					return -1
+				}
+				return 1
+			}
+		}
+		return 0
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if c := compare(entries[i], entries[j]); c != 0 {
+			return c < 0
+		}
+		return entries[i].UserID.String() < entries[j].UserID.String()
+	})
+
+	current := 0
+	for i := range entries {
+		if i == 0 || compare(entries[i], entries[i-1]) != 0 {
+			current++
+		}
+		entries[i].Rank = current
+	}
+}
+
+func toEntry(score leaderboardmodels.Score, name string) leaderboardmodels.LeaderboardEntry {
+	return leaderboardmodels.LeaderboardEntry{
+		UserID:    score.UserID,
+		UserName:  name,
+		Score:     score.Score,
+		Season:    score.Season,
+		Timestamp: score.Timestamp,
+	}
+}
+
+func paginate[T any](entries []T, limit, offset int) []T {
+	if offset >= len(entries) {
+		return []T{}
+	}
+	end := len(entries)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return entries[offset:end]
+}
+
+var (
+	_ repository.UserRepository  = (*InMemoryUserRepository)(nil)
+	_ repository.ScoreRepository = (*InMemoryScoreRepository)(nil)
+)
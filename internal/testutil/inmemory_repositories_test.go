@@ -0,0 +1,265 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryScoreRepository_LeaderboardMatchesSQLOrdering(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	alice, bob, carol := store.AddUser("alice"), store.AddUser("bob"), store.AddUser("carol")
+	ghost := uuid.New() // has a score but no user row
+
+	for _, s := range []leaderboardmodels.Score{
+		{UserID: alice, Score: 500, Season: "global", Timestamp: base.Add(time.Minute)},
+		{UserID: bob, Score: 500, Season: "global", Timestamp: base},
+		{UserID: carol, Score: 100, Season: "global", Timestamp: base},
+		{UserID: ghost, Score: 900, Season: "global", Timestamp: base},
+	} {
+		s := s
+		require.NoError(t, store.Scores.Upsert(ctx, &s))
+	}
+
+	entries, total, err := store.Scores.GetLeaderboard(ctx, "global", 10, 0, leaderboardmodels.SortByScore, "desc", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, entries, 3)
+	assert.Equal(t, []uuid.UUID{bob, alice, carol}, []uuid.UUID{entries[0].UserID, entries[1].UserID, entries[2].UserID})
+	assert.Equal(t, []int{1, 2, 3}, []int{entries[0].Rank, entries[1].Rank, entries[2].Rank})
+
+	page, _, err := store.Scores.GetLeaderboard(ctx, "global", 1, 1, leaderboardmodels.SortByScore, "desc", []uuid.UUID{bob})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, carol, page[0].UserID)
+	assert.Equal(t, 2, page[0].Rank, "excluded players do not take a rank")
+
+	rank, err := store.Scores.GetUserRank(ctx, alice, "global")
+	require.NoError(t, err)
+	assert.Equal(t, 2, rank.Rank)
+}
+
+func TestInMemoryScoreRepository_LeaderboardSortByGamesPlayed(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	alice, bob := store.AddUser("alice"), store.AddUser("bob")
+
+	require.NoError(t, store.Scores.Upsert(ctx, &leaderboardmodels.Score{UserID: alice, Score: 900, Season: "global"}))
+	for _, score := range []int64{100, 50, 200} {
+		_, err := store.Scores.UpsertOnlyIfHigher(ctx, &leaderboardmodels.Score{UserID: bob, Score: score, Season: "global"})
+		require.NoError(t, err)
+	}
+
+	entries, _, err := store.Scores.GetLeaderboard(ctx, "global", 10, 0, leaderboardmodels.SortByGamesPlayed, "desc", nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, bob, entries[0].UserID)
+	assert.Equal(t, 3, entries[0].GamesPlayed, "a score below the personal best still counts as a game")
+	assert.Equal(t, []int{1, 2}, []int{entries[0].Rank, entries[1].Rank})
+
+	entries, _, err = store.Scores.GetLeaderboard(ctx, "global", 10, 0, leaderboardmodels.SortByGamesPlayed, "asc", nil)
+	require.NoError(t, err)
+	assert.Equal(t, alice, entries[0].UserID)
+	assert.Equal(t, 2, entries[0].Rank)
+}
+
+func TestInMemoryScoreRepository_UpsertOnlyIfHigher(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	userID := store.AddUser("alice")
+
+	written, err := store.Scores.UpsertOnlyIfHigher(ctx, &leaderboardmodels.Score{UserID: userID, Score: 300, Season: "global"})
+	require.NoError(t, err)
+	assert.True(t, written)
+
+	written, err = store.Scores.UpsertOnlyIfHigher(ctx, &leaderboardmodels.Score{UserID: userID, Score: 200, Season: "global"})
+	require.NoError(t, err)
+	assert.False(t, written)
+
+	stored, err := store.Scores.FindByUserAndSeason(ctx, userID, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), stored.Score)
+
+	_, err = store.Scores.FindByUserAndSeason(ctx, userID, "other")
+	assert.ErrorIs(t, err, repository.ErrRecordNotFound)
+}
+
+func TestInMemoryScoreRepository_GetStreak(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	alice := store.AddUser("alice")
+	day := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	submitOn := func(offsetDays int, score int64) {
+		store.Scores.now = func() time.Time { return day.AddDate(0, 0, offsetDays) }
+		_, err := store.Scores.UpsertOnlyIfHigher(ctx, &leaderboardmodels.Score{UserID: alice, Score: score, Season: "global"})
+		require.NoError(t, err)
+	}
+
+	// Days 0-3 in a row (day 1 twice, day 2 without a new best), a gap, then days 5-6
+	for _, d := range []int{0, 1, 1, 2, 3, 5, 6} {
+		submitOn(d, int64(100-d))
+	}
+
+	store.Scores.now = func() time.Time { return day.AddDate(0, 0, 7) }
+	current, longest, err := store.Scores.GetStreak(ctx, alice, "global")
+	require.NoError(t, err)
+	assert.Equal(t, 2, current, "yesterday's submission keeps the streak alive")
+	assert.Equal(t, 4, longest)
+
+	entries, _, err := store.Scores.GetLeaderboard(ctx, "global", 10, 0, "", "desc", nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 2, entries[0].StreakCurrent)
+	assert.Equal(t, 4, entries[0].StreakLongest)
+
+	store.Scores.now = func() time.Time { return day.AddDate(0, 0, 8) }
+	current, longest, err = store.Scores.GetStreak(ctx, alice, "global")
+	require.NoError(t, err)
+	assert.Zero(t, current, "a full day without a submission ends the streak")
+	assert.Equal(t, 4, longest)
+
+	current, longest, err = store.Scores.GetStreak(ctx, alice, "winter")
+	require.NoError(t, err)
+	assert.Zero(t, current)
+	assert.Zero(t, longest)
+
+	// Deleting the score drops its history, so the streak starts over
+	require.NoError(t, store.Scores.DeleteByUserAndSeason(ctx, alice, "global"))
+	_, longest, err = store.Scores.GetStreak(ctx, alice, "global")
+	require.NoError(t, err)
+	assert.Zero(t, longest)
+}
+
+func TestInMemoryScoreRepository_Median(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+
+	median, err := store.Scores.GetMedianScore(ctx, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), median)
+
+	for _, value := range []int64{10, 20, 31, 40} {
+		require.NoError(t, store.Scores.Upsert(ctx, &leaderboardmodels.Score{UserID: uuid.New(), Score: value, Season: "global"}))
+	}
+	median, err = store.Scores.GetMedianScore(ctx, "global")
+	require.NoError(t, err)
+	assert.Equal(t, int64(26), median, "25.5 rounds half away from zero")
+}
+
+func TestInMemoryUserRepository_DuplicateEmail(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	userID := store.AddUser("alice")
+
+	user, err := store.Users.FindByID(ctx, userID)
+	require.NoError(t, err)
+
+	duplicate := *user
+	duplicate.ID = uuid.Nil
+	assert.Error(t, store.Users.Create(ctx, &duplicate))
+
+	found, err := store.Users.FindBySpec(ctx, repository.SearchUsersSpec("ali"))
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+}
+
+func TestInMemoryScoreRepository_FindAllAndCount(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, store.Scores.Upsert(ctx, &leaderboardmodels.Score{UserID: first, Score: 200, Season: "global", Timestamp: base}))
+	require.NoError(t, store.Scores.Upsert(ctx, &leaderboardmodels.Score{UserID: second, Score: 200, Season: "global", Timestamp: base.Add(time.Second)}))
+	require.NoError(t, store.Scores.Upsert(ctx, &leaderboardmodels.Score{UserID: third, Score: 100, Season: "global", Timestamp: base}))
+	require.NoError(t, store.Scores.Upsert(ctx, &leaderboardmodels.Score{UserID: first, Score: 50, Season: "winter", Timestamp: base}))
+
+	page, err := store.Scores.FindAll(ctx, "global", "desc", 2, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, second, page[0].UserID)
+	assert.Equal(t, third, page[1].UserID)
+
+	asc, err := store.Scores.FindAll(ctx, "global", "asc", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, asc, 3)
+	assert.Equal(t, third, asc[0].UserID)
+
+	total, err := store.Scores.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+}
+
+func TestInMemoryScoreRepository_GetScoreDistribution(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+
+	for i, value := range []int64{0, 10, 49, 50, 100} {
+		score := leaderboardmodels.Score{UserID: uuid.New(), Score: value, Season: "global"}
+		require.NoError(t, store.Scores.Upsert(ctx, &score), "score %d", i)
+	}
+
+	distribution, err := store.Scores.GetScoreDistribution(ctx, "global", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []leaderboardmodels.ScoreBucket{
+		{Min: 0, Max: 50, Count: 4},
+		{Min: 51, Max: 100, Count: 1},
+	}, distribution)
+
+	narrow, err := store.Scores.GetScoreDistribution(ctx, "global", 1000)
+	require.NoError(t, err)
+	assert.Len(t, narrow, 101, "no more buckets than distinct scores in the range")
+
+	empty, err := store.Scores.GetScoreDistribution(ctx, "winter", 10)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestInMemoryScoreRepository_GetCrossSeasonRankings(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryStore()
+	alice, bob, carol := store.AddUser("alice"), store.AddUser("bob"), store.AddUser("carol")
+
+	for _, s := range []leaderboardmodels.Score{
+		{UserID: alice, Score: 1000, Season: "spring"},
+		{UserID: bob, Score: 500, Season: "spring"},
+		{UserID: bob, Score: 400, Season: "summer"},
+		{UserID: carol, Score: 100, Season: "summer"},
+		{UserID: carol, Score: 9000, Season: "autumn"},
+	} {
+		s := s
+		require.NoError(t, store.Scores.Upsert(ctx, &s))
+	}
+
+	entries, err := store.Scores.GetCrossSeasonRankings(ctx, []string{"spring", "summer"}, true, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, []uuid.UUID{bob, alice, carol}, []uuid.UUID{entries[0].UserID, entries[1].UserID, entries[2].UserID})
+	assert.Equal(t, []float64{75, 50, 12.5}, []float64{entries[0].NormalizedScore, entries[1].NormalizedScore, entries[2].NormalizedScore},
+		"a season without a score counts as 0")
+	assert.Equal(t, map[string]int64{"spring": 500, "summer": 400}, entries[0].SeasonScores)
+	assert.Equal(t, map[string]int64{"summer": 100}, entries[2].SeasonScores, "seasons outside the request are ignored")
+
+	ranged, err := store.Scores.GetCrossSeasonRankings(ctx, []string{"spring", "summer"}, false, 10)
+	require.NoError(t, err)
+	require.Len(t, ranged, 3)
+	assert.Equal(t, []int{1, 1, 2}, []int{ranged[0].Rank, ranged[1].Rank, ranged[2].Rank}, "alice and bob both average 50 with min-max normalization")
+	assert.Equal(t, carol, ranged[2].UserID)
+	assert.Equal(t, float64(0), ranged[2].NormalizedScore)
+
+	top, err := store.Scores.GetCrossSeasonRankings(ctx, []string{"spring", "summer"}, true, 1)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, bob, top[0].UserID)
+}
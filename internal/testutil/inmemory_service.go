@@ -0,0 +1,90 @@
+// Package testutil provides infrastructure-free building blocks for unit tests.
+package testutil
+
+import (
+	"context"
+	"fmt"
+
+	authmodels "leaderboard-service/internal/auth/models"
+	leaderboardservice "leaderboard-service/internal/leaderboard/service"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/shared/repository"
+
+	"github.com/google/uuid"
+)
+
+// InMemoryStore bundles the in-memory repositories behind a test service so tests can seed and inspect data
+type InMemoryStore struct {
+	Users  *InMemoryUserRepository
+	Scores *InMemoryScoreRepository
+}
+
+// NewInMemoryStore creates empty user and score repositories joined to each other
+func NewInMemoryStore() *InMemoryStore {
+	users := NewInMemoryUserRepository()
+	return &InMemoryStore{
+		Users:  users,
+		Scores: NewInMemoryScoreRepository(users),
+	}
+}
+
+// AddUser creates a user with the given name and returns its ID.
+// Leaderboards only show scores of existing users, like the SQL JOIN on users.
+func (s *InMemoryStore) AddUser(name string) uuid.UUID {
+	user := &authmodels.User{
+		Name:     name,
+		Email:    fmt.Sprintf("%s-%s@example.com", name, uuid.NewString()[:8]),
+		Password: "hashed",
+	}
+	if err := s.Users.Create(context.Background(), user); err != nil {
+		panic(err) // emails are random, so this only fails on a repository bug
+	}
+	return user.ID
+}
+
+// LeaderboardService builds a LeaderboardService over this store without Redis or a WebSocket hub.
+// A nil cfg uses TestConfig.
+func (s *InMemoryStore) LeaderboardService(cfg *config.Config) *leaderboardservice.LeaderboardService {
+	if cfg == nil {
+		cfg = TestConfig()
+	}
+	return leaderboardservice.NewLeaderboardService(s.Scores, s.Users, nil, cfg)
+}
+
+// NewInMemoryLeaderboardService creates a LeaderboardService backed by fresh in-memory repositories.
+// Use NewInMemoryStore instead when the test needs to seed users.
+func NewInMemoryLeaderboardService(cfg *config.Config) *leaderboardservice.LeaderboardService {
+	return NewInMemoryStore().LeaderboardService(cfg)
+}
+
+// TestConfig returns the smallest config a LeaderboardService needs
+func TestConfig() *config.Config {
+	return &config.Config{
+		Validation: config.ValidationConfig{
+			MinScore: 0,
+			MaxScore: 1000000,
+		},
+	}
+}
+
+// UnitOfWork returns a unit of work over this store's repositories.
+// It has no isolation and no rollback: writes made before a failing step stay in the store.
+func (s *InMemoryStore) UnitOfWork() repository.UnitOfWork {
+	return &inMemoryUnitOfWork{store: s}
+}
+
+type inMemoryUnitOfWork struct {
+	store *InMemoryStore
+}
+
+func (u *inMemoryUnitOfWork) Begin(ctx context.Context) error { return nil }
+func (u *inMemoryUnitOfWork) Commit() error                   { return nil }
+func (u *inMemoryUnitOfWork) Rollback() error                 { return nil }
+
+func (u *inMemoryUnitOfWork) GetUserRepository() repository.UserRepository { return u.store.Users }
+
+func (u *inMemoryUnitOfWork) GetScoreRepository() repository.ScoreRepository { return u.store.Scores }
+
+func (u *inMemoryUnitOfWork) Do(ctx context.Context, fn func(uow repository.UnitOfWork) error) error {
+	return fn(u)
+}
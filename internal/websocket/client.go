@@ -2,6 +2,10 @@ package websocket
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +19,31 @@ type ClientConfig struct {
 	PongWait       time.Duration
 	PingPeriod     time.Duration
 	MaxMessageSize int64
+	// MaxRequestedLimit caps RequestedLimit (0 means no cap)
+	MaxRequestedLimit int
+}
+
+// ClampLimit bounds a requested entry limit to [1, max]; max <= 0 leaves the upper bound open
+func ClampLimit(limit, max int) int {
+	if limit < 1 {
+		return 1
+	}
+	if max > 0 && limit > max {
+		return max
+	}
+	return limit
+}
+
+// Conn is the part of *websocket.Conn a Client uses; tests substitute a fake connection
+type Conn interface {
+	SetReadLimit(limit int64)
+	SetReadDeadline(t time.Time) error
+	SetWriteDeadline(t time.Time) error
+	SetPongHandler(h func(appData string) error)
+	ReadMessage() (messageType int, p []byte, err error)
+	NextWriter(messageType int) (io.WriteCloser, error)
+	WriteMessage(messageType int, data []byte) error
+	Close() error
 }
 
 // Client represents a single WebSocket connection
@@ -23,7 +52,7 @@ type Client struct {
 	Hub *Hub
 
 	// The WebSocket connection
-	Conn *websocket.Conn
+	Conn Conn
 
 	// Buffered channel of outbound messages
 	Send chan []byte
@@ -39,10 +68,13 @@ type Client struct {
 
 	// Configuration
 	config ClientConfig
+
+	// writeMu serializes writes to Conn: gorilla/websocket allows only one concurrent writer
+	writeMu sync.Mutex
 }
 
 // NewClient creates a new WebSocket client
-func NewClient(hub *Hub, conn *websocket.Conn, userID uuid.UUID, season string, config ClientConfig) *Client {
+func NewClient(hub *Hub, conn Conn, userID uuid.UUID, season string, config ClientConfig) *Client {
 	return &Client{
 		Hub:            hub,
 		Conn:           conn,
@@ -58,7 +90,7 @@ func NewClient(hub *Hub, conn *websocket.Conn, userID uuid.UUID, season string,
 // The application runs ReadPump in a per-connection goroutine
 func (c *Client) ReadPump() {
 	defer func() {
-		c.Hub.Unregister <- c
+		c.Hub.requestUnregister(c)
 		_ = c.Conn.Close()
 	}()
 
@@ -91,7 +123,7 @@ func (c *Client) ReadPump() {
 			if msgType, ok := msg["type"].(string); ok && msgType == "update_limit" {
 				if limit, ok := msg["limit"].(float64); ok {
 					old := c.RequestedLimit
-					c.RequestedLimit = int(limit)
+					c.RequestedLimit = ClampLimit(int(limit), c.config.MaxRequestedLimit)
 					log.Info().
 						Str("user_id", c.UserID.String()).
 						Int("old_limit", old).
@@ -110,21 +142,24 @@ func (c *Client) ReadPump() {
 }
 
 // WritePump pumps messages from the hub to the WebSocket connection
-// A goroutine running WritePump is started for each connection
+// A goroutine running WritePump is started for each connection.
+// Every write has a WriteWait deadline; a client that cannot take a message in time
+// is disconnected and unregistered instead of holding its Send buffer forever.
 func (c *Client) WritePump() {
 	ticker := time.NewTicker(c.config.PingPeriod)
 	defer func() {
 		ticker.Stop()
 		_ = c.Conn.Close()
+		// Повторная отписка после ReadPump или закрытия Send хабом ничего не делает
+		c.Hub.requestUnregister(c)
 	}()
 
 	for {
 		select {
 		case message, ok := <-c.Send:
-			_ = c.Conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
 			if !ok {
 				// The hub closed the channel
-				_ = c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+				_ = c.writeMessage(websocket.CloseMessage, []byte{})
 				return
 			}
 
@@ -134,32 +169,78 @@ func (c *Client) WritePump() {
 				Int("message_size", len(message)).
 				Msg("📤📤📤 WritePump: Sending message to WebSocket")
 
-			w, err := c.Conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				log.Error().Err(err).Msg("❌ WritePump: Failed to get NextWriter")
-				return
-			}
-			_, _ = w.Write(message)
-
-			// Add queued messages to the current WebSocket message
-			n := len(c.Send)
-			for i := 0; i < n; i++ {
-				_, _ = w.Write([]byte{'\n'})
-				_, _ = w.Write(<-c.Send)
-			}
-
-			if err := w.Close(); err != nil {
-				log.Error().Err(err).Msg("❌ WritePump: Failed to close writer")
+			if err := c.writeBatch(message); err != nil {
+				c.logWriteError(err)
 				return
 			}
 
 			log.Info().Msg("✅ WritePump: Message successfully written to WebSocket")
 
 		case <-ticker.C:
-			_ = c.Conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
-			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			if err := c.writeMessage(websocket.PingMessage, nil); err != nil {
+				c.logWriteError(err)
 				return
 			}
 		}
 	}
 }
+
+// logWriteError logs why WritePump gives up on the connection
+func (c *Client) logWriteError(err error) {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		log.Warn().
+			Str("user_id", c.UserID.String()).
+			Str("season", c.Season).
+			Dur("write_wait", c.config.WriteWait).
+			Msg("⚠️ WritePump: Write deadline exceeded, disconnecting slow client")
+		return
+	}
+	log.Error().Err(err).Str("user_id", c.UserID.String()).Msg("❌ WritePump: Failed to write message")
+}
+
+// writeBatch writes message together with everything already queued in Send as one text frame
+func (c *Client) writeBatch(message []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.Conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
+	w, err := c.Conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+	_, _ = w.Write(message)
+
+	// Add queued messages to the current WebSocket message
+	n := len(c.Send)
+	for i := 0; i < n; i++ {
+		_, _ = w.Write([]byte{'\n'})
+		_, _ = w.Write(<-c.Send)
+	}
+
+	return w.Close()
+}
+
+// writeMessage writes a single frame under the write lock
+func (c *Client) writeMessage(messageType int, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.Conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
+	return c.Conn.WriteMessage(messageType, data)
+}
+
+// rejectSeasonFull sends a close frame to a client the hub refused to register and stops its WritePump.
+// The client never entered the hub, so closing Send here cannot race with unregisterClient.
+func (c *Client) rejectSeasonFull() {
+	if c.Conn != nil {
+		_ = c.writeMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "season is full"))
+	}
+	close(c.Send)
+}
+
+// WriteDirect writes a text message straight to the connection, bypassing the Send channel.
+// Used when Send is full and the message must still reach the client.
+func (c *Client) WriteDirect(message []byte) error {
+	return c.writeMessage(websocket.TextMessage, message)
+}
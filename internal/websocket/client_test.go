@@ -0,0 +1,107 @@
+package websocket
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+// timeoutError is what a net.Conn returns once its write deadline passes
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// blockingConn is a client that never reads: every write hangs until the write deadline
+type blockingConn struct {
+	mu       sync.Mutex
+	deadline time.Time
+	closed   chan struct{}
+	once     sync.Once
+}
+
+func newBlockingConn() *blockingConn {
+	return &blockingConn{closed: make(chan struct{})}
+}
+
+func (c *blockingConn) SetReadLimit(limit int64)                    {}
+func (c *blockingConn) SetReadDeadline(t time.Time) error           { return nil }
+func (c *blockingConn) SetPongHandler(h func(appData string) error) {}
+func (c *blockingConn) ReadMessage() (int, []byte, error)           { <-c.closed; return 0, nil, io.EOF }
+
+func (c *blockingConn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deadline = t
+	return nil
+}
+
+func (c *blockingConn) NextWriter(messageType int) (io.WriteCloser, error) {
+	return nil, c.block()
+}
+
+func (c *blockingConn) WriteMessage(messageType int, data []byte) error {
+	return c.block()
+}
+
+func (c *blockingConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+
+// block waits until the write deadline and fails like a stalled TCP write
+func (c *blockingConn) block() error {
+	c.mu.Lock()
+	deadline := c.deadline
+	c.mu.Unlock()
+
+	select {
+	case <-time.After(time.Until(deadline)):
+		return timeoutError{}
+	case <-c.closed:
+		return io.ErrClosedPipe
+	}
+}
+
+func TestWritePumpDisconnectsClientAfterWriteDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hub := NewHub(ctx, time.Second, 10).WithLogger(zerolog.New(io.Discard)).WithPolling(false)
+	go hub.Run()
+
+	conn := newBlockingConn()
+	client := NewClient(hub, conn, uuid.New(), "global", ClientConfig{
+		WriteWait:  20 * time.Millisecond,
+		PongWait:   time.Minute,
+		PingPeriod: time.Minute,
+	})
+	hub.Register <- client
+
+	done := make(chan struct{})
+	go func() {
+		client.WritePump()
+		close(done)
+	}()
+	client.Send <- []byte(`{"type":"leaderboard_update"}`)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("WritePump kept waiting on a client that does not read")
+	}
+
+	select {
+	case <-conn.closed:
+	default:
+		t.Fatal("connection was not closed")
+	}
+	require.Eventually(t, func() bool { return hub.GetSeasonClientCount("global") == 0 },
+		time.Second, 10*time.Millisecond, "client was not unregistered")
+}
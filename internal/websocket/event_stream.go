@@ -0,0 +1,234 @@
+package websocket
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sseSubscriberBuffer - размер очереди событий одного SSE подписчика
+const sseSubscriberBuffer = 16
+
+// sseStreamIdleTTL - сколько хранится буфер сезона без подписчиков и новых событий.
+// Клиент, вернувшийся позже, получит поток с новой нумерацией
+const sseStreamIdleTTL = 5 * time.Minute
+
+// SSEEvent is one server-sent event; ID grows by one with every event of a season
+type SSEEvent struct {
+	ID   uint64
+	Data []byte
+}
+
+// SSESubscription delivers the events of one season to an SSE client.
+// Events is closed when the client falls behind or the hub stops streaming.
+type SSESubscription struct {
+	Season string
+	Events <-chan SSEEvent
+
+	events chan SSEEvent
+	stream *eventStream
+}
+
+// eventStream хранит номер последнего события сезона, кольцевой буфер последних событий
+// для повторной отправки по Last-Event-ID и текущих SSE подписчиков
+type eventStream struct {
+	seq atomic.Uint64
+
+	mu            sync.Mutex
+	buffer        []SSEEvent // кольцо емкостью len(buffer)
+	start         int        // индекс самого старого события
+	count         int
+	lastPublished time.Time
+	subscribers   map[*SSESubscription]struct{}
+}
+
+func newEventStream(bufferSize int) *eventStream {
+	return &eventStream{
+		buffer:      make([]SSEEvent, bufferSize),
+		subscribers: make(map[*SSESubscription]struct{}),
+	}
+}
+
+// publish присваивает событию следующий номер, кладет его в буфер и рассылает подписчикам.
+// Подписчик с переполненной очередью отключается: он переподключится с Last-Event-ID
+// и получит пропущенное из буфера
+func (s *eventStream) publish(data []byte) SSEEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event := SSEEvent{ID: s.seq.Add(1), Data: data}
+	s.lastPublished = time.Now()
+	if size := len(s.buffer); size > 0 {
+		if s.count < size {
+			s.buffer[(s.start+s.count)%size] = event
+			s.count++
+		} else {
+			s.buffer[s.start] = event
+			s.start = (s.start + 1) % size
+		}
+	}
+
+	for sub := range s.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			delete(s.subscribers, sub)
+			close(sub.events)
+		}
+	}
+	return event
+}
+
+// subscribe добавляет подписчика и возвращает события после lastEventID. Буфер и подписка
+// меняются под одной блокировкой, поэтому между повтором и новыми событиями нет пропуска.
+// lastEventID больше текущего номера означает, что сервер перезапускался и нумерация
+// началась заново, - тогда повторяется весь буфер
+func (s *eventStream) subscribe(sub *SSESubscription, lastEventID uint64, resume bool) []SSEEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscribers[sub] = struct{}{}
+	if !resume {
+		return nil
+	}
+
+	if lastEventID > s.seq.Load() {
+		lastEventID = 0
+	}
+	var replay []SSEEvent
+	for i := 0; i < s.count; i++ {
+		event := s.buffer[(s.start+i)%len(s.buffer)]
+		if event.ID > lastEventID {
+			replay = append(replay, event)
+		}
+	}
+	return replay
+}
+
+func (s *eventStream) unsubscribe(sub *SSESubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (s *eventStream) subscriberCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+// idle сообщает, что поток можно удалить: подписчиков нет, а буфер пуст
+// или не пополнялся дольше ttl
+func (s *eventStream) idle(now time.Time, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers) == 0 && (s.count == 0 || now.Sub(s.lastPublished) > ttl)
+}
+
+// closeSubscribers отключает всех подписчиков; буфер остается для переподключения
+func (s *eventStream) closeSubscribers() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// pruneEventStreams удаляет потоки без подписчиков с пустым или устаревшим буфером,
+// чтобы карта потоков не росла от сезонов, которые больше никто не слушает
+func (h *Hub) pruneEventStreams() {
+	h.streamsMu.Lock()
+	defer h.streamsMu.Unlock()
+
+	now := time.Now()
+	for season, stream := range h.streams {
+		if stream.idle(now, sseStreamIdleTTL) {
+			delete(h.streams, season)
+		}
+	}
+}
+
+// SubscribeSSE subscribes to the events of a season. With resume set, the events after
+// lastEventID that are still in the season's buffer are returned for replay first.
+// Call UnsubscribeSSE when the client disconnects.
+func (h *Hub) SubscribeSSE(season string, lastEventID uint64, resume bool) (*SSESubscription, []SSEEvent) {
+	events := make(chan SSEEvent, sseSubscriberBuffer)
+	sub := &SSESubscription{Season: season, Events: events, events: events}
+
+	// Поток создается и получает подписчика под streamsMu, иначе pruneEventStreams
+	// мог бы удалить его между созданием и подпиской
+	h.streamsMu.Lock()
+	stream, ok := h.streams[season]
+	if !ok {
+		stream = newEventStream(h.sseBufferSize)
+		h.streams[season] = stream
+	}
+	sub.stream = stream
+	replay := stream.subscribe(sub, lastEventID, resume)
+	h.streamsMu.Unlock()
+
+	h.logger.Info().
+		Str("season", season).
+		Bool("resume", resume).
+		Uint64("last_event_id", lastEventID).
+		Int("replayed", len(replay)).
+		Msg("✅ SSE client subscribed")
+	return sub, replay
+}
+
+// UnsubscribeSSE removes a subscription; safe to call more than once.
+// A season left without subscribers and buffered events is forgotten right away.
+func (h *Hub) UnsubscribeSSE(sub *SSESubscription) {
+	sub.stream.unsubscribe(sub)
+	h.pruneEventStreams()
+}
+
+// LastEventID returns the ID of the latest event of a season (0 before the first one)
+func (h *Hub) LastEventID(season string) uint64 {
+	h.streamsMu.Lock()
+	stream, ok := h.streams[season]
+	h.streamsMu.Unlock()
+	if !ok {
+		return 0
+	}
+	return stream.seq.Load()
+}
+
+// CloseEventStreams disconnects every SSE client, e.g. when the HTTP server shuts down,
+// which waits for the streaming handlers to return
+func (h *Hub) CloseEventStreams() {
+	h.streamsMu.Lock()
+	defer h.streamsMu.Unlock()
+
+	for _, stream := range h.streams {
+		stream.closeSubscribers()
+	}
+}
+
+// sseSeasons возвращает сезоны, на которые подписан хотя бы один SSE клиент
+func (h *Hub) sseSeasons() []string {
+	h.streamsMu.Lock()
+	defer h.streamsMu.Unlock()
+
+	var seasons []string
+	for season, stream := range h.streams {
+		if stream.subscriberCount() > 0 {
+			seasons = append(seasons, season)
+		}
+	}
+	return seasons
+}
+
+// hasSSESubscribers сообщает, есть ли у сезона SSE клиенты
+func (h *Hub) hasSSESubscribers(season string) bool {
+	h.streamsMu.Lock()
+	stream, ok := h.streams[season]
+	h.streamsMu.Unlock()
+	return ok && stream.subscriberCount() > 0
+}
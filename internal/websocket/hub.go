@@ -10,10 +10,63 @@ import (
 	"time"
 
 	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/metrics"
+	"leaderboard-service/internal/shared/utils"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
+// PeriodicUpdateRegistrar принимает callback, который вызывается каждые broadcastInterval
+// с максимальным запрошенным лимитом по каждому сезону. Реализуется Hub; в тестах - MockHub
+type PeriodicUpdateRegistrar interface {
+	SetPeriodicUpdateCallback(fn func(seasonLimits map[string]int))
+}
+
+// SeasonEventBroadcaster рассылает клиентам сезона произвольное JSON-сообщение (например, SeasonEventMessage).
+// Реализуется Hub; сервисы проверяют поддержку через type assertion, как и для PeriodicUpdateRegistrar
+type SeasonEventBroadcaster interface {
+	BroadcastSeason(season string, message interface{})
+}
+
+// Season state changes announced with SeasonEventMessage
+const (
+	SeasonEventOpened = "opened"
+	SeasonEventClosed = "closed"
+	SeasonEventReset  = "reset"
+)
+
+// SeasonEventMessage tells a season's clients that the season was opened, closed or reset,
+// so they can drop data they still show
+type SeasonEventMessage struct {
+	Type      string `json:"type"` // always "season_event"
+	Event     string `json:"event"`
+	Season    string `json:"season"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// NewSeasonEventMessage creates a season_event message stamped with the current time
+func NewSeasonEventMessage(event, season string) *SeasonEventMessage {
+	return &SeasonEventMessage{Type: "season_event", Event: event, Season: season, Timestamp: time.Now().Unix()}
+}
+
+// ShutdownNotice is sent to every client when the server is going down
+const ShutdownNotice = "Server is restarting, please reconnect in 10 seconds"
+
+// shutdownPollInterval is how often Shutdown checks whether clients have disconnected
+const shutdownPollInterval = 50 * time.Millisecond
+
+// ShutdownMessage tells clients that the server is stopping and they should reconnect later
+type ShutdownMessage struct {
+	Type    string `json:"type"` // always "shutdown"
+	Message string `json:"message"`
+}
+
+// NewShutdownMessage creates a shutdown message with the default notice
+func NewShutdownMessage() *ShutdownMessage {
+	return &ShutdownMessage{Type: "shutdown", Message: ShutdownNotice}
+}
+
 // Hub maintains the set of active clients and broadcasts messages to clients
 type Hub struct {
 	// Registered clients per season
@@ -40,15 +93,29 @@ type Hub struct {
 	// Callback for periodic updates (called every N seconds with max requested limit per season)
 	OnPeriodicUpdate func(seasonLimits map[string]int)
 
+	// Logger used by all hub methods (global logger unless replaced via WithLogger)
+	logger zerolog.Logger
+
 	// Configuration
 	broadcastInterval time.Duration
 	defaultLimit      int
+	maxClientLimit    int // 0 means no cap
+	// maxClientsPerSeason rejects connections beyond this many subscribers of one season; 0 means no cap
+	maxClientsPerSeason int
+	polling             bool
+
+	// SSE streams per season: event IDs and the recent events replayed on reconnect
+	streamsMu     sync.Mutex
+	streams       map[string]*eventStream
+	sseBufferSize int
 }
 
-// BroadcastMessage contains the season and leaderboard data to broadcast
+// BroadcastMessage contains the season and the data to broadcast: either a leaderboard,
+// trimmed to each client's limit, or a Payload sent to every client as is
 type BroadcastMessage struct {
 	Season      string
 	Leaderboard *leaderboardmodels.LeaderboardResponse
+	Payload     interface{}
 }
 
 // NewHub creates a new Hub instance
@@ -59,21 +126,77 @@ func NewHub(ctx context.Context, broadcastInterval time.Duration, defaultLimit i
 		Unregister:        make(chan *Client),
 		Clients:           make(map[string]map[*Client]bool),
 		lastBroadcastHash: make(map[string]string),
+		streams:           make(map[string]*eventStream),
 		ctx:               ctx,
+		logger:            log.Logger,
 		broadcastInterval: broadcastInterval,
 		defaultLimit:      defaultLimit,
+		polling:           true,
 	}
 }
 
+// WithLogger replaces the hub logger, e.g. to set a per-hub level or capture output in tests
+// Must be called before Run
+func (h *Hub) WithLogger(logger zerolog.Logger) *Hub {
+	h.logger = logger
+	return h
+}
+
+// WithMaxClientLimit caps the per-season limit passed to OnPeriodicUpdate
+// Must be called before Run
+func (h *Hub) WithMaxClientLimit(limit int) *Hub {
+	h.maxClientLimit = limit
+	return h
+}
+
+// WithMaxClientsPerSeason caps the number of clients subscribed to one season;
+// further connections are closed with "try again later" (1013)
+// Must be called before Run
+func (h *Hub) WithMaxClientsPerSeason(limit int) *Hub {
+	h.maxClientsPerSeason = limit
+	return h
+}
+
+// WithPolling enables or disables the periodic OnPeriodicUpdate calls (enabled by default).
+// Disable it when NotifySeasonChanged is driven by database notifications.
+// Must be called before Run
+func (h *Hub) WithPolling(enabled bool) *Hub {
+	h.polling = enabled
+	return h
+}
+
+// WithSSEBufferSize sets how many recent events per season are kept for SSE clients
+// that reconnect with Last-Event-ID; 0 disables the replay.
+// Must be called before Run
+func (h *Hub) WithSSEBufferSize(size int) *Hub {
+	h.sseBufferSize = size
+	return h
+}
+
+// SetPeriodicUpdateCallback sets OnPeriodicUpdate
+// Must be called before Run
+func (h *Hub) SetPeriodicUpdateCallback(fn func(seasonLimits map[string]int)) {
+	h.OnPeriodicUpdate = fn
+}
+
 // Run starts the hub's main loop (must be run in a goroutine)
 func (h *Hub) Run() {
-	log.Info().Msg("🔌 WebSocket Hub started")
+	h.logger.Info().Msg("🔌 WebSocket Hub started")
+
+	// Ticker for periodic broadcasts; a nil channel never fires when polling is disabled
+	var tick <-chan time.Time
+	if h.polling {
+		ticker := time.NewTicker(h.broadcastInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
 
-	// Ticker for periodic broadcasts
-	ticker := time.NewTicker(h.broadcastInterval)
-	defer ticker.Stop()
+	// Потоки SSE без подписчиков чистятся независимо от опроса
+	pruneTicker := time.NewTicker(sseStreamIdleTTL)
+	defer pruneTicker.Stop()
 
-	log.Info().
+	h.logger.Info().
+		Bool("polling", h.polling).
 		Dur("interval", h.broadcastInterval).
 		Int("default_limit", h.defaultLimit).
 		Msg("⚙️ Hub configuration loaded")
@@ -89,11 +212,14 @@ func (h *Hub) Run() {
 		case message := <-h.BroadcastChan:
 			h.broadcastToSeason(message)
 
-		case <-ticker.C:
+		case <-tick:
 			h.triggerPeriodicUpdates()
 
+		case <-pruneTicker.C:
+			h.pruneEventStreams()
+
 		case <-h.ctx.Done():
-			log.Info().Msg("🛑 WebSocket Hub shutting down")
+			h.logger.Info().Msg("🛑 WebSocket Hub shutting down")
 			h.closeAllClients()
 			return
 		}
@@ -105,12 +231,24 @@ func (h *Hub) registerClient(client *Client) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	if h.maxClientsPerSeason > 0 && len(h.Clients[client.Season]) >= h.maxClientsPerSeason {
+		h.logger.Warn().
+			Str("season", client.Season).
+			Str("user_id", client.UserID.String()).
+			Int("limit", h.maxClientsPerSeason).
+			Msg("🚫 WebSocket client rejected: season is full")
+		// Запись close frame может ждать до WriteWait, поэтому не держим блокировку хаба
+		go client.rejectSeasonFull()
+		return
+	}
+
 	if h.Clients[client.Season] == nil {
 		h.Clients[client.Season] = make(map[*Client]bool)
 	}
 	h.Clients[client.Season][client] = true
+	metrics.WSConnections.Set(float64(len(h.Clients[client.Season])), client.Season)
 
-	log.Info().
+	h.logger.Info().
 		Str("season", client.Season).
 		Str("user_id", client.UserID.String()).
 		Int("total_clients", h.getTotalClients()).
@@ -131,8 +269,9 @@ func (h *Hub) unregisterClient(client *Client) {
 			if len(clients) == 0 {
 				delete(h.Clients, client.Season)
 			}
+			h.updateConnectionsGauge(client.Season, len(clients))
 
-			log.Info().
+			h.logger.Info().
 				Str("season", client.Season).
 				Str("user_id", client.UserID.String()).
 				Int("total_clients", h.getTotalClients()).
@@ -141,6 +280,25 @@ func (h *Hub) unregisterClient(client *Client) {
 	}
 }
 
+// updateConnectionsGauge выставляет число клиентов сезона в метрике; сезон без клиентов
+// удаляется из нее, чтобы закрытые сезоны не копили серии. Вызывается под h.mu
+func (h *Hub) updateConnectionsGauge(season string, clients int) {
+	if clients == 0 {
+		metrics.WSConnections.Delete(season)
+		return
+	}
+	metrics.WSConnections.Set(float64(clients), season)
+}
+
+// requestUnregister hands client to the Run loop for unregistration;
+// gives up once the hub is stopped, so pumps never block on a hub that no longer reads
+func (h *Hub) requestUnregister(client *Client) {
+	select {
+	case h.Unregister <- client:
+	case <-h.ctx.Done():
+	}
+}
+
 // broadcastToSeason sends a message to all clients in a specific season
 func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 	h.mu.RLock()
@@ -148,14 +306,23 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 	clientCount := len(clients)
 	h.mu.RUnlock()
 
-	log.Info().
+	h.logger.Info().
 		Str("season", message.Season).
 		Int("clients", clientCount).
-		Int("entries", len(message.Leaderboard.Entries)).
+		Bool("leaderboard", message.Leaderboard != nil).
 		Msg("📤 broadcastToSeason called")
 
+	h.publishSSE(message)
+
 	if clientCount == 0 {
-		log.Warn().Str("season", message.Season).Msg("⚠️ No clients connected for this season")
+		h.logger.Warn().Str("season", message.Season).Msg("⚠️ No clients connected for this season")
+		return
+	}
+
+	metrics.WSBroadcasts.Inc(message.Season)
+
+	if message.Leaderboard == nil {
+		h.broadcastPayload(message.Season, clients, message.Payload)
 		return
 	}
 
@@ -166,7 +333,7 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 		// Calculate hash of the leaderboard data to avoid sending duplicate broadcasts
 		hashData, err := json.Marshal(message.Leaderboard)
 		if err != nil {
-			log.Error().Err(err).Msg("Failed to marshal leaderboard for hash calculation")
+			h.logger.Error().Err(err).Msg("Failed to marshal leaderboard for hash calculation")
 			return
 		}
 		hash := sha256.Sum256(hashData)
@@ -176,7 +343,7 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 		h.mu.Lock()
 		lastHash, exists := h.lastBroadcastHash[message.Season]
 		if exists && lastHash == hashStr {
-			log.Info().
+			h.logger.Info().
 				Str("season", message.Season).
 				Str("hash", hashStr[:16]+"...").
 				Msg("🔄 Skipping broadcast - data unchanged from last broadcast")
@@ -186,14 +353,14 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 		h.lastBroadcastHash[message.Season] = hashStr
 		h.mu.Unlock()
 
-		log.Info().
+		h.logger.Info().
 			Str("season", message.Season).
 			Str("hash", hashStr[:16]+"...").
 			Bool("data_changed", !exists || lastHash != hashStr).
 			Msg("✨ Data changed - proceeding with broadcast")
 	*/
 
-	log.Info().
+	h.logger.Info().
 		Str("season", message.Season).
 		Msg("✨ Proceeding with broadcast (hash check disabled)")
 
@@ -208,7 +375,7 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 			entry.Rank, entry.Score, entry.UserName, entry.Timestamp)
 	}
 	top3Log += "]"
-	log.Info().
+	h.logger.Info().
 		Str("season", message.Season).
 		Int("total_entries", len(message.Leaderboard.Entries)).
 		Str("top3_in_hub", top3Log).
@@ -228,6 +395,8 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 		// Create custom leaderboard response for this client
 		clientLeaderboard := *message.Leaderboard // Copy struct
 		clientLeaderboard.Entries = filteredEntries
+		// Клиент видит только первую страницу своего размера, поэтому пересчитываем метаданные
+		clientLeaderboard.PaginationMeta = utils.NewPaginationMeta(1, client.RequestedLimit, message.Leaderboard.TotalCount)
 
 		// Marshal message for this specific client
 		jsonData, err := json.Marshal(map[string]interface{}{
@@ -237,11 +406,11 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 			"timestamp":   time.Now().Unix(),
 		})
 		if err != nil {
-			log.Error().Err(err).Msg("Failed to marshal broadcast message")
+			h.logger.Error().Err(err).Msg("Failed to marshal broadcast message")
 			continue
 		}
 
-		log.Info().
+		h.logger.Info().
 			Str("season", message.Season).
 			Str("user_id", client.UserID.String()).
 			Int("requested_limit", client.RequestedLimit).
@@ -250,29 +419,19 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 			Int("json_size", len(jsonData)).
 			Msg("📡 Broadcasting leaderboard update to client")
 
-		select {
-		case client.Send <- jsonData:
+		if h.sendToClient(clients, client, jsonData) {
 			sentCount++
-			log.Info().
+			h.logger.Info().
 				Str("user_id", client.UserID.String()).
 				Str("season", client.Season).
 				Int("entries_sent", len(filteredEntries)).
 				Msg("✅ Message queued to client send channel")
-		default:
-			// Client's send channel is full, close it
+		} else {
 			failedCount++
-			h.mu.Lock()
-			close(client.Send)
-			delete(clients, client)
-			h.mu.Unlock()
-			log.Warn().
-				Str("season", client.Season).
-				Str("user_id", client.UserID.String()).
-				Msg("⚠️ Client send buffer full, disconnecting")
 		}
 	}
 
-	log.Info().
+	h.logger.Info().
 		Str("season", message.Season).
 		Int("sent", sentCount).
 		Int("failed", failedCount).
@@ -280,9 +439,93 @@ func (h *Hub) broadcastToSeason(message *BroadcastMessage) {
 		Msg("✅ Broadcast complete")
 }
 
+// publishSSE записывает сообщение в поток сезона, если у сезона были SSE клиенты.
+// SSE клиенты не выбирают лимит, поэтому получают лидерборд целиком
+func (h *Hub) publishSSE(message *BroadcastMessage) {
+	h.streamsMu.Lock()
+	stream, ok := h.streams[message.Season]
+	h.streamsMu.Unlock()
+	if !ok {
+		return
+	}
+
+	var payload interface{} = message.Payload
+	if message.Leaderboard != nil {
+		payload = map[string]interface{}{
+			"type":        "leaderboard_update",
+			"season":      message.Season,
+			"leaderboard": message.Leaderboard,
+			"timestamp":   time.Now().Unix(),
+		}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		h.logger.Error().Err(err).Str("season", message.Season).Msg("Failed to marshal SSE event")
+		return
+	}
+
+	event := stream.publish(data)
+	h.logger.Debug().
+		Str("season", message.Season).
+		Uint64("event_id", event.ID).
+		Msg("📡 SSE event published")
+}
+
+// broadcastPayload sends the same JSON payload to every client of a season
+func (h *Hub) broadcastPayload(season string, clients map[*Client]bool, payload interface{}) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		h.logger.Error().Err(err).Str("season", season).Msg("Failed to marshal broadcast payload")
+		return
+	}
+
+	sentCount := 0
+	for client := range clients {
+		if h.sendToClient(clients, client, jsonData) {
+			sentCount++
+		}
+	}
+
+	h.logger.Info().
+		Str("season", season).
+		Int("sent", sentCount).
+		Int("total", len(clients)).
+		Int("json_size", len(jsonData)).
+		Msg("✅ Payload broadcast complete")
+}
+
+// sendToClient ставит сообщение в очередь клиента; клиент с переполненным буфером отключается
+func (h *Hub) sendToClient(clients map[*Client]bool, client *Client, jsonData []byte) bool {
+	select {
+	case client.Send <- jsonData:
+		return true
+	default:
+		h.mu.Lock()
+		close(client.Send)
+		delete(clients, client)
+		h.updateConnectionsGauge(client.Season, len(clients))
+		h.mu.Unlock()
+		h.logger.Warn().
+			Str("season", client.Season).
+			Str("user_id", client.UserID.String()).
+			Msg("⚠️ Client send buffer full, disconnecting")
+		return false
+	}
+}
+
+// BroadcastSeason sends message, marshalled to JSON, to all clients in a season
+func (h *Hub) BroadcastSeason(season string, message interface{}) {
+	select {
+	case h.BroadcastChan <- &BroadcastMessage{Season: season, Payload: message}:
+		h.logger.Info().Str("season", season).Msg("✅ Payload queued to BroadcastChan")
+	default:
+		h.logger.Warn().Str("season", season).Msg("⚠️ Broadcast channel full, dropping message")
+	}
+}
+
 // Broadcast sends a leaderboard update to all clients in a season
 func (h *Hub) Broadcast(season string, leaderboard *leaderboardmodels.LeaderboardResponse) {
-	log.Info().
+	h.logger.Info().
 		Str("season", season).
 		Int("entries", len(leaderboard.Entries)).
 		Msg("🔔 Hub.Broadcast() called")
@@ -292,9 +535,9 @@ func (h *Hub) Broadcast(season string, leaderboard *leaderboardmodels.Leaderboar
 		Season:      season,
 		Leaderboard: leaderboard,
 	}:
-		log.Info().Str("season", season).Msg("✅ Message queued to BroadcastChan")
+		h.logger.Info().Str("season", season).Msg("✅ Message queued to BroadcastChan")
 	default:
-		log.Warn().Str("season", season).Msg("⚠️ Broadcast channel full, dropping message")
+		h.logger.Warn().Str("season", season).Msg("⚠️ Broadcast channel full, dropping message")
 	}
 }
 
@@ -314,13 +557,43 @@ func (h *Hub) logStats() {
 
 	totalClients := h.getTotalClients()
 	if totalClients > 0 {
-		log.Debug().
+		h.logger.Debug().
 			Int("total_clients", totalClients).
 			Int("seasons", len(h.Clients)).
 			Msg("📊 WebSocket stats")
 	}
 }
 
+// seasonLimit returns the max requested limit of a season's clients (caller holds h.mu)
+func (h *Hub) seasonLimit(clients map[*Client]bool) int {
+	maxLimit := h.defaultLimit // Use default from config
+	for client := range clients {
+		if client.RequestedLimit > maxLimit {
+			maxLimit = client.RequestedLimit
+		}
+	}
+	return ClampLimit(maxLimit, h.maxClientLimit)
+}
+
+// NotifySeasonChanged calls OnPeriodicUpdate for one season right away, e.g. when the
+// database reports a changed score. Does nothing if the season has no clients.
+// Safe to call from any goroutine.
+func (h *Hub) NotifySeasonChanged(season string) {
+	h.mu.RLock()
+	clients := h.Clients[season]
+	if len(clients) == 0 && !h.hasSSESubscribers(season) {
+		h.mu.RUnlock()
+		return
+	}
+	limit := h.seasonLimit(clients)
+	h.mu.RUnlock()
+
+	h.logger.Debug().Str("season", season).Int("limit", limit).Msg("🔔 Season changed, triggering update")
+	if h.OnPeriodicUpdate != nil {
+		h.OnPeriodicUpdate(map[string]int{season: limit})
+	}
+}
+
 // triggerPeriodicUpdates calls callback with max requested limit per season
 func (h *Hub) triggerPeriodicUpdates() {
 	h.mu.RLock()
@@ -330,19 +603,20 @@ func (h *Hub) triggerPeriodicUpdates() {
 	// Find max requested limit per season
 	for season, clients := range h.Clients {
 		if len(clients) > 0 {
-			maxLimit := h.defaultLimit // Use default from config
-			for client := range clients {
-				if client.RequestedLimit > maxLimit {
-					maxLimit = client.RequestedLimit
-				}
-			}
-			seasonLimits[season] = maxLimit
+			seasonLimits[season] = h.seasonLimit(clients)
 			totalClients += len(clients)
 		}
 	}
 	h.mu.RUnlock()
 
-	log.Info().
+	// Сезоны, которые слушают только SSE клиенты, обновляются с лимитом по умолчанию
+	for _, season := range h.sseSeasons() {
+		if _, ok := seasonLimits[season]; !ok {
+			seasonLimits[season] = ClampLimit(h.defaultLimit, h.maxClientLimit)
+		}
+	}
+
+	h.logger.Info().
 		Int("active_seasons", len(seasonLimits)).
 		Int("total_clients", totalClients).
 		Interface("season_limits", seasonLimits).
@@ -351,17 +625,17 @@ func (h *Hub) triggerPeriodicUpdates() {
 
 	// Call callback if set
 	if h.OnPeriodicUpdate != nil && len(seasonLimits) > 0 {
-		log.Info().
+		h.logger.Info().
 			Int("seasons", len(seasonLimits)).
 			Interface("limits", seasonLimits).
 			Msg("✅ Calling OnPeriodicUpdate callback with dynamic limits")
 		h.OnPeriodicUpdate(seasonLimits)
 	} else {
 		if h.OnPeriodicUpdate == nil {
-			log.Error().Msg("❌❌❌ OnPeriodicUpdate callback is NIL - NO UPDATES WILL BE SENT!")
+			h.logger.Error().Msg("❌❌❌ OnPeriodicUpdate callback is NIL - NO UPDATES WILL BE SENT!")
 		}
 		if len(seasonLimits) == 0 {
-			log.Warn().Msg("⚠️ No active seasons with clients")
+			h.logger.Warn().Msg("⚠️ No active seasons with clients")
 		}
 	}
 }
@@ -377,9 +651,64 @@ func (h *Hub) closeAllClients() {
 			delete(clients, client)
 		}
 		delete(h.Clients, season)
+		metrics.WSConnections.Delete(season)
 	}
+	h.CloseEventStreams()
 
-	log.Info().Msg("All WebSocket clients closed")
+	h.logger.Info().Msg("All WebSocket clients closed")
+}
+
+// Shutdown sends a shutdown message to the clients of every season, waits until they
+// disconnect or ctx is done, then closes the remaining connections.
+// Returns ctx.Err() if some clients had to be closed forcibly.
+// Run must still be running, otherwise disconnecting clients are never unregistered
+func (h *Hub) Shutdown(ctx context.Context) error {
+	jsonData, err := json.Marshal(NewShutdownMessage())
+	if err != nil {
+		return fmt.Errorf("failed to marshal shutdown message: %w", err)
+	}
+
+	h.mu.RLock()
+	notified := 0
+	for _, clients := range h.Clients {
+		for client := range clients {
+			// Не блокируемся на переполненном буфере: такой клиент будет закрыт после ожидания
+			select {
+			case client.Send <- jsonData:
+				notified++
+			default:
+			}
+		}
+	}
+	total := h.getTotalClients()
+	h.mu.RUnlock()
+
+	h.logger.Info().
+		Int("clients", total).
+		Int("notified", notified).
+		Msg("🛑 WebSocket Hub draining clients")
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+
+	for {
+		h.mu.RLock()
+		remaining := h.getTotalClients()
+		h.mu.RUnlock()
+
+		if remaining == 0 {
+			h.logger.Info().Msg("All WebSocket clients disconnected")
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			h.logger.Warn().Int("clients", remaining).Msg("⚠️ Drain period expired, closing remaining WebSocket clients")
+			h.closeAllClients()
+			return ctx.Err()
+		}
+	}
 }
 
 // computeLeaderboardHash вычисляет SHA256 hash от leaderboard entries
@@ -392,7 +721,7 @@ func (h *Hub) computeLeaderboardHash(leaderboard *leaderboardmodels.LeaderboardR
 	// Сериализуем только entries (без timestamp и прочей метаданных)
 	data, err := json.Marshal(leaderboard.Entries)
 	if err != nil {
-		log.Warn().Err(err).Msg("Failed to marshal leaderboard for hashing")
+		h.logger.Warn().Err(err).Msg("Failed to marshal leaderboard for hashing")
 		return "error"
 	}
 
@@ -400,7 +729,26 @@ func (h *Hub) computeLeaderboardHash(leaderboard *leaderboardmodels.LeaderboardR
 	return hex.EncodeToString(hash[:])
 }
 
-// GetStats returns current hub statistics
+// GetSeasonClientCount returns the number of clients connected to a season
+func (h *Hub) GetSeasonClientCount(season string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.Clients[season])
+}
+
+// GetTotals returns the number of connected clients and of seasons with at least one client,
+// without naming the seasons
+func (h *Hub) GetTotals() map[string]interface{} {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return map[string]interface{}{
+		"total_clients":  h.getTotalClients(),
+		"active_seasons": len(h.Clients),
+	}
+}
+
+// GetStats returns current hub statistics with per-season client counts
 func (h *Hub) GetStats() map[string]interface{} {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
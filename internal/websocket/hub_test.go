@@ -0,0 +1,417 @@
+package websocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/shared/metrics"
+	"leaderboard-service/internal/shared/utils"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHub создает Hub, который пишет логи в buf
+func newTestHub(buf *bytes.Buffer) *Hub {
+	return NewHub(context.Background(), time.Second, 10).WithLogger(zerolog.New(buf))
+}
+
+func TestHubLogsClientLifecycle(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	client := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10}
+	hub.registerClient(client)
+	hub.unregisterClient(client)
+
+	output := buf.String()
+	assert.Contains(t, output, "WebSocket client connected")
+	assert.Contains(t, output, "WebSocket client disconnected")
+	assert.Contains(t, output, client.UserID.String())
+}
+
+func TestHubLogsBroadcastQueueing(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	hub.Broadcast("global", &leaderboardmodels.LeaderboardResponse{GeneratedAt: time.Now()})
+
+	assert.Contains(t, buf.String(), "Message queued to BroadcastChan")
+	assert.Contains(t, buf.String(), `"season":"global"`)
+}
+
+func TestHubLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	hub := NewHub(context.Background(), time.Second, 10).WithLogger(zerolog.New(&buf).Level(zerolog.WarnLevel))
+
+	// Без клиентов broadcastToSeason пишет Info о вызове и Warn об отсутствии клиентов
+	hub.broadcastToSeason(&BroadcastMessage{Season: "global", Leaderboard: &leaderboardmodels.LeaderboardResponse{GeneratedAt: time.Now()}})
+
+	output := buf.String()
+	assert.NotContains(t, output, "broadcastToSeason called")
+	assert.Contains(t, output, "No clients connected for this season")
+}
+
+func TestHubBroadcastIncludesGeneratedAt(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	client := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10}
+	hub.registerClient(client)
+
+	generatedAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	hub.broadcastToSeason(&BroadcastMessage{Season: "global", Leaderboard: &leaderboardmodels.LeaderboardResponse{
+		PaginationMeta: utils.NewPaginationMeta(1, 50, 25),
+		GeneratedAt:    generatedAt,
+	}})
+
+	var message struct {
+		Leaderboard leaderboardmodels.LeaderboardResponse `json:"leaderboard"`
+	}
+	assert.NoError(t, json.Unmarshal(<-client.Send, &message))
+	assert.True(t, generatedAt.Equal(message.Leaderboard.GeneratedAt))
+	assert.Equal(t, 10, message.Leaderboard.PageSize, "pagination follows the client's limit")
+	assert.Equal(t, 3, message.Leaderboard.TotalPages)
+}
+
+func TestHubBroadcastSeasonEvent(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	winter := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 10}
+	global := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10}
+	hub.registerClient(winter)
+	hub.registerClient(global)
+
+	hub.BroadcastSeason("winter", NewSeasonEventMessage(SeasonEventClosed, "winter"))
+	hub.broadcastToSeason(<-hub.BroadcastChan)
+
+	var message map[string]interface{}
+	assert.NoError(t, json.Unmarshal(<-winter.Send, &message))
+	assert.Equal(t, "season_event", message["type"])
+	assert.Equal(t, "closed", message["event"])
+	assert.Equal(t, "winter", message["season"])
+	assert.Empty(t, global.Send, "other seasons are not notified")
+}
+
+func TestHubSeasonClientCounts(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	hub.registerClient(&Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10})
+	hub.registerClient(&Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 10})
+
+	assert.Equal(t, 1, hub.GetSeasonClientCount("global"))
+	assert.Equal(t, 1, hub.GetSeasonClientCount("winter"))
+	assert.Equal(t, 0, hub.GetSeasonClientCount("summer"))
+
+	stats := hub.GetStats()
+	assert.Equal(t, 2, stats["total_clients"])
+	assert.Equal(t, map[string]int{"global": 1, "winter": 1}, stats["seasons"])
+	assert.Equal(t, map[string]interface{}{"total_clients": 2, "active_seasons": 2}, hub.GetTotals())
+}
+
+func TestHubConnectionMetrics(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+	// Метрики глобальные, поэтому у теста свой сезон
+	season := "metrics_" + uuid.NewString()
+
+	first := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: season, RequestedLimit: 10}
+	second := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: season, RequestedLimit: 10}
+	hub.registerClient(first)
+	hub.registerClient(second)
+	assert.Equal(t, float64(2), metrics.WSConnections.Value(season))
+
+	hub.broadcastToSeason(&BroadcastMessage{Season: season, Payload: NewSeasonEventMessage(SeasonEventReset, season)})
+	assert.Equal(t, float64(1), metrics.WSBroadcasts.Value(season))
+
+	hub.unregisterClient(first)
+	assert.Equal(t, float64(1), metrics.WSConnections.Value(season))
+
+	hub.unregisterClient(second)
+	assert.Equal(t, float64(0), metrics.WSConnections.Value(season))
+	var out bytes.Buffer
+	require.NoError(t, metrics.DefaultRegistry.WriteText(&out))
+	assert.NotContains(t, out.String(), `leaderboard_ws_connections{season="`+season+`"}`, "a season without clients leaves the gauge")
+	assert.Contains(t, out.String(), `leaderboard_ws_broadcasts_total{season="`+season+`"} 1`)
+}
+
+func TestHubReplaysMissedSSEEventsAfterReconnect(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf).WithSSEBufferSize(3)
+	reset := func() *BroadcastMessage {
+		return &BroadcastMessage{Season: "global", Payload: NewSeasonEventMessage(SeasonEventReset, "global")}
+	}
+
+	sub, replay := hub.SubscribeSSE("global", 0, false)
+	assert.Empty(t, replay)
+	hub.broadcastToSeason(reset())
+	first := <-sub.Events
+	assert.Equal(t, uint64(1), first.ID)
+	assert.Contains(t, string(first.Data), `"event":"reset"`)
+
+	// Клиент отключился и пропустил четыре события; в буфере остаются три последних
+	hub.UnsubscribeSSE(sub)
+	for i := 0; i < 4; i++ {
+		hub.broadcastToSeason(reset())
+	}
+	assert.Equal(t, uint64(5), hub.LastEventID("global"))
+
+	sub, replay = hub.SubscribeSSE("global", first.ID, true)
+	defer hub.UnsubscribeSSE(sub)
+	ids := make([]uint64, 0, len(replay))
+	for _, event := range replay {
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []uint64{3, 4, 5}, ids)
+
+	hub.broadcastToSeason(reset())
+	assert.Equal(t, uint64(6), (<-sub.Events).ID, "live events continue after the replay")
+
+	_, replay = hub.SubscribeSSE("global", 6, true)
+	assert.Empty(t, replay, "an up-to-date client gets nothing replayed")
+	_, replay = hub.SubscribeSSE("global", 100, true)
+	assert.Len(t, replay, 3, "an ID from before a restart replays the whole buffer")
+}
+
+func TestHubDropsSlowSSESubscriber(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	sub, _ := hub.SubscribeSSE("global", 0, false)
+	for i := 0; i <= sseSubscriberBuffer; i++ {
+		hub.broadcastToSeason(&BroadcastMessage{Season: "global", Payload: NewSeasonEventMessage(SeasonEventReset, "global")})
+	}
+
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	assert.Equal(t, sseSubscriberBuffer, received, "the channel is closed once the queue is full")
+	assert.False(t, hub.hasSSESubscribers("global"))
+	hub.UnsubscribeSSE(sub)
+}
+
+func TestHubPrunesUnusedSSEStreams(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf).WithSSEBufferSize(10)
+
+	// A stream that never buffered anything is dropped with its last subscriber
+	sub, _ := hub.SubscribeSSE("winter", 0, false)
+	hub.UnsubscribeSSE(sub)
+	hub.streamsMu.Lock()
+	assert.NotContains(t, hub.streams, "winter")
+	hub.streamsMu.Unlock()
+
+	// A buffered stream survives for reconnects until it goes idle
+	sub, _ = hub.SubscribeSSE("global", 0, false)
+	hub.broadcastToSeason(&BroadcastMessage{Season: "global", Payload: NewSeasonEventMessage(SeasonEventReset, "global")})
+	hub.UnsubscribeSSE(sub)
+	hub.streamsMu.Lock()
+	require.Contains(t, hub.streams, "global")
+	hub.streams["global"].lastPublished = time.Now().Add(-2 * sseStreamIdleTTL)
+	hub.streamsMu.Unlock()
+
+	hub.pruneEventStreams()
+	assert.Equal(t, uint64(0), hub.LastEventID("global"))
+}
+
+func TestHubRejectsClientsBeyondSeasonLimit(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf).WithMaxClientsPerSeason(2)
+
+	for i := 0; i < 2; i++ {
+		hub.registerClient(&Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10})
+	}
+	rejected := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10}
+	hub.registerClient(rejected)
+	hub.registerClient(&Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 10})
+
+	assert.Equal(t, 2, hub.GetSeasonClientCount("global"))
+	assert.Equal(t, 1, hub.GetSeasonClientCount("winter"), "the limit applies per season")
+
+	select {
+	case _, ok := <-rejected.Send:
+		assert.False(t, ok, "the rejected client's Send channel is closed")
+	case <-time.After(time.Second):
+		t.Fatal("rejected client was not closed")
+	}
+	assert.Contains(t, buf.String(), "season is full")
+	assert.Contains(t, buf.String(), `"limit":2`)
+
+	// The unregister that ReadPump sends on disconnect must be a no-op for a rejected client
+	hub.unregisterClient(rejected)
+	assert.Equal(t, 2, hub.GetSeasonClientCount("global"))
+}
+
+func TestClampLimit(t *testing.T) {
+	assert.Equal(t, 1, ClampLimit(0, 1000))
+	assert.Equal(t, 1, ClampLimit(1, 1000))
+	assert.Equal(t, 1000, ClampLimit(1000, 1000))
+	assert.Equal(t, 1000, ClampLimit(1001, 1000))
+	assert.Equal(t, 5000, ClampLimit(5000, 0), "no cap when max is not set")
+}
+
+func TestHubPeriodicUpdateClampsLimit(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf).WithMaxClientLimit(100)
+
+	var got map[string]int
+	hub.OnPeriodicUpdate = func(seasonLimits map[string]int) { got = seasonLimits }
+
+	hub.registerClient(&Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 5000})
+	hub.registerClient(&Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 20})
+	hub.triggerPeriodicUpdates()
+
+	assert.Equal(t, map[string]int{"global": 100, "winter": 20}, got)
+}
+
+func TestHubNotifySeasonChanged(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	var calls []map[string]int
+	hub.OnPeriodicUpdate = func(seasonLimits map[string]int) { calls = append(calls, seasonLimits) }
+
+	hub.registerClient(&Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 50})
+	hub.registerClient(&Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 20})
+
+	hub.NotifySeasonChanged("global")
+	// Сезон без клиентов не требует запроса к базе
+	hub.NotifySeasonChanged("summer")
+
+	assert.Equal(t, []map[string]int{{"global": 50}}, calls)
+}
+
+func TestHubWithoutPollingSkipsPeriodicUpdates(t *testing.T) {
+	var buf bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	hub := NewHub(ctx, 5*time.Millisecond, 10).WithLogger(zerolog.New(&buf)).WithPolling(false)
+
+	calls := make(chan map[string]int, 10)
+	hub.OnPeriodicUpdate = func(seasonLimits map[string]int) { calls <- seasonLimits }
+
+	done := make(chan struct{})
+	go func() {
+		hub.Run()
+		close(done)
+	}()
+	hub.Register <- &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10}
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Empty(t, calls)
+}
+
+func TestHubShutdownNotifiesClientsAndWaitsForDisconnect(t *testing.T) {
+	// Run и Shutdown пишут логи из разных горутин, поэтому без bytes.Buffer
+	hubCtx, stopHub := context.WithCancel(context.Background())
+	defer stopHub()
+	hub := NewHub(hubCtx, time.Second, 10).WithLogger(zerolog.Nop()).WithPolling(false)
+
+	global := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10}
+	winter := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 10}
+	hub.registerClient(global)
+	hub.registerClient(winter)
+	go hub.Run()
+
+	// Клиенты отключаются сами, получив уведомление, как это делает ReadPump
+	for _, client := range []*Client{global, winter} {
+		go func(c *Client) {
+			var message ShutdownMessage
+			if assert.NoError(t, json.Unmarshal(<-c.Send, &message)) {
+				assert.Equal(t, "shutdown", message.Type)
+				assert.Equal(t, ShutdownNotice, message.Message)
+			}
+			hub.Unregister <- c
+		}(client)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, hub.Shutdown(ctx))
+	assert.Equal(t, 0, hub.GetTotals()["total_clients"])
+}
+
+func TestHubShutdownClosesClientsAfterDrainPeriod(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	client := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "global", RequestedLimit: 10}
+	hub.registerClient(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, hub.Shutdown(ctx), context.DeadlineExceeded)
+
+	message, ok := <-client.Send
+	assert.True(t, ok)
+	assert.Contains(t, string(message), `"type":"shutdown"`)
+	_, ok = <-client.Send
+	assert.False(t, ok, "the client is closed once the drain period expires")
+	assert.Contains(t, buf.String(), "Drain period expired")
+}
+
+func TestBroadcast_WebSocketMessageFormat(t *testing.T) {
+	var buf bytes.Buffer
+	hub := newTestHub(&buf)
+
+	wide := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 10}
+	narrow := &Client{Hub: hub, Send: make(chan []byte, 1), UserID: uuid.New(), Season: "winter", RequestedLimit: 2}
+	hub.registerClient(wide)
+	hub.registerClient(narrow)
+
+	entries := make([]leaderboardmodels.LeaderboardEntry, 5)
+	for i := range entries {
+		entries[i] = leaderboardmodels.LeaderboardEntry{
+			Rank:     i + 1,
+			UserID:   uuid.New(),
+			UserName: "player",
+			Score:    int64(1000 - i*100),
+			Season:   "winter",
+		}
+	}
+	before := time.Now().Unix()
+	hub.broadcastToSeason(&BroadcastMessage{Season: "winter", Leaderboard: &leaderboardmodels.LeaderboardResponse{
+		Entries:        entries,
+		PaginationMeta: utils.NewPaginationMeta(1, 50, int64(len(entries))),
+		GeneratedAt:    time.Now(),
+	}})
+
+	decode := func(raw []byte) map[string]interface{} {
+		t.Helper()
+		var message map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &message))
+		return message
+	}
+
+	message := decode(<-wide.Send)
+	assert.Equal(t, "leaderboard_update", message["type"])
+	assert.Equal(t, "winter", message["season"])
+
+	timestamp, ok := message["timestamp"].(float64)
+	require.True(t, ok, "timestamp must be a number, got %T", message["timestamp"])
+	assert.GreaterOrEqual(t, int64(timestamp), before)
+	assert.LessOrEqual(t, int64(timestamp), time.Now().Unix())
+
+	leaderboard, ok := message["leaderboard"].(map[string]interface{})
+	require.True(t, ok, "leaderboard must be an object")
+	wideEntries, ok := leaderboard["entries"].([]interface{})
+	require.True(t, ok, "leaderboard.entries must be an array, got %T", leaderboard["entries"])
+	assert.Len(t, wideEntries, 5)
+
+	narrowLeaderboard := decode(<-narrow.Send)["leaderboard"].(map[string]interface{})
+	narrowEntries, ok := narrowLeaderboard["entries"].([]interface{})
+	require.True(t, ok, "leaderboard.entries must be an array")
+	assert.LessOrEqual(t, len(narrowEntries), 2)
+	assert.Equal(t, float64(1), narrowEntries[0].(map[string]interface{})["rank"])
+}
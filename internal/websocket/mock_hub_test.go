@@ -0,0 +1,108 @@
+package websocket_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	leaderboardmodels "leaderboard-service/internal/leaderboard/models"
+	"leaderboard-service/internal/leaderboard/service"
+	"leaderboard-service/internal/shared/config"
+	"leaderboard-service/internal/testutil"
+	ws "leaderboard-service/internal/websocket"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockHub записывает broadcast'ы и callback периодических обновлений вместо реальной рассылки
+type MockHub struct {
+	mu         sync.Mutex
+	broadcasts map[string][]*leaderboardmodels.LeaderboardResponse
+	events     map[string][]interface{}
+	onPeriodic func(seasonLimits map[string]int)
+}
+
+var (
+	_ service.BroadcastHub       = (*MockHub)(nil)
+	_ ws.PeriodicUpdateRegistrar = (*MockHub)(nil)
+	_ ws.PeriodicUpdateRegistrar = (*ws.Hub)(nil)
+	_ ws.SeasonEventBroadcaster  = (*MockHub)(nil)
+	_ ws.SeasonEventBroadcaster  = (*ws.Hub)(nil)
+)
+
+func NewMockHub() *MockHub {
+	return &MockHub{
+		broadcasts: make(map[string][]*leaderboardmodels.LeaderboardResponse),
+		events:     make(map[string][]interface{}),
+	}
+}
+
+func (m *MockHub) BroadcastSeason(season string, message interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events[season] = append(m.events[season], message)
+}
+
+func (m *MockHub) Broadcast(season string, leaderboard *leaderboardmodels.LeaderboardResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.broadcasts[season] = append(m.broadcasts[season], leaderboard)
+}
+
+func (m *MockHub) SetPeriodicUpdateCallback(fn func(seasonLimits map[string]int)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onPeriodic = fn
+}
+
+// Callback возвращает зарегистрированный callback периодических обновлений
+func (m *MockHub) Callback() func(seasonLimits map[string]int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.onPeriodic
+}
+
+// broadcastOnlyHub реализует только BroadcastHub, без периодических обновлений
+type broadcastOnlyHub struct{}
+
+func (broadcastOnlyHub) Broadcast(string, *leaderboardmodels.LeaderboardResponse) {}
+
+func TestSetHub_RegistersPeriodicUpdateCallback(t *testing.T) {
+	svc := service.NewLeaderboardService(nil, nil, nil, &config.Config{})
+	hub := NewMockHub()
+
+	svc.SetHub(hub)
+
+	callback := hub.Callback()
+	require.NotNil(t, callback)
+	assert.NotPanics(t, func() { callback(map[string]int{}) })
+}
+
+func TestSetHub_AcceptsHubWithoutPeriodicUpdates(t *testing.T) {
+	svc := service.NewLeaderboardService(nil, nil, nil, &config.Config{})
+
+	assert.NotPanics(t, func() { svc.SetHub(broadcastOnlyHub{}) })
+	assert.NotPanics(t, func() { svc.SetHub(nil) })
+}
+
+func TestResetSeason_BroadcastsSeasonEvent(t *testing.T) {
+	repo := testutil.NewInMemoryScoreRepository(testutil.NewInMemoryUserRepository())
+	svc := service.NewLeaderboardService(repo, nil, nil, &config.Config{})
+	hub := NewMockHub()
+	svc.SetHub(hub)
+
+	require.NoError(t, svc.ResetSeason(context.Background(), "winter", uuid.NewString()))
+
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	require.Len(t, hub.events["winter"], 1)
+	event, ok := hub.events["winter"][0].(*ws.SeasonEventMessage)
+	require.True(t, ok)
+	assert.Equal(t, "season_event", event.Type)
+	assert.Equal(t, ws.SeasonEventReset, event.Event)
+	assert.Equal(t, "winter", event.Season)
+	require.Len(t, hub.broadcasts["winter"], 1)
+	assert.Empty(t, hub.broadcasts["winter"][0].Entries)
+}
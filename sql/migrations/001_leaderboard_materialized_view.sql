@@ -0,0 +1,33 @@
+-- Converts leaderboard_view from a plain view into a materialized view.
+-- Apply to databases created before the materialized view was introduced:
+--   psql $DATABASE_URL < sql/migrations/001_leaderboard_materialized_view.sql
+
+BEGIN;
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_views WHERE viewname = 'leaderboard_view') THEN
+        DROP VIEW leaderboard_view;
+    END IF;
+END
+$$;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS leaderboard_view AS
+SELECT
+    DENSE_RANK() OVER (PARTITION BY s.season ORDER BY s.score DESC, s.timestamp ASC) as rank,
+    s.id,
+    s.user_id,
+    u.name as user_name,
+    s.score,
+    s.season,
+    s.timestamp
+FROM scores s
+JOIN users u ON s.user_id = u.id
+ORDER BY s.season, s.score DESC, s.timestamp ASC;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leaderboard_view_season_user ON leaderboard_view(season, user_id);
+CREATE INDEX IF NOT EXISTS idx_leaderboard_view_season_rank ON leaderboard_view(season, rank);
+
+COMMENT ON MATERIALIZED VIEW leaderboard_view IS 'Materialized view for fast leaderboard queries';
+
+COMMIT;
@@ -0,0 +1,23 @@
+-- Adds the scoring_config table read by DBWeightedScoringStrategy.
+-- Apply to databases created before scoring multipliers moved to the database:
+--   psql $DATABASE_URL < sql/migrations/002_scoring_config.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS scoring_config (
+    season TEXT NOT NULL,
+    game_mode TEXT NOT NULL,
+    difficulty_multiplier DOUBLE PRECISION NOT NULL DEFAULT 1.5,
+    combo_bonus DOUBLE PRECISION NOT NULL DEFAULT 0.1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (season, game_mode)
+);
+
+DROP TRIGGER IF EXISTS update_scoring_config_updated_at ON scoring_config;
+CREATE TRIGGER update_scoring_config_updated_at BEFORE UPDATE ON scoring_config
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE scoring_config IS 'Difficulty and combo multipliers by season and game mode';
+
+COMMIT;
@@ -0,0 +1,32 @@
+-- Adds the challenges table used by the /challenges endpoints.
+-- Apply to databases created before player challenges were introduced:
+--   psql $DATABASE_URL < sql/migrations/003_challenges.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    challenger_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    challenged_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    season TEXT NOT NULL DEFAULT 'global',
+    target_score BIGINT NOT NULL CHECK (target_score > 0),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'won', 'lost', 'expired')),
+    accepted_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT challenge_distinct_players CHECK (challenger_id <> challenged_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenges_challenged_season_status ON challenges(challenged_id, season, status);
+CREATE INDEX IF NOT EXISTS idx_challenges_challenger_id ON challenges(challenger_id);
+CREATE INDEX IF NOT EXISTS idx_challenges_status_expires_at ON challenges(status, expires_at);
+
+DROP TRIGGER IF EXISTS update_challenges_updated_at ON challenges;
+CREATE TRIGGER update_challenges_updated_at BEFORE UPDATE ON challenges
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE challenges IS 'Score challenges between players';
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Adds the scoring_configs table for runtime scoring rules.
+-- Apply to databases created before score limits became editable at runtime:
+--   psql $DATABASE_URL < sql/migrations/004_scoring_configs.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS scoring_configs (
+    key TEXT NOT NULL,
+    season TEXT NOT NULL DEFAULT '',
+    value TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (key, season)
+);
+
+DROP TRIGGER IF EXISTS update_scoring_configs_updated_at ON scoring_configs;
+CREATE TRIGGER update_scoring_configs_updated_at BEFORE UPDATE ON scoring_configs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE scoring_configs IS 'Scoring rules reloaded by the service without restarts';
+
+COMMIT;
@@ -0,0 +1,26 @@
+-- Adds the seasons table with display names and schedules.
+-- Apply to databases created before season metadata was introduced:
+--   psql $DATABASE_URL < sql/migrations/005_seasons.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS seasons (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT season_ends_after_start CHECK (ends_at IS NULL OR ends_at >= starts_at)
+);
+
+INSERT INTO seasons (name, display_name) VALUES ('global', 'Global') ON CONFLICT (name) DO NOTHING;
+
+DROP TRIGGER IF EXISTS update_seasons_updated_at ON seasons;
+CREATE TRIGGER update_seasons_updated_at BEFORE UPDATE ON seasons
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE seasons IS 'Season display names and schedules';
+
+COMMIT;
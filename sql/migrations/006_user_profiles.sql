@@ -0,0 +1,13 @@
+-- Adds public profile fields to users, returned by leaderboard queries with profiles.
+-- Apply to databases created before player profiles were introduced:
+--   psql $DATABASE_URL < sql/migrations/006_user_profiles.sql
+
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_url TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS country VARCHAR(2);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tier VARCHAR(32);
+
+COMMENT ON COLUMN users.country IS 'ISO 3166-1 alpha-2 country code';
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Counts submissions per player and season so leaderboards can be sorted by games played.
+-- Every upsert increments the counter, including personal-best submissions that keep the old score.
+-- Apply to databases created before sort_by=games_played was introduced:
+--   psql $DATABASE_URL < sql/migrations/007_games_played.sql
+
+BEGIN;
+
+ALTER TABLE scores ADD COLUMN IF NOT EXISTS games_played INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX IF NOT EXISTS idx_scores_season_games_played ON scores(season, games_played DESC);
+
+COMMIT;
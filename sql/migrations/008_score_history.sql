@@ -0,0 +1,18 @@
+-- Keeps one row per score submission so daily streaks can be computed (GetStreak, streak_* in leaderboards).
+-- Rows are written for every submission, including personal-best submissions that keep the old score.
+-- Apply to databases created before streaks were introduced:
+--   psql $DATABASE_URL < sql/migrations/008_score_history.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS score_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    season TEXT NOT NULL,
+    score BIGINT NOT NULL,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_score_history_season_user_submitted ON score_history(season, user_id, submitted_at);
+
+COMMIT;
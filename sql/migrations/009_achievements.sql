@@ -0,0 +1,19 @@
+-- Badges awarded when a player's stored score reaches a threshold (SCORING_ACHIEVEMENT_THRESHOLDS).
+-- Each badge is awarded once per player and season.
+-- Apply to databases created before achievements were introduced:
+--   psql $DATABASE_URL < sql/migrations/009_achievements.sql
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS achievements (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    badge_name TEXT NOT NULL,
+    season TEXT NOT NULL,
+    awarded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, season, badge_name)
+);
+
+COMMENT ON TABLE achievements IS 'Score milestone badges per player and season';
+
+COMMIT;
@@ -0,0 +1,34 @@
+-- Soft delete for scores: rows with deleted_at set are hidden from the leaderboard
+-- when DATABASE_SOFT_DELETE_ENABLED=true.
+-- Apply to databases created before soft delete was introduced:
+--   psql $DATABASE_URL < sql/migrations/010_scores_soft_delete.sql
+
+BEGIN;
+
+ALTER TABLE scores ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN scores.deleted_at IS 'Set when the score is soft-deleted; NULL for live scores';
+
+-- leaderboard_view (001) predates the column; recreate it without soft-deleted scores
+DROP MATERIALIZED VIEW IF EXISTS leaderboard_view;
+
+CREATE MATERIALIZED VIEW leaderboard_view AS
+SELECT
+    DENSE_RANK() OVER (PARTITION BY s.season ORDER BY s.score DESC, s.timestamp ASC) as rank,
+    s.id,
+    s.user_id,
+    u.name as user_name,
+    s.score,
+    s.season,
+    s.timestamp
+FROM scores s
+JOIN users u ON s.user_id = u.id
+WHERE s.deleted_at IS NULL
+ORDER BY s.season, s.score DESC, s.timestamp ASC;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leaderboard_view_season_user ON leaderboard_view(season, user_id);
+CREATE INDEX IF NOT EXISTS idx_leaderboard_view_season_rank ON leaderboard_view(season, rank);
+
+COMMENT ON MATERIALIZED VIEW leaderboard_view IS 'Materialized view for fast leaderboard queries';
+
+COMMIT;
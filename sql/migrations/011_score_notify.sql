@@ -0,0 +1,21 @@
+-- Trigger for WS_USE_DB_NOTIFY: every inserted or updated score is announced on the
+-- score_updates channel with its season as payload, so WebSocket clients are updated
+-- without waiting for the periodic broadcast.
+-- Apply to databases created before LISTEN/NOTIFY broadcasts were introduced:
+--   psql $DATABASE_URL < sql/migrations/011_score_notify.sql
+
+BEGIN;
+
+CREATE OR REPLACE FUNCTION notify_score_update() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('score_updates', NEW.season);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS scores_notify_update ON scores;
+CREATE TRIGGER scores_notify_update
+    AFTER INSERT OR UPDATE ON scores
+    FOR EACH ROW EXECUTE FUNCTION notify_score_update();
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- Index for country leaderboards (GET /api/v1/leaderboard/country/{countryCode}),
+-- which join scores to the users of one country.
+-- Apply to databases created before country leaderboards were introduced:
+--   psql $DATABASE_URL < sql/migrations/012_users_country_index.sql
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_users_country ON users(country);
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Full-text search over user names and emails (SearchUsersSpec).
+-- search_vector is generated by PostgreSQL (12+), so the application never writes it.
+-- Apply to databases created before full-text user search was introduced:
+--   psql $DATABASE_URL < sql/migrations/013_users_search_vector.sql
+
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', coalesce(name, '') || ' ' || coalesce(email, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_users_search_vector ON users USING GIN (search_vector);
+
+COMMIT;
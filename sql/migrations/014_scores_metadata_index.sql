@@ -0,0 +1,10 @@
+-- GIN index on score metadata for the admin score search
+-- (GET /api/v1/admin/scores?metadata_key=...&metadata_value=...).
+-- Apply to databases created before metadata search was introduced:
+--   psql $DATABASE_URL < sql/migrations/014_scores_metadata_index.sql
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_scores_metadata ON scores USING GIN (metadata);
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Admin flag on users, checked when a login token is issued (role "admin").
+-- Apply to databases created before the flag was introduced:
+--   psql $DATABASE_URL < sql/migrations/015_users_is_admin.sql
+
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
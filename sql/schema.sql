@@ -4,8 +4,13 @@ CREATE TABLE IF NOT EXISTS users (
     name TEXT NOT NULL,
     email TEXT UNIQUE NOT NULL,
     password_hash TEXT NOT NULL,
+    avatar_url TEXT,
+    country VARCHAR(2),
+    tier VARCHAR(32),
+    is_admin BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    search_vector tsvector GENERATED ALWAYS AS (to_tsvector('english', coalesce(name, '') || ' ' || coalesce(email, ''))) STORED
 );
 
 CREATE TABLE IF NOT EXISTS scores (
@@ -15,6 +20,8 @@ CREATE TABLE IF NOT EXISTS scores (
     season TEXT NOT NULL DEFAULT 'global',
     metadata JSONB,
     timestamp TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    games_played INTEGER NOT NULL DEFAULT 1,
+    deleted_at TIMESTAMPTZ,
 
     CONSTRAINT unique_user_season UNIQUE (user_id, season)
 );
@@ -23,7 +30,87 @@ CREATE INDEX IF NOT EXISTS idx_scores_user_id ON scores(user_id);
 CREATE INDEX IF NOT EXISTS idx_scores_season ON scores(season);
 CREATE INDEX IF NOT EXISTS idx_scores_season_score ON scores(season, score DESC);
 CREATE INDEX IF NOT EXISTS idx_scores_timestamp ON scores(timestamp DESC);
+CREATE INDEX IF NOT EXISTS idx_scores_season_games_played ON scores(season, games_played DESC);
+CREATE INDEX IF NOT EXISTS idx_scores_metadata ON scores USING GIN (metadata);
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+CREATE INDEX IF NOT EXISTS idx_users_country ON users(country);
+CREATE INDEX IF NOT EXISTS idx_users_search_vector ON users USING GIN (search_vector);
+
+-- One row per submission; daily streaks are computed from it
+CREATE TABLE IF NOT EXISTS score_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    season TEXT NOT NULL,
+    score BIGINT NOT NULL,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_score_history_season_user_submitted ON score_history(season, user_id, submitted_at);
+
+-- Score milestone badges, awarded once per player and season
+CREATE TABLE IF NOT EXISTS achievements (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    badge_name TEXT NOT NULL,
+    season TEXT NOT NULL,
+    awarded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, season, badge_name)
+);
+
+-- Per-season / per-game-mode multipliers for DBWeightedScoringStrategy
+CREATE TABLE IF NOT EXISTS scoring_config (
+    season TEXT NOT NULL,
+    game_mode TEXT NOT NULL,
+    difficulty_multiplier DOUBLE PRECISION NOT NULL DEFAULT 1.5,
+    combo_bonus DOUBLE PRECISION NOT NULL DEFAULT 0.1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (season, game_mode)
+);
+
+-- Runtime scoring rules (min_score, max_score); season '' applies to every season
+CREATE TABLE IF NOT EXISTS scoring_configs (
+    key TEXT NOT NULL,
+    season TEXT NOT NULL DEFAULT '',
+    value TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (key, season)
+);
+
+-- Player-to-player challenges, settled when the challenged player submits a score
+CREATE TABLE IF NOT EXISTS challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    challenger_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    challenged_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    season TEXT NOT NULL DEFAULT 'global',
+    target_score BIGINT NOT NULL CHECK (target_score > 0),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'won', 'lost', 'expired')),
+    accepted_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT challenge_distinct_players CHECK (challenger_id <> challenged_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenges_challenged_season_status ON challenges(challenged_id, season, status);
+CREATE INDEX IF NOT EXISTS idx_challenges_challenger_id ON challenges(challenger_id);
+CREATE INDEX IF NOT EXISTS idx_challenges_status_expires_at ON challenges(status, expires_at);
+
+-- Season display names and schedules; name matches scores.season
+CREATE TABLE IF NOT EXISTS seasons (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT season_ends_after_start CHECK (ends_at IS NULL OR ends_at >= starts_at)
+);
+
+INSERT INTO seasons (name, display_name) VALUES ('global', 'Global') ON CONFLICT (name) DO NOTHING;
 
 CREATE OR REPLACE FUNCTION update_updated_at_column()
 RETURNS TRIGGER AS $$
@@ -36,8 +123,21 @@ $$ language 'plpgsql';
 CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
--- View for leaderboard with user info
-CREATE OR REPLACE VIEW leaderboard_view AS
+CREATE TRIGGER update_scoring_config_updated_at BEFORE UPDATE ON scoring_config
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_scoring_configs_updated_at BEFORE UPDATE ON scoring_configs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_challenges_updated_at BEFORE UPDATE ON challenges
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_seasons_updated_at BEFORE UPDATE ON seasons
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Materialized view for leaderboard with pre-computed ranks
+-- Refreshed periodically by the service (LEADERBOARD_USE_MATERIALIZED_VIEW=true)
+CREATE MATERIALIZED VIEW IF NOT EXISTS leaderboard_view AS
 SELECT
     DENSE_RANK() OVER (PARTITION BY s.season ORDER BY s.score DESC, s.timestamp ASC) as rank,
     s.id,
@@ -48,8 +148,30 @@ SELECT
     s.timestamp
 FROM scores s
 JOIN users u ON s.user_id = u.id
+WHERE s.deleted_at IS NULL
 ORDER BY s.season, s.score DESC, s.timestamp ASC;
 
+-- Unique index is required for REFRESH MATERIALIZED VIEW CONCURRENTLY
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leaderboard_view_season_user ON leaderboard_view(season, user_id);
+CREATE INDEX IF NOT EXISTS idx_leaderboard_view_season_rank ON leaderboard_view(season, rank);
+
+-- WS_USE_DB_NOTIFY: announce changed scores on the score_updates channel (payload: season)
+CREATE OR REPLACE FUNCTION notify_score_update() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('score_updates', NEW.season);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS scores_notify_update ON scores;
+CREATE TRIGGER scores_notify_update
+    AFTER INSERT OR UPDATE ON scores
+    FOR EACH ROW EXECUTE FUNCTION notify_score_update();
+
 COMMENT ON TABLE users IS 'Game players with authentication';
 COMMENT ON TABLE scores IS 'Player scores with seasonal support and metadata';
-COMMENT ON VIEW leaderboard_view IS 'Materialized view for fast leaderboard queries';
+COMMENT ON TABLE scoring_config IS 'Difficulty and combo multipliers by season and game mode';
+COMMENT ON TABLE scoring_configs IS 'Scoring rules reloaded by the service without restarts';
+COMMENT ON TABLE challenges IS 'Score challenges between players';
+COMMENT ON TABLE seasons IS 'Season display names and schedules';
+COMMENT ON MATERIALIZED VIEW leaderboard_view IS 'Materialized view for fast leaderboard queries';